@@ -0,0 +1,439 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+)
+
+// adminFlags are the flags of the commands calling the AdminService of a running tc server
+var adminFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "config",
+		Aliases: []string{"c"},
+		Usage:   "Load the port and the TLS settings of the tc server from `FILE`",
+	},
+	&cli.StringFlag{
+		Name:    "address",
+		Aliases: []string{"a"},
+		Usage:   "the `HOST:PORT` of the tc server, default is 127.0.0.1 and the port of the configuration",
+	},
+	&cli.StringFlag{
+		Name:    "output",
+		Aliases: []string{"o"},
+		Value:   tableOutput,
+		Usage:   "the output format, table or json",
+	},
+	&cli.DurationFlag{
+		Name:  "timeout",
+		Value: 30 * time.Second,
+		Usage: "the timeout of a call to the tc server",
+	},
+}
+
+var reasonFlag = &cli.StringFlag{
+	Name:  "reason",
+	Usage: "the reason written to the audit log of the tc server",
+}
+
+var sessionsCommand = &cli.Command{
+	Name:  "sessions",
+	Usage: "manage the global sessions of a running tc server",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "list the global sessions in the order they began",
+			Flags: append([]cli.Flag{
+				&cli.StringSliceFlag{
+					Name:  "status",
+					Usage: "list the global sessions in `STATUS`, such as CommitRetrying, may be repeated",
+				},
+				&cli.StringFlag{
+					Name:  "addressing",
+					Usage: "list the global sessions began by the application at `ADDRESSING`",
+				},
+				&cli.StringFlag{
+					Name:  "name",
+					Usage: "list the global sessions of the transaction `NAME`",
+				},
+				&cli.DurationFlag{
+					Name:  "min-age",
+					Usage: "list the global sessions began at least `DURATION` ago",
+				},
+				&cli.IntFlag{
+					Name:  "offset",
+					Usage: "skip the first `N` global sessions",
+				},
+				&cli.IntFlag{
+					Name:  "limit",
+					Value: 100,
+					Usage: "list at most `N` global sessions",
+				},
+			}, adminFlags...),
+			Action: adminAction(func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error) {
+				var statuses []apis.GlobalSession_GlobalStatus
+				for _, s := range c.StringSlice("status") {
+					globalStatus, ok := apis.GlobalSession_GlobalStatus_value[s]
+					if !ok {
+						return nil, fmt.Errorf("unknown global status %s", s)
+					}
+					statuses = append(statuses, apis.GlobalSession_GlobalStatus(globalStatus))
+				}
+				return client.ListSessions(ctx, &apis.SessionListRequest{
+					Statuses:        statuses,
+					Addressing:      c.String("addressing"),
+					TransactionName: c.String("name"),
+					MinAge:          c.Duration("min-age").Milliseconds(),
+					Offset:          int32(c.Int("offset")),
+					Limit:           int32(c.Int("limit")),
+				})
+			}),
+		},
+		{
+			Name:      "show",
+			Usage:     "show a global session with its branch sessions and row locks",
+			ArgsUsage: "XID",
+			Flags:     adminFlags,
+			Action: adminAction(func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error) {
+				xid, err := xidArg(c)
+				if err != nil {
+					return nil, err
+				}
+				session, err := client.GetSession(ctx, &apis.SessionGetRequest{XID: xid})
+				if err != nil || session.ResultCode != apis.ResultCodeSuccess {
+					return session, err
+				}
+				locks, err := client.ListLocks(ctx, &apis.LockListRequest{XID: xid})
+				if err != nil {
+					return nil, err
+				}
+				if err := responseError(locks); err != nil {
+					return nil, err
+				}
+				return &sessionDetail{session: session, locks: locks.Locks}, nil
+			}),
+		},
+		{
+			Name:      "retry",
+			Usage:     "retry the commit of a global session waiting to be committed at once",
+			ArgsUsage: "XID",
+			Flags:     append([]cli.Flag{reasonFlag}, adminFlags...),
+			Action: adminAction(func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error) {
+				xid, err := xidArg(c)
+				if err != nil {
+					return nil, err
+				}
+				return client.RetryCommit(ctx, &apis.SessionRetryRequest{XID: xid, Reason: c.String("reason")})
+			}),
+		},
+		{
+			Name:      "rollback",
+			Usage:     "retry the rollback of a global session waiting to be rolled back at once",
+			ArgsUsage: "XID",
+			Flags:     append([]cli.Flag{reasonFlag}, adminFlags...),
+			Action: adminAction(func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error) {
+				xid, err := xidArg(c)
+				if err != nil {
+					return nil, err
+				}
+				return client.RetryRollback(ctx, &apis.SessionRetryRequest{XID: xid, Reason: c.String("reason")})
+			}),
+		},
+		{
+			Name:      "remove",
+			Usage:     "remove a stuck global session with its branch sessions",
+			ArgsUsage: "XID",
+			Flags: append([]cli.Flag{
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "remove a global session which has not timed out yet",
+				},
+				&cli.BoolFlag{
+					Name:  "release-locks",
+					Usage: "release the row locks of the global session, they are kept otherwise",
+				},
+				reasonFlag,
+			}, adminFlags...),
+			Action: adminAction(func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error) {
+				xid, err := xidArg(c)
+				if err != nil {
+					return nil, err
+				}
+				return client.RemoveSession(ctx, &apis.SessionRemoveRequest{
+					XID:          xid,
+					Force:        c.Bool("force"),
+					ReleaseLocks: c.Bool("release-locks"),
+					Reason:       c.String("reason"),
+				})
+			}),
+		},
+	},
+}
+
+var locksCommand = &cli.Command{
+	Name:  "locks",
+	Usage: "manage the row locks of a running tc server",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "list the row locks in the order of their xids and row keys",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "resource",
+					Usage: "list the row locks of the resource `ID`",
+				},
+				&cli.StringFlag{
+					Name:  "table",
+					Usage: "list the row locks of the `TABLE`",
+				},
+				&cli.StringFlag{
+					Name:  "xid",
+					Usage: "list the row locks of the global session `XID`",
+				},
+				&cli.Int64Flag{
+					Name:  "branch",
+					Usage: "list the row locks of the branch session `ID`",
+				},
+				&cli.IntFlag{
+					Name:  "offset",
+					Usage: "skip the first `N` row locks",
+				},
+				&cli.IntFlag{
+					Name:  "limit",
+					Value: 100,
+					Usage: "list at most `N` row locks",
+				},
+			}, adminFlags...),
+			Action: adminAction(func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error) {
+				return client.ListLocks(ctx, &apis.LockListRequest{
+					ResourceID: c.String("resource"),
+					TableName:  c.String("table"),
+					XID:        c.String("xid"),
+					BranchID:   c.Int64("branch"),
+					Offset:     int32(c.Int("offset")),
+					Limit:      int32(c.Int("limit")),
+				})
+			}),
+		},
+		{
+			Name:      "release",
+			Usage:     "release the row locks of a global session, of one of its branches or of a lock key",
+			ArgsUsage: "XID",
+			Flags: append([]cli.Flag{
+				&cli.Int64Flag{
+					Name:  "branch",
+					Usage: "release the row locks of the branch session `ID`",
+				},
+				&cli.StringFlag{
+					Name:  "resource",
+					Usage: "the resource `ID` of the lock key",
+				},
+				&cli.StringFlag{
+					Name:  "lock-key",
+					Usage: "release the rows of the `LOCK_KEY`, such as product:1,2",
+				},
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "release the row locks of a global session which has not timed out yet",
+				},
+				reasonFlag,
+			}, adminFlags...),
+			Action: adminAction(func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error) {
+				xid, err := xidArg(c)
+				if err != nil {
+					return nil, err
+				}
+				return client.ReleaseLocks(ctx, &apis.LockReleaseRequest{
+					XID:        xid,
+					BranchID:   c.Int64("branch"),
+					ResourceID: c.String("resource"),
+					LockKey:    c.String("lock-key"),
+					Force:      c.Bool("force"),
+					Reason:     c.String("reason"),
+				})
+			}),
+		},
+	},
+}
+
+// unfinishedStatuses are the statuses the status command counts the global sessions in
+var unfinishedStatuses = []apis.GlobalSession_GlobalStatus{
+	apis.Begin, apis.Committing, apis.CommitRetrying, apis.AsyncCommitting, apis.RollingBack,
+	apis.RollbackRetrying, apis.TimeoutRollingBack, apis.TimeoutRollbackRetrying,
+}
+
+var statusCommand = &cli.Command{
+	Name:  "status",
+	Usage: "print the number of the global sessions by status and of the row locks of a running tc server",
+	Flags: adminFlags,
+	Action: adminAction(func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error) {
+		summary := &statusSummary{}
+		for _, globalStatus := range unfinishedStatuses {
+			sessions, err := client.ListSessions(ctx, &apis.SessionListRequest{
+				Statuses: []apis.GlobalSession_GlobalStatus{globalStatus},
+				Limit:    1,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := responseError(sessions); err != nil {
+				return nil, err
+			}
+			summary.Sessions = append(summary.Sessions, statusCount{Status: globalStatus.String(), Count: sessions.Total})
+		}
+		locks, err := client.ListLocks(ctx, &apis.LockListRequest{Limit: 1})
+		if err != nil {
+			return nil, err
+		}
+		if err := responseError(locks); err != nil {
+			return nil, err
+		}
+		summary.Locks = locks.Total
+		return summary, nil
+	}),
+}
+
+type adminCall func(ctx context.Context, c *cli.Context, client apis.AdminServiceClient) (interface{}, error)
+
+// adminAction dials the tc server, makes the call and prints its response, the failed responses are
+// printed as errors
+func adminAction(call adminCall) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		format := c.String("output")
+		if format != tableOutput && format != jsonOutput {
+			return fmt.Errorf("unknown output format %s, it is one of table and json", format)
+		}
+
+		conn, err := dialAdmin(c)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.Duration("timeout"))
+		defer cancel()
+		response, err := call(ctx, c, apis.NewAdminServiceClient(conn))
+		if err != nil {
+			if status.Code(err) == codes.Unimplemented {
+				return fmt.Errorf("the admin service is not enabled, set server.enableAdmin of the tc server: %v", err)
+			}
+			return err
+		}
+		if err := responseError(response); err != nil {
+			return err
+		}
+		return printResponse(c.App.Writer, format, response)
+	}
+}
+
+// dialAdmin dials the tc server of the address flag, or of the port of the configuration
+func dialAdmin(c *cli.Context) (*grpc.ClientConn, error) {
+	cfg := &config.Configuration{}
+	if c.String("config") != "" || os.Getenv("SEATA_CONFIGURATION_PATH") != "" {
+		var err error
+		cfg, err = resolveConfiguration(c.String("config"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	address := c.String("address")
+	if address == "" {
+		if cfg.Server.Port == 0 {
+			return nil, fmt.Errorf("either the address or the configuration of the tc server is required")
+		}
+		address = fmt.Sprintf("127.0.0.1:%d", cfg.Server.Port)
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %v", address, err)
+	}
+
+	creds, err := cfg.GetAdminClientTLS(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS settings: %v", err)
+	}
+	options := []grpc.DialOption{grpc.WithInsecure()}
+	if creds != nil {
+		options = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	}
+	return grpc.Dial(address, options...)
+}
+
+func xidArg(c *cli.Context) (string, error) {
+	if c.NArg() != 1 {
+		return "", fmt.Errorf("%s requires the xid of the global session", c.Command.FullName())
+	}
+	return c.Args().First(), nil
+}
+
+// responseError returns the error of a failed response
+func responseError(response interface{}) error {
+	failed, ok := response.(interface {
+		GetResultCode() apis.ResultCode
+		GetExceptionCode() apis.ExceptionCode
+		GetMessage() string
+	})
+	if !ok || failed.GetResultCode() == apis.ResultCodeSuccess {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", failed.GetExceptionCode(), failed.GetMessage())
+}
+
+// sessionDetail is the response of the show command
+type sessionDetail struct {
+	session *apis.SessionGetResponse
+	locks   []*apis.RowLockHolder
+}
+
+func (detail *sessionDetail) MarshalJSON() ([]byte, error) {
+	view := struct {
+		GlobalSession  json.RawMessage
+		BranchSessions []json.RawMessage
+		Locks          []json.RawMessage
+	}{
+		BranchSessions: make([]json.RawMessage, 0, len(detail.session.BranchSessions)),
+		Locks:          make([]json.RawMessage, 0, len(detail.locks)),
+	}
+	var err error
+	if view.GlobalSession, err = marshalProto(detail.session.GlobalSession); err != nil {
+		return nil, err
+	}
+	for _, bs := range detail.session.BranchSessions {
+		raw, err := marshalProto(bs)
+		if err != nil {
+			return nil, err
+		}
+		view.BranchSessions = append(view.BranchSessions, raw)
+	}
+	for _, holder := range detail.locks {
+		raw, err := marshalProto(holder)
+		if err != nil {
+			return nil, err
+		}
+		view.Locks = append(view.Locks, raw)
+	}
+	return json.Marshal(view)
+}
+
+// statusSummary is the response of the status command
+type statusSummary struct {
+	Sessions []statusCount
+	Locks    int32
+}
+
+type statusCount struct {
+	Status string
+	Count  int32
+}
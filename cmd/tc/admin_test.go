@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+)
+
+type fakeAdmin struct {
+	apis.UnimplementedAdminServiceServer
+
+	listRequest   *apis.SessionListRequest
+	removeRequest *apis.SessionRemoveRequest
+}
+
+func (admin *fakeAdmin) ListSessions(ctx context.Context, request *apis.SessionListRequest) (*apis.SessionListResponse, error) {
+	admin.listRequest = request
+	return &apis.SessionListResponse{
+		ResultCode: apis.ResultCodeSuccess,
+		Sessions: []*apis.GlobalSession{
+			{XID: "localhost:8091:1", TransactionName: "createOrder", Status: apis.CommitRetrying, Timeout: 60000},
+		},
+		Total: 1,
+	}, nil
+}
+
+func (admin *fakeAdmin) GetSession(ctx context.Context, request *apis.SessionGetRequest) (*apis.SessionGetResponse, error) {
+	return &apis.SessionGetResponse{
+		ResultCode:    apis.ResultCodeSuccess,
+		GlobalSession: &apis.GlobalSession{XID: request.XID, Status: apis.RollbackRetrying},
+		BranchSessions: []*apis.BranchSession{
+			{XID: request.XID, BranchID: 11, ResourceID: "db", LockKey: "product:1"},
+		},
+	}, nil
+}
+
+func (admin *fakeAdmin) ListLocks(ctx context.Context, request *apis.LockListRequest) (*apis.LockListResponse, error) {
+	return &apis.LockListResponse{
+		ResultCode: apis.ResultCodeSuccess,
+		Locks: []*apis.RowLockHolder{
+			{RowLock: &apis.RowLock{XID: request.XID, BranchID: 11, ResourceID: "db", TableName: "product", PK: "1"}},
+		},
+		Total: 1,
+	}, nil
+}
+
+func (admin *fakeAdmin) RemoveSession(ctx context.Context, request *apis.SessionRemoveRequest) (*apis.SessionRemoveResponse, error) {
+	admin.removeRequest = request
+	return &apis.SessionRemoveResponse{
+		ResultCode:    apis.ResultCodeFailed,
+		ExceptionCode: apis.GlobalTransactionStatusInvalid,
+		Message:       "force is required",
+	}, nil
+}
+
+// runAdmin runs the admin command with the args against the fake admin service, it returns the
+// output
+func runAdmin(t *testing.T, admin apis.AdminServiceServer, command string, args ...string) (string, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	s := grpc.NewServer()
+	apis.RegisterAdminServiceServer(s, admin)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	defer s.Stop()
+
+	var out bytes.Buffer
+	app := &cli.App{
+		Commands: []*cli.Command{sessionsCommand, locksCommand, statusCommand},
+		Writer:   &out,
+	}
+	// the flags are parsed up to the first argument
+	runArgs := append([]string{"tc"}, strings.Fields(command)...)
+	runArgs = append(runArgs, "--address", lis.Addr().String())
+	err = app.Run(append(runArgs, args...))
+	return out.String(), err
+}
+
+func TestSessionsList(t *testing.T) {
+	admin := &fakeAdmin{}
+	out, err := runAdmin(t, admin, "sessions list", "--status", "CommitRetrying", "--status", "AsyncCommitting",
+		"--min-age", "1m", "--limit", "10")
+	assert.Nil(t, err)
+	assert.Equal(t, []apis.GlobalSession_GlobalStatus{apis.CommitRetrying, apis.AsyncCommitting}, admin.listRequest.Statuses)
+	assert.Equal(t, int64(60000), admin.listRequest.MinAge)
+	assert.Equal(t, int32(10), admin.listRequest.Limit)
+
+	lines := strings.Split(out, "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "XID "))
+	assert.Contains(t, lines[1], "localhost:8091:1")
+	assert.Contains(t, lines[1], "CommitRetrying")
+	assert.Contains(t, out, "1 of 1 global sessions")
+
+	_, err = runAdmin(t, admin, "sessions list", "--status", "Stuck")
+	assert.EqualError(t, err, "unknown global status Stuck")
+}
+
+func TestSessionsShow(t *testing.T) {
+	out, err := runAdmin(t, &fakeAdmin{}, "sessions show", "-o", "json", "localhost:8091:1")
+	assert.Nil(t, err)
+
+	var detail struct {
+		GlobalSession  map[string]interface{}
+		BranchSessions []map[string]interface{}
+		Locks          []map[string]interface{}
+	}
+	assert.Nil(t, json.Unmarshal([]byte(out), &detail))
+	assert.Equal(t, "RollbackRetrying", detail.GlobalSession["Status"])
+	if assert.Len(t, detail.BranchSessions, 1) {
+		assert.Equal(t, "product:1", detail.BranchSessions[0]["LockKey"])
+	}
+	assert.Len(t, detail.Locks, 1)
+
+	_, err = runAdmin(t, &fakeAdmin{}, "sessions show")
+	assert.EqualError(t, err, "sessions show requires the xid of the global session")
+}
+
+func TestSessionsRemove(t *testing.T) {
+	admin := &fakeAdmin{}
+	_, err := runAdmin(t, admin, "sessions remove", "--release-locks", "--reason", "stuck", "localhost:8091:1")
+	assert.EqualError(t, err, "GlobalTransactionStatusInvalid: force is required")
+	if assert.NotNil(t, admin.removeRequest) {
+		assert.Equal(t, "localhost:8091:1", admin.removeRequest.XID)
+		assert.True(t, admin.removeRequest.ReleaseLocks)
+		assert.False(t, admin.removeRequest.Force)
+		assert.Equal(t, "stuck", admin.removeRequest.Reason)
+	}
+}
+
+func TestLocksRelease_Unimplemented(t *testing.T) {
+	_, err := runAdmin(t, &fakeAdmin{}, "locks release", "localhost:8091:1")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "set server.enableAdmin")
+	}
+}
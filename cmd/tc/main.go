@@ -1,26 +1,39 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
 
-	"github.com/opentrx/seata-golang/v2/pkg/apis"
 	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
-	_ "github.com/opentrx/seata-golang/v2/pkg/tc/metrics"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/console"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/metrics"
 	"github.com/opentrx/seata-golang/v2/pkg/tc/server"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/bolt"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/file"
 	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/inmemory"
 	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/mysql"
 	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/pgsql"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/raft"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/redis"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/sqlite"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/migration"
 	"github.com/opentrx/seata-golang/v2/pkg/util/log"
 	"github.com/opentrx/seata-golang/v2/pkg/util/uuid"
 )
 
+// defaultShutdownTimeout is how long the tc drains on SIGTERM unless server.shutdownTimeout is set
+const defaultShutdownTimeout = 30 * time.Second
+
 func main() {
 	app := &cli.App{
 		Commands: []*cli.Command{
@@ -51,6 +64,7 @@ func main() {
 
 					_ = uuid.Init(serverNode)
 					log.Init(cfg.Log.LogPath, cfg.Log.LogLevel)
+					metrics.StartExporter()
 
 					address := fmt.Sprintf(":%v", cfg.Server.Port)
 					lis, err := net.Listen("tcp", address)
@@ -58,17 +72,25 @@ func main() {
 						log.Fatalf("failed to listen: %v", err)
 					}
 
-					s := grpc.NewServer(grpc.KeepaliveEnforcementPolicy(cfg.GetEnforcementPolicy()),
-						grpc.KeepaliveParams(cfg.GetServerParameters()), grpc.Creds(cfg.GetServerTLS()))
-
 					tc := server.NewTransactionCoordinator(cfg)
-					apis.RegisterTransactionManagerServiceServer(s, tc)
-					apis.RegisterResourceManagerServiceServer(s, tc)
+					s := grpc.NewServer(grpc.KeepaliveEnforcementPolicy(cfg.GetEnforcementPolicy()),
+						grpc.KeepaliveParams(cfg.GetServerParameters()), grpc.Creds(cfg.GetServerTLS()),
+						grpc.UnaryInterceptor(tc.UnaryInterceptor), grpc.StreamInterceptor(tc.StreamInterceptor))
+					tc.Register(s)
 
 					go func() {
+						// the followers of a replicated storage are unhealthy, so that a load balancer
+						// routes the clients to the leader
 						http.HandleFunc("/health", func(writer http.ResponseWriter, request *http.Request) {
+							if !tc.IsLeader() {
+								writer.WriteHeader(http.StatusServiceUnavailable)
+								return
+							}
 							writer.WriteHeader(http.StatusOK)
 						})
+						if cfg.Server.EnableConsole {
+							console.NewConsole(tc, consoleCounters).Register(http.DefaultServeMux)
+						}
 						err = http.ListenAndServe(":10001", nil)
 						if err != nil {
 							return
@@ -77,18 +99,95 @@ func main() {
 
 					printStartUpLogo()
 					log.Infof("start to serve on port %d", cfg.Server.Port)
-					if err := s.Serve(lis); err != nil {
-						log.Fatalf("failed to serve: %v", err)
+					served := make(chan error, 1)
+					go func() {
+						served <- s.Serve(lis)
+					}()
+
+					signals := make(chan os.Signal, 1)
+					signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+					select {
+					case err := <-served:
+						if err != nil {
+							log.Fatalf("failed to serve: %v", err)
+						}
+						return nil
+					case sig := <-signals:
+						log.Infof("received %v", sig)
+					}
+
+					timeout := cfg.Server.ShutdownTimeout
+					if timeout <= 0 {
+						timeout = defaultShutdownTimeout
+					}
+					ctx, cancel := context.WithTimeout(context.Background(), timeout)
+					defer cancel()
+					return tc.Stop(ctx)
+				},
+			},
+			{
+				Name:  "migrate",
+				Usage: "migrate the schema of the sql storage to the version the tc server requires",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Aliases: []string{"c"},
+						Usage:   "Load configuration from `FILE`",
+					},
+					&cli.BoolFlag{
+						Name:  "status",
+						Usage: "print the schema versions without migrating",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					cfg, err := resolveConfiguration(c.String("config"))
+					if err != nil || cfg == nil {
+						return err
+					}
+					log.Init(cfg.Log.LogPath, cfg.Log.LogLevel)
+
+					migrator, err := migration.Create(cfg.Storage.Type(), cfg.Storage.Parameters())
+					if err != nil {
+						return err
 					}
+					defer func() {
+						if err := migrator.Close(); err != nil {
+							log.Error(err)
+						}
+					}()
+
+					if c.Bool("status") {
+						current, err := migrator.CurrentVersion()
+						if err != nil {
+							return err
+						}
+						fmt.Printf("current schema version: %d, latest schema version: %d\n",
+							current, migrator.LatestVersion())
+						return nil
+					}
+
+					applied, err := migrator.Up()
+					for _, m := range applied {
+						fmt.Printf("applied migration %d: %s\n", m.Version, m.Description)
+					}
+					if err != nil {
+						return err
+					}
+					fmt.Printf("the schema is at version %d\n", migrator.LatestVersion())
 					return nil
 				},
 			},
+			sessionsCommand,
+			locksCommand,
+			statusCommand,
+			configCommand,
 		},
 	}
 
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Error(err)
+		os.Exit(1)
 	}
 }
 
@@ -125,6 +224,18 @@ func resolveConfiguration(configPath string) (*config.Configuration, error) {
 	return cfg, nil
 }
 
+func consoleCounters() []console.Counter {
+	var counters []console.Counter
+	for _, counter := range metrics.AllCounters() {
+		counters = append(counters, console.Counter{
+			Name:   counter.Name,
+			Labels: counter.Labels,
+			Value:  counter.Count(),
+		})
+	}
+	return counters
+}
+
 func printStartUpLogo() {
 	logoStr := "                _                          _                   \n" +
 		" ___  ___  __ _| |_ __ _        __ _  ___ | | __ _ _ __   __ _ \n" +
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+)
+
+const (
+	tableOutput = "table"
+	jsonOutput  = "json"
+)
+
+var marshaler = &jsonpb.Marshaler{EmitDefaults: true, OrigName: true, Indent: "  "}
+
+// printResponse prints the response of an admin call in the output format
+func printResponse(w io.Writer, format string, response interface{}) error {
+	if format == jsonOutput {
+		var (
+			out []byte
+			err error
+		)
+		if m, ok := response.(proto.Message); ok {
+			out, err = marshalProto(m)
+		} else {
+			out, err = json.MarshalIndent(response, "", "  ")
+		}
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	now := time.Now()
+	switch r := response.(type) {
+	case *apis.SessionListResponse:
+		printSessions(tw, now, r.Sessions)
+		fmt.Fprintf(tw, "\n%d of %d global sessions\n", len(r.Sessions), r.Total)
+	case *sessionDetail:
+		gs := r.session.GlobalSession
+		fmt.Fprintf(tw, "XID:\t%s\n", gs.XID)
+		fmt.Fprintf(tw, "Name:\t%s\n", gs.TransactionName)
+		fmt.Fprintf(tw, "Addressing:\t%s\n", gs.Addressing)
+		fmt.Fprintf(tw, "Status:\t%s\n", gs.Status)
+		fmt.Fprintf(tw, "Active:\t%t\n", gs.Active)
+		fmt.Fprintf(tw, "Begin:\t%s\n", beginTime(gs.BeginTime))
+		fmt.Fprintf(tw, "Age:\t%s\n", age(now, gs.BeginTime))
+		fmt.Fprintf(tw, "Timeout:\t%s\n", time.Duration(gs.Timeout)*time.Millisecond)
+		fmt.Fprintln(tw, "\nBRANCH\tTYPE\tRESOURCE\tADDRESSING\tSTATUS\tLOCK KEY")
+		for _, bs := range r.session.BranchSessions {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", bs.BranchID, bs.Type, bs.ResourceID, bs.Addressing, bs.Status,
+				bs.LockKey)
+		}
+		fmt.Fprintln(tw)
+		printLocks(tw, r.locks)
+	case *apis.SessionRetryResponse:
+		fmt.Fprintf(tw, "global status: %s\n", r.GlobalStatus)
+		if r.Message != "" {
+			fmt.Fprintln(tw, r.Message)
+		}
+	case *apis.SessionRemoveResponse:
+		fmt.Fprintln(tw, "the global session is removed")
+	case *apis.LockListResponse:
+		printLocks(tw, r.Locks)
+		fmt.Fprintf(tw, "\n%d of %d row locks\n", len(r.Locks), r.Total)
+	case *apis.LockReleaseResponse:
+		fmt.Fprintf(tw, "%d row locks are released\n", r.Released)
+	case *statusSummary:
+		fmt.Fprintln(tw, "STATUS\tGLOBAL SESSIONS")
+		for _, count := range r.Sessions {
+			fmt.Fprintf(tw, "%s\t%d\n", count.Status, count.Count)
+		}
+		fmt.Fprintf(tw, "\nrow locks:\t%d\n", r.Locks)
+	default:
+		return fmt.Errorf("unknown response %T", response)
+	}
+	return tw.Flush()
+}
+
+func printSessions(w io.Writer, now time.Time, sessions []*apis.GlobalSession) {
+	fmt.Fprintln(w, "XID\tNAME\tADDRESSING\tSTATUS\tACTIVE\tBEGIN\tAGE\tTIMEOUT")
+	for _, gs := range sessions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\t%s\t%s\n", gs.XID, gs.TransactionName, gs.Addressing, gs.Status,
+			gs.Active, beginTime(gs.BeginTime), age(now, gs.BeginTime), time.Duration(gs.Timeout)*time.Millisecond)
+	}
+}
+
+func printLocks(w io.Writer, locks []*apis.RowLockHolder) {
+	fmt.Fprintln(w, "XID\tBRANCH\tRESOURCE\tTABLE\tPK\tSTATUS\tAGE")
+	for _, holder := range locks {
+		rowLock := holder.RowLock
+		lockAge := ""
+		if holder.BeginTime != 0 {
+			lockAge = (time.Duration(holder.Age) * time.Millisecond).String()
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", rowLock.XID, rowLock.BranchID, rowLock.ResourceID,
+			rowLock.TableName, rowLock.PK, holder.Status, lockAge)
+	}
+}
+
+func beginTime(millis int64) string {
+	return time.Unix(0, millis*int64(time.Millisecond)).Format("2006-01-02 15:04:05")
+}
+
+func age(now time.Time, beginTime int64) string {
+	return now.Sub(time.Unix(0, beginTime*int64(time.Millisecond))).Truncate(time.Second).String()
+}
+
+// marshalProto marshals the protobuf message to json, the enums are their names
+func marshalProto(m proto.Message) (json.RawMessage, error) {
+	var sb strings.Builder
+	if err := marshaler.Marshal(&sb, m); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(sb.String()), nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/lock"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/server"
+)
+
+var configCommand = &cli.Command{
+	Name:  "config",
+	Usage: "check the configuration of the tc server",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "validate",
+			Usage: "validate the configuration without starting the tc server",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "config",
+					Aliases: []string{"c"},
+					Usage:   "Load configuration from `FILE`",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				cfg, err := resolveConfiguration(c.String("config"))
+				if err != nil {
+					return err
+				}
+				problems := validateConfiguration(cfg)
+				for _, problem := range problems {
+					fmt.Fprintln(c.App.Writer, problem)
+				}
+				if len(problems) != 0 {
+					return fmt.Errorf("the configuration has %d problems", len(problems))
+				}
+				fmt.Fprintln(c.App.Writer, "the configuration is valid")
+				return nil
+			},
+		},
+	},
+}
+
+// validateConfiguration returns the problems of the configuration the tc server would fail on, or
+// would misbehave with, at startup
+func validateConfiguration(cfg *config.Configuration) []string {
+	var problems []string
+	if cfg.Server.Port <= 0 || cfg.Server.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("server.port %d is not a valid port", cfg.Server.Port))
+	}
+	periods := []struct {
+		name   string
+		period time.Duration
+	}{
+		{"server.asyncCommittingRetryPeriod", cfg.Server.AsyncCommittingRetryPeriod},
+		{"server.committingRetryPeriod", cfg.Server.CommittingRetryPeriod},
+		{"server.rollingBackRetryPeriod", cfg.Server.RollingBackRetryPeriod},
+		{"server.timeoutRetryPeriod", cfg.Server.TimeoutRetryPeriod},
+	}
+	for _, p := range periods {
+		if p.period <= 0 {
+			problems = append(problems, fmt.Sprintf("%s should be positive", p.name))
+		}
+	}
+	if cfg.Server.LockWaitTimeout < 0 {
+		problems = append(problems, "server.lockWaitTimeout should not be negative")
+	}
+
+	if cfg.ServerTLS.Enable {
+		if _, err := tls.LoadX509KeyPair(cfg.ServerTLS.CertFilePath, cfg.ServerTLS.KeyFilePath); err != nil {
+			problems = append(problems, fmt.Sprintf("serverTLS: %v", err))
+		}
+	}
+
+	storageType := cfg.Storage.Type()
+	if storageType == "" {
+		problems = append(problems, "storage should specify a storage driver")
+	}
+	if storageType == "mysql" || storageType == "pgsql" {
+		if dsn := cfg.Storage.Parameters()["dsn"]; dsn == nil || fmt.Sprint(dsn) == "" {
+			problems = append(problems, fmt.Sprintf("storage.%s.dsn should not be empty", storageType))
+		}
+	}
+
+	switch cfg.Locker.Type {
+	case "", server.MemoryGlobalSessionLockerType:
+	case server.DatabaseGlobalSessionLockerType:
+		if storageType != "mysql" && storageType != "pgsql" {
+			problems = append(problems, fmt.Sprintf("locker.type database does not support %s storage", storageType))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("locker.type %s is not one of memory and database", cfg.Locker.Type))
+	}
+	if cfg.Locker.Lease < 0 || cfg.Locker.RetryPeriod < 0 {
+		problems = append(problems, "locker.lease and locker.retryPeriod should not be negative")
+	}
+
+	switch cfg.LockTable.Persistence {
+	case "", lock.WriteThroughPersistence, lock.BackgroundPersistence:
+	default:
+		problems = append(problems, fmt.Sprintf("lockTable.persistence %s is not one of writethrough and background",
+			cfg.LockTable.Persistence))
+	}
+	if cfg.LockTable.Shards < 0 || cfg.LockTable.QueueSize < 0 {
+		problems = append(problems, "lockTable.shards and lockTable.queueSize should not be negative")
+	}
+	if cfg.LockTable.Enable && cfg.Locker.Type == server.DatabaseGlobalSessionLockerType {
+		problems = append(problems, "lockTable should not be enabled on the tc nodes sharing a storage with the database locker")
+	}
+	return problems
+}
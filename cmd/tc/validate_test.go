@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+)
+
+func TestValidateConfiguration(t *testing.T) {
+	cfg, err := config.Parse(strings.NewReader(`
+server:
+  port: 8091
+  asyncCommittingRetryPeriod: 10s
+  committingRetryPeriod: 1s
+  rollingBackRetryPeriod: 1s
+  timeoutRetryPeriod: 1s
+storage:
+  mysql:
+    dsn: "root:123456@tcp(127.0.0.1:3306)/seata?timeout=1s"
+locker:
+  type: database
+`))
+	assert.Nil(t, err)
+	assert.Empty(t, validateConfiguration(cfg))
+
+	cfg.Server.Port = 0
+	cfg.Server.TimeoutRetryPeriod = 0
+	cfg.Storage = config.Storage{"inmemory": config.Parameters{}}
+	cfg.LockTable.Persistence = "eventually"
+	cfg.ServerTLS.Enable = true
+	cfg.ServerTLS.CertFilePath = "missing.crt"
+	assert.Equal(t, []string{
+		"server.port 0 is not a valid port",
+		"server.timeoutRetryPeriod should be positive",
+		"serverTLS: open missing.crt: no such file or directory",
+		"locker.type database does not support inmemory storage",
+		"lockTable.persistence eventually is not one of writethrough and background",
+	}, validateConfiguration(cfg))
+}
@@ -90,6 +90,8 @@ const (
 	FailedWriteSession ExceptionCode = 17
 	// Failed to holder error code
 	FailedStore ExceptionCode = 18
+	// The branch registration is chosen as the victim of a lock wait deadlock.
+	LockWaitDeadlock ExceptionCode = 19
 )
 
 var ExceptionCode_name = map[int32]string{
@@ -112,6 +114,7 @@ var ExceptionCode_name = map[int32]string{
 	16: "FailedLockGlobalTransaction",
 	17: "FailedWriteSession",
 	18: "FailedStore",
+	19: "LockWaitDeadlock",
 }
 
 var ExceptionCode_value = map[string]int32{
@@ -134,6 +137,7 @@ var ExceptionCode_value = map[string]int32{
 	"FailedLockGlobalTransaction":       16,
 	"FailedWriteSession":                17,
 	"FailedStore":                       18,
+	"LockWaitDeadlock":                  19,
 }
 
 func (ExceptionCode) EnumDescriptor() ([]byte, []int) {
@@ -573,7 +577,7 @@ type RowLock struct {
 	BranchID      int64  `protobuf:"varint,3,opt,name=BranchID,proto3" json:"BranchID,omitempty" xorm:"branch_id"`
 	ResourceID    string `protobuf:"bytes,4,opt,name=ResourceID,proto3" json:"ResourceID,omitempty" xorm:"resource_id"`
 	TableName     string `protobuf:"bytes,5,opt,name=TableName,proto3" json:"TableName,omitempty" xorm:"table_name"`
-	PK            string `protobuf:"bytes,6,opt,name=PK,proto3" json:"PK,omitempty" xorm:"pk"`
+	PK            string `protobuf:"bytes,6,opt,name=PK,proto3" json:"PK,omitempty" xorm:"'pk'"`
 	RowKey        string `protobuf:"bytes,7,opt,name=RowKey,proto3" json:"RowKey,omitempty" xorm:"row_key"`
 }
 
@@ -2077,562 +2081,1311 @@ func (m *BranchMessage) GetMessage() *types.Any {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("apis.ResultCode", ResultCode_name, ResultCode_value)
-	proto.RegisterEnum("apis.ExceptionCode", ExceptionCode_name, ExceptionCode_value)
-	proto.RegisterEnum("apis.BranchMessageType", BranchMessageType_name, BranchMessageType_value)
-	proto.RegisterEnum("apis.GlobalSession_GlobalStatus", GlobalSession_GlobalStatus_name, GlobalSession_GlobalStatus_value)
-	proto.RegisterEnum("apis.BranchSession_BranchType", BranchSession_BranchType_name, BranchSession_BranchType_value)
-	proto.RegisterEnum("apis.BranchSession_BranchStatus", BranchSession_BranchStatus_name, BranchSession_BranchStatus_value)
-	proto.RegisterType((*GlobalSession)(nil), "apis.GlobalSession")
-	proto.RegisterType((*BranchSession)(nil), "apis.BranchSession")
-	proto.RegisterType((*RowLock)(nil), "apis.RowLock")
-	proto.RegisterType((*GlobalBeginRequest)(nil), "apis.GlobalBeginRequest")
-	proto.RegisterType((*GlobalBeginResponse)(nil), "apis.GlobalBeginResponse")
-	proto.RegisterType((*BranchRegisterRequest)(nil), "apis.BranchRegisterRequest")
-	proto.RegisterType((*BranchRegisterResponse)(nil), "apis.BranchRegisterResponse")
-	proto.RegisterType((*BranchReportRequest)(nil), "apis.BranchReportRequest")
-	proto.RegisterType((*BranchReportResponse)(nil), "apis.BranchReportResponse")
-	proto.RegisterType((*GlobalLockQueryRequest)(nil), "apis.GlobalLockQueryRequest")
-	proto.RegisterType((*GlobalLockQueryResponse)(nil), "apis.GlobalLockQueryResponse")
-	proto.RegisterType((*GlobalStatusRequest)(nil), "apis.GlobalStatusRequest")
-	proto.RegisterType((*GlobalStatusResponse)(nil), "apis.GlobalStatusResponse")
-	proto.RegisterType((*GlobalCommitRequest)(nil), "apis.GlobalCommitRequest")
-	proto.RegisterType((*GlobalCommitResponse)(nil), "apis.GlobalCommitResponse")
-	proto.RegisterType((*GlobalRollbackRequest)(nil), "apis.GlobalRollbackRequest")
-	proto.RegisterType((*GlobalRollbackResponse)(nil), "apis.GlobalRollbackResponse")
-	proto.RegisterType((*GlobalReportRequest)(nil), "apis.GlobalReportRequest")
-	proto.RegisterType((*GlobalReportResponse)(nil), "apis.GlobalReportResponse")
-	proto.RegisterType((*BranchCommitRequest)(nil), "apis.BranchCommitRequest")
-	proto.RegisterType((*BranchCommitResponse)(nil), "apis.BranchCommitResponse")
-	proto.RegisterType((*BranchRollbackRequest)(nil), "apis.BranchRollbackRequest")
-	proto.RegisterType((*BranchRollbackResponse)(nil), "apis.BranchRollbackResponse")
-	proto.RegisterType((*BranchMessage)(nil), "apis.BranchMessage")
+// RowLockHolder represents a row lock and the global session holding it
+type RowLockHolder struct {
+	RowLock   *RowLock                   `protobuf:"bytes,1,opt,name=RowLock,proto3" json:"RowLock,omitempty"`
+	Status    GlobalSession_GlobalStatus `protobuf:"varint,2,opt,name=Status,proto3,enum=apis.GlobalSession_GlobalStatus" json:"Status,omitempty"`
+	BeginTime int64                      `protobuf:"varint,3,opt,name=BeginTime,proto3" json:"BeginTime,omitempty"`
+	// Age is the milliseconds elapsed since the global session began
+	Age int64 `protobuf:"varint,4,opt,name=Age,proto3" json:"Age,omitempty"`
 }
 
-func init() { proto.RegisterFile("seata.proto", fileDescriptor_450a439f8893981f) }
-
-var fileDescriptor_450a439f8893981f = []byte{
-	// 1943 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x59, 0x4f, 0x6f, 0x23, 0x49,
-	0x15, 0x77, 0xb7, 0xff, 0xbf, 0xc4, 0x76, 0xa5, 0x3c, 0x49, 0x1c, 0xcf, 0xa6, 0xed, 0xe9, 0xd5,
-	0x0a, 0xef, 0x88, 0xcd, 0x8c, 0x32, 0x80, 0x34, 0x08, 0xb1, 0xb2, 0x93, 0xec, 0x10, 0xc2, 0xee,
-	0x0e, 0x9d, 0xac, 0xd8, 0x5b, 0xd4, 0xb1, 0x6b, 0x9d, 0x56, 0x9c, 0x6e, 0xd3, 0xdd, 0x9e, 0xc4,
-	0xe2, 0xc2, 0x37, 0x58, 0xd0, 0x4a, 0x1c, 0x90, 0xb8, 0x71, 0xe0, 0xc4, 0x8d, 0x03, 0x82, 0x33,
-	0xe2, 0xc0, 0x61, 0x4e, 0x68, 0x4f, 0x16, 0x93, 0x11, 0x02, 0x24, 0x0e, 0x2b, 0x7f, 0x02, 0x54,
-	0x7f, 0xda, 0x5d, 0x65, 0x3b, 0xeb, 0xc9, 0x02, 0x22, 0xec, 0xcd, 0xf5, 0xde, 0xaf, 0x5e, 0x55,
-	0xbf, 0xf7, 0x7e, 0xef, 0x55, 0x95, 0x61, 0x29, 0x20, 0x76, 0x68, 0x6f, 0xf5, 0x7d, 0x2f, 0xf4,
-	0x70, 0xca, 0xee, 0x3b, 0x41, 0xf5, 0xad, 0xae, 0x13, 0x9e, 0x0e, 0x4e, 0xb6, 0xda, 0xde, 0xf9,
-	0x83, 0xae, 0xd7, 0xf5, 0x1e, 0x30, 0xe5, 0xc9, 0xe0, 0x23, 0x36, 0x62, 0x03, 0xf6, 0x8b, 0x4f,
-	0xaa, 0x6e, 0x74, 0x3d, 0xaf, 0xdb, 0x23, 0x31, 0xca, 0x76, 0x87, 0x5c, 0x65, 0xfe, 0x3c, 0x03,
-	0x85, 0x27, 0x3d, 0xef, 0xc4, 0xee, 0x1d, 0x92, 0x20, 0x70, 0x3c, 0x17, 0x7f, 0x1d, 0xa0, 0xd9,
-	0xe9, 0xf8, 0x74, 0xe4, 0x76, 0x2b, 0x5a, 0x5d, 0x6b, 0xe4, 0x5b, 0xab, 0xe3, 0x51, 0x6d, 0xe5,
-	0xd2, 0xf3, 0xcf, 0xbf, 0x69, 0xda, 0x13, 0x9d, 0x69, 0x49, 0x40, 0x5c, 0x87, 0xe4, 0x87, 0xfb,
-	0xbb, 0x15, 0x9d, 0xe1, 0x8b, 0xe3, 0x51, 0x0d, 0x38, 0xfe, 0xd2, 0xe9, 0x98, 0x16, 0x55, 0xe1,
-	0xb7, 0xa1, 0x70, 0xe4, 0xdb, 0x6e, 0x60, 0xb7, 0x43, 0xc7, 0x73, 0xf7, 0x77, 0x2b, 0xc9, 0xba,
-	0xd6, 0x48, 0xb6, 0x36, 0xc6, 0xa3, 0xda, 0x2a, 0xc7, 0x86, 0xb1, 0xfa, 0x98, 0x4e, 0x53, 0xf1,
-	0x78, 0x0f, 0x4a, 0x92, 0xe0, 0x3d, 0xfb, 0x9c, 0x54, 0x52, 0x6c, 0xb9, 0xbb, 0xe3, 0x51, 0x6d,
-	0x7d, 0xd6, 0x84, 0x6b, 0x9f, 0x13, 0xd3, 0x9a, 0x9e, 0x83, 0xbf, 0x0a, 0xd9, 0x23, 0xe7, 0x9c,
-	0x78, 0x83, 0xb0, 0x92, 0xae, 0x6b, 0x8d, 0x74, 0x0b, 0x8f, 0x47, 0xb5, 0xa2, 0x98, 0xce, 0x15,
-	0xa6, 0x15, 0x41, 0xf0, 0x23, 0xc8, 0xb7, 0x48, 0xd7, 0x71, 0xe9, 0xb8, 0x92, 0x61, 0x3b, 0x96,
-	0xbc, 0x71, 0x42, 0x55, 0xc7, 0x74, 0x96, 0x69, 0xc5, 0x38, 0x7c, 0x00, 0x99, 0xc3, 0xd0, 0x0e,
-	0x07, 0x41, 0x25, 0x5b, 0xd7, 0x1a, 0xc5, 0xed, 0xfa, 0x16, 0x0d, 0xdb, 0x96, 0xe2, 0xe8, 0x68,
-	0xc4, 0x70, 0xad, 0x95, 0xf1, 0xa8, 0x56, 0xe0, 0x36, 0x03, 0x26, 0x31, 0x2d, 0x61, 0x02, 0xbf,
-	0x09, 0x99, 0x66, 0x3b, 0x74, 0x9e, 0x91, 0x4a, 0xae, 0xae, 0x35, 0x72, 0x32, 0xd4, 0x66, 0x72,
-	0xd3, 0x12, 0x00, 0xf3, 0x4f, 0x3a, 0x2c, 0xcb, 0x66, 0xf1, 0x3a, 0x94, 0x3f, 0x70, 0xcf, 0x5c,
-	0xef, 0xc2, 0x95, 0xc5, 0x28, 0x81, 0xf3, 0x90, 0x66, 0xdb, 0x45, 0x1a, 0x2e, 0x02, 0xec, 0x78,
-	0xe7, 0xe7, 0x4e, 0x18, 0x3a, 0x6e, 0x17, 0xe9, 0x18, 0x43, 0x91, 0x8f, 0x2d, 0x12, 0xfa, 0x43,
-	0x2a, 0x4b, 0xe2, 0x12, 0x2c, 0x59, 0x5e, 0xaf, 0xe7, 0xb8, 0xdd, 0x96, 0xdd, 0x3e, 0x43, 0x29,
-	0x7c, 0x07, 0x10, 0x15, 0x9c, 0xd8, 0xed, 0xb3, 0x09, 0x2c, 0x8d, 0xd7, 0x00, 0x0b, 0xbf, 0xc9,
-	0xe8, 0x0c, 0xbe, 0x0b, 0xeb, 0x92, 0x5c, 0x99, 0x94, 0xc5, 0x65, 0x28, 0x35, 0x83, 0xa1, 0xdb,
-	0x96, 0x36, 0x91, 0xc3, 0x05, 0xc8, 0x8b, 0x31, 0xe9, 0xa0, 0x3c, 0x46, 0xb0, 0xcc, 0x87, 0xef,
-	0xd8, 0x4e, 0x8f, 0x74, 0x10, 0xd0, 0x5d, 0x53, 0x5b, 0xa4, 0xc3, 0x96, 0x58, 0xa2, 0xbb, 0x8e,
-	0x6c, 0x0b, 0xcc, 0x32, 0x5e, 0x85, 0x15, 0x69, 0x59, 0x01, 0x2d, 0xe0, 0x0d, 0x58, 0x9d, 0xda,
-	0x8d, 0x98, 0x51, 0xc4, 0xcb, 0x90, 0x7b, 0xc7, 0x71, 0x9d, 0xe0, 0x94, 0x74, 0x50, 0xc9, 0xfc,
-	0x43, 0x16, 0x0a, 0x2d, 0xdf, 0x76, 0xdb, 0xa7, 0xff, 0x75, 0x72, 0x3c, 0x84, 0x1c, 0x5f, 0x69,
-	0xc2, 0x8b, 0x3b, 0xe3, 0x51, 0x0d, 0x89, 0x2c, 0x63, 0x1a, 0x46, 0x89, 0x09, 0x6a, 0x96, 0x4e,
-	0xa9, 0x1b, 0xd2, 0xe9, 0x1b, 0x00, 0x16, 0x09, 0xbc, 0x81, 0xdf, 0x26, 0xfb, 0xbb, 0x8c, 0x0a,
-	0xf9, 0xd6, 0xda, 0x78, 0x54, 0xc3, 0x7c, 0xb6, 0x2f, 0x74, 0x6c, 0xaa, 0x84, 0xc4, 0x6f, 0x41,
-	0xf6, 0x7b, 0x5e, 0xfb, 0xec, 0x80, 0x0c, 0x19, 0x1f, 0xf2, 0xad, 0xf2, 0x78, 0x54, 0x2b, 0xf1,
-	0x49, 0x3d, 0xaf, 0x7d, 0x76, 0x7c, 0x46, 0x86, 0xa6, 0x15, 0x61, 0xf0, 0x77, 0x21, 0x75, 0x34,
-	0xec, 0x13, 0xc1, 0x04, 0x83, 0x33, 0x41, 0xf1, 0xaa, 0x18, 0x51, 0x94, 0xbc, 0x01, 0xf1, 0xd5,
-	0xe1, 0xb0, 0x4f, 0x4c, 0x8b, 0xd9, 0x90, 0x78, 0x95, 0x93, 0x79, 0x35, 0xcf, 0xda, 0x62, 0x5e,
-	0xed, 0x41, 0xa9, 0xd9, 0xef, 0xf7, 0x9c, 0xb6, 0x4d, 0x1d, 0xb2, 0x6b, 0x87, 0x76, 0x25, 0x5f,
-	0xd7, 0x1a, 0xcb, 0x72, 0x39, 0xb1, 0x63, 0xc0, 0x71, 0xc7, 0x0e, 0x6d, 0xd3, 0x9a, 0x9e, 0x83,
-	0x1f, 0xc3, 0x92, 0x94, 0xbe, 0x15, 0x60, 0x1c, 0x5d, 0x1f, 0x8f, 0x6a, 0x65, 0x61, 0x82, 0x2a,
-	0x8f, 0xdb, 0x4c, 0x6b, 0x5a, 0x32, 0xd6, 0x7c, 0x00, 0x10, 0x7f, 0x3a, 0xce, 0x80, 0xde, 0x3c,
-	0x42, 0x09, 0x9c, 0x85, 0xe4, 0xd1, 0xce, 0x0e, 0xd2, 0x70, 0x0e, 0x52, 0x87, 0xcd, 0x27, 0x4d,
-	0xa4, 0x53, 0xd5, 0x87, 0x4d, 0x94, 0x34, 0x7f, 0xa3, 0xc3, 0xb2, 0xfc, 0x79, 0x12, 0xbf, 0x65,
-	0x31, 0x4a, 0x30, 0x7a, 0x90, 0xae, 0x13, 0x84, 0xc4, 0x27, 0x1d, 0xa4, 0x51, 0x02, 0x3d, 0x3d,
-	0xb5, 0x03, 0xf2, 0xbe, 0x4b, 0x76, 0x3d, 0x97, 0x70, 0x9a, 0x47, 0x12, 0x91, 0xfe, 0x49, 0x4a,
-	0xc5, 0x48, 0x26, 0x18, 0x82, 0x52, 0x94, 0x45, 0x4c, 0x78, 0x74, 0xe1, 0xc5, 0x94, 0x4c, 0xe3,
-	0x7b, 0xb0, 0xa9, 0x8a, 0xb9, 0x15, 0x46, 0x6c, 0xfb, 0xa4, 0x47, 0x50, 0x06, 0xbf, 0x0e, 0xb5,
-	0x79, 0x90, 0x1d, 0xdb, 0x7d, 0xcf, 0xe3, 0xd5, 0x05, 0x65, 0x69, 0xcd, 0x88, 0x40, 0x12, 0x4b,
-	0x73, 0xf2, 0x64, 0x95, 0xa6, 0xf1, 0x0a, 0x79, 0xfc, 0x06, 0xdc, 0x9b, 0x0f, 0x92, 0xd7, 0x00,
-	0xf3, 0xcf, 0x3a, 0x64, 0x2d, 0xef, 0x82, 0xa6, 0x64, 0xc4, 0x45, 0xed, 0x06, 0x8d, 0x4a, 0xbf,
-	0x21, 0xb3, 0x6e, 0x4e, 0x66, 0x95, 0x8b, 0xa9, 0x57, 0xe6, 0xe2, 0x23, 0xc8, 0x1f, 0x51, 0x57,
-	0xb0, 0x66, 0x98, 0x9e, 0x2e, 0x47, 0x21, 0x55, 0x89, 0x36, 0x18, 0xe3, 0xf0, 0x26, 0xe8, 0x4f,
-	0x0f, 0x04, 0x77, 0x0b, 0xe3, 0x51, 0x2d, 0xcf, 0xd1, 0xfd, 0x33, 0xd3, 0xd2, 0x9f, 0x1e, 0xe0,
-	0xfb, 0x90, 0xb1, 0xbc, 0x0b, 0x4a, 0xef, 0x2c, 0x83, 0x48, 0xed, 0xd1, 0xf7, 0x2e, 0x38, 0xbb,
-	0x05, 0xc2, 0xbc, 0x04, 0xcc, 0x1b, 0x0b, 0x6b, 0x26, 0x16, 0xf9, 0xe1, 0x80, 0x04, 0x21, 0x36,
-	0x66, 0xab, 0xa4, 0x52, 0x0e, 0x2b, 0x71, 0x07, 0xa6, 0xae, 0x4d, 0xc7, 0xdd, 0xb6, 0x31, 0xdb,
-	0xe2, 0x93, 0x6c, 0xfa, 0xb4, 0xd8, 0xfc, 0xb5, 0x06, 0x65, 0x65, 0xe9, 0xa0, 0xef, 0xb9, 0x01,
-	0xc1, 0x0f, 0x99, 0x27, 0x07, 0xbd, 0x70, 0xc7, 0xeb, 0x10, 0xb6, 0x76, 0x71, 0x1b, 0xf1, 0x32,
-	0x11, 0xcb, 0x2d, 0x09, 0x83, 0x1f, 0x43, 0x61, 0xef, 0xb2, 0x4d, 0xfa, 0xd4, 0x34, 0x9b, 0xa4,
-	0xb3, 0x49, 0x65, 0x3e, 0x49, 0x51, 0x59, 0x2a, 0x92, 0x7e, 0xc8, 0xbb, 0x24, 0x08, 0xec, 0x6e,
-	0xb4, 0xcd, 0x68, 0x88, 0x11, 0xcf, 0x32, 0x16, 0x49, 0x96, 0x55, 0xe6, 0xc7, 0x3a, 0xac, 0xf2,
-	0x78, 0x47, 0xc4, 0x7c, 0x55, 0x77, 0x21, 0xa9, 0x7b, 0xf0, 0x0c, 0x35, 0x94, 0x74, 0xe1, 0x4b,
-	0xcb, 0x69, 0x51, 0x89, 0x4b, 0x34, 0xdf, 0xc1, 0xa4, 0x1a, 0x7f, 0x5b, 0x2e, 0x39, 0x2c, 0x63,
-	0x16, 0xd6, 0x64, 0x4b, 0x2e, 0x52, 0x8d, 0xd9, 0xa2, 0x49, 0x13, 0x69, 0x79, 0xb6, 0x2e, 0xd6,
-	0xd5, 0xba, 0x48, 0x73, 0x29, 0xa7, 0x96, 0xbf, 0xdf, 0x69, 0xb0, 0x36, 0xed, 0x91, 0xdb, 0x15,
-	0xc5, 0xaa, 0x44, 0x64, 0xd6, 0x5e, 0x63, 0xca, 0x9a, 0x9f, 0xe8, 0x50, 0x8e, 0x76, 0xdf, 0xf7,
-	0xfc, 0x30, 0x8a, 0x26, 0x92, 0xea, 0x0b, 0x8f, 0x96, 0x6c, 0x45, 0x57, 0xad, 0x2c, 0x8c, 0xa4,
-	0x1a, 0xaf, 0xd4, 0x8d, 0xe3, 0xb5, 0xab, 0x36, 0x0c, 0x11, 0xf1, 0x85, 0x7d, 0xd3, 0x52, 0xdb,
-	0xcc, 0x2b, 0x47, 0xdd, 0xfc, 0x85, 0x06, 0x77, 0x54, 0xaf, 0xdc, 0xaa, 0x88, 0x9a, 0xbf, 0xd4,
-	0x60, 0x8d, 0x97, 0x0d, 0xca, 0x88, 0xef, 0x0f, 0x88, 0x3f, 0xbc, 0x3e, 0x70, 0x6a, 0x70, 0xf4,
-	0xcf, 0xa3, 0x59, 0xf2, 0xf3, 0x68, 0x76, 0xe3, 0xb0, 0x99, 0xbf, 0xd7, 0x60, 0x7d, 0x66, 0x9b,
-	0xb7, 0x8e, 0x1b, 0x74, 0x6f, 0xb4, 0xab, 0xb0, 0x0f, 0xcc, 0x59, 0x93, 0xb1, 0xf9, 0x95, 0xa8,
-	0x36, 0x8b, 0x6c, 0xba, 0xce, 0xc3, 0xe6, 0x4b, 0x0d, 0xee, 0xa8, 0xc8, 0xdb, 0xf5, 0x91, 0xbb,
-	0xea, 0x7d, 0x4a, 0x44, 0x72, 0xe1, 0x75, 0xce, 0x52, 0x66, 0xc5, 0xee, 0x88, 0xee, 0x55, 0x8b,
-	0xdd, 0x11, 0x21, 0xbf, 0x8c, 0xee, 0x78, 0x13, 0x56, 0xf9, 0x38, 0xbe, 0x0c, 0x5e, 0xe7, 0x90,
-	0xbf, 0x4e, 0xe8, 0x1a, 0x63, 0xbf, 0x8c, 0x2e, 0x39, 0x8f, 0x32, 0x64, 0x51, 0x2f, 0x99, 0x5e,
-	0x4e, 0xff, 0x42, 0xcb, 0xc5, 0x79, 0x76, 0x2b, 0xab, 0xf4, 0x7f, 0xc8, 0xa9, 0x7f, 0xd3, 0xa2,
-	0x0e, 0xbd, 0x80, 0x77, 0xff, 0x56, 0x87, 0xbe, 0x05, 0x67, 0x2d, 0xf3, 0xa7, 0x7a, 0xd4, 0x75,
-	0x6f, 0x67, 0xdd, 0x98, 0x39, 0x0d, 0x2b, 0x1e, 0x4f, 0x4f, 0x79, 0x7c, 0xfa, 0xcc, 0x92, 0xf9,
-	0x22, 0x67, 0x16, 0xf3, 0x1f, 0xda, 0xe4, 0xbc, 0xbd, 0xa8, 0xcc, 0xfc, 0xdf, 0xc7, 0xff, 0x13,
-	0x7d, 0x72, 0x92, 0xbe, 0xa5, 0x65, 0xf2, 0x7f, 0x91, 0x01, 0x3f, 0xd3, 0xa2, 0xe7, 0xbb, 0x68,
-	0x17, 0x45, 0xd0, 0x45, 0xe0, 0x93, 0x96, 0xce, 0x5e, 0x94, 0x57, 0x14, 0x00, 0x0b, 0x14, 0xff,
-	0xdc, 0x75, 0x79, 0x31, 0x49, 0x6d, 0xcd, 0xce, 0xc0, 0x5b, 0xea, 0x67, 0x2f, 0x6d, 0xdf, 0xd9,
-	0xe2, 0x2f, 0xee, 0x5b, 0xd1, 0x8b, 0xfb, 0x56, 0xd3, 0x1d, 0x4e, 0x9c, 0x71, 0xff, 0xb1, 0x1c,
-	0x13, 0xf6, 0x94, 0x3a, 0x19, 0x89, 0xa7, 0x98, 0x04, 0x5e, 0x85, 0x95, 0x58, 0x7a, 0x38, 0x68,
-	0xb7, 0x49, 0x10, 0x20, 0xed, 0xfe, 0xc7, 0xa9, 0xa9, 0xe8, 0xe0, 0x22, 0x80, 0x78, 0x02, 0xda,
-	0xf3, 0x7d, 0x94, 0xc0, 0x25, 0x58, 0x62, 0x37, 0x62, 0x61, 0x49, 0xc3, 0x65, 0x28, 0x89, 0x8c,
-	0xdc, 0xf1, 0xdc, 0x8f, 0x7a, 0x4e, 0x3b, 0xe4, 0x2f, 0x4a, 0xfb, 0xef, 0xa3, 0x24, 0xbe, 0x07,
-	0x9b, 0x6a, 0xe2, 0x4c, 0xbf, 0xb1, 0xa4, 0xf0, 0xeb, 0x50, 0x9b, 0x07, 0xf9, 0xc0, 0xf5, 0x27,
-	0xa0, 0x34, 0xae, 0xc4, 0xc7, 0x7e, 0x7e, 0x95, 0x13, 0xcb, 0x67, 0xf0, 0x1a, 0x60, 0xf9, 0x42,
-	0x20, 0xe4, 0x59, 0xbc, 0x0e, 0xe5, 0xe8, 0xbc, 0xb8, 0x73, 0x4a, 0x26, 0x6f, 0xb0, 0x39, 0xbc,
-	0x09, 0x1b, 0x82, 0x04, 0xd2, 0x95, 0xdf, 0x0b, 0xf7, 0x2e, 0x9d, 0x20, 0x44, 0x79, 0xaa, 0xe6,
-	0x55, 0x7e, 0x9e, 0x1a, 0xb0, 0x01, 0xd5, 0x79, 0x6a, 0xfe, 0x40, 0x8e, 0x96, 0xb0, 0x09, 0xc6,
-	0x8c, 0x9e, 0xe7, 0xcb, 0xbe, 0xfb, 0xcc, 0xee, 0x39, 0x1d, 0xb4, 0x4c, 0xbf, 0x98, 0xef, 0xe6,
-	0xc8, 0x3b, 0x24, 0x6e, 0x67, 0x4e, 0x0f, 0x41, 0x05, 0xfc, 0x06, 0xdc, 0x9b, 0x05, 0x4d, 0x95,
-	0x1a, 0x54, 0xa4, 0x71, 0x8c, 0x60, 0xcd, 0x8e, 0x40, 0xa1, 0x12, 0xae, 0xc1, 0x5d, 0x2e, 0xa6,
-	0x3e, 0x98, 0xd9, 0x10, 0x42, 0xd4, 0x6d, 0x1c, 0xf0, 0x03, 0xdf, 0x09, 0x89, 0xc8, 0x76, 0xb4,
-	0x42, 0xc3, 0xcb, 0xe5, 0x87, 0xa1, 0xe7, 0x13, 0x84, 0xef, 0xff, 0x68, 0x4e, 0x0e, 0xd3, 0x9c,
-	0x62, 0xcf, 0xa9, 0xd2, 0xce, 0x51, 0x02, 0x57, 0x61, 0x6d, 0x5a, 0xca, 0x73, 0x0c, 0x69, 0xec,
-	0xe9, 0x7e, 0xa2, 0x8b, 0x3e, 0x03, 0xe9, 0xf8, 0x35, 0xa8, 0xa8, 0xf2, 0x16, 0xaf, 0x2e, 0x74,
-	0x56, 0x72, 0xfb, 0x9f, 0x3a, 0x6c, 0x48, 0xfb, 0x7e, 0xd7, 0x76, 0xed, 0x2e, 0xf1, 0x0f, 0x89,
-	0xff, 0xcc, 0x69, 0x13, 0xfc, 0x2d, 0xf1, 0x27, 0x03, 0xae, 0xc8, 0x9d, 0x5b, 0x7e, 0x2a, 0xaa,
-	0x6e, 0xcc, 0xd1, 0x88, 0xca, 0xd5, 0x82, 0xfc, 0x13, 0x12, 0x8a, 0x1b, 0xa8, 0x82, 0x53, 0x6e,
-	0x15, 0xd5, 0xea, 0x3c, 0x95, 0xb0, 0xb1, 0x17, 0x1d, 0x24, 0x78, 0xf2, 0xa9, 0x66, 0x94, 0xb3,
-	0x96, 0x6a, 0x66, 0xea, 0x58, 0xf4, 0x36, 0x64, 0xb8, 0xbb, 0x54, 0x03, 0x4a, 0x4a, 0xa8, 0x06,
-	0xa6, 0xfa, 0xf0, 0x1e, 0xe4, 0x22, 0x9f, 0xe2, 0xbb, 0xca, 0x42, 0x6a, 0xc2, 0x54, 0x5f, 0x9b,
-	0xaf, 0xe4, 0x66, 0xb6, 0x7f, 0xab, 0xc3, 0x5a, 0xd4, 0x7a, 0xa6, 0x7c, 0xdd, 0x8c, 0xd2, 0x80,
-	0xae, 0x3c, 0x70, 0x69, 0x77, 0x20, 0xb8, 0x3c, 0xa7, 0x88, 0x55, 0xe7, 0x09, 0x1b, 0xda, 0x43,
-	0x0d, 0x1f, 0x40, 0x51, 0xe5, 0x70, 0xb4, 0xd5, 0xb9, 0xcf, 0x56, 0xd1, 0x56, 0xaf, 0x79, 0xc1,
-	0xd9, 0x8b, 0x4a, 0xb8, 0xea, 0xf9, 0x39, 0x2f, 0x26, 0x91, 0xe3, 0xe6, 0x3e, 0x1b, 0x7c, 0x07,
-	0xf2, 0x93, 0x1b, 0x30, 0x56, 0x9c, 0x33, 0x7d, 0x7f, 0xaf, 0x6e, 0x5e, 0xa3, 0xe5, 0x96, 0x5a,
-	0x5f, 0x7b, 0xfe, 0xc2, 0x48, 0x7c, 0xfa, 0xc2, 0x48, 0x7c, 0xf6, 0xc2, 0xd0, 0x7e, 0x7c, 0x65,
-	0x68, 0xbf, 0xba, 0x32, 0xb4, 0x3f, 0x5e, 0x19, 0xda, 0xf3, 0x2b, 0x43, 0xfb, 0xcb, 0x95, 0xa1,
-	0xfd, 0xfd, 0xca, 0x48, 0x7c, 0x76, 0x65, 0x68, 0x3f, 0x79, 0x69, 0x24, 0x9e, 0xbf, 0x34, 0x12,
-	0x9f, 0xbe, 0x34, 0x12, 0x27, 0x19, 0x56, 0xc1, 0x1f, 0xfd, 0x2b, 0x00, 0x00, 0xff, 0xff, 0x29,
-	0x5b, 0x99, 0x9e, 0x85, 0x1d, 0x00, 0x00,
+func (m *RowLockHolder) Reset()      { *m = RowLockHolder{} }
+func (*RowLockHolder) ProtoMessage() {}
+func (*RowLockHolder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{24}
 }
-
-func (x ResultCode) String() string {
-	s, ok := ResultCode_name[int32(x)]
-	if ok {
-		return s
+func (m *RowLockHolder) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RowLockHolder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RowLockHolder.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return strconv.Itoa(int(x))
 }
-func (x ExceptionCode) String() string {
-	s, ok := ExceptionCode_name[int32(x)]
-	if ok {
-		return s
+func (m *RowLockHolder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowLockHolder.Merge(m, src)
+}
+func (m *RowLockHolder) XXX_Size() int {
+	return m.Size()
+}
+func (m *RowLockHolder) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowLockHolder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowLockHolder proto.InternalMessageInfo
+
+func (m *RowLockHolder) GetRowLock() *RowLock {
+	if m != nil {
+		return m.RowLock
 	}
-	return strconv.Itoa(int(x))
+	return nil
 }
-func (x BranchMessageType) String() string {
-	s, ok := BranchMessageType_name[int32(x)]
-	if ok {
-		return s
+
+func (m *RowLockHolder) GetStatus() GlobalSession_GlobalStatus {
+	if m != nil {
+		return m.Status
 	}
-	return strconv.Itoa(int(x))
+	return UnknownGlobalStatus
 }
-func (x GlobalSession_GlobalStatus) String() string {
-	s, ok := GlobalSession_GlobalStatus_name[int32(x)]
-	if ok {
-		return s
+
+func (m *RowLockHolder) GetBeginTime() int64 {
+	if m != nil {
+		return m.BeginTime
 	}
-	return strconv.Itoa(int(x))
+	return 0
 }
-func (x BranchSession_BranchType) String() string {
-	s, ok := BranchSession_BranchType_name[int32(x)]
-	if ok {
-		return s
+
+func (m *RowLockHolder) GetAge() int64 {
+	if m != nil {
+		return m.Age
 	}
-	return strconv.Itoa(int(x))
+	return 0
 }
-func (x BranchSession_BranchStatus) String() string {
-	s, ok := BranchSession_BranchStatus_name[int32(x)]
-	if ok {
-		return s
+
+// LockListRequest represents a request to list the row locks held by the global sessions, the empty
+// filters match all the row locks
+type LockListRequest struct {
+	ResourceID string `protobuf:"bytes,1,opt,name=ResourceID,proto3" json:"ResourceID,omitempty"`
+	TableName  string `protobuf:"bytes,2,opt,name=TableName,proto3" json:"TableName,omitempty"`
+	XID        string `protobuf:"bytes,3,opt,name=XID,proto3" json:"XID,omitempty"`
+	BranchID   int64  `protobuf:"varint,4,opt,name=BranchID,proto3" json:"BranchID,omitempty"`
+	Offset     int32  `protobuf:"varint,5,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Limit      int32  `protobuf:"varint,6,opt,name=Limit,proto3" json:"Limit,omitempty"`
+}
+
+func (m *LockListRequest) Reset()      { *m = LockListRequest{} }
+func (*LockListRequest) ProtoMessage() {}
+func (*LockListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{25}
+}
+func (m *LockListRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockListRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return strconv.Itoa(int(x))
 }
-func (this *GlobalSession) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *LockListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockListRequest.Merge(m, src)
+}
+func (m *LockListRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockListRequest proto.InternalMessageInfo
+
+func (m *LockListRequest) GetResourceID() string {
+	if m != nil {
+		return m.ResourceID
 	}
+	return ""
+}
 
-	that1, ok := that.(*GlobalSession)
-	if !ok {
-		that2, ok := that.(GlobalSession)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *LockListRequest) GetTableName() string {
+	if m != nil {
+		return m.TableName
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return ""
+}
+
+func (m *LockListRequest) GetXID() string {
+	if m != nil {
+		return m.XID
 	}
-	if this.Addressing != that1.Addressing {
-		return false
+	return ""
+}
+
+func (m *LockListRequest) GetBranchID() int64 {
+	if m != nil {
+		return m.BranchID
 	}
-	if this.XID != that1.XID {
-		return false
+	return 0
+}
+
+func (m *LockListRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
 	}
-	if this.TransactionID != that1.TransactionID {
-		return false
+	return 0
+}
+
+func (m *LockListRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
-	if this.TransactionName != that1.TransactionName {
-		return false
+	return 0
+}
+
+// LockListResponse represents a response to LockListRequest
+type LockListResponse struct {
+	ResultCode    ResultCode       `protobuf:"varint,1,opt,name=ResultCode,proto3,enum=apis.ResultCode" json:"ResultCode,omitempty"`
+	ExceptionCode ExceptionCode    `protobuf:"varint,2,opt,name=ExceptionCode,proto3,enum=apis.ExceptionCode" json:"ExceptionCode,omitempty"`
+	Message       string           `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+	Locks         []*RowLockHolder `protobuf:"bytes,4,rep,name=Locks,proto3" json:"Locks,omitempty"`
+	// Total is the number of the row locks matching the filters
+	Total int32 `protobuf:"varint,5,opt,name=Total,proto3" json:"Total,omitempty"`
+}
+
+func (m *LockListResponse) Reset()      { *m = LockListResponse{} }
+func (*LockListResponse) ProtoMessage() {}
+func (*LockListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{26}
+}
+func (m *LockListResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockListResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if this.Timeout != that1.Timeout {
-		return false
+}
+func (m *LockListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockListResponse.Merge(m, src)
+}
+func (m *LockListResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockListResponse proto.InternalMessageInfo
+
+func (m *LockListResponse) GetResultCode() ResultCode {
+	if m != nil {
+		return m.ResultCode
 	}
-	if this.BeginTime != that1.BeginTime {
-		return false
+	return ResultCodeFailed
+}
+
+func (m *LockListResponse) GetExceptionCode() ExceptionCode {
+	if m != nil {
+		return m.ExceptionCode
 	}
-	if this.Status != that1.Status {
-		return false
+	return UnknownErr
+}
+
+func (m *LockListResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	if this.Active != that1.Active {
-		return false
+	return ""
+}
+
+func (m *LockListResponse) GetLocks() []*RowLockHolder {
+	if m != nil {
+		return m.Locks
 	}
-	return true
+	return nil
 }
-func (this *BranchSession) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *LockListResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
 	}
+	return 0
+}
 
-	that1, ok := that.(*BranchSession)
-	if !ok {
-		that2, ok := that.(BranchSession)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// LockHolderQueryRequest represents a request to find the holders of the rows of a lock key
+type LockHolderQueryRequest struct {
+	ResourceID string `protobuf:"bytes,1,opt,name=ResourceID,proto3" json:"ResourceID,omitempty"`
+	LockKey    string `protobuf:"bytes,2,opt,name=LockKey,proto3" json:"LockKey,omitempty"`
+}
+
+func (m *LockHolderQueryRequest) Reset()      { *m = LockHolderQueryRequest{} }
+func (*LockHolderQueryRequest) ProtoMessage() {}
+func (*LockHolderQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{27}
+}
+func (m *LockHolderQueryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockHolderQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockHolderQueryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *LockHolderQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockHolderQueryRequest.Merge(m, src)
+}
+func (m *LockHolderQueryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockHolderQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockHolderQueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockHolderQueryRequest proto.InternalMessageInfo
+
+func (m *LockHolderQueryRequest) GetResourceID() string {
+	if m != nil {
+		return m.ResourceID
 	}
-	if this.Addressing != that1.Addressing {
-		return false
+	return ""
+}
+
+func (m *LockHolderQueryRequest) GetLockKey() string {
+	if m != nil {
+		return m.LockKey
 	}
-	if this.XID != that1.XID {
-		return false
-	}
-	if this.BranchID != that1.BranchID {
-		return false
-	}
-	if this.TransactionID != that1.TransactionID {
-		return false
-	}
-	if this.ResourceID != that1.ResourceID {
-		return false
-	}
-	if this.LockKey != that1.LockKey {
-		return false
-	}
-	if this.Type != that1.Type {
-		return false
+	return ""
+}
+
+// LockHolderQueryResponse represents a response to LockHolderQueryRequest
+type LockHolderQueryResponse struct {
+	ResultCode    ResultCode       `protobuf:"varint,1,opt,name=ResultCode,proto3,enum=apis.ResultCode" json:"ResultCode,omitempty"`
+	ExceptionCode ExceptionCode    `protobuf:"varint,2,opt,name=ExceptionCode,proto3,enum=apis.ExceptionCode" json:"ExceptionCode,omitempty"`
+	Message       string           `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+	Holders       []*RowLockHolder `protobuf:"bytes,4,rep,name=Holders,proto3" json:"Holders,omitempty"`
+}
+
+func (m *LockHolderQueryResponse) Reset()      { *m = LockHolderQueryResponse{} }
+func (*LockHolderQueryResponse) ProtoMessage() {}
+func (*LockHolderQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{28}
+}
+func (m *LockHolderQueryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockHolderQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockHolderQueryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if this.Status != that1.Status {
-		return false
+}
+func (m *LockHolderQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockHolderQueryResponse.Merge(m, src)
+}
+func (m *LockHolderQueryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockHolderQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockHolderQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockHolderQueryResponse proto.InternalMessageInfo
+
+func (m *LockHolderQueryResponse) GetResultCode() ResultCode {
+	if m != nil {
+		return m.ResultCode
 	}
-	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
-		return false
+	return ResultCodeFailed
+}
+
+func (m *LockHolderQueryResponse) GetExceptionCode() ExceptionCode {
+	if m != nil {
+		return m.ExceptionCode
 	}
-	if this.AsyncCommit != that1.AsyncCommit {
-		return false
+	return UnknownErr
+}
+
+func (m *LockHolderQueryResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return true
+	return ""
 }
-func (this *RowLock) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *LockHolderQueryResponse) GetHolders() []*RowLockHolder {
+	if m != nil {
+		return m.Holders
 	}
+	return nil
+}
 
-	that1, ok := that.(*RowLock)
-	if !ok {
-		that2, ok := that.(RowLock)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// LockReleaseRequest represents a request to force the release of the row locks of a global
+// transaction, of one of its branches if BranchID is set, or of the rows of a lock key if LockKey
+// is set
+type LockReleaseRequest struct {
+	XID        string `protobuf:"bytes,1,opt,name=XID,proto3" json:"XID,omitempty"`
+	BranchID   int64  `protobuf:"varint,2,opt,name=BranchID,proto3" json:"BranchID,omitempty"`
+	ResourceID string `protobuf:"bytes,3,opt,name=ResourceID,proto3" json:"ResourceID,omitempty"`
+	LockKey    string `protobuf:"bytes,4,opt,name=LockKey,proto3" json:"LockKey,omitempty"`
+	// Force releases the row locks of a global session which has not timed out yet
+	Force  bool   `protobuf:"varint,5,opt,name=Force,proto3" json:"Force,omitempty"`
+	Reason string `protobuf:"bytes,6,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (m *LockReleaseRequest) Reset()      { *m = LockReleaseRequest{} }
+func (*LockReleaseRequest) ProtoMessage() {}
+func (*LockReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{29}
+}
+func (m *LockReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockReleaseRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.XID != that1.XID {
-		return false
-	}
-	if this.TransactionID != that1.TransactionID {
-		return false
-	}
-	if this.BranchID != that1.BranchID {
-		return false
+}
+func (m *LockReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockReleaseRequest.Merge(m, src)
+}
+func (m *LockReleaseRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockReleaseRequest proto.InternalMessageInfo
+
+func (m *LockReleaseRequest) GetXID() string {
+	if m != nil {
+		return m.XID
 	}
-	if this.ResourceID != that1.ResourceID {
-		return false
+	return ""
+}
+
+func (m *LockReleaseRequest) GetBranchID() int64 {
+	if m != nil {
+		return m.BranchID
 	}
-	if this.TableName != that1.TableName {
-		return false
+	return 0
+}
+
+func (m *LockReleaseRequest) GetResourceID() string {
+	if m != nil {
+		return m.ResourceID
 	}
-	if this.PK != that1.PK {
-		return false
+	return ""
+}
+
+func (m *LockReleaseRequest) GetLockKey() string {
+	if m != nil {
+		return m.LockKey
 	}
-	if this.RowKey != that1.RowKey {
-		return false
+	return ""
+}
+
+func (m *LockReleaseRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
 	}
-	return true
+	return false
 }
-func (this *GlobalBeginRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *LockReleaseRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
+	return ""
+}
 
-	that1, ok := that.(*GlobalBeginRequest)
-	if !ok {
-		that2, ok := that.(GlobalBeginRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// LockReleaseResponse represents a response to LockReleaseRequest
+type LockReleaseResponse struct {
+	ResultCode    ResultCode    `protobuf:"varint,1,opt,name=ResultCode,proto3,enum=apis.ResultCode" json:"ResultCode,omitempty"`
+	ExceptionCode ExceptionCode `protobuf:"varint,2,opt,name=ExceptionCode,proto3,enum=apis.ExceptionCode" json:"ExceptionCode,omitempty"`
+	Message       string        `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+	Released      int32         `protobuf:"varint,4,opt,name=Released,proto3" json:"Released,omitempty"`
+}
+
+func (m *LockReleaseResponse) Reset()      { *m = LockReleaseResponse{} }
+func (*LockReleaseResponse) ProtoMessage() {}
+func (*LockReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{30}
+}
+func (m *LockReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockReleaseResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Addressing != that1.Addressing {
-		return false
+}
+func (m *LockReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockReleaseResponse.Merge(m, src)
+}
+func (m *LockReleaseResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockReleaseResponse proto.InternalMessageInfo
+
+func (m *LockReleaseResponse) GetResultCode() ResultCode {
+	if m != nil {
+		return m.ResultCode
 	}
-	if this.Timeout != that1.Timeout {
-		return false
+	return ResultCodeFailed
+}
+
+func (m *LockReleaseResponse) GetExceptionCode() ExceptionCode {
+	if m != nil {
+		return m.ExceptionCode
 	}
-	if this.TransactionName != that1.TransactionName {
-		return false
+	return UnknownErr
+}
+
+func (m *LockReleaseResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return true
+	return ""
 }
-func (this *GlobalBeginResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *LockReleaseResponse) GetReleased() int32 {
+	if m != nil {
+		return m.Released
 	}
+	return 0
+}
 
-	that1, ok := that.(*GlobalBeginResponse)
-	if !ok {
-		that2, ok := that.(GlobalBeginResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// SessionListRequest represents a request to list the global sessions, the empty filters match
+// all the global sessions
+type SessionListRequest struct {
+	Statuses        []GlobalSession_GlobalStatus `protobuf:"varint,1,rep,packed,name=Statuses,proto3,enum=apis.GlobalSession_GlobalStatus" json:"Statuses,omitempty"`
+	Addressing      string                       `protobuf:"bytes,2,opt,name=Addressing,proto3" json:"Addressing,omitempty"`
+	TransactionName string                       `protobuf:"bytes,3,opt,name=TransactionName,proto3" json:"TransactionName,omitempty"`
+	// MinAge matches the global sessions which began at least MinAge milliseconds ago
+	MinAge int64 `protobuf:"varint,4,opt,name=MinAge,proto3" json:"MinAge,omitempty"`
+	Offset int32 `protobuf:"varint,5,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Limit  int32 `protobuf:"varint,6,opt,name=Limit,proto3" json:"Limit,omitempty"`
+}
+
+func (m *SessionListRequest) Reset()      { *m = SessionListRequest{} }
+func (*SessionListRequest) ProtoMessage() {}
+func (*SessionListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{31}
+}
+func (m *SessionListRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionListRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *SessionListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionListRequest.Merge(m, src)
+}
+func (m *SessionListRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionListRequest proto.InternalMessageInfo
+
+func (m *SessionListRequest) GetStatuses() []GlobalSession_GlobalStatus {
+	if m != nil {
+		return m.Statuses
 	}
-	if this.ResultCode != that1.ResultCode {
-		return false
+	return nil
+}
+
+func (m *SessionListRequest) GetAddressing() string {
+	if m != nil {
+		return m.Addressing
 	}
-	if this.ExceptionCode != that1.ExceptionCode {
-		return false
+	return ""
+}
+
+func (m *SessionListRequest) GetTransactionName() string {
+	if m != nil {
+		return m.TransactionName
 	}
-	if this.Message != that1.Message {
-		return false
+	return ""
+}
+
+func (m *SessionListRequest) GetMinAge() int64 {
+	if m != nil {
+		return m.MinAge
 	}
-	if this.XID != that1.XID {
-		return false
+	return 0
+}
+
+func (m *SessionListRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
 	}
-	return true
+	return 0
 }
-func (this *BranchRegisterRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *SessionListRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
+	return 0
+}
 
-	that1, ok := that.(*BranchRegisterRequest)
-	if !ok {
-		that2, ok := that.(BranchRegisterRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// SessionListResponse represents a response to SessionListRequest
+type SessionListResponse struct {
+	ResultCode    ResultCode       `protobuf:"varint,1,opt,name=ResultCode,proto3,enum=apis.ResultCode" json:"ResultCode,omitempty"`
+	ExceptionCode ExceptionCode    `protobuf:"varint,2,opt,name=ExceptionCode,proto3,enum=apis.ExceptionCode" json:"ExceptionCode,omitempty"`
+	Message       string           `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+	Sessions      []*GlobalSession `protobuf:"bytes,4,rep,name=Sessions,proto3" json:"Sessions,omitempty"`
+	// Total is the number of the global sessions matching the filters
+	Total int32 `protobuf:"varint,5,opt,name=Total,proto3" json:"Total,omitempty"`
+}
+
+func (m *SessionListResponse) Reset()      { *m = SessionListResponse{} }
+func (*SessionListResponse) ProtoMessage() {}
+func (*SessionListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{32}
+}
+func (m *SessionListResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionListResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Addressing != that1.Addressing {
-		return false
-	}
-	if this.XID != that1.XID {
-		return false
-	}
-	if this.ResourceID != that1.ResourceID {
-		return false
-	}
-	if this.LockKey != that1.LockKey {
-		return false
+}
+func (m *SessionListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionListResponse.Merge(m, src)
+}
+func (m *SessionListResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionListResponse proto.InternalMessageInfo
+
+func (m *SessionListResponse) GetResultCode() ResultCode {
+	if m != nil {
+		return m.ResultCode
 	}
-	if this.BranchType != that1.BranchType {
-		return false
+	return ResultCodeFailed
+}
+
+func (m *SessionListResponse) GetExceptionCode() ExceptionCode {
+	if m != nil {
+		return m.ExceptionCode
 	}
-	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
-		return false
+	return UnknownErr
+}
+
+func (m *SessionListResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	if this.AsyncCommit != that1.AsyncCommit {
-		return false
+	return ""
+}
+
+func (m *SessionListResponse) GetSessions() []*GlobalSession {
+	if m != nil {
+		return m.Sessions
 	}
-	return true
+	return nil
 }
-func (this *BranchRegisterResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *SessionListResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
 	}
+	return 0
+}
 
-	that1, ok := that.(*BranchRegisterResponse)
-	if !ok {
-		that2, ok := that.(BranchRegisterResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// SessionGetRequest represents a request to fetch a global session with its branch sessions
+type SessionGetRequest struct {
+	XID string `protobuf:"bytes,1,opt,name=XID,proto3" json:"XID,omitempty"`
+}
+
+func (m *SessionGetRequest) Reset()      { *m = SessionGetRequest{} }
+func (*SessionGetRequest) ProtoMessage() {}
+func (*SessionGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{33}
+}
+func (m *SessionGetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionGetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.ResultCode != that1.ResultCode {
-		return false
-	}
-	if this.ExceptionCode != that1.ExceptionCode {
-		return false
-	}
-	if this.Message != that1.Message {
-		return false
-	}
-	if this.BranchID != that1.BranchID {
-		return false
-	}
-	return true
 }
-func (this *BranchReportRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *SessionGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionGetRequest.Merge(m, src)
+}
+func (m *SessionGetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionGetRequest proto.InternalMessageInfo
+
+func (m *SessionGetRequest) GetXID() string {
+	if m != nil {
+		return m.XID
 	}
+	return ""
+}
 
-	that1, ok := that.(*BranchReportRequest)
-	if !ok {
-		that2, ok := that.(BranchReportRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// SessionGetResponse represents a response to SessionGetRequest
+type SessionGetResponse struct {
+	ResultCode     ResultCode       `protobuf:"varint,1,opt,name=ResultCode,proto3,enum=apis.ResultCode" json:"ResultCode,omitempty"`
+	ExceptionCode  ExceptionCode    `protobuf:"varint,2,opt,name=ExceptionCode,proto3,enum=apis.ExceptionCode" json:"ExceptionCode,omitempty"`
+	Message        string           `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+	GlobalSession  *GlobalSession   `protobuf:"bytes,4,opt,name=GlobalSession,proto3" json:"GlobalSession,omitempty"`
+	BranchSessions []*BranchSession `protobuf:"bytes,5,rep,name=BranchSessions,proto3" json:"BranchSessions,omitempty"`
+}
+
+func (m *SessionGetResponse) Reset()      { *m = SessionGetResponse{} }
+func (*SessionGetResponse) ProtoMessage() {}
+func (*SessionGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{34}
+}
+func (m *SessionGetResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionGetResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.XID != that1.XID {
-		return false
-	}
-	if this.BranchID != that1.BranchID {
-		return false
-	}
-	if this.ResourceID != that1.ResourceID {
-		return false
+}
+func (m *SessionGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionGetResponse.Merge(m, src)
+}
+func (m *SessionGetResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionGetResponse proto.InternalMessageInfo
+
+func (m *SessionGetResponse) GetResultCode() ResultCode {
+	if m != nil {
+		return m.ResultCode
 	}
-	if this.BranchType != that1.BranchType {
-		return false
+	return ResultCodeFailed
+}
+
+func (m *SessionGetResponse) GetExceptionCode() ExceptionCode {
+	if m != nil {
+		return m.ExceptionCode
 	}
-	if this.BranchStatus != that1.BranchStatus {
-		return false
+	return UnknownErr
+}
+
+func (m *SessionGetResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
-		return false
+	return ""
+}
+
+func (m *SessionGetResponse) GetGlobalSession() *GlobalSession {
+	if m != nil {
+		return m.GlobalSession
 	}
-	return true
+	return nil
 }
-func (this *BranchReportResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *SessionGetResponse) GetBranchSessions() []*BranchSession {
+	if m != nil {
+		return m.BranchSessions
 	}
+	return nil
+}
 
-	that1, ok := that.(*BranchReportResponse)
-	if !ok {
-		that2, ok := that.(BranchReportResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// SessionRetryRequest represents a request to retry the commit or the rollback of a global
+// transaction at once instead of waiting for the retry period
+type SessionRetryRequest struct {
+	XID    string `protobuf:"bytes,1,opt,name=XID,proto3" json:"XID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (m *SessionRetryRequest) Reset()      { *m = SessionRetryRequest{} }
+func (*SessionRetryRequest) ProtoMessage() {}
+func (*SessionRetryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{35}
+}
+func (m *SessionRetryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionRetryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionRetryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.ResultCode != that1.ResultCode {
-		return false
-	}
-	if this.ExceptionCode != that1.ExceptionCode {
-		return false
-	}
-	if this.Message != that1.Message {
-		return false
-	}
-	return true
 }
-func (this *GlobalLockQueryRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *SessionRetryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionRetryRequest.Merge(m, src)
+}
+func (m *SessionRetryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionRetryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionRetryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionRetryRequest proto.InternalMessageInfo
+
+func (m *SessionRetryRequest) GetXID() string {
+	if m != nil {
+		return m.XID
 	}
+	return ""
+}
 
-	that1, ok := that.(*GlobalLockQueryRequest)
+func (m *SessionRetryRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// SessionRetryResponse represents a response to SessionRetryRequest
+type SessionRetryResponse struct {
+	ResultCode    ResultCode    `protobuf:"varint,1,opt,name=ResultCode,proto3,enum=apis.ResultCode" json:"ResultCode,omitempty"`
+	ExceptionCode ExceptionCode `protobuf:"varint,2,opt,name=ExceptionCode,proto3,enum=apis.ExceptionCode" json:"ExceptionCode,omitempty"`
+	Message       string        `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+	// GlobalStatus is the status of the global transaction after the retry
+	GlobalStatus GlobalSession_GlobalStatus `protobuf:"varint,4,opt,name=GlobalStatus,proto3,enum=apis.GlobalSession_GlobalStatus" json:"GlobalStatus,omitempty"`
+}
+
+func (m *SessionRetryResponse) Reset()      { *m = SessionRetryResponse{} }
+func (*SessionRetryResponse) ProtoMessage() {}
+func (*SessionRetryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{36}
+}
+func (m *SessionRetryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionRetryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionRetryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SessionRetryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionRetryResponse.Merge(m, src)
+}
+func (m *SessionRetryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionRetryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionRetryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionRetryResponse proto.InternalMessageInfo
+
+func (m *SessionRetryResponse) GetResultCode() ResultCode {
+	if m != nil {
+		return m.ResultCode
+	}
+	return ResultCodeFailed
+}
+
+func (m *SessionRetryResponse) GetExceptionCode() ExceptionCode {
+	if m != nil {
+		return m.ExceptionCode
+	}
+	return UnknownErr
+}
+
+func (m *SessionRetryResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *SessionRetryResponse) GetGlobalStatus() GlobalSession_GlobalStatus {
+	if m != nil {
+		return m.GlobalStatus
+	}
+	return UnknownGlobalStatus
+}
+
+// SessionRemoveRequest represents a request to remove a stuck global transaction with its branch
+// sessions
+type SessionRemoveRequest struct {
+	XID string `protobuf:"bytes,1,opt,name=XID,proto3" json:"XID,omitempty"`
+	// Force removes a global session which has not timed out yet
+	Force bool `protobuf:"varint,2,opt,name=Force,proto3" json:"Force,omitempty"`
+	// ReleaseLocks releases the row locks of the global transaction, they are kept otherwise
+	ReleaseLocks bool   `protobuf:"varint,3,opt,name=ReleaseLocks,proto3" json:"ReleaseLocks,omitempty"`
+	Reason       string `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`
+}
+
+func (m *SessionRemoveRequest) Reset()      { *m = SessionRemoveRequest{} }
+func (*SessionRemoveRequest) ProtoMessage() {}
+func (*SessionRemoveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{37}
+}
+func (m *SessionRemoveRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionRemoveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionRemoveRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SessionRemoveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionRemoveRequest.Merge(m, src)
+}
+func (m *SessionRemoveRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionRemoveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionRemoveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionRemoveRequest proto.InternalMessageInfo
+
+func (m *SessionRemoveRequest) GetXID() string {
+	if m != nil {
+		return m.XID
+	}
+	return ""
+}
+
+func (m *SessionRemoveRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *SessionRemoveRequest) GetReleaseLocks() bool {
+	if m != nil {
+		return m.ReleaseLocks
+	}
+	return false
+}
+
+func (m *SessionRemoveRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// SessionRemoveResponse represents a response to SessionRemoveRequest
+type SessionRemoveResponse struct {
+	ResultCode    ResultCode    `protobuf:"varint,1,opt,name=ResultCode,proto3,enum=apis.ResultCode" json:"ResultCode,omitempty"`
+	ExceptionCode ExceptionCode `protobuf:"varint,2,opt,name=ExceptionCode,proto3,enum=apis.ExceptionCode" json:"ExceptionCode,omitempty"`
+	Message       string        `protobuf:"bytes,3,opt,name=Message,proto3" json:"Message,omitempty"`
+}
+
+func (m *SessionRemoveResponse) Reset()      { *m = SessionRemoveResponse{} }
+func (*SessionRemoveResponse) ProtoMessage() {}
+func (*SessionRemoveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_450a439f8893981f, []int{38}
+}
+func (m *SessionRemoveResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SessionRemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SessionRemoveResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SessionRemoveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionRemoveResponse.Merge(m, src)
+}
+func (m *SessionRemoveResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SessionRemoveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionRemoveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionRemoveResponse proto.InternalMessageInfo
+
+func (m *SessionRemoveResponse) GetResultCode() ResultCode {
+	if m != nil {
+		return m.ResultCode
+	}
+	return ResultCodeFailed
+}
+
+func (m *SessionRemoveResponse) GetExceptionCode() ExceptionCode {
+	if m != nil {
+		return m.ExceptionCode
+	}
+	return UnknownErr
+}
+
+func (m *SessionRemoveResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("apis.ResultCode", ResultCode_name, ResultCode_value)
+	proto.RegisterEnum("apis.ExceptionCode", ExceptionCode_name, ExceptionCode_value)
+	proto.RegisterEnum("apis.BranchMessageType", BranchMessageType_name, BranchMessageType_value)
+	proto.RegisterEnum("apis.GlobalSession_GlobalStatus", GlobalSession_GlobalStatus_name, GlobalSession_GlobalStatus_value)
+	proto.RegisterEnum("apis.BranchSession_BranchType", BranchSession_BranchType_name, BranchSession_BranchType_value)
+	proto.RegisterEnum("apis.BranchSession_BranchStatus", BranchSession_BranchStatus_name, BranchSession_BranchStatus_value)
+	proto.RegisterType((*GlobalSession)(nil), "apis.GlobalSession")
+	proto.RegisterType((*BranchSession)(nil), "apis.BranchSession")
+	proto.RegisterType((*RowLock)(nil), "apis.RowLock")
+	proto.RegisterType((*GlobalBeginRequest)(nil), "apis.GlobalBeginRequest")
+	proto.RegisterType((*GlobalBeginResponse)(nil), "apis.GlobalBeginResponse")
+	proto.RegisterType((*BranchRegisterRequest)(nil), "apis.BranchRegisterRequest")
+	proto.RegisterType((*BranchRegisterResponse)(nil), "apis.BranchRegisterResponse")
+	proto.RegisterType((*BranchReportRequest)(nil), "apis.BranchReportRequest")
+	proto.RegisterType((*BranchReportResponse)(nil), "apis.BranchReportResponse")
+	proto.RegisterType((*GlobalLockQueryRequest)(nil), "apis.GlobalLockQueryRequest")
+	proto.RegisterType((*GlobalLockQueryResponse)(nil), "apis.GlobalLockQueryResponse")
+	proto.RegisterType((*GlobalStatusRequest)(nil), "apis.GlobalStatusRequest")
+	proto.RegisterType((*GlobalStatusResponse)(nil), "apis.GlobalStatusResponse")
+	proto.RegisterType((*GlobalCommitRequest)(nil), "apis.GlobalCommitRequest")
+	proto.RegisterType((*GlobalCommitResponse)(nil), "apis.GlobalCommitResponse")
+	proto.RegisterType((*GlobalRollbackRequest)(nil), "apis.GlobalRollbackRequest")
+	proto.RegisterType((*GlobalRollbackResponse)(nil), "apis.GlobalRollbackResponse")
+	proto.RegisterType((*GlobalReportRequest)(nil), "apis.GlobalReportRequest")
+	proto.RegisterType((*GlobalReportResponse)(nil), "apis.GlobalReportResponse")
+	proto.RegisterType((*BranchCommitRequest)(nil), "apis.BranchCommitRequest")
+	proto.RegisterType((*BranchCommitResponse)(nil), "apis.BranchCommitResponse")
+	proto.RegisterType((*BranchRollbackRequest)(nil), "apis.BranchRollbackRequest")
+	proto.RegisterType((*BranchRollbackResponse)(nil), "apis.BranchRollbackResponse")
+	proto.RegisterType((*BranchMessage)(nil), "apis.BranchMessage")
+	proto.RegisterType((*RowLockHolder)(nil), "apis.RowLockHolder")
+	proto.RegisterType((*LockListRequest)(nil), "apis.LockListRequest")
+	proto.RegisterType((*LockListResponse)(nil), "apis.LockListResponse")
+	proto.RegisterType((*LockHolderQueryRequest)(nil), "apis.LockHolderQueryRequest")
+	proto.RegisterType((*LockHolderQueryResponse)(nil), "apis.LockHolderQueryResponse")
+	proto.RegisterType((*LockReleaseRequest)(nil), "apis.LockReleaseRequest")
+	proto.RegisterType((*LockReleaseResponse)(nil), "apis.LockReleaseResponse")
+	proto.RegisterType((*SessionListRequest)(nil), "apis.SessionListRequest")
+	proto.RegisterType((*SessionListResponse)(nil), "apis.SessionListResponse")
+	proto.RegisterType((*SessionGetRequest)(nil), "apis.SessionGetRequest")
+	proto.RegisterType((*SessionGetResponse)(nil), "apis.SessionGetResponse")
+	proto.RegisterType((*SessionRetryRequest)(nil), "apis.SessionRetryRequest")
+	proto.RegisterType((*SessionRetryResponse)(nil), "apis.SessionRetryResponse")
+	proto.RegisterType((*SessionRemoveRequest)(nil), "apis.SessionRemoveRequest")
+	proto.RegisterType((*SessionRemoveResponse)(nil), "apis.SessionRemoveResponse")
+}
+
+func init() { proto.RegisterFile("seata.proto", fileDescriptor_450a439f8893981f) }
+
+var fileDescriptor_450a439f8893981f = []byte{
+	// 2538 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcd, 0x6f, 0x1c, 0x49,
+	0x15, 0x9f, 0xee, 0xf9, 0xf0, 0xcc, 0xb3, 0x67, 0xdc, 0xae, 0xf1, 0xc7, 0xb8, 0x93, 0xcc, 0x78,
+	0x7b, 0xb5, 0x8a, 0x37, 0x10, 0x7b, 0xe5, 0x08, 0x44, 0x96, 0x15, 0xd1, 0x8c, 0xed, 0x7c, 0x90,
+	0x64, 0x13, 0xda, 0x5e, 0xed, 0x8a, 0x4b, 0xd4, 0x9e, 0xa9, 0x4c, 0x5a, 0x1e, 0x77, 0x0f, 0xdd,
+	0xed, 0xc4, 0x86, 0x0b, 0xff, 0x01, 0xa0, 0x48, 0x1c, 0x90, 0xe0, 0x80, 0x40, 0x42, 0x20, 0x71,
+	0x43, 0x02, 0x81, 0x38, 0x22, 0x0e, 0x48, 0xe4, 0x18, 0x71, 0x18, 0x11, 0x47, 0x08, 0x90, 0x38,
+	0xac, 0xe6, 0x2f, 0x40, 0xf5, 0xd1, 0xdd, 0x55, 0x3d, 0xed, 0xd8, 0x0e, 0x1f, 0x1a, 0x22, 0x4e,
+	0x76, 0xbd, 0xf7, 0xab, 0x57, 0xaf, 0x5e, 0xbd, 0xdf, 0xab, 0xae, 0xaa, 0x81, 0x49, 0x1f, 0x5b,
+	0x81, 0xb5, 0xd2, 0xf7, 0xdc, 0xc0, 0x45, 0x39, 0xab, 0x6f, 0xfb, 0xfa, 0xe5, 0xae, 0x1d, 0x3c,
+	0xda, 0xdf, 0x59, 0x69, 0xbb, 0x7b, 0xab, 0x5d, 0xb7, 0xeb, 0xae, 0x52, 0xe5, 0xce, 0xfe, 0x43,
+	0xda, 0xa2, 0x0d, 0xfa, 0x1f, 0xeb, 0xa4, 0x2f, 0x76, 0x5d, 0xb7, 0xdb, 0xc3, 0x31, 0xca, 0x72,
+	0x0e, 0x99, 0xca, 0xf8, 0x5e, 0x01, 0xca, 0x37, 0x7a, 0xee, 0x8e, 0xd5, 0xdb, 0xc2, 0xbe, 0x6f,
+	0xbb, 0x0e, 0xfa, 0x1c, 0x40, 0xb3, 0xd3, 0xf1, 0x48, 0xcb, 0xe9, 0xd6, 0x94, 0x25, 0x65, 0xb9,
+	0xd4, 0x9a, 0x1b, 0x0e, 0x1a, 0x33, 0x07, 0xae, 0xb7, 0xf7, 0xbe, 0x61, 0x45, 0x3a, 0xc3, 0x14,
+	0x80, 0x68, 0x09, 0xb2, 0x9f, 0xdc, 0xda, 0xa8, 0xa9, 0x14, 0x5f, 0x19, 0x0e, 0x1a, 0xc0, 0xf0,
+	0x07, 0x76, 0xc7, 0x30, 0x89, 0x0a, 0x5d, 0x83, 0xf2, 0xb6, 0x67, 0x39, 0xbe, 0xd5, 0x0e, 0x6c,
+	0xd7, 0xb9, 0xb5, 0x51, 0xcb, 0x2e, 0x29, 0xcb, 0xd9, 0xd6, 0xe2, 0x70, 0xd0, 0x98, 0x63, 0xd8,
+	0x20, 0x56, 0x3f, 0x20, 0xdd, 0x64, 0x3c, 0xda, 0x84, 0x69, 0x41, 0xf0, 0xa1, 0xb5, 0x87, 0x6b,
+	0x39, 0x3a, 0xdc, 0xb9, 0xe1, 0xa0, 0xb1, 0x30, 0x6a, 0xc2, 0xb1, 0xf6, 0xb0, 0x61, 0x26, 0xfb,
+	0xa0, 0xcf, 0xc2, 0xc4, 0xb6, 0xbd, 0x87, 0xdd, 0xfd, 0xa0, 0x96, 0x5f, 0x52, 0x96, 0xf3, 0x2d,
+	0x34, 0x1c, 0x34, 0x2a, 0xbc, 0x3b, 0x53, 0x18, 0x66, 0x08, 0x41, 0x57, 0xa0, 0xd4, 0xc2, 0x5d,
+	0xdb, 0x21, 0xed, 0x5a, 0x81, 0x7a, 0x2c, 0x44, 0x63, 0x87, 0xa8, 0x1e, 0x90, 0x5e, 0x86, 0x19,
+	0xe3, 0xd0, 0x6d, 0x28, 0x6c, 0x05, 0x56, 0xb0, 0xef, 0xd7, 0x26, 0x96, 0x94, 0xe5, 0xca, 0xda,
+	0xd2, 0x0a, 0x59, 0xb6, 0x15, 0x29, 0xd0, 0x61, 0x8b, 0xe2, 0x5a, 0x33, 0xc3, 0x41, 0xa3, 0xcc,
+	0x6c, 0xfa, 0x54, 0x62, 0x98, 0xdc, 0x04, 0x7a, 0x17, 0x0a, 0xcd, 0x76, 0x60, 0x3f, 0xc6, 0xb5,
+	0xe2, 0x92, 0xb2, 0x5c, 0x14, 0xa1, 0x16, 0x95, 0x1b, 0x26, 0x07, 0x18, 0x7f, 0x50, 0x61, 0x4a,
+	0x34, 0x8b, 0x16, 0xa0, 0xfa, 0x91, 0xb3, 0xeb, 0xb8, 0x4f, 0x1c, 0x51, 0xac, 0x65, 0x50, 0x09,
+	0xf2, 0xd4, 0x5d, 0x4d, 0x41, 0x15, 0x80, 0x75, 0x77, 0x6f, 0xcf, 0x0e, 0x02, 0xdb, 0xe9, 0x6a,
+	0x2a, 0x42, 0x50, 0x61, 0x6d, 0x13, 0x07, 0xde, 0x21, 0x91, 0x65, 0xd1, 0x34, 0x4c, 0x9a, 0x6e,
+	0xaf, 0x67, 0x3b, 0xdd, 0x96, 0xd5, 0xde, 0xd5, 0x72, 0x68, 0x16, 0x34, 0x22, 0xd8, 0xb1, 0xda,
+	0xbb, 0x11, 0x2c, 0x8f, 0xe6, 0x01, 0xf1, 0xb8, 0x89, 0xe8, 0x02, 0x3a, 0x07, 0x0b, 0x82, 0x5c,
+	0xea, 0x34, 0x81, 0xaa, 0x30, 0xdd, 0xf4, 0x0f, 0x9d, 0xb6, 0xe0, 0x44, 0x11, 0x95, 0xa1, 0xc4,
+	0xdb, 0xb8, 0xa3, 0x95, 0x90, 0x06, 0x53, 0xac, 0x79, 0xdd, 0xb2, 0x7b, 0xb8, 0xa3, 0x01, 0xf1,
+	0x9a, 0xd8, 0xc2, 0x1d, 0x3a, 0xc4, 0x24, 0xf1, 0x3a, 0xb4, 0xcd, 0x31, 0x53, 0x68, 0x0e, 0x66,
+	0x84, 0x61, 0x39, 0xb4, 0x8c, 0x16, 0x61, 0x2e, 0xe1, 0x0d, 0xef, 0x51, 0x41, 0x53, 0x50, 0xbc,
+	0x6e, 0x3b, 0xb6, 0xff, 0x08, 0x77, 0xb4, 0x69, 0xe3, 0x77, 0x13, 0x50, 0x6e, 0x79, 0x96, 0xd3,
+	0x7e, 0xf4, 0x1f, 0x27, 0xc7, 0x7b, 0x50, 0x64, 0x23, 0x45, 0xbc, 0x98, 0x1d, 0x0e, 0x1a, 0x1a,
+	0xcf, 0x32, 0xaa, 0xa1, 0x94, 0x88, 0x50, 0xa3, 0x74, 0xca, 0x9d, 0x91, 0x4e, 0x9f, 0x07, 0x30,
+	0xb1, 0xef, 0xee, 0x7b, 0x6d, 0x7c, 0x6b, 0x83, 0x52, 0xa1, 0xd4, 0x9a, 0x1f, 0x0e, 0x1a, 0x88,
+	0xf5, 0xf6, 0xb8, 0x8e, 0x76, 0x15, 0x90, 0xe8, 0x32, 0x4c, 0xdc, 0x71, 0xdb, 0xbb, 0xb7, 0xf1,
+	0x21, 0xe5, 0x43, 0xa9, 0x55, 0x1d, 0x0e, 0x1a, 0xd3, 0xac, 0x53, 0xcf, 0x6d, 0xef, 0x3e, 0xd8,
+	0xc5, 0x87, 0x86, 0x19, 0x62, 0xd0, 0x97, 0x21, 0xb7, 0x7d, 0xd8, 0xc7, 0x9c, 0x09, 0x75, 0xc6,
+	0x04, 0x29, 0xaa, 0xbc, 0x45, 0x50, 0xa2, 0x03, 0x7c, 0xd6, 0xc1, 0x61, 0x1f, 0x1b, 0x26, 0xb5,
+	0x21, 0xf0, 0xaa, 0x28, 0xf2, 0x2a, 0xcd, 0xda, 0xc9, 0xbc, 0xda, 0x84, 0xe9, 0x66, 0xbf, 0xdf,
+	0xb3, 0xdb, 0x16, 0x09, 0xc8, 0x86, 0x15, 0x58, 0xb5, 0xd2, 0x92, 0xb2, 0x3c, 0x25, 0x96, 0x13,
+	0x2b, 0x06, 0x3c, 0xe8, 0x58, 0x81, 0x65, 0x98, 0xc9, 0x3e, 0xe8, 0x2a, 0x4c, 0x0a, 0xe9, 0x5b,
+	0x03, 0xca, 0xd1, 0x85, 0xe1, 0xa0, 0x51, 0xe5, 0x26, 0x88, 0xf2, 0x41, 0x9b, 0x6a, 0x0d, 0x53,
+	0xc4, 0x1a, 0xab, 0x00, 0xf1, 0xd4, 0x51, 0x01, 0xd4, 0xe6, 0xb6, 0x96, 0x41, 0x13, 0x90, 0xdd,
+	0x5e, 0x5f, 0xd7, 0x14, 0x54, 0x84, 0xdc, 0x56, 0xf3, 0x46, 0x53, 0x53, 0x89, 0xea, 0x93, 0xa6,
+	0x96, 0x35, 0x7e, 0xa1, 0xc2, 0x94, 0x38, 0x3d, 0x81, 0xdf, 0xa2, 0x58, 0xcb, 0x50, 0x7a, 0xe0,
+	0xae, 0xed, 0x07, 0xd8, 0xc3, 0x1d, 0x4d, 0x21, 0x04, 0xba, 0xff, 0xc8, 0xf2, 0xf1, 0x3d, 0x07,
+	0x6f, 0xb8, 0x0e, 0x66, 0x34, 0x0f, 0x25, 0x3c, 0xfd, 0xb3, 0x84, 0x8a, 0xa1, 0x8c, 0x33, 0x44,
+	0xcb, 0x11, 0x16, 0x51, 0xe1, 0xf6, 0x13, 0x37, 0xa6, 0x64, 0x1e, 0xbd, 0x05, 0x17, 0x64, 0x31,
+	0xb3, 0x42, 0x89, 0x6d, 0xed, 0xf4, 0xb0, 0x56, 0x40, 0x6f, 0x43, 0x23, 0x0d, 0xb2, 0x6e, 0x39,
+	0x1f, 0xba, 0xac, 0xba, 0x68, 0x13, 0xa4, 0x66, 0x84, 0x20, 0x81, 0xa5, 0x45, 0xb1, 0xb3, 0x4c,
+	0xd3, 0x78, 0x84, 0x12, 0x7a, 0x07, 0xde, 0x4a, 0x07, 0x89, 0x63, 0x80, 0xf1, 0x5c, 0x85, 0x09,
+	0xd3, 0x7d, 0x42, 0x52, 0x32, 0xe4, 0xa2, 0x72, 0x86, 0x8d, 0x4a, 0x3d, 0x23, 0xb3, 0xce, 0x4e,
+	0x66, 0x99, 0x8b, 0xb9, 0x53, 0x73, 0xf1, 0x0a, 0x94, 0xb6, 0x49, 0x28, 0xe8, 0x66, 0x98, 0x4f,
+	0x96, 0xa3, 0x80, 0xa8, 0xf8, 0x36, 0x18, 0xe3, 0x50, 0x03, 0xd4, 0xfb, 0xb7, 0x39, 0x77, 0xa7,
+	0x87, 0x83, 0xc6, 0x24, 0x43, 0x5f, 0xec, 0xef, 0x5e, 0x34, 0x4c, 0xf5, 0xfe, 0x6d, 0x74, 0x09,
+	0x0a, 0xa6, 0xfb, 0x84, 0x10, 0x7c, 0x82, 0x82, 0x84, 0x0d, 0xd2, 0x73, 0x9f, 0x30, 0x7e, 0x73,
+	0x84, 0x71, 0x00, 0x88, 0x6d, 0x2d, 0x74, 0x3b, 0x31, 0xf1, 0xd7, 0xf6, 0xb1, 0x1f, 0xa0, 0xfa,
+	0x68, 0x9d, 0x94, 0x0a, 0x62, 0x2d, 0xde, 0x83, 0x49, 0x70, 0xf3, 0xf1, 0x7e, 0xbb, 0x3c, 0xba,
+	0xc9, 0x67, 0x69, 0xf7, 0xa4, 0xd8, 0xf8, 0xb9, 0x02, 0x55, 0x69, 0x68, 0xbf, 0xef, 0x3a, 0x3e,
+	0x46, 0xef, 0xd1, 0x58, 0xee, 0xf7, 0x82, 0x75, 0xb7, 0x83, 0xe9, 0xd8, 0x95, 0x35, 0x8d, 0x15,
+	0x8a, 0x58, 0x6e, 0x0a, 0x18, 0x74, 0x15, 0xca, 0x9b, 0x07, 0x6d, 0xdc, 0x27, 0xa6, 0x69, 0x27,
+	0x95, 0x76, 0xaa, 0xb2, 0x4e, 0x92, 0xca, 0x94, 0x91, 0x64, 0x22, 0x77, 0xb1, 0xef, 0x5b, 0xdd,
+	0xd0, 0xcd, 0xb0, 0x89, 0x34, 0x96, 0x67, 0x74, 0x2d, 0x69, 0x5e, 0x19, 0xdf, 0x52, 0x61, 0x8e,
+	0xad, 0x78, 0x48, 0xcd, 0xd3, 0x86, 0x4b, 0x13, 0xf6, 0x0f, 0x96, 0xa3, 0x75, 0x29, 0x61, 0xd8,
+	0xd0, 0x82, 0x84, 0xf8, 0x15, 0x16, 0x69, 0xe6, 0x41, 0xd8, 0x44, 0x5f, 0x12, 0x8b, 0x4e, 0x2d,
+	0x7f, 0x9a, 0xaa, 0x6c, 0x0a, 0x3d, 0xc8, 0x02, 0x25, 0xcb, 0x26, 0x49, 0xa5, 0xa9, 0xd1, 0xca,
+	0xb8, 0x24, 0x57, 0x46, 0x92, 0x4b, 0x45, 0xb9, 0x00, 0xfe, 0x5a, 0x81, 0xf9, 0x64, 0x44, 0xc6,
+	0x6b, 0x15, 0x75, 0x81, 0xca, 0x74, 0x83, 0x8d, 0x49, 0x6b, 0x3c, 0x55, 0xa1, 0x1a, 0x7a, 0xdf,
+	0x77, 0xbd, 0x20, 0x5c, 0x4d, 0x4d, 0xa8, 0x30, 0x6c, 0xb5, 0x44, 0x2b, 0xaa, 0x6c, 0xe5, 0xc4,
+	0x95, 0x94, 0xd7, 0x2b, 0x77, 0xe6, 0xf5, 0xda, 0x90, 0xb7, 0x8c, 0x5a, 0xfe, 0x74, 0x3b, 0xa7,
+	0x29, 0xf5, 0x3a, 0xfd, 0xaa, 0x1b, 0xdf, 0x57, 0x60, 0x56, 0x8e, 0xca, 0x58, 0xad, 0xa8, 0xf1,
+	0x23, 0x05, 0xe6, 0x59, 0xd9, 0x20, 0x8c, 0xf8, 0xca, 0x3e, 0xf6, 0x0e, 0x8f, 0x5f, 0x38, 0x79,
+	0x71, 0xd4, 0x57, 0xd1, 0x2c, 0xfb, 0x2a, 0x9a, 0x9d, 0x79, 0xd9, 0x8c, 0xdf, 0x28, 0xb0, 0x30,
+	0xe2, 0xe6, 0xd8, 0x71, 0x83, 0xf8, 0x46, 0xf6, 0x15, 0x3a, 0xc1, 0xa2, 0x19, 0xb5, 0x8d, 0x8b,
+	0x61, 0x6d, 0xe6, 0xd9, 0x74, 0x5c, 0x84, 0x8d, 0x97, 0x0a, 0xcc, 0xca, 0xc8, 0xf1, 0x9a, 0xe4,
+	0x86, 0x7c, 0xa2, 0xaa, 0xe5, 0x44, 0xfa, 0x1c, 0x7f, 0xa0, 0x33, 0xa5, 0x5e, 0x71, 0x38, 0xc2,
+	0x93, 0xd5, 0xc9, 0xe1, 0x08, 0x91, 0x6f, 0x62, 0x38, 0xde, 0x85, 0x39, 0xd6, 0x8e, 0x8f, 0x83,
+	0xc7, 0x05, 0xe4, 0x2f, 0x11, 0x5d, 0x63, 0xec, 0x9b, 0x18, 0x92, 0xbd, 0x30, 0x43, 0x4e, 0xda,
+	0x4b, 0x92, 0xc3, 0xa9, 0xaf, 0x35, 0x5c, 0x9c, 0x67, 0x63, 0x59, 0xa5, 0xff, 0x4d, 0x41, 0xfd,
+	0xab, 0x12, 0xee, 0xd0, 0x27, 0xf0, 0xee, 0x5f, 0xda, 0xa1, 0xc7, 0xe0, 0x5b, 0xcb, 0xf8, 0x8e,
+	0x0a, 0xb3, 0xf2, 0x4c, 0xc7, 0xfc, 0x6b, 0x58, 0x8a, 0x78, 0x3e, 0x11, 0xf1, 0xe4, 0x37, 0x4b,
+	0xe1, 0x75, 0xbe, 0x59, 0x8c, 0xbf, 0x2b, 0xd1, 0xf7, 0xf6, 0x49, 0x65, 0xe6, 0x7f, 0x7e, 0xfd,
+	0x9f, 0xaa, 0x30, 0x9f, 0x9c, 0xeb, 0xff, 0x33, 0xe0, 0xbb, 0x4a, 0x78, 0x81, 0x17, 0x7a, 0x51,
+	0x01, 0x95, 0x2f, 0x7c, 0xd6, 0x54, 0xe9, 0x9d, 0xf2, 0x8c, 0x04, 0xa0, 0x0b, 0xc5, 0xa6, 0xbb,
+	0x20, 0x0e, 0x26, 0xa8, 0xcd, 0xd1, 0x1e, 0x68, 0x45, 0x9e, 0xf6, 0xe4, 0xda, 0xec, 0x0a, 0xbb,
+	0x73, 0x5f, 0x09, 0xef, 0xdc, 0x57, 0x9a, 0xce, 0x61, 0xfc, 0x11, 0xfa, 0x43, 0x05, 0xca, 0xfc,
+	0x42, 0xe2, 0xa6, 0xdb, 0xeb, 0x60, 0x0f, 0x5d, 0x8c, 0x6e, 0x28, 0xa8, 0x77, 0x93, 0x6b, 0x65,
+	0xbe, 0x44, 0x4c, 0x68, 0x86, 0x5a, 0xf4, 0x85, 0xe8, 0x0e, 0xec, 0xb4, 0x95, 0x9f, 0xe3, 0xd1,
+	0x79, 0xf1, 0x2a, 0x9b, 0xde, 0x4b, 0x88, 0x77, 0xd6, 0x1a, 0x64, 0x9b, 0x5d, 0xcc, 0x0f, 0x39,
+	0xe4, 0x5f, 0xe3, 0x67, 0x0a, 0x4c, 0x93, 0x21, 0xef, 0xd8, 0x7e, 0x20, 0x9c, 0x54, 0x05, 0x2e,
+	0x28, 0x23, 0x5c, 0x38, 0x2f, 0x5e, 0x48, 0xb0, 0xef, 0xe5, 0x58, 0x10, 0xe6, 0x40, 0x36, 0x3d,
+	0x07, 0x12, 0xe7, 0x2b, 0x34, 0x0f, 0x85, 0x7b, 0x0f, 0x1f, 0xfa, 0x98, 0xdf, 0xd3, 0x9b, 0xbc,
+	0x85, 0x66, 0x21, 0x7f, 0xc7, 0x26, 0x27, 0xca, 0x02, 0x15, 0xb3, 0x86, 0xf1, 0x27, 0x05, 0xb4,
+	0xd8, 0xdb, 0xf1, 0xca, 0xfd, 0x77, 0x21, 0x4f, 0x5c, 0x23, 0xdb, 0x58, 0x76, 0x79, 0x32, 0x34,
+	0x26, 0x25, 0x80, 0xc9, 0x10, 0x64, 0x72, 0xdb, 0x6e, 0x60, 0xf5, 0xf8, 0x9c, 0x59, 0xc3, 0x30,
+	0x61, 0x3e, 0x86, 0x4a, 0x67, 0x96, 0x93, 0x16, 0x44, 0x28, 0x4e, 0xaa, 0x54, 0x9c, 0x8c, 0x3f,
+	0x2a, 0xb0, 0x30, 0x62, 0x74, 0xbc, 0xe2, 0x76, 0x19, 0x26, 0x98, 0x77, 0xaf, 0x8c, 0x5c, 0x88,
+	0x31, 0x7e, 0xaa, 0x00, 0x22, 0x72, 0x13, 0xf7, 0xb0, 0xe5, 0xe3, 0xff, 0x76, 0xb5, 0x9f, 0x85,
+	0xfc, 0x75, 0xd7, 0x6b, 0xb3, 0x42, 0x5f, 0x34, 0x59, 0x83, 0x64, 0xb1, 0x89, 0x2d, 0xdf, 0x75,
+	0xd8, 0x8d, 0x9b, 0xc9, 0x5b, 0xc6, 0x2f, 0x15, 0xa8, 0x4a, 0xce, 0x8e, 0xdd, 0xe1, 0x8e, 0x7b,
+	0xd6, 0xa1, 0xf3, 0xcc, 0x9b, 0x51, 0xdb, 0x38, 0x52, 0x00, 0xf1, 0x4a, 0x23, 0xd6, 0x86, 0x0f,
+	0xa0, 0xc8, 0x2a, 0x0d, 0xf6, 0x6b, 0xca, 0x52, 0xf6, 0x54, 0xb5, 0x29, 0xea, 0x91, 0xb8, 0x03,
+	0x53, 0x47, 0xee, 0xc0, 0x4e, 0x7d, 0x31, 0x48, 0x22, 0x7e, 0xd7, 0x76, 0xe2, 0x62, 0xc6, 0x5b,
+	0x67, 0xac, 0x27, 0x2f, 0x15, 0xa8, 0x4a, 0x93, 0x1c, 0xaf, 0xf5, 0x59, 0x85, 0x22, 0xf7, 0x2e,
+	0xc1, 0x0d, 0x29, 0xd8, 0x66, 0x04, 0x3a, 0xa6, 0xb0, 0xbc, 0x03, 0x33, 0x1c, 0x71, 0x03, 0xbf,
+	0xe2, 0x58, 0xfa, 0x54, 0x05, 0x24, 0xe2, 0xc6, 0x2b, 0x16, 0x57, 0x13, 0x6f, 0xd8, 0x74, 0xdd,
+	0x8f, 0x09, 0x88, 0x8c, 0x44, 0x5f, 0x84, 0x8a, 0xf4, 0x35, 0x41, 0xee, 0xc7, 0x84, 0x60, 0x4a,
+	0x3a, 0x33, 0x01, 0x35, 0xae, 0x45, 0x19, 0x42, 0x1f, 0x1a, 0x8e, 0xaf, 0x37, 0x71, 0x0d, 0x50,
+	0xa5, 0x1a, 0x40, 0x4e, 0x61, 0xb2, 0x85, 0x37, 0xf1, 0x14, 0xf6, 0x75, 0x61, 0x92, 0x7b, 0xee,
+	0xe3, 0x57, 0xd4, 0xe5, 0xa8, 0x82, 0xaa, 0x62, 0x05, 0x35, 0x60, 0x8a, 0x97, 0x1e, 0xb6, 0x89,
+	0x66, 0xa9, 0x52, 0x92, 0x09, 0x11, 0xce, 0x49, 0x11, 0xfe, 0x81, 0x02, 0x73, 0x89, 0xc1, 0xc7,
+	0x2a, 0xc4, 0x97, 0xae, 0x8a, 0x6e, 0xd0, 0x67, 0xf5, 0xa8, 0xc5, 0x9f, 0xe5, 0x32, 0xe4, 0x05,
+	0x2e, 0x96, 0x6e, 0xed, 0xb7, 0xdb, 0xd8, 0xf7, 0x35, 0xe5, 0xd2, 0x8f, 0x73, 0x09, 0x87, 0xc8,
+	0xab, 0x1f, 0x7f, 0x0e, 0xdc, 0xf4, 0x3c, 0x2d, 0x43, 0x9e, 0xed, 0xe9, 0x07, 0x1e, 0xb7, 0xa4,
+	0x90, 0x07, 0x3e, 0xbe, 0x5b, 0xad, 0xbb, 0xce, 0xc3, 0x9e, 0xdd, 0x0e, 0xd8, 0xeb, 0xe2, 0xad,
+	0x7b, 0x5a, 0x96, 0xbc, 0xe8, 0xc9, 0x47, 0x88, 0xe4, 0x7b, 0x5b, 0x8e, 0x3c, 0xca, 0xa5, 0x41,
+	0x3e, 0x72, 0xbc, 0x08, 0x94, 0x47, 0x35, 0x98, 0x95, 0x2f, 0xf5, 0xf9, 0xf0, 0x05, 0xf2, 0xd6,
+	0x27, 0x5e, 0x0d, 0x73, 0xf9, 0x04, 0x79, 0xc6, 0x0c, 0x6f, 0x0e, 0xd7, 0x1f, 0xe1, 0xe8, 0x3d,
+	0xbe, 0x88, 0x2e, 0xc0, 0x22, 0x3f, 0x0e, 0x09, 0x35, 0xde, 0x0d, 0x36, 0x0f, 0x6c, 0x3f, 0xd0,
+	0x4a, 0x44, 0xcd, 0x32, 0x2d, 0x4d, 0x0d, 0xa8, 0x0e, 0x7a, 0x9a, 0x9a, 0xfd, 0x58, 0x42, 0x9b,
+	0x44, 0x06, 0xd4, 0x47, 0xf4, 0x2c, 0x67, 0x6f, 0x39, 0x8f, 0xad, 0x9e, 0x4d, 0x7e, 0x43, 0xf0,
+	0x36, 0x34, 0x98, 0x37, 0xdb, 0xee, 0x16, 0x76, 0x3a, 0x29, 0xb7, 0x09, 0x5a, 0x99, 0x3c, 0x43,
+	0x8e, 0x82, 0x12, 0x87, 0x4e, 0xad, 0x42, 0xd6, 0x31, 0x84, 0x35, 0x3b, 0x1c, 0xa5, 0x4d, 0xa3,
+	0x06, 0x9c, 0x63, 0x62, 0x12, 0x83, 0x11, 0x87, 0x34, 0x8d, 0x84, 0x8d, 0x01, 0x3e, 0xf6, 0xec,
+	0x00, 0xf3, 0x74, 0xd6, 0x66, 0xc8, 0xf2, 0x32, 0xf9, 0x56, 0xe0, 0x7a, 0x58, 0x43, 0x24, 0x7d,
+	0x88, 0x8d, 0x8f, 0x2d, 0x3b, 0xd8, 0xc0, 0x56, 0x87, 0xbc, 0xc6, 0x6b, 0xd5, 0x4b, 0xdf, 0x48,
+	0x39, 0xe3, 0x10, 0x28, 0xf9, 0x2b, 0xce, 0x47, 0xcb, 0x20, 0x1d, 0xe6, 0x93, 0x52, 0x96, 0x79,
+	0x9a, 0x42, 0x7f, 0xdc, 0x11, 0xe9, 0xc2, 0xc9, 0x69, 0x2a, 0x3a, 0x0f, 0x35, 0x59, 0xde, 0xa2,
+	0x93, 0xa6, 0xbd, 0xb2, 0x6b, 0xff, 0x50, 0x61, 0x51, 0x98, 0xcd, 0x5d, 0xcb, 0xb1, 0xba, 0xd8,
+	0xdb, 0xc2, 0xde, 0x63, 0xbb, 0x8d, 0xd1, 0x07, 0xfc, 0x67, 0x28, 0xa8, 0x26, 0xd6, 0x14, 0xf1,
+	0x29, 0x51, 0x5f, 0x4c, 0xd1, 0x70, 0x0a, 0xb7, 0xa0, 0x74, 0x03, 0x07, 0xfc, 0x74, 0x23, 0xe1,
+	0xa4, 0x5b, 0x67, 0x5d, 0x4f, 0x53, 0x71, 0x1b, 0x9b, 0x61, 0x89, 0x63, 0x29, 0x29, 0x9b, 0x91,
+	0xee, 0xe2, 0x74, 0x3d, 0x4d, 0xc5, 0xcd, 0x5c, 0x83, 0x02, 0x0b, 0x97, 0x6c, 0x40, 0x4a, 0x14,
+	0x5d, 0x4f, 0x53, 0x45, 0x7e, 0x14, 0xc3, 0x98, 0xa2, 0x73, 0xd2, 0x40, 0x72, 0x1a, 0xe9, 0xe7,
+	0xd3, 0x95, 0xcc, 0xcc, 0xda, 0xaf, 0x54, 0x98, 0x0f, 0x3f, 0x56, 0x13, 0xb1, 0x6e, 0x86, 0x69,
+	0x40, 0x46, 0xde, 0x77, 0xc8, 0xed, 0x01, 0x46, 0xd5, 0x94, 0x43, 0xae, 0x9e, 0x26, 0x5c, 0x56,
+	0xde, 0x53, 0xd0, 0x6d, 0xa8, 0xc8, 0xcc, 0x0e, 0x5d, 0x4d, 0x7d, 0xd6, 0xd4, 0xcf, 0xa7, 0x2b,
+	0xe3, 0xc8, 0x8b, 0xc5, 0x20, 0x0c, 0x5c, 0xca, 0x8b, 0x9a, 0xae, 0xa7, 0xa9, 0xb8, 0x99, 0x9b,
+	0x50, 0x8a, 0x5e, 0x48, 0x90, 0x14, 0x9c, 0xe4, 0xfb, 0x8e, 0x7e, 0xe1, 0x18, 0x2d, 0x8f, 0xdd,
+	0x6f, 0x73, 0x30, 0xd5, 0xec, 0xec, 0xd9, 0x4e, 0x18, 0xb1, 0xf7, 0xa1, 0x44, 0x3e, 0xfd, 0xd8,
+	0x0e, 0x33, 0xc7, 0x3a, 0x27, 0x0e, 0xc4, 0xfa, 0x7c, 0x52, 0xcc, 0xdd, 0xba, 0x07, 0x1a, 0xb5,
+	0x1e, 0x9f, 0x53, 0xfc, 0xd0, 0xbb, 0xf4, 0x93, 0x9c, 0x7e, 0xe1, 0x18, 0x2d, 0x37, 0xb8, 0x2e,
+	0xef, 0x82, 0x21, 0x63, 0x46, 0xcf, 0x3b, 0xfa, 0x62, 0x8a, 0x26, 0x36, 0x42, 0xbc, 0x8c, 0x3e,
+	0x0a, 0xb9, 0x91, 0xd1, 0x8f, 0x79, 0x7d, 0x31, 0x45, 0x13, 0xe5, 0x3a, 0x10, 0xda, 0x31, 0x0d,
+	0x5a, 0x90, 0x80, 0xf1, 0x57, 0xa4, 0x5e, 0x1b, 0x55, 0x70, 0x03, 0x1b, 0x30, 0x49, 0x37, 0x15,
+	0x99, 0x31, 0x29, 0x9f, 0x52, 0xba, 0x9e, 0xa6, 0xe2, 0x56, 0xae, 0x43, 0x99, 0x09, 0x42, 0xda,
+	0xbc, 0xa6, 0x9d, 0x9b, 0x50, 0x66, 0x9f, 0x06, 0xe1, 0x8c, 0x92, 0x60, 0xe1, 0x9b, 0x45, 0x3f,
+	0x97, 0xaa, 0x63, 0x96, 0x5a, 0xd6, 0xb3, 0x17, 0xf5, 0xcc, 0xf3, 0x17, 0xf5, 0xcc, 0xa7, 0x2f,
+	0xea, 0xca, 0x37, 0x8f, 0xea, 0xca, 0x4f, 0x8e, 0xea, 0xca, 0xef, 0x8f, 0xea, 0xca, 0xb3, 0xa3,
+	0xba, 0xf2, 0xe7, 0xa3, 0xba, 0xf2, 0xb7, 0xa3, 0x7a, 0xe6, 0xd3, 0xa3, 0xba, 0xf2, 0xed, 0x97,
+	0xf5, 0xcc, 0xb3, 0x97, 0xf5, 0xcc, 0xf3, 0x97, 0xf5, 0xcc, 0x57, 0x3f, 0x23, 0xfc, 0x98, 0xd3,
+	0xed, 0x63, 0x27, 0xf0, 0x0e, 0x56, 0xe9, 0x4f, 0x3e, 0x2f, 0x77, 0xdd, 0x9e, 0xe5, 0x74, 0x57,
+	0x1f, 0xaf, 0xad, 0xf6, 0x77, 0xbb, 0xab, 0x64, 0xe0, 0x9d, 0x02, 0xbd, 0x4f, 0xba, 0xf2, 0xcf,
+	0x01, 0x00, 0x50, 0xf9, 0xa1, 0x5f, 0x15, 0x2a, 0x00, 0x00,
+}
+
+func (x ResultCode) String() string {
+	s, ok := ResultCode_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x ExceptionCode) String() string {
+	s, ok := ExceptionCode_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x BranchMessageType) String() string {
+	s, ok := BranchMessageType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x GlobalSession_GlobalStatus) String() string {
+	s, ok := GlobalSession_GlobalStatus_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x BranchSession_BranchType) String() string {
+	s, ok := BranchSession_BranchType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x BranchSession_BranchStatus) String() string {
+	s, ok := BranchSession_BranchStatus_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *GlobalSession) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GlobalSession)
 	if !ok {
-		that2, ok := that.(GlobalLockQueryRequest)
+		that2, ok := that.(GlobalSession)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2644,28 +3397,40 @@ func (this *GlobalLockQueryRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.Addressing != that1.Addressing {
+		return false
+	}
 	if this.XID != that1.XID {
 		return false
 	}
-	if this.ResourceID != that1.ResourceID {
+	if this.TransactionID != that1.TransactionID {
 		return false
 	}
-	if this.LockKey != that1.LockKey {
+	if this.TransactionName != that1.TransactionName {
 		return false
 	}
-	if this.BranchType != that1.BranchType {
+	if this.Timeout != that1.Timeout {
+		return false
+	}
+	if this.BeginTime != that1.BeginTime {
+		return false
+	}
+	if this.Status != that1.Status {
+		return false
+	}
+	if this.Active != that1.Active {
 		return false
 	}
 	return true
 }
-func (this *GlobalLockQueryResponse) Equal(that interface{}) bool {
+func (this *BranchSession) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlobalLockQueryResponse)
+	that1, ok := that.(*BranchSession)
 	if !ok {
-		that2, ok := that.(GlobalLockQueryResponse)
+		that2, ok := that.(BranchSession)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2677,52 +3442,46 @@ func (this *GlobalLockQueryResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.ResultCode != that1.ResultCode {
+	if this.Addressing != that1.Addressing {
 		return false
 	}
-	if this.ExceptionCode != that1.ExceptionCode {
+	if this.XID != that1.XID {
 		return false
 	}
-	if this.Message != that1.Message {
+	if this.BranchID != that1.BranchID {
 		return false
 	}
-	if this.Lockable != that1.Lockable {
+	if this.TransactionID != that1.TransactionID {
 		return false
 	}
-	return true
-}
-func (this *GlobalStatusRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+	if this.ResourceID != that1.ResourceID {
+		return false
 	}
-
-	that1, ok := that.(*GlobalStatusRequest)
-	if !ok {
-		that2, ok := that.(GlobalStatusRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+	if this.LockKey != that1.LockKey {
+		return false
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
+	if this.Type != that1.Type {
 		return false
 	}
-	if this.XID != that1.XID {
+	if this.Status != that1.Status {
+		return false
+	}
+	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
+		return false
+	}
+	if this.AsyncCommit != that1.AsyncCommit {
 		return false
 	}
 	return true
 }
-func (this *GlobalStatusResponse) Equal(that interface{}) bool {
+func (this *RowLock) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlobalStatusResponse)
+	that1, ok := that.(*RowLock)
 	if !ok {
-		that2, ok := that.(GlobalStatusResponse)
+		that2, ok := that.(RowLock)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2734,28 +3493,37 @@ func (this *GlobalStatusResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.ResultCode != that1.ResultCode {
+	if this.XID != that1.XID {
 		return false
 	}
-	if this.ExceptionCode != that1.ExceptionCode {
+	if this.TransactionID != that1.TransactionID {
 		return false
 	}
-	if this.Message != that1.Message {
+	if this.BranchID != that1.BranchID {
 		return false
 	}
-	if this.GlobalStatus != that1.GlobalStatus {
+	if this.ResourceID != that1.ResourceID {
+		return false
+	}
+	if this.TableName != that1.TableName {
+		return false
+	}
+	if this.PK != that1.PK {
+		return false
+	}
+	if this.RowKey != that1.RowKey {
 		return false
 	}
 	return true
 }
-func (this *GlobalCommitRequest) Equal(that interface{}) bool {
+func (this *GlobalBeginRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlobalCommitRequest)
+	that1, ok := that.(*GlobalBeginRequest)
 	if !ok {
-		that2, ok := that.(GlobalCommitRequest)
+		that2, ok := that.(GlobalBeginRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2767,19 +3535,25 @@ func (this *GlobalCommitRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.XID != that1.XID {
+	if this.Addressing != that1.Addressing {
+		return false
+	}
+	if this.Timeout != that1.Timeout {
+		return false
+	}
+	if this.TransactionName != that1.TransactionName {
 		return false
 	}
 	return true
 }
-func (this *GlobalCommitResponse) Equal(that interface{}) bool {
+func (this *GlobalBeginResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlobalCommitResponse)
+	that1, ok := that.(*GlobalBeginResponse)
 	if !ok {
-		that2, ok := that.(GlobalCommitResponse)
+		that2, ok := that.(GlobalBeginResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2800,19 +3574,19 @@ func (this *GlobalCommitResponse) Equal(that interface{}) bool {
 	if this.Message != that1.Message {
 		return false
 	}
-	if this.GlobalStatus != that1.GlobalStatus {
+	if this.XID != that1.XID {
 		return false
 	}
 	return true
 }
-func (this *GlobalRollbackRequest) Equal(that interface{}) bool {
+func (this *BranchRegisterRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlobalRollbackRequest)
+	that1, ok := that.(*BranchRegisterRequest)
 	if !ok {
-		that2, ok := that.(GlobalRollbackRequest)
+		that2, ok := that.(BranchRegisterRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2824,19 +3598,37 @@ func (this *GlobalRollbackRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
+	if this.Addressing != that1.Addressing {
+		return false
+	}
 	if this.XID != that1.XID {
 		return false
 	}
+	if this.ResourceID != that1.ResourceID {
+		return false
+	}
+	if this.LockKey != that1.LockKey {
+		return false
+	}
+	if this.BranchType != that1.BranchType {
+		return false
+	}
+	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
+		return false
+	}
+	if this.AsyncCommit != that1.AsyncCommit {
+		return false
+	}
 	return true
 }
-func (this *GlobalRollbackResponse) Equal(that interface{}) bool {
+func (this *BranchRegisterResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlobalRollbackResponse)
+	that1, ok := that.(*BranchRegisterResponse)
 	if !ok {
-		that2, ok := that.(GlobalRollbackResponse)
+		that2, ok := that.(BranchRegisterResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2857,19 +3649,19 @@ func (this *GlobalRollbackResponse) Equal(that interface{}) bool {
 	if this.Message != that1.Message {
 		return false
 	}
-	if this.GlobalStatus != that1.GlobalStatus {
+	if this.BranchID != that1.BranchID {
 		return false
 	}
 	return true
 }
-func (this *GlobalReportRequest) Equal(that interface{}) bool {
+func (this *BranchReportRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlobalReportRequest)
+	that1, ok := that.(*BranchReportRequest)
 	if !ok {
-		that2, ok := that.(GlobalReportRequest)
+		that2, ok := that.(BranchReportRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2884,19 +3676,31 @@ func (this *GlobalReportRequest) Equal(that interface{}) bool {
 	if this.XID != that1.XID {
 		return false
 	}
-	if this.GlobalStatus != that1.GlobalStatus {
+	if this.BranchID != that1.BranchID {
+		return false
+	}
+	if this.ResourceID != that1.ResourceID {
+		return false
+	}
+	if this.BranchType != that1.BranchType {
+		return false
+	}
+	if this.BranchStatus != that1.BranchStatus {
+		return false
+	}
+	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
 		return false
 	}
 	return true
 }
-func (this *GlobalReportResponse) Equal(that interface{}) bool {
+func (this *BranchReportResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GlobalReportResponse)
+	that1, ok := that.(*BranchReportResponse)
 	if !ok {
-		that2, ok := that.(GlobalReportResponse)
+		that2, ok := that.(BranchReportResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2917,19 +3721,16 @@ func (this *GlobalReportResponse) Equal(that interface{}) bool {
 	if this.Message != that1.Message {
 		return false
 	}
-	if this.GlobalStatus != that1.GlobalStatus {
-		return false
-	}
 	return true
 }
-func (this *BranchCommitRequest) Equal(that interface{}) bool {
+func (this *GlobalLockQueryRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*BranchCommitRequest)
+	that1, ok := that.(*GlobalLockQueryRequest)
 	if !ok {
-		that2, ok := that.(BranchCommitRequest)
+		that2, ok := that.(GlobalLockQueryRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2944,9 +3745,6 @@ func (this *BranchCommitRequest) Equal(that interface{}) bool {
 	if this.XID != that1.XID {
 		return false
 	}
-	if this.BranchID != that1.BranchID {
-		return false
-	}
 	if this.ResourceID != that1.ResourceID {
 		return false
 	}
@@ -2956,19 +3754,16 @@ func (this *BranchCommitRequest) Equal(that interface{}) bool {
 	if this.BranchType != that1.BranchType {
 		return false
 	}
-	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
-		return false
-	}
 	return true
 }
-func (this *BranchCommitResponse) Equal(that interface{}) bool {
+func (this *GlobalLockQueryResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*BranchCommitResponse)
+	that1, ok := that.(*GlobalLockQueryResponse)
 	if !ok {
-		that2, ok := that.(BranchCommitResponse)
+		that2, ok := that.(GlobalLockQueryResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2989,25 +3784,43 @@ func (this *BranchCommitResponse) Equal(that interface{}) bool {
 	if this.Message != that1.Message {
 		return false
 	}
-	if this.XID != that1.XID {
+	if this.Lockable != that1.Lockable {
 		return false
 	}
-	if this.BranchID != that1.BranchID {
+	return true
+}
+func (this *GlobalStatusRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GlobalStatusRequest)
+	if !ok {
+		that2, ok := that.(GlobalStatusRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if this.BranchStatus != that1.BranchStatus {
+	if this.XID != that1.XID {
 		return false
 	}
 	return true
 }
-func (this *BranchRollbackRequest) Equal(that interface{}) bool {
+func (this *GlobalStatusResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*BranchRollbackRequest)
+	that1, ok := that.(*GlobalStatusResponse)
 	if !ok {
-		that2, ok := that.(BranchRollbackRequest)
+		that2, ok := that.(GlobalStatusResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3019,34 +3832,52 @@ func (this *BranchRollbackRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.XID != that1.XID {
+	if this.ResultCode != that1.ResultCode {
 		return false
 	}
-	if this.BranchID != that1.BranchID {
+	if this.ExceptionCode != that1.ExceptionCode {
 		return false
 	}
-	if this.ResourceID != that1.ResourceID {
+	if this.Message != that1.Message {
 		return false
 	}
-	if this.LockKey != that1.LockKey {
+	if this.GlobalStatus != that1.GlobalStatus {
 		return false
 	}
-	if this.BranchType != that1.BranchType {
+	return true
+}
+func (this *GlobalCommitRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GlobalCommitRequest)
+	if !ok {
+		that2, ok := that.(GlobalCommitRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
+	if this.XID != that1.XID {
 		return false
 	}
 	return true
 }
-func (this *BranchRollbackResponse) Equal(that interface{}) bool {
+func (this *GlobalCommitResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*BranchRollbackResponse)
+	that1, ok := that.(*GlobalCommitResponse)
 	if !ok {
-		that2, ok := that.(BranchRollbackResponse)
+		that2, ok := that.(GlobalCommitResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3067,25 +3898,43 @@ func (this *BranchRollbackResponse) Equal(that interface{}) bool {
 	if this.Message != that1.Message {
 		return false
 	}
-	if this.XID != that1.XID {
+	if this.GlobalStatus != that1.GlobalStatus {
 		return false
 	}
-	if this.BranchID != that1.BranchID {
+	return true
+}
+func (this *GlobalRollbackRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GlobalRollbackRequest)
+	if !ok {
+		that2, ok := that.(GlobalRollbackRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if this.BranchStatus != that1.BranchStatus {
+	if this.XID != that1.XID {
 		return false
 	}
 	return true
 }
-func (this *BranchMessage) Equal(that interface{}) bool {
+func (this *GlobalRollbackResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*BranchMessage)
+	that1, ok := that.(*GlobalRollbackResponse)
 	if !ok {
-		that2, ok := that.(BranchMessage)
+		that2, ok := that.(GlobalRollbackResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -3097,2514 +3946,6946 @@ func (this *BranchMessage) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.ID != that1.ID {
+	if this.ResultCode != that1.ResultCode {
 		return false
 	}
-	if this.BranchMessageType != that1.BranchMessageType {
+	if this.ExceptionCode != that1.ExceptionCode {
 		return false
 	}
-	if !this.Message.Equal(that1.Message) {
+	if this.Message != that1.Message {
+		return false
+	}
+	if this.GlobalStatus != that1.GlobalStatus {
 		return false
 	}
 	return true
 }
-func (this *GlobalSession) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *GlobalReportRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 12)
-	s = append(s, "&apis.GlobalSession{")
-	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "TransactionID: "+fmt.Sprintf("%#v", this.TransactionID)+",\n")
-	s = append(s, "TransactionName: "+fmt.Sprintf("%#v", this.TransactionName)+",\n")
-	s = append(s, "Timeout: "+fmt.Sprintf("%#v", this.Timeout)+",\n")
-	s = append(s, "BeginTime: "+fmt.Sprintf("%#v", this.BeginTime)+",\n")
-	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
-	s = append(s, "Active: "+fmt.Sprintf("%#v", this.Active)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *BranchSession) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*GlobalReportRequest)
+	if !ok {
+		that2, ok := that.(GlobalReportRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 14)
-	s = append(s, "&apis.BranchSession{")
-	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
-	s = append(s, "TransactionID: "+fmt.Sprintf("%#v", this.TransactionID)+",\n")
-	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
-	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
-	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
-	s = append(s, "AsyncCommit: "+fmt.Sprintf("%#v", this.AsyncCommit)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *RowLock) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 11)
-	s = append(s, "&apis.RowLock{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "TransactionID: "+fmt.Sprintf("%#v", this.TransactionID)+",\n")
-	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
-	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
-	s = append(s, "TableName: "+fmt.Sprintf("%#v", this.TableName)+",\n")
-	s = append(s, "PK: "+fmt.Sprintf("%#v", this.PK)+",\n")
-	s = append(s, "RowKey: "+fmt.Sprintf("%#v", this.RowKey)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalBeginRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.XID != that1.XID {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&apis.GlobalBeginRequest{")
-	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
-	s = append(s, "Timeout: "+fmt.Sprintf("%#v", this.Timeout)+",\n")
-	s = append(s, "TransactionName: "+fmt.Sprintf("%#v", this.TransactionName)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalBeginResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.GlobalStatus != that1.GlobalStatus {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&apis.GlobalBeginResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *BranchRegisterRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *GlobalReportResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 11)
-	s = append(s, "&apis.BranchRegisterRequest{")
-	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
-	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
-	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
-	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
-	s = append(s, "AsyncCommit: "+fmt.Sprintf("%#v", this.AsyncCommit)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *BranchRegisterResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*GlobalReportResponse)
+	if !ok {
+		that2, ok := that.(GlobalReportResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&apis.BranchRegisterResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *BranchReportRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&apis.BranchReportRequest{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
-	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
-	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
-	s = append(s, "BranchStatus: "+fmt.Sprintf("%#v", this.BranchStatus)+",\n")
-	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *BranchReportResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&apis.BranchReportResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalLockQueryRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&apis.GlobalLockQueryRequest{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
-	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
-	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalLockQueryResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Message != that1.Message {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&apis.GlobalLockQueryResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "Lockable: "+fmt.Sprintf("%#v", this.Lockable)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalStatusRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.GlobalStatus != that1.GlobalStatus {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&apis.GlobalStatusRequest{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *GlobalStatusResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *BranchCommitRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&apis.GlobalStatusResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalCommitRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*BranchCommitRequest)
+	if !ok {
+		that2, ok := that.(BranchCommitRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&apis.GlobalCommitRequest{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalCommitResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&apis.GlobalCommitResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalRollbackRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.XID != that1.XID {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&apis.GlobalRollbackRequest{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalRollbackResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.BranchID != that1.BranchID {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&apis.GlobalRollbackResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalReportRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.ResourceID != that1.ResourceID {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&apis.GlobalReportRequest{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GlobalReportResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.LockKey != that1.LockKey {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&apis.GlobalReportResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *BranchCommitRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.BranchType != that1.BranchType {
+		return false
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&apis.BranchCommitRequest{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
-	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
-	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
-	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
-	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *BranchCommitResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
+		return false
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&apis.BranchCommitResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
-	s = append(s, "BranchStatus: "+fmt.Sprintf("%#v", this.BranchStatus)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *BranchRollbackRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *BranchCommitResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&apis.BranchRollbackRequest{")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
-	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
-	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
-	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
-	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *BranchRollbackResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*BranchCommitResponse)
+	if !ok {
+		that2, ok := that.(BranchCommitResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&apis.BranchRollbackResponse{")
-	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
-	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
-	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
-	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
-	s = append(s, "BranchStatus: "+fmt.Sprintf("%#v", this.BranchStatus)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *BranchMessage) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&apis.BranchMessage{")
-	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
-	s = append(s, "BranchMessageType: "+fmt.Sprintf("%#v", this.BranchMessageType)+",\n")
-	if this.Message != nil {
-		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func valueToGoStringSeata(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-func (m *GlobalSession) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Message != that1.Message {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalSession) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.XID != that1.XID {
+		return false
+	}
+	if this.BranchID != that1.BranchID {
+		return false
+	}
+	if this.BranchStatus != that1.BranchStatus {
+		return false
+	}
+	return true
 }
+func (this *BranchRollbackRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GlobalSession) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Active {
-		i--
-		if m.Active {
-			dAtA[i] = 1
+	that1, ok := that.(*BranchRollbackRequest)
+	if !ok {
+		that2, ok := that.(BranchRollbackRequest)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i--
-		dAtA[i] = 0x40
 	}
-	if m.Status != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x38
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.BeginTime != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BeginTime))
-		i--
-		dAtA[i] = 0x30
+	if this.XID != that1.XID {
+		return false
 	}
-	if m.Timeout != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.Timeout))
-		i--
-		dAtA[i] = 0x28
+	if this.BranchID != that1.BranchID {
+		return false
 	}
-	if len(m.TransactionName) > 0 {
-		i -= len(m.TransactionName)
-		copy(dAtA[i:], m.TransactionName)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.TransactionName)))
-		i--
-		dAtA[i] = 0x22
+	if this.ResourceID != that1.ResourceID {
+		return false
 	}
-	if m.TransactionID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.TransactionID))
-		i--
-		dAtA[i] = 0x18
+	if this.LockKey != that1.LockKey {
+		return false
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0x12
+	if this.BranchType != that1.BranchType {
+		return false
 	}
-	if len(m.Addressing) > 0 {
-		i -= len(m.Addressing)
-		copy(dAtA[i:], m.Addressing)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
-		i--
-		dAtA[i] = 0xa
+	if !bytes.Equal(this.ApplicationData, that1.ApplicationData) {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
+func (this *BranchRollbackResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *BranchSession) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*BranchRollbackResponse)
+	if !ok {
+		that2, ok := that.(BranchRollbackResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *BranchSession) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ResultCode != that1.ResultCode {
+		return false
+	}
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
+	}
+	if this.Message != that1.Message {
+		return false
+	}
+	if this.XID != that1.XID {
+		return false
+	}
+	if this.BranchID != that1.BranchID {
+		return false
+	}
+	if this.BranchStatus != that1.BranchStatus {
+		return false
+	}
+	return true
 }
+func (this *BranchMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *BranchSession) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.AsyncCommit {
-		i--
-		if m.AsyncCommit {
-			dAtA[i] = 1
+	that1, ok := that.(*BranchMessage)
+	if !ok {
+		that2, ok := that.(BranchMessage)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i--
-		dAtA[i] = 0x50
 	}
-	if len(m.ApplicationData) > 0 {
-		i -= len(m.ApplicationData)
-		copy(dAtA[i:], m.ApplicationData)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
-		i--
-		dAtA[i] = 0x4a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Status != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x40
+	if this.ID != that1.ID {
+		return false
 	}
-	if m.Type != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x38
+	if this.BranchMessageType != that1.BranchMessageType {
+		return false
 	}
-	if len(m.LockKey) > 0 {
-		i -= len(m.LockKey)
-		copy(dAtA[i:], m.LockKey)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
-		i--
-		dAtA[i] = 0x32
+	if !this.Message.Equal(that1.Message) {
+		return false
 	}
-	if len(m.ResourceID) > 0 {
-		i -= len(m.ResourceID)
-		copy(dAtA[i:], m.ResourceID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
-		i--
-		dAtA[i] = 0x2a
+	return true
+}
+func (this *RowLockHolder) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.TransactionID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.TransactionID))
-		i--
-		dAtA[i] = 0x20
+
+	that1, ok := that.(*RowLockHolder)
+	if !ok {
+		that2, ok := that.(RowLockHolder)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.BranchID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
-		i--
-		dAtA[i] = 0x18
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0x12
+	if !this.RowLock.Equal(that1.RowLock) {
+		return false
 	}
-	if len(m.Addressing) > 0 {
-		i -= len(m.Addressing)
-		copy(dAtA[i:], m.Addressing)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
-		i--
-		dAtA[i] = 0xa
+	if this.Status != that1.Status {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *RowLock) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.BeginTime != that1.BeginTime {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *RowLock) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.Age != that1.Age {
+		return false
+	}
+	return true
 }
+func (this *LockListRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *RowLock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.RowKey) > 0 {
-		i -= len(m.RowKey)
-		copy(dAtA[i:], m.RowKey)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.RowKey)))
-		i--
-		dAtA[i] = 0x3a
+	that1, ok := that.(*LockListRequest)
+	if !ok {
+		that2, ok := that.(LockListRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.PK) > 0 {
-		i -= len(m.PK)
-		copy(dAtA[i:], m.PK)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.PK)))
-		i--
-		dAtA[i] = 0x32
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.TableName) > 0 {
-		i -= len(m.TableName)
-		copy(dAtA[i:], m.TableName)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.TableName)))
-		i--
-		dAtA[i] = 0x2a
+	if this.ResourceID != that1.ResourceID {
+		return false
 	}
-	if len(m.ResourceID) > 0 {
-		i -= len(m.ResourceID)
-		copy(dAtA[i:], m.ResourceID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
-		i--
-		dAtA[i] = 0x22
+	if this.TableName != that1.TableName {
+		return false
 	}
-	if m.BranchID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
-		i--
-		dAtA[i] = 0x18
+	if this.XID != that1.XID {
+		return false
 	}
-	if m.TransactionID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.TransactionID))
-		i--
-		dAtA[i] = 0x10
+	if this.BranchID != that1.BranchID {
+		return false
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	if this.Offset != that1.Offset {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalBeginRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Limit != that1.Limit {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalBeginRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
-
-func (m *GlobalBeginRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.TransactionName) > 0 {
-		i -= len(m.TransactionName)
-		copy(dAtA[i:], m.TransactionName)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.TransactionName)))
-		i--
-		dAtA[i] = 0x1a
+func (this *LockListResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.Timeout != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.Timeout))
-		i--
-		dAtA[i] = 0x10
+
+	that1, ok := that.(*LockListResponse)
+	if !ok {
+		that2, ok := that.(LockListResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Addressing) > 0 {
-		i -= len(m.Addressing)
-		copy(dAtA[i:], m.Addressing)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalBeginResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalBeginResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GlobalBeginResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0x22
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	if this.Message != that1.Message {
+		return false
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
+	if len(this.Locks) != len(that1.Locks) {
+		return false
 	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	for i := range this.Locks {
+		if !this.Locks[i].Equal(that1.Locks[i]) {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *BranchRegisterRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Total != that1.Total {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
+func (this *LockHolderQueryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *BranchRegisterRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*LockHolderQueryRequest)
+	if !ok {
+		that2, ok := that.(LockHolderQueryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ResourceID != that1.ResourceID {
+		return false
+	}
+	if this.LockKey != that1.LockKey {
+		return false
+	}
+	return true
 }
+func (this *LockHolderQueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *BranchRegisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.AsyncCommit {
-		i--
-		if m.AsyncCommit {
-			dAtA[i] = 1
+	that1, ok := that.(*LockHolderQueryResponse)
+	if !ok {
+		that2, ok := that.(LockHolderQueryResponse)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i--
-		dAtA[i] = 0x38
 	}
-	if len(m.ApplicationData) > 0 {
-		i -= len(m.ApplicationData)
-		copy(dAtA[i:], m.ApplicationData)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
-		i--
-		dAtA[i] = 0x32
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.BranchType != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
-		i--
-		dAtA[i] = 0x28
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	if len(m.LockKey) > 0 {
-		i -= len(m.LockKey)
-		copy(dAtA[i:], m.LockKey)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
-		i--
-		dAtA[i] = 0x22
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	if len(m.ResourceID) > 0 {
-		i -= len(m.ResourceID)
-		copy(dAtA[i:], m.ResourceID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
-		i--
-		dAtA[i] = 0x1a
+	if this.Message != that1.Message {
+		return false
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0x12
+	if len(this.Holders) != len(that1.Holders) {
+		return false
 	}
-	if len(m.Addressing) > 0 {
-		i -= len(m.Addressing)
-		copy(dAtA[i:], m.Addressing)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
-		i--
-		dAtA[i] = 0xa
+	for i := range this.Holders {
+		if !this.Holders[i].Equal(that1.Holders[i]) {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *BranchRegisterResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LockReleaseRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *BranchRegisterResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *BranchRegisterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.BranchID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
-		i--
-		dAtA[i] = 0x20
+	that1, ok := that.(*LockReleaseRequest)
+	if !ok {
+		that2, ok := that.(LockReleaseRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
+	if this.XID != that1.XID {
+		return false
 	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	if this.BranchID != that1.BranchID {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *BranchReportRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.ResourceID != that1.ResourceID {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *BranchReportRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.LockKey != that1.LockKey {
+		return false
+	}
+	if this.Force != that1.Force {
+		return false
+	}
+	if this.Reason != that1.Reason {
+		return false
+	}
+	return true
 }
+func (this *LockReleaseResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *BranchReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ApplicationData) > 0 {
-		i -= len(m.ApplicationData)
-		copy(dAtA[i:], m.ApplicationData)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
-		i--
-		dAtA[i] = 0x32
+	that1, ok := that.(*LockReleaseResponse)
+	if !ok {
+		that2, ok := that.(LockReleaseResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.BranchStatus != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchStatus))
-		i--
-		dAtA[i] = 0x28
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.BranchType != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
-		i--
-		dAtA[i] = 0x20
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	if len(m.ResourceID) > 0 {
-		i -= len(m.ResourceID)
-		copy(dAtA[i:], m.ResourceID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
-		i--
-		dAtA[i] = 0x1a
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	if m.BranchID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
-		i--
-		dAtA[i] = 0x10
+	if this.Message != that1.Message {
+		return false
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	if this.Released != that1.Released {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *BranchReportResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *SessionListRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *BranchReportResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *BranchReportResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	that1, ok := that.(*SessionListRequest)
+	if !ok {
+		that2, ok := that.(SessionListRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	if len(this.Statuses) != len(that1.Statuses) {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalLockQueryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	for i := range this.Statuses {
+		if this.Statuses[i] != that1.Statuses[i] {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalLockQueryRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GlobalLockQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.BranchType != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
-		i--
-		dAtA[i] = 0x20
+	if this.Addressing != that1.Addressing {
+		return false
 	}
-	if len(m.LockKey) > 0 {
-		i -= len(m.LockKey)
-		copy(dAtA[i:], m.LockKey)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
-		i--
-		dAtA[i] = 0x1a
+	if this.TransactionName != that1.TransactionName {
+		return false
 	}
-	if len(m.ResourceID) > 0 {
-		i -= len(m.ResourceID)
-		copy(dAtA[i:], m.ResourceID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
-		i--
-		dAtA[i] = 0x12
+	if this.MinAge != that1.MinAge {
+		return false
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	if this.Offset != that1.Offset {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalLockQueryResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Limit != that1.Limit {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalLockQueryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
+func (this *SessionListResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GlobalLockQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Lockable {
-		i--
-		if m.Lockable {
-			dAtA[i] = 1
+	that1, ok := that.(*SessionListResponse)
+	if !ok {
+		that2, ok := that.(SessionListResponse)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i--
-		dAtA[i] = 0x20
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalStatusRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Message != that1.Message {
+		return false
 	}
-	return dAtA[:n], nil
+	if len(this.Sessions) != len(that1.Sessions) {
+		return false
+	}
+	for i := range this.Sessions {
+		if !this.Sessions[i].Equal(that1.Sessions[i]) {
+			return false
+		}
+	}
+	if this.Total != that1.Total {
+		return false
+	}
+	return true
 }
+func (this *SessionGetRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GlobalStatusRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*SessionGetRequest)
+	if !ok {
+		that2, ok := that.(SessionGetRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.XID != that1.XID {
+		return false
+	}
+	return true
 }
+func (this *SessionGetResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *GlobalStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	that1, ok := that.(*SessionGetResponse)
+	if !ok {
+		that2, ok := that.(SessionGetResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalStatusResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalStatusResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GlobalStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.GlobalStatus != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
-		i--
-		dAtA[i] = 0x20
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
+	if this.Message != that1.Message {
+		return false
 	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	if !this.GlobalSession.Equal(that1.GlobalSession) {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(this.BranchSessions) != len(that1.BranchSessions) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GlobalCommitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	for i := range this.BranchSessions {
+		if !this.BranchSessions[i].Equal(that1.BranchSessions[i]) {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *GlobalCommitResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *SessionRetryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalCommitResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *GlobalCommitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.GlobalStatus != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
-		i--
-		dAtA[i] = 0x20
+	that1, ok := that.(*SessionRetryRequest)
+	if !ok {
+		that2, ok := that.(SessionRetryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
+	if this.XID != that1.XID {
+		return false
 	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	if this.Reason != that1.Reason {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *GlobalRollbackRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *SessionRetryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalRollbackRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *GlobalRollbackRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	that1, ok := that.(*SessionRetryResponse)
+	if !ok {
+		that2, ok := that.(SessionRetryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalRollbackResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalRollbackResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GlobalRollbackResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.GlobalStatus != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
-		i--
-		dAtA[i] = 0x20
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
+	if this.Message != that1.Message {
+		return false
 	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	if this.GlobalStatus != that1.GlobalStatus {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *GlobalReportRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *SessionRemoveRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalReportRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *GlobalReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.GlobalStatus != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	that1, ok := that.(*SessionRemoveRequest)
+	if !ok {
+		that2, ok := that.(SessionRemoveRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GlobalReportResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GlobalReportResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GlobalReportResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.GlobalStatus != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
-		i--
-		dAtA[i] = 0x20
+	if this.XID != that1.XID {
+		return false
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	if this.Force != that1.Force {
+		return false
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
+	if this.ReleaseLocks != that1.ReleaseLocks {
+		return false
 	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	if this.Reason != that1.Reason {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *BranchCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *SessionRemoveResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *BranchCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *BranchCommitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ApplicationData) > 0 {
-		i -= len(m.ApplicationData)
-		copy(dAtA[i:], m.ApplicationData)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if m.BranchType != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
-		i--
-		dAtA[i] = 0x28
+	that1, ok := that.(*SessionRemoveResponse)
+	if !ok {
+		that2, ok := that.(SessionRemoveResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.LockKey) > 0 {
-		i -= len(m.LockKey)
-		copy(dAtA[i:], m.LockKey)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
-		i--
-		dAtA[i] = 0x22
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.ResourceID) > 0 {
-		i -= len(m.ResourceID)
-		copy(dAtA[i:], m.ResourceID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
-		i--
-		dAtA[i] = 0x1a
+	if this.ResultCode != that1.ResultCode {
+		return false
 	}
-	if m.BranchID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
-		i--
-		dAtA[i] = 0x10
+	if this.ExceptionCode != that1.ExceptionCode {
+		return false
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	if this.Message != that1.Message {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *BranchCommitResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *GlobalSession) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 12)
+	s = append(s, "&apis.GlobalSession{")
+	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "TransactionID: "+fmt.Sprintf("%#v", this.TransactionID)+",\n")
+	s = append(s, "TransactionName: "+fmt.Sprintf("%#v", this.TransactionName)+",\n")
+	s = append(s, "Timeout: "+fmt.Sprintf("%#v", this.Timeout)+",\n")
+	s = append(s, "BeginTime: "+fmt.Sprintf("%#v", this.BeginTime)+",\n")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	s = append(s, "Active: "+fmt.Sprintf("%#v", this.Active)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchCommitResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *BranchSession) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 14)
+	s = append(s, "&apis.BranchSession{")
+	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "TransactionID: "+fmt.Sprintf("%#v", this.TransactionID)+",\n")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
+	s = append(s, "AsyncCommit: "+fmt.Sprintf("%#v", this.AsyncCommit)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchCommitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.BranchStatus != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchStatus))
-		i--
-		dAtA[i] = 0x30
+func (this *RowLock) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.BranchID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
-		i--
-		dAtA[i] = 0x28
+	s := make([]string, 0, 11)
+	s = append(s, "&apis.RowLock{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "TransactionID: "+fmt.Sprintf("%#v", this.TransactionID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "TableName: "+fmt.Sprintf("%#v", this.TableName)+",\n")
+	s = append(s, "PK: "+fmt.Sprintf("%#v", this.PK)+",\n")
+	s = append(s, "RowKey: "+fmt.Sprintf("%#v", this.RowKey)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GlobalBeginRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0x22
+	s := make([]string, 0, 7)
+	s = append(s, "&apis.GlobalBeginRequest{")
+	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
+	s = append(s, "Timeout: "+fmt.Sprintf("%#v", this.Timeout)+",\n")
+	s = append(s, "TransactionName: "+fmt.Sprintf("%#v", this.TransactionName)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GlobalBeginResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.GlobalBeginResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *BranchRegisterRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
+	s := make([]string, 0, 11)
+	s = append(s, "&apis.BranchRegisterRequest{")
+	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
+	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
+	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
+	s = append(s, "AsyncCommit: "+fmt.Sprintf("%#v", this.AsyncCommit)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *BranchRegisterResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.BranchRegisterResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchRollbackRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *BranchReportRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 10)
+	s = append(s, "&apis.BranchReportRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
+	s = append(s, "BranchStatus: "+fmt.Sprintf("%#v", this.BranchStatus)+",\n")
+	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchRollbackRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *BranchReportResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&apis.BranchReportResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchRollbackRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ApplicationData) > 0 {
-		i -= len(m.ApplicationData)
-		copy(dAtA[i:], m.ApplicationData)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
-		i--
-		dAtA[i] = 0x32
+func (this *GlobalLockQueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.BranchType != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
-		i--
-		dAtA[i] = 0x28
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.GlobalLockQueryRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
+	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GlobalLockQueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.LockKey) > 0 {
-		i -= len(m.LockKey)
-		copy(dAtA[i:], m.LockKey)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
-		i--
-		dAtA[i] = 0x22
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.GlobalLockQueryResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "Lockable: "+fmt.Sprintf("%#v", this.Lockable)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GlobalStatusRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.ResourceID) > 0 {
-		i -= len(m.ResourceID)
-		copy(dAtA[i:], m.ResourceID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
-		i--
-		dAtA[i] = 0x1a
+	s := make([]string, 0, 5)
+	s = append(s, "&apis.GlobalStatusRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GlobalStatusResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.BranchID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
-		i--
-		dAtA[i] = 0x10
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.GlobalStatusResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GlobalCommitRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 5)
+	s = append(s, "&apis.GlobalCommitRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GlobalCommitResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.GlobalCommitResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchRollbackResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *GlobalRollbackRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 5)
+	s = append(s, "&apis.GlobalRollbackRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchRollbackResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *GlobalRollbackResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.GlobalRollbackResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchRollbackResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.BranchStatus != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchStatus))
-		i--
-		dAtA[i] = 0x30
+func (this *GlobalReportRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.BranchID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
-		i--
-		dAtA[i] = 0x28
+	s := make([]string, 0, 6)
+	s = append(s, "&apis.GlobalReportRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GlobalReportResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.XID) > 0 {
-		i -= len(m.XID)
-		copy(dAtA[i:], m.XID)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
-		i--
-		dAtA[i] = 0x22
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.GlobalReportResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *BranchCommitRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.Message) > 0 {
-		i -= len(m.Message)
-		copy(dAtA[i:], m.Message)
-		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.ExceptionCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.ResultCode != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 10)
+	s = append(s, "&apis.BranchCommitRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
+	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
+	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *BranchCommitResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 10)
+	s = append(s, "&apis.BranchCommitResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "BranchStatus: "+fmt.Sprintf("%#v", this.BranchStatus)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *BranchRollbackRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&apis.BranchRollbackRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
+	s = append(s, "BranchType: "+fmt.Sprintf("%#v", this.BranchType)+",\n")
+	s = append(s, "ApplicationData: "+fmt.Sprintf("%#v", this.ApplicationData)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Message != nil {
-		{
-			size, err := m.Message.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintSeata(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+func (this *BranchRollbackResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.BranchMessageType != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.BranchMessageType))
-		i--
-		dAtA[i] = 0x10
+	s := make([]string, 0, 10)
+	s = append(s, "&apis.BranchRollbackResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "BranchStatus: "+fmt.Sprintf("%#v", this.BranchStatus)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *BranchMessage) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.ID != 0 {
-		i = encodeVarintSeata(dAtA, i, uint64(m.ID))
-		i--
-		dAtA[i] = 0x8
+	s := make([]string, 0, 7)
+	s = append(s, "&apis.BranchMessage{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "BranchMessageType: "+fmt.Sprintf("%#v", this.BranchMessageType)+",\n")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func encodeVarintSeata(dAtA []byte, offset int, v uint64) int {
-	offset -= sovSeata(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (this *RowLockHolder) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.RowLockHolder{")
+	if this.RowLock != nil {
+		s = append(s, "RowLock: "+fmt.Sprintf("%#v", this.RowLock)+",\n")
+	}
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	s = append(s, "BeginTime: "+fmt.Sprintf("%#v", this.BeginTime)+",\n")
+	s = append(s, "Age: "+fmt.Sprintf("%#v", this.Age)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-func (m *GlobalSession) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *LockListRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	l = len(m.Addressing)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	s := make([]string, 0, 10)
+	s = append(s, "&apis.LockListRequest{")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "TableName: "+fmt.Sprintf("%#v", this.TableName)+",\n")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "Offset: "+fmt.Sprintf("%#v", this.Offset)+",\n")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockListResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	s := make([]string, 0, 9)
+	s = append(s, "&apis.LockListResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	if this.Locks != nil {
+		s = append(s, "Locks: "+fmt.Sprintf("%#v", this.Locks)+",\n")
 	}
-	if m.TransactionID != 0 {
-		n += 1 + sovSeata(uint64(m.TransactionID))
+	s = append(s, "Total: "+fmt.Sprintf("%#v", this.Total)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockHolderQueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	l = len(m.TransactionName)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	s := make([]string, 0, 6)
+	s = append(s, "&apis.LockHolderQueryRequest{")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockHolderQueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Timeout != 0 {
-		n += 1 + sovSeata(uint64(m.Timeout))
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.LockHolderQueryResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	if this.Holders != nil {
+		s = append(s, "Holders: "+fmt.Sprintf("%#v", this.Holders)+",\n")
 	}
-	if m.BeginTime != 0 {
-		n += 1 + sovSeata(uint64(m.BeginTime))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockReleaseRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Status != 0 {
-		n += 1 + sovSeata(uint64(m.Status))
+	s := make([]string, 0, 10)
+	s = append(s, "&apis.LockReleaseRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "BranchID: "+fmt.Sprintf("%#v", this.BranchID)+",\n")
+	s = append(s, "ResourceID: "+fmt.Sprintf("%#v", this.ResourceID)+",\n")
+	s = append(s, "LockKey: "+fmt.Sprintf("%#v", this.LockKey)+",\n")
+	s = append(s, "Force: "+fmt.Sprintf("%#v", this.Force)+",\n")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LockReleaseResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Active {
-		n += 2
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.LockReleaseResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "Released: "+fmt.Sprintf("%#v", this.Released)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionListRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return n
+	s := make([]string, 0, 10)
+	s = append(s, "&apis.SessionListRequest{")
+	s = append(s, "Statuses: "+fmt.Sprintf("%#v", this.Statuses)+",\n")
+	s = append(s, "Addressing: "+fmt.Sprintf("%#v", this.Addressing)+",\n")
+	s = append(s, "TransactionName: "+fmt.Sprintf("%#v", this.TransactionName)+",\n")
+	s = append(s, "MinAge: "+fmt.Sprintf("%#v", this.MinAge)+",\n")
+	s = append(s, "Offset: "+fmt.Sprintf("%#v", this.Offset)+",\n")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *BranchSession) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *SessionListResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	l = len(m.Addressing)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	s := make([]string, 0, 9)
+	s = append(s, "&apis.SessionListResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	if this.Sessions != nil {
+		s = append(s, "Sessions: "+fmt.Sprintf("%#v", this.Sessions)+",\n")
 	}
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	s = append(s, "Total: "+fmt.Sprintf("%#v", this.Total)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionGetRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.BranchID != 0 {
-		n += 1 + sovSeata(uint64(m.BranchID))
+	s := make([]string, 0, 5)
+	s = append(s, "&apis.SessionGetRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionGetResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.TransactionID != 0 {
-		n += 1 + sovSeata(uint64(m.TransactionID))
+	s := make([]string, 0, 9)
+	s = append(s, "&apis.SessionGetResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	if this.GlobalSession != nil {
+		s = append(s, "GlobalSession: "+fmt.Sprintf("%#v", this.GlobalSession)+",\n")
 	}
-	l = len(m.ResourceID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if this.BranchSessions != nil {
+		s = append(s, "BranchSessions: "+fmt.Sprintf("%#v", this.BranchSessions)+",\n")
 	}
-	l = len(m.LockKey)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionRetryRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Type != 0 {
-		n += 1 + sovSeata(uint64(m.Type))
+	s := make([]string, 0, 6)
+	s = append(s, "&apis.SessionRetryRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionRetryResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.Status != 0 {
-		n += 1 + sovSeata(uint64(m.Status))
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.SessionRetryResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "GlobalStatus: "+fmt.Sprintf("%#v", this.GlobalStatus)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionRemoveRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	l = len(m.ApplicationData)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	s := make([]string, 0, 8)
+	s = append(s, "&apis.SessionRemoveRequest{")
+	s = append(s, "XID: "+fmt.Sprintf("%#v", this.XID)+",\n")
+	s = append(s, "Force: "+fmt.Sprintf("%#v", this.Force)+",\n")
+	s = append(s, "ReleaseLocks: "+fmt.Sprintf("%#v", this.ReleaseLocks)+",\n")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SessionRemoveResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.AsyncCommit {
-		n += 2
+	s := make([]string, 0, 7)
+	s = append(s, "&apis.SessionRemoveResponse{")
+	s = append(s, "ResultCode: "+fmt.Sprintf("%#v", this.ResultCode)+",\n")
+	s = append(s, "ExceptionCode: "+fmt.Sprintf("%#v", this.ExceptionCode)+",\n")
+	s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringSeata(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
 	}
-	return n
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
 }
-
-func (m *RowLock) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GlobalSession) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GlobalSession) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GlobalSession) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.Active {
+		i--
+		if m.Active {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
 	}
-	if m.TransactionID != 0 {
-		n += 1 + sovSeata(uint64(m.TransactionID))
+	if m.Status != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x38
 	}
-	if m.BranchID != 0 {
-		n += 1 + sovSeata(uint64(m.BranchID))
+	if m.BeginTime != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BeginTime))
+		i--
+		dAtA[i] = 0x30
 	}
-	l = len(m.ResourceID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.Timeout != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x28
 	}
-	l = len(m.TableName)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.TransactionName) > 0 {
+		i -= len(m.TransactionName)
+		copy(dAtA[i:], m.TransactionName)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.TransactionName)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.PK)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.TransactionID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.TransactionID))
+		i--
+		dAtA[i] = 0x18
 	}
-	l = len(m.RowKey)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.Addressing) > 0 {
+		i -= len(m.Addressing)
+		copy(dAtA[i:], m.Addressing)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *GlobalBeginRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Addressing)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	if m.Timeout != 0 {
-		n += 1 + sovSeata(uint64(m.Timeout))
-	}
-	l = len(m.TransactionName)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+func (m *BranchSession) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GlobalBeginResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
-	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
-	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	return n
+func (m *BranchSession) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BranchRegisterRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *BranchSession) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Addressing)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.AsyncCommit {
+		i--
+		if m.AsyncCommit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
 	}
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.ApplicationData) > 0 {
+		i -= len(m.ApplicationData)
+		copy(dAtA[i:], m.ApplicationData)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
+		i--
+		dAtA[i] = 0x4a
 	}
-	l = len(m.ResourceID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.Status != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x40
 	}
-	l = len(m.LockKey)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.Type != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x38
 	}
-	if m.BranchType != 0 {
-		n += 1 + sovSeata(uint64(m.BranchType))
+	if len(m.LockKey) > 0 {
+		i -= len(m.LockKey)
+		copy(dAtA[i:], m.LockKey)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
+		i--
+		dAtA[i] = 0x32
 	}
-	l = len(m.ApplicationData)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.AsyncCommit {
-		n += 2
+	if m.TransactionID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.TransactionID))
+		i--
+		dAtA[i] = 0x20
 	}
-	return n
-}
-
-func (m *BranchRegisterResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
-	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x18
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.BranchID != 0 {
-		n += 1 + sovSeata(uint64(m.BranchID))
+	if len(m.Addressing) > 0 {
+		i -= len(m.Addressing)
+		copy(dAtA[i:], m.Addressing)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BranchReportRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RowLock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RowLock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RowLock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.RowKey) > 0 {
+		i -= len(m.RowKey)
+		copy(dAtA[i:], m.RowKey)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.RowKey)))
+		i--
+		dAtA[i] = 0x3a
 	}
-	if m.BranchID != 0 {
-		n += 1 + sovSeata(uint64(m.BranchID))
+	if len(m.PK) > 0 {
+		i -= len(m.PK)
+		copy(dAtA[i:], m.PK)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.PK)))
+		i--
+		dAtA[i] = 0x32
 	}
-	l = len(m.ResourceID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.TableName) > 0 {
+		i -= len(m.TableName)
+		copy(dAtA[i:], m.TableName)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.TableName)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.BranchType != 0 {
-		n += 1 + sovSeata(uint64(m.BranchType))
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0x22
 	}
-	if m.BranchStatus != 0 {
-		n += 1 + sovSeata(uint64(m.BranchStatus))
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x18
 	}
-	l = len(m.ApplicationData)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.TransactionID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.TransactionID))
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *BranchReportResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
-	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
-	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+func (m *GlobalBeginRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GlobalLockQueryRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GlobalBeginRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GlobalBeginRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	l = len(m.ResourceID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.TransactionName) > 0 {
+		i -= len(m.TransactionName)
+		copy(dAtA[i:], m.TransactionName)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.TransactionName)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.LockKey)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.Timeout != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.BranchType != 0 {
-		n += 1 + sovSeata(uint64(m.BranchType))
+	if len(m.Addressing) > 0 {
+		i -= len(m.Addressing)
+		copy(dAtA[i:], m.Addressing)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GlobalLockQueryResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GlobalBeginResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GlobalBeginResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GlobalBeginResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0x22
 	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.Lockable {
-		n += 2
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GlobalStatusRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+func (m *BranchRegisterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GlobalStatusResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *BranchRegisterRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchRegisterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
+	if m.AsyncCommit {
+		i--
+		if m.AsyncCommit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
 	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	if len(m.ApplicationData) > 0 {
+		i -= len(m.ApplicationData)
+		copy(dAtA[i:], m.ApplicationData)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
+		i--
+		dAtA[i] = 0x32
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.BranchType != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
+		i--
+		dAtA[i] = 0x28
 	}
-	if m.GlobalStatus != 0 {
-		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	if len(m.LockKey) > 0 {
+		i -= len(m.LockKey)
+		copy(dAtA[i:], m.LockKey)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
+		i--
+		dAtA[i] = 0x22
 	}
-	return n
-}
-
-func (m *GlobalCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	var l int
-	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	if len(m.Addressing) > 0 {
+		i -= len(m.Addressing)
+		copy(dAtA[i:], m.Addressing)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *GlobalCommitResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BranchRegisterResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BranchRegisterResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchRegisterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x20
 	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.GlobalStatus != 0 {
-		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GlobalRollbackRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+func (m *BranchReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GlobalRollbackResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *BranchReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
-	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	if len(m.ApplicationData) > 0 {
+		i -= len(m.ApplicationData)
+		copy(dAtA[i:], m.ApplicationData)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
+		i--
+		dAtA[i] = 0x32
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.BranchStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchStatus))
+		i--
+		dAtA[i] = 0x28
 	}
-	if m.GlobalStatus != 0 {
-		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	if m.BranchType != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
+		i--
+		dAtA[i] = 0x20
 	}
-	return n
-}
-
-func (m *GlobalReportRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	var l int
-	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.GlobalStatus != 0 {
-		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GlobalReportResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BranchReportResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BranchReportResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchReportResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
 	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
-	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.GlobalStatus != 0 {
-		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BranchCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GlobalLockQueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	if m.BranchID != 0 {
-		n += 1 + sovSeata(uint64(m.BranchID))
-	}
-	l = len(m.ResourceID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	l = len(m.LockKey)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	if m.BranchType != 0 {
-		n += 1 + sovSeata(uint64(m.BranchType))
-	}
-	l = len(m.ApplicationData)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *BranchCommitResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GlobalLockQueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GlobalLockQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
-	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
-	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.BranchType != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.LockKey) > 0 {
+		i -= len(m.LockKey)
+		copy(dAtA[i:], m.LockKey)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.BranchID != 0 {
-		n += 1 + sovSeata(uint64(m.BranchID))
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.BranchStatus != 0 {
-		n += 1 + sovSeata(uint64(m.BranchStatus))
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BranchRollbackRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GlobalLockQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GlobalLockQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GlobalLockQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
-	}
-	if m.BranchID != 0 {
-		n += 1 + sovSeata(uint64(m.BranchID))
-	}
-	l = len(m.ResourceID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.Lockable {
+		i--
+		if m.Lockable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.LockKey)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.BranchType != 0 {
-		n += 1 + sovSeata(uint64(m.BranchType))
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	l = len(m.ApplicationData)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BranchRollbackResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GlobalStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GlobalStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GlobalStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ResultCode != 0 {
-		n += 1 + sovSeata(uint64(m.ResultCode))
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	if m.ExceptionCode != 0 {
-		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	return len(dAtA) - i, nil
+}
+
+func (m *GlobalStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *GlobalStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GlobalStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GlobalStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.XID)
-	if l > 0 {
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.BranchID != 0 {
-		n += 1 + sovSeata(uint64(m.BranchID))
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.BranchStatus != 0 {
-		n += 1 + sovSeata(uint64(m.BranchStatus))
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BranchMessage) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GlobalCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GlobalCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GlobalCommitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovSeata(uint64(m.ID))
-	}
-	if m.BranchMessageType != 0 {
-		n += 1 + sovSeata(uint64(m.BranchMessageType))
-	}
-	if m.Message != nil {
-		l = m.Message.Size()
-		n += 1 + l + sovSeata(uint64(l))
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func sovSeata(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *GlobalCommitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func sozSeata(x uint64) (n int) {
-	return sovSeata(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *GlobalCommitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *GlobalSession) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalCommitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GlobalStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
+		i--
+		dAtA[i] = 0x20
 	}
-	s := strings.Join([]string{`&GlobalSession{`,
-		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`TransactionID:` + fmt.Sprintf("%v", this.TransactionID) + `,`,
-		`TransactionName:` + fmt.Sprintf("%v", this.TransactionName) + `,`,
-		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
-		`BeginTime:` + fmt.Sprintf("%v", this.BeginTime) + `,`,
-		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`Active:` + fmt.Sprintf("%v", this.Active) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *BranchSession) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	s := strings.Join([]string{`&BranchSession{`,
-		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
-		`TransactionID:` + fmt.Sprintf("%v", this.TransactionID) + `,`,
-		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
-		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
-		`AsyncCommit:` + fmt.Sprintf("%v", this.AsyncCommit) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *RowLock) String() string {
-	if this == nil {
-		return "nil"
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	s := strings.Join([]string{`&RowLock{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`TransactionID:` + fmt.Sprintf("%v", this.TransactionID) + `,`,
-		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
-		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
-		`TableName:` + fmt.Sprintf("%v", this.TableName) + `,`,
-		`PK:` + fmt.Sprintf("%v", this.PK) + `,`,
-		`RowKey:` + fmt.Sprintf("%v", this.RowKey) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *GlobalBeginRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
 	}
-	s := strings.Join([]string{`&GlobalBeginRequest{`,
-		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
-		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
-		`TransactionName:` + fmt.Sprintf("%v", this.TransactionName) + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *GlobalBeginResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalRollbackRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&GlobalBeginResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *BranchRegisterRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&BranchRegisterRequest{`,
-		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
-		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
-		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
-		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
-		`AsyncCommit:` + fmt.Sprintf("%v", this.AsyncCommit) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *GlobalRollbackRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *BranchRegisterResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalRollbackRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	s := strings.Join([]string{`&BranchRegisterResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *BranchReportRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalRollbackResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&BranchReportRequest{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
-		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
-		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
-		`BranchStatus:` + fmt.Sprintf("%v", this.BranchStatus) + `,`,
-		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *BranchReportResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&BranchReportResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *GlobalRollbackResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *GlobalLockQueryRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalRollbackResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GlobalStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
+		i--
+		dAtA[i] = 0x20
 	}
-	s := strings.Join([]string{`&GlobalLockQueryRequest{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
-		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
-		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *GlobalLockQueryResponse) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	s := strings.Join([]string{`&GlobalLockQueryResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`Lockable:` + fmt.Sprintf("%v", this.Lockable) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *GlobalStatusRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	s := strings.Join([]string{`&GlobalStatusRequest{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`}`,
-	}, "")
-	return s
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
-func (this *GlobalStatusResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalReportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&GlobalStatusResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *GlobalCommitRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&GlobalCommitRequest{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *GlobalReportRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *GlobalCommitResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalReportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GlobalStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
+		i--
+		dAtA[i] = 0x10
 	}
-	s := strings.Join([]string{`&GlobalCommitResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *GlobalRollbackRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
 	}
-	s := strings.Join([]string{`&GlobalRollbackRequest{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *GlobalRollbackResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalReportResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&GlobalRollbackResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *GlobalReportRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&GlobalReportRequest{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *GlobalReportResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *GlobalReportResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *GlobalReportResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GlobalStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
+		i--
+		dAtA[i] = 0x20
 	}
-	s := strings.Join([]string{`&GlobalReportResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *BranchCommitRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	s := strings.Join([]string{`&BranchCommitRequest{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
-		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
-		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
-		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
-		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *BranchCommitResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
 	}
-	s := strings.Join([]string{`&BranchCommitResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
-		`BranchStatus:` + fmt.Sprintf("%v", this.BranchStatus) + `,`,
-		`}`,
-	}, "")
-	return s
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
-func (this *BranchRollbackRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *BranchCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&BranchRollbackRequest{`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
-		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
-		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
-		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
-		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *BranchRollbackResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *BranchCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchCommitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ApplicationData) > 0 {
+		i -= len(m.ApplicationData)
+		copy(dAtA[i:], m.ApplicationData)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
+		i--
+		dAtA[i] = 0x32
 	}
-	s := strings.Join([]string{`&BranchRollbackResponse{`,
-		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
-		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
-		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
-		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
-		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
-		`BranchStatus:` + fmt.Sprintf("%v", this.BranchStatus) + `,`,
-		`}`,
-	}, "")
-	return s
+	if m.BranchType != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.LockKey) > 0 {
+		i -= len(m.LockKey)
+		copy(dAtA[i:], m.LockKey)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
-func (this *BranchMessage) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *BranchCommitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BranchCommitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchCommitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BranchStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchStatus))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BranchRollbackRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BranchRollbackRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchRollbackRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ApplicationData) > 0 {
+		i -= len(m.ApplicationData)
+		copy(dAtA[i:], m.ApplicationData)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ApplicationData)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.BranchType != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchType))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.LockKey) > 0 {
+		i -= len(m.LockKey)
+		copy(dAtA[i:], m.LockKey)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BranchRollbackResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BranchRollbackResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchRollbackResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BranchStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchStatus))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BranchMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BranchMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BranchMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Message != nil {
+		{
+			size, err := m.Message.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSeata(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.BranchMessageType != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchMessageType))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RowLockHolder) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RowLockHolder) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RowLockHolder) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Age != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Age))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.BeginTime != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BeginTime))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Status != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Status))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.RowLock != nil {
+		{
+			size, err := m.RowLock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSeata(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Offset != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TableName) > 0 {
+		i -= len(m.TableName)
+		copy(dAtA[i:], m.TableName)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.TableName)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockListResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockListResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Total != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Locks) > 0 {
+		for iNdEx := len(m.Locks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Locks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSeata(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockHolderQueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockHolderQueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockHolderQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LockKey) > 0 {
+		i -= len(m.LockKey)
+		copy(dAtA[i:], m.LockKey)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockHolderQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockHolderQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockHolderQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Holders) > 0 {
+		for iNdEx := len(m.Holders) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Holders[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSeata(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockReleaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockReleaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockReleaseRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Force {
+		i--
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.LockKey) > 0 {
+		i -= len(m.LockKey)
+		copy(dAtA[i:], m.LockKey)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.LockKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ResourceID) > 0 {
+		i -= len(m.ResourceID)
+		copy(dAtA[i:], m.ResourceID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.ResourceID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.BranchID != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.BranchID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockReleaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockReleaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockReleaseResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Released != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Released))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Offset != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MinAge != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.MinAge))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.TransactionName) > 0 {
+		i -= len(m.TransactionName)
+		copy(dAtA[i:], m.TransactionName)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.TransactionName)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Addressing) > 0 {
+		i -= len(m.Addressing)
+		copy(dAtA[i:], m.Addressing)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Addressing)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Statuses) > 0 {
+		dAtA4 := make([]byte, len(m.Statuses)*10)
+		var j3 int
+		for _, num := range m.Statuses {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintSeata(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionListResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionListResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionListResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Total != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Sessions) > 0 {
+		for iNdEx := len(m.Sessions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sessions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSeata(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionGetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionGetRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionGetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionGetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionGetResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionGetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BranchSessions) > 0 {
+		for iNdEx := len(m.BranchSessions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BranchSessions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSeata(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.GlobalSession != nil {
+		{
+			size, err := m.GlobalSession.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSeata(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionRetryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionRetryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionRetryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionRetryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionRetryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionRetryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GlobalStatus != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.GlobalStatus))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionRemoveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionRemoveRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionRemoveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ReleaseLocks {
+		i--
+		if m.ReleaseLocks {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Force {
+		i--
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.XID) > 0 {
+		i -= len(m.XID)
+		copy(dAtA[i:], m.XID)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.XID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SessionRemoveResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SessionRemoveResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SessionRemoveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintSeata(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ExceptionCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ExceptionCode))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ResultCode != 0 {
+		i = encodeVarintSeata(dAtA, i, uint64(m.ResultCode))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSeata(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSeata(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GlobalSession) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addressing)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.TransactionID != 0 {
+		n += 1 + sovSeata(uint64(m.TransactionID))
+	}
+	l = len(m.TransactionName)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovSeata(uint64(m.Timeout))
+	}
+	if m.BeginTime != 0 {
+		n += 1 + sovSeata(uint64(m.BeginTime))
+	}
+	if m.Status != 0 {
+		n += 1 + sovSeata(uint64(m.Status))
+	}
+	if m.Active {
+		n += 2
+	}
+	return n
+}
+
+func (m *BranchSession) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addressing)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	if m.TransactionID != 0 {
+		n += 1 + sovSeata(uint64(m.TransactionID))
+	}
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.LockKey)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovSeata(uint64(m.Type))
+	}
+	if m.Status != 0 {
+		n += 1 + sovSeata(uint64(m.Status))
+	}
+	l = len(m.ApplicationData)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.AsyncCommit {
+		n += 2
+	}
+	return n
+}
+
+func (m *RowLock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.TransactionID != 0 {
+		n += 1 + sovSeata(uint64(m.TransactionID))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.TableName)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.PK)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.RowKey)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *GlobalBeginRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addressing)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovSeata(uint64(m.Timeout))
+	}
+	l = len(m.TransactionName)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *GlobalBeginResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *BranchRegisterRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addressing)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.LockKey)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchType != 0 {
+		n += 1 + sovSeata(uint64(m.BranchType))
+	}
+	l = len(m.ApplicationData)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.AsyncCommit {
+		n += 2
+	}
+	return n
+}
+
+func (m *BranchRegisterResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	return n
+}
+
+func (m *BranchReportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchType != 0 {
+		n += 1 + sovSeata(uint64(m.BranchType))
+	}
+	if m.BranchStatus != 0 {
+		n += 1 + sovSeata(uint64(m.BranchStatus))
+	}
+	l = len(m.ApplicationData)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *BranchReportResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *GlobalLockQueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.LockKey)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchType != 0 {
+		n += 1 + sovSeata(uint64(m.BranchType))
+	}
+	return n
+}
+
+func (m *GlobalLockQueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.Lockable {
+		n += 2
+	}
+	return n
+}
+
+func (m *GlobalStatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *GlobalStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.GlobalStatus != 0 {
+		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	}
+	return n
+}
+
+func (m *GlobalCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *GlobalCommitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.GlobalStatus != 0 {
+		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	}
+	return n
+}
+
+func (m *GlobalRollbackRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *GlobalRollbackResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.GlobalStatus != 0 {
+		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	}
+	return n
+}
+
+func (m *GlobalReportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.GlobalStatus != 0 {
+		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	}
+	return n
+}
+
+func (m *GlobalReportResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.GlobalStatus != 0 {
+		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	}
+	return n
+}
+
+func (m *BranchCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.LockKey)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchType != 0 {
+		n += 1 + sovSeata(uint64(m.BranchType))
+	}
+	l = len(m.ApplicationData)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *BranchCommitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	if m.BranchStatus != 0 {
+		n += 1 + sovSeata(uint64(m.BranchStatus))
+	}
+	return n
+}
+
+func (m *BranchRollbackRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.LockKey)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchType != 0 {
+		n += 1 + sovSeata(uint64(m.BranchType))
+	}
+	l = len(m.ApplicationData)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *BranchRollbackResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	if m.BranchStatus != 0 {
+		n += 1 + sovSeata(uint64(m.BranchStatus))
+	}
+	return n
+}
+
+func (m *BranchMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovSeata(uint64(m.ID))
+	}
+	if m.BranchMessageType != 0 {
+		n += 1 + sovSeata(uint64(m.BranchMessageType))
+	}
+	if m.Message != nil {
+		l = m.Message.Size()
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *RowLockHolder) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RowLock != nil {
+		l = m.RowLock.Size()
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.Status != 0 {
+		n += 1 + sovSeata(uint64(m.Status))
+	}
+	if m.BeginTime != 0 {
+		n += 1 + sovSeata(uint64(m.BeginTime))
+	}
+	if m.Age != 0 {
+		n += 1 + sovSeata(uint64(m.Age))
+	}
+	return n
+}
+
+func (m *LockListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.TableName)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovSeata(uint64(m.Offset))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovSeata(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *LockListResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if len(m.Locks) > 0 {
+		for _, e := range m.Locks {
+			l = e.Size()
+			n += 1 + l + sovSeata(uint64(l))
+		}
+	}
+	if m.Total != 0 {
+		n += 1 + sovSeata(uint64(m.Total))
+	}
+	return n
+}
+
+func (m *LockHolderQueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.LockKey)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *LockHolderQueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if len(m.Holders) > 0 {
+		for _, e := range m.Holders {
+			l = e.Size()
+			n += 1 + l + sovSeata(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LockReleaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.BranchID != 0 {
+		n += 1 + sovSeata(uint64(m.BranchID))
+	}
+	l = len(m.ResourceID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.LockKey)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *LockReleaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.Released != 0 {
+		n += 1 + sovSeata(uint64(m.Released))
+	}
+	return n
+}
+
+func (m *SessionListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Statuses) > 0 {
+		l = 0
+		for _, e := range m.Statuses {
+			l += sovSeata(uint64(e))
+		}
+		n += 1 + sovSeata(uint64(l)) + l
+	}
+	l = len(m.Addressing)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.TransactionName)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.MinAge != 0 {
+		n += 1 + sovSeata(uint64(m.MinAge))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovSeata(uint64(m.Offset))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovSeata(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *SessionListResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if len(m.Sessions) > 0 {
+		for _, e := range m.Sessions {
+			l = e.Size()
+			n += 1 + l + sovSeata(uint64(l))
+		}
+	}
+	if m.Total != 0 {
+		n += 1 + sovSeata(uint64(m.Total))
+	}
+	return n
+}
+
+func (m *SessionGetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *SessionGetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.GlobalSession != nil {
+		l = m.GlobalSession.Size()
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if len(m.BranchSessions) > 0 {
+		for _, e := range m.BranchSessions {
+			l = e.Size()
+			n += 1 + l + sovSeata(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SessionRetryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *SessionRetryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.GlobalStatus != 0 {
+		n += 1 + sovSeata(uint64(m.GlobalStatus))
+	}
+	return n
+}
+
+func (m *SessionRemoveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.XID)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.ReleaseLocks {
+		n += 2
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func (m *SessionRemoveResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResultCode != 0 {
+		n += 1 + sovSeata(uint64(m.ResultCode))
+	}
+	if m.ExceptionCode != 0 {
+		n += 1 + sovSeata(uint64(m.ExceptionCode))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovSeata(uint64(l))
+	}
+	return n
+}
+
+func sovSeata(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSeata(x uint64) (n int) {
+	return sovSeata(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *GlobalSession) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalSession{`,
+		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`TransactionID:` + fmt.Sprintf("%v", this.TransactionID) + `,`,
+		`TransactionName:` + fmt.Sprintf("%v", this.TransactionName) + `,`,
+		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
+		`BeginTime:` + fmt.Sprintf("%v", this.BeginTime) + `,`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Active:` + fmt.Sprintf("%v", this.Active) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchSession) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchSession{`,
+		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`TransactionID:` + fmt.Sprintf("%v", this.TransactionID) + `,`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
+		`AsyncCommit:` + fmt.Sprintf("%v", this.AsyncCommit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RowLock) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RowLock{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`TransactionID:` + fmt.Sprintf("%v", this.TransactionID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`TableName:` + fmt.Sprintf("%v", this.TableName) + `,`,
+		`PK:` + fmt.Sprintf("%v", this.PK) + `,`,
+		`RowKey:` + fmt.Sprintf("%v", this.RowKey) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalBeginRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalBeginRequest{`,
+		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
+		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
+		`TransactionName:` + fmt.Sprintf("%v", this.TransactionName) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalBeginResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalBeginResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchRegisterRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchRegisterRequest{`,
+		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
+		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
+		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
+		`AsyncCommit:` + fmt.Sprintf("%v", this.AsyncCommit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchRegisterResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchRegisterResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchReportRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchReportRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
+		`BranchStatus:` + fmt.Sprintf("%v", this.BranchStatus) + `,`,
+		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchReportResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchReportResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalLockQueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalLockQueryRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
+		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalLockQueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalLockQueryResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`Lockable:` + fmt.Sprintf("%v", this.Lockable) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalStatusRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalStatusRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalStatusResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalStatusResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalCommitRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalCommitRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalCommitResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalCommitResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalRollbackRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalRollbackRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalRollbackResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalRollbackResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalReportRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalReportRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GlobalReportResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GlobalReportResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchCommitRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchCommitRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
+		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
+		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchCommitResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchCommitResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`BranchStatus:` + fmt.Sprintf("%v", this.BranchStatus) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchRollbackRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchRollbackRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
+		`BranchType:` + fmt.Sprintf("%v", this.BranchType) + `,`,
+		`ApplicationData:` + fmt.Sprintf("%v", this.ApplicationData) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchRollbackResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchRollbackResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`BranchStatus:` + fmt.Sprintf("%v", this.BranchStatus) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BranchMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BranchMessage{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`BranchMessageType:` + fmt.Sprintf("%v", this.BranchMessageType) + `,`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Any", "types.Any", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RowLockHolder) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RowLockHolder{`,
+		`RowLock:` + strings.Replace(this.RowLock.String(), "RowLock", "RowLock", 1) + `,`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`BeginTime:` + fmt.Sprintf("%v", this.BeginTime) + `,`,
+		`Age:` + fmt.Sprintf("%v", this.Age) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockListRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LockListRequest{`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`TableName:` + fmt.Sprintf("%v", this.TableName) + `,`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockListResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForLocks := "[]*RowLockHolder{"
+	for _, f := range this.Locks {
+		repeatedStringForLocks += strings.Replace(f.String(), "RowLockHolder", "RowLockHolder", 1) + ","
+	}
+	repeatedStringForLocks += "}"
+	s := strings.Join([]string{`&LockListResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`Locks:` + repeatedStringForLocks + `,`,
+		`Total:` + fmt.Sprintf("%v", this.Total) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockHolderQueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LockHolderQueryRequest{`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockHolderQueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHolders := "[]*RowLockHolder{"
+	for _, f := range this.Holders {
+		repeatedStringForHolders += strings.Replace(f.String(), "RowLockHolder", "RowLockHolder", 1) + ","
+	}
+	repeatedStringForHolders += "}"
+	s := strings.Join([]string{`&LockHolderQueryResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`Holders:` + repeatedStringForHolders + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockReleaseRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LockReleaseRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`BranchID:` + fmt.Sprintf("%v", this.BranchID) + `,`,
+		`ResourceID:` + fmt.Sprintf("%v", this.ResourceID) + `,`,
+		`LockKey:` + fmt.Sprintf("%v", this.LockKey) + `,`,
+		`Force:` + fmt.Sprintf("%v", this.Force) + `,`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LockReleaseResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LockReleaseResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`Released:` + fmt.Sprintf("%v", this.Released) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionListRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionListRequest{`,
+		`Statuses:` + fmt.Sprintf("%v", this.Statuses) + `,`,
+		`Addressing:` + fmt.Sprintf("%v", this.Addressing) + `,`,
+		`TransactionName:` + fmt.Sprintf("%v", this.TransactionName) + `,`,
+		`MinAge:` + fmt.Sprintf("%v", this.MinAge) + `,`,
+		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionListResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSessions := "[]*GlobalSession{"
+	for _, f := range this.Sessions {
+		repeatedStringForSessions += strings.Replace(f.String(), "GlobalSession", "GlobalSession", 1) + ","
+	}
+	repeatedStringForSessions += "}"
+	s := strings.Join([]string{`&SessionListResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`Sessions:` + repeatedStringForSessions + `,`,
+		`Total:` + fmt.Sprintf("%v", this.Total) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionGetRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionGetRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionGetResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForBranchSessions := "[]*BranchSession{"
+	for _, f := range this.BranchSessions {
+		repeatedStringForBranchSessions += strings.Replace(f.String(), "BranchSession", "BranchSession", 1) + ","
+	}
+	repeatedStringForBranchSessions += "}"
+	s := strings.Join([]string{`&SessionGetResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`GlobalSession:` + strings.Replace(this.GlobalSession.String(), "GlobalSession", "GlobalSession", 1) + `,`,
+		`BranchSessions:` + repeatedStringForBranchSessions + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionRetryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionRetryRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionRetryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionRetryResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`GlobalStatus:` + fmt.Sprintf("%v", this.GlobalStatus) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionRemoveRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionRemoveRequest{`,
+		`XID:` + fmt.Sprintf("%v", this.XID) + `,`,
+		`Force:` + fmt.Sprintf("%v", this.Force) + `,`,
+		`ReleaseLocks:` + fmt.Sprintf("%v", this.ReleaseLocks) + `,`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SessionRemoveResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SessionRemoveResponse{`,
+		`ResultCode:` + fmt.Sprintf("%v", this.ResultCode) + `,`,
+		`ExceptionCode:` + fmt.Sprintf("%v", this.ExceptionCode) + `,`,
+		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringSeata(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *GlobalSession) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalSession: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalSession: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addressing = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+			}
+			m.TransactionID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TransactionID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TransactionName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeout |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginTime", wireType)
+			}
+			m.BeginTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BeginTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= GlobalSession_GlobalStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Active", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Active = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchSession) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchSession: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchSession: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addressing = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+			}
+			m.BranchID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+			}
+			m.TransactionID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TransactionID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LockKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= BranchSession_BranchType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= BranchSession_BranchStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplicationData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ApplicationData = append(m.ApplicationData[:0], dAtA[iNdEx:postIndex]...)
+			if m.ApplicationData == nil {
+				m.ApplicationData = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AsyncCommit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AsyncCommit = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RowLock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RowLock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RowLock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+			}
+			m.TransactionID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TransactionID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+			}
+			m.BranchID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TableName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PK", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PK = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RowKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalBeginRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalBeginRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalBeginRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addressing = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeout |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TransactionName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalBeginResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalBeginResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalBeginResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+			}
+			m.ResultCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultCode |= ResultCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+			}
+			m.ExceptionCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchRegisterRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchRegisterRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchRegisterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addressing = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LockKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchType", wireType)
+			}
+			m.BranchType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchType |= BranchSession_BranchType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplicationData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ApplicationData = append(m.ApplicationData[:0], dAtA[iNdEx:postIndex]...)
+			if m.ApplicationData == nil {
+				m.ApplicationData = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AsyncCommit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AsyncCommit = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchRegisterResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchRegisterResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchRegisterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+			}
+			m.ResultCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultCode |= ResultCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+			}
+			m.ExceptionCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+			}
+			m.BranchID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+			}
+			m.BranchID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchType", wireType)
+			}
+			m.BranchType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchType |= BranchSession_BranchType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchStatus", wireType)
+			}
+			m.BranchStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchStatus |= BranchSession_BranchStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplicationData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ApplicationData = append(m.ApplicationData[:0], dAtA[iNdEx:postIndex]...)
+			if m.ApplicationData == nil {
+				m.ApplicationData = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchReportResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchReportResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchReportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+			}
+			m.ResultCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultCode |= ResultCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+			}
+			m.ExceptionCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalLockQueryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalLockQueryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalLockQueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LockKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchType", wireType)
+			}
+			m.BranchType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchType |= BranchSession_BranchType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalLockQueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalLockQueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalLockQueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+			}
+			m.ResultCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultCode |= ResultCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+			}
+			m.ExceptionCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lockable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Lockable = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&BranchMessage{`,
-		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
-		`BranchMessageType:` + fmt.Sprintf("%v", this.BranchMessageType) + `,`,
-		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Any", "types.Any", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringSeata(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *GlobalSession) Unmarshal(dAtA []byte) error {
+func (m *GlobalStatusRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5627,15 +10908,15 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalSession: wiretype end group for non-group")
+			return fmt.Errorf("proto: GlobalStatusRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalSession: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GlobalStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5663,11 +10944,99 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addressing = string(dAtA[iNdEx:postIndex])
+			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+			}
+			m.ResultCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultCode |= ResultCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+			}
+			m.ExceptionCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5695,13 +11064,13 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XID = string(dAtA[iNdEx:postIndex])
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
 			}
-			m.TransactionID = 0
+			m.GlobalStatus = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -5711,14 +11080,64 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TransactionID |= int64(b&0x7F) << shift
+				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalCommitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalCommitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransactionName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5746,13 +11165,63 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TransactionName = string(dAtA[iNdEx:postIndex])
+			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalCommitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalCommitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalCommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
 			}
-			m.Timeout = 0
+			m.ResultCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -5762,16 +11231,16 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timeout |= int32(b&0x7F) << shift
+				m.ResultCode |= ResultCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
 			}
-			m.BeginTime = 0
+			m.ExceptionCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -5781,16 +11250,16 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BeginTime |= int64(b&0x7F) << shift
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			m.Status = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -5800,16 +11269,29 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= GlobalSession_GlobalStatus(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Active", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
 			}
-			var v int
+			m.GlobalStatus = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -5819,12 +11301,11 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Active = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -5846,7 +11327,7 @@ func (m *GlobalSession) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchSession) Unmarshal(dAtA []byte) error {
+func (m *GlobalRollbackRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5869,15 +11350,15 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchSession: wiretype end group for non-group")
+			return fmt.Errorf("proto: GlobalRollbackRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchSession: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GlobalRollbackRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5905,45 +11386,63 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addressing = string(dAtA[iNdEx:postIndex])
+			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalRollbackResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalRollbackResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalRollbackResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
 			}
-			m.BranchID = 0
+			m.ResultCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -5953,16 +11452,16 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BranchID |= int64(b&0x7F) << shift
+				m.ResultCode |= ResultCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
 			}
-			m.TransactionID = 0
+			m.ExceptionCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -5972,14 +11471,14 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TransactionID |= int64(b&0x7F) << shift
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6007,13 +11506,13 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
 			}
-			var stringLen uint64
+			m.GlobalStatus = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6023,67 +11522,66 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthSeata
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LockKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= BranchSession_BranchType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GlobalReportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
 			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.Status = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Status |= BranchSession_BranchStatus(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 9:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GlobalReportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GlobalReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplicationData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6093,31 +11591,29 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ApplicationData = append(m.ApplicationData[:0], dAtA[iNdEx:postIndex]...)
-			if m.ApplicationData == nil {
-				m.ApplicationData = []byte{}
-			}
+			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 10:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AsyncCommit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
 			}
-			var v int
+			m.GlobalStatus = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6127,12 +11623,11 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.AsyncCommit = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -6154,7 +11649,7 @@ func (m *BranchSession) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RowLock) Unmarshal(dAtA []byte) error {
+func (m *GlobalReportResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6177,17 +11672,17 @@ func (m *RowLock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RowLock: wiretype end group for non-group")
+			return fmt.Errorf("proto: GlobalReportResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RowLock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GlobalReportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
 			}
-			var stringLen uint64
+			m.ResultCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6197,29 +11692,16 @@ func (m *RowLock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.ResultCode |= ResultCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransactionID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
 			}
-			m.TransactionID = 0
+			m.ExceptionCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6229,33 +11711,14 @@ func (m *RowLock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TransactionID |= int64(b&0x7F) << shift
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
-			}
-			m.BranchID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BranchID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6283,13 +11746,13 @@ func (m *RowLock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableName", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
 			}
-			var stringLen uint64
+			m.GlobalStatus = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6299,27 +11762,64 @@ func (m *RowLock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthSeata
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TableName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 6:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BranchCommitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BranchCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PK", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6347,11 +11847,30 @@ func (m *RowLock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PK = string(dAtA[iNdEx:postIndex])
+			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+			}
+			m.BranchID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6379,61 +11898,11 @@ func (m *RowLock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RowKey = string(dAtA[iNdEx:postIndex])
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSeata(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GlobalBeginRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSeata
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalBeginRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalBeginRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6461,13 +11930,13 @@ func (m *GlobalBeginRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addressing = string(dAtA[iNdEx:postIndex])
+			m.LockKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchType", wireType)
 			}
-			m.Timeout = 0
+			m.BranchType = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6477,16 +11946,16 @@ func (m *GlobalBeginRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timeout |= int32(b&0x7F) << shift
+				m.BranchType |= BranchSession_BranchType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransactionName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplicationData", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6496,23 +11965,25 @@ func (m *GlobalBeginRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TransactionName = string(dAtA[iNdEx:postIndex])
+			m.ApplicationData = append(m.ApplicationData[:0], dAtA[iNdEx:postIndex]...)
+			if m.ApplicationData == nil {
+				m.ApplicationData = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6535,7 +12006,7 @@ func (m *GlobalBeginRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalBeginResponse) Unmarshal(dAtA []byte) error {
+func (m *BranchCommitResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6558,10 +12029,10 @@ func (m *GlobalBeginResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalBeginResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BranchCommitResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalBeginResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BranchCommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6666,6 +12137,44 @@ func (m *GlobalBeginResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+			}
+			m.BranchID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchStatus", wireType)
+			}
+			m.BranchStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BranchStatus |= BranchSession_BranchStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -6687,7 +12196,7 @@ func (m *GlobalBeginResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchRegisterRequest) Unmarshal(dAtA []byte) error {
+func (m *BranchRollbackRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6710,15 +12219,15 @@ func (m *BranchRegisterRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchRegisterRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: BranchRollbackRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchRegisterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BranchRollbackRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6746,13 +12255,13 @@ func (m *BranchRegisterRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addressing = string(dAtA[iNdEx:postIndex])
+			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
 			}
-			var stringLen uint64
+			m.BranchID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -6762,24 +12271,11 @@ func (m *BranchRegisterRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.BranchID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
@@ -6897,26 +12393,6 @@ func (m *BranchRegisterRequest) Unmarshal(dAtA []byte) error {
 				m.ApplicationData = []byte{}
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AsyncCommit", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.AsyncCommit = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -6938,7 +12414,7 @@ func (m *BranchRegisterRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchRegisterResponse) Unmarshal(dAtA []byte) error {
+func (m *BranchRollbackResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6961,10 +12437,10 @@ func (m *BranchRegisterResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchRegisterResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BranchRollbackResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchRegisterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BranchRollbackResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7038,75 +12514,6 @@ func (m *BranchRegisterResponse) Unmarshal(dAtA []byte) error {
 			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
-			}
-			m.BranchID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BranchID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSeata(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *BranchReportRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSeata
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BranchReportRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
@@ -7138,7 +12545,7 @@ func (m *BranchReportRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
 			}
@@ -7157,58 +12564,7 @@ func (m *BranchReportRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ResourceID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchType", wireType)
-			}
-			m.BranchType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BranchType |= BranchSession_BranchType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
+		case 6:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field BranchStatus", wireType)
 			}
@@ -7227,40 +12583,6 @@ func (m *BranchReportRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplicationData", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ApplicationData = append(m.ApplicationData[:0], dAtA[iNdEx:postIndex]...)
-			if m.ApplicationData == nil {
-				m.ApplicationData = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -7282,7 +12604,7 @@ func (m *BranchReportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchReportResponse) Unmarshal(dAtA []byte) error {
+func (m *BranchMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7305,17 +12627,17 @@ func (m *BranchReportResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchReportResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BranchMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchReportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BranchMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			m.ResultCode = 0
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7325,16 +12647,16 @@ func (m *BranchReportResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ResultCode |= ResultCode(b&0x7F) << shift
+				m.ID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchMessageType", wireType)
 			}
-			m.ExceptionCode = 0
+			m.BranchMessageType = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7344,7 +12666,7 @@ func (m *BranchReportResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				m.BranchMessageType |= BranchMessageType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7353,7 +12675,7 @@ func (m *BranchReportResponse) Unmarshal(dAtA []byte) error {
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7363,23 +12685,27 @@ func (m *BranchReportResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			if m.Message == nil {
+				m.Message = &types.Any{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7402,7 +12728,7 @@ func (m *BranchReportResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalLockQueryRequest) Unmarshal(dAtA []byte) error {
+func (m *RowLockHolder) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7425,17 +12751,17 @@ func (m *GlobalLockQueryRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalLockQueryRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RowLockHolder: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalLockQueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RowLockHolder: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RowLock", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7445,29 +12771,33 @@ func (m *GlobalLockQueryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XID = string(dAtA[iNdEx:postIndex])
+			if m.RowLock == nil {
+				m.RowLock = &RowLock{}
+			}
+			if err := m.RowLock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			var stringLen uint64
+			m.Status = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7477,29 +12807,16 @@ func (m *GlobalLockQueryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Status |= GlobalSession_GlobalStatus(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ResourceID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginTime", wireType)
 			}
-			var stringLen uint64
+			m.BeginTime = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7509,29 +12826,16 @@ func (m *GlobalLockQueryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.BeginTime |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.LockKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Age", wireType)
 			}
-			m.BranchType = 0
+			m.Age = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7541,7 +12845,7 @@ func (m *GlobalLockQueryRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BranchType |= BranchSession_BranchType(b&0x7F) << shift
+				m.Age |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7567,7 +12871,7 @@ func (m *GlobalLockQueryRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalLockQueryResponse) Unmarshal(dAtA []byte) error {
+func (m *LockListRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7590,17 +12894,17 @@ func (m *GlobalLockQueryResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalLockQueryResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LockListRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalLockQueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LockListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
 			}
-			m.ResultCode = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7610,16 +12914,29 @@ func (m *GlobalLockQueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ResultCode |= ResultCode(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableName", wireType)
 			}
-			m.ExceptionCode = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7629,14 +12946,27 @@ func (m *GlobalLockQueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TableName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7664,13 +12994,13 @@ func (m *GlobalLockQueryResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lockable", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
 			}
-			var v int
+			m.BranchID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7680,67 +13010,16 @@ func (m *GlobalLockQueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.BranchID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Lockable = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSeata(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GlobalStatusRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSeata
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalStatusRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			var stringLen uint64
+			m.Offset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7750,24 +13029,30 @@ func (m *GlobalStatusRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Offset |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -7789,7 +13074,7 @@ func (m *GlobalStatusRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalStatusResponse) Unmarshal(dAtA []byte) error {
+func (m *LockListResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7812,10 +13097,10 @@ func (m *GlobalStatusResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalStatusResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LockListResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LockListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7856,11 +13141,43 @@ func (m *GlobalStatusResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 3:
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Locks", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7870,29 +13187,31 @@ func (m *GlobalStatusResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Locks = append(m.Locks, &RowLockHolder{})
+			if err := m.Locks[len(m.Locks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
 			}
-			m.GlobalStatus = 0
+			m.Total = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -7902,7 +13221,7 @@ func (m *GlobalStatusResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
+				m.Total |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7928,7 +13247,7 @@ func (m *GlobalStatusResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *LockHolderQueryRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7951,15 +13270,15 @@ func (m *GlobalCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LockHolderQueryRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LockHolderQueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7987,7 +13306,39 @@ func (m *GlobalCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XID = string(dAtA[iNdEx:postIndex])
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LockKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8010,7 +13361,7 @@ func (m *GlobalCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalCommitResponse) Unmarshal(dAtA []byte) error {
+func (m *LockHolderQueryResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8033,10 +13384,10 @@ func (m *GlobalCommitResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalCommitResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LockHolderQueryResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalCommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LockHolderQueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8110,10 +13461,10 @@ func (m *GlobalCommitResponse) Unmarshal(dAtA []byte) error {
 			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Holders", wireType)
 			}
-			m.GlobalStatus = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8123,11 +13474,26 @@ func (m *GlobalCommitResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Holders = append(m.Holders, &RowLockHolder{})
+			if err := m.Holders[len(m.Holders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -8149,7 +13515,7 @@ func (m *GlobalCommitResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalRollbackRequest) Unmarshal(dAtA []byte) error {
+func (m *LockReleaseRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8172,10 +13538,10 @@ func (m *GlobalRollbackRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalRollbackRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LockReleaseRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalRollbackRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LockReleaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8210,61 +13576,11 @@ func (m *GlobalRollbackRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSeata(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GlobalRollbackResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSeata
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalRollbackResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalRollbackResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
 			}
-			m.ResultCode = 0
+			m.BranchID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8274,16 +13590,16 @@ func (m *GlobalRollbackResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ResultCode |= ResultCode(b&0x7F) << shift
+				m.BranchID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
 			}
-			m.ExceptionCode = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8293,14 +13609,27 @@ func (m *GlobalRollbackResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8328,13 +13657,13 @@ func (m *GlobalRollbackResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.LockKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
 			}
-			m.GlobalStatus = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8344,11 +13673,44 @@ func (m *GlobalRollbackResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -8370,7 +13732,7 @@ func (m *GlobalRollbackResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalReportRequest) Unmarshal(dAtA []byte) error {
+func (m *LockReleaseResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8393,15 +13755,53 @@ func (m *GlobalReportRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalReportRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LockReleaseResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalReportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LockReleaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
+			}
+			m.ResultCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultCode |= ResultCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+			}
+			m.ExceptionCode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8429,13 +13829,13 @@ func (m *GlobalReportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XID = string(dAtA[iNdEx:postIndex])
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Released", wireType)
 			}
-			m.GlobalStatus = 0
+			m.Released = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8445,7 +13845,7 @@ func (m *GlobalReportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
+				m.Released |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -8471,7 +13871,7 @@ func (m *GlobalReportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GlobalReportResponse) Unmarshal(dAtA []byte) error {
+func (m *SessionListRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8494,36 +13894,86 @@ func (m *GlobalReportResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GlobalReportResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionListRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GlobalReportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
-			}
-			m.ResultCode = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
+			if wireType == 0 {
+				var v GlobalSession_GlobalStatus
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSeata
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= GlobalSession_GlobalStatus(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Statuses = append(m.Statuses, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSeata
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthSeata
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthSeata
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ResultCode |= ResultCode(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+				var elementCount int
+				if elementCount != 0 && len(m.Statuses) == 0 {
+					m.Statuses = make([]GlobalSession_GlobalStatus, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v GlobalSession_GlobalStatus
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSeata
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= GlobalSession_GlobalStatus(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Statuses = append(m.Statuses, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Statuses", wireType)
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addressing", wireType)
 			}
-			m.ExceptionCode = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8533,14 +13983,27 @@ func (m *GlobalReportResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addressing = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TransactionName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8568,13 +14031,13 @@ func (m *GlobalReportResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.TransactionName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinAge", wireType)
 			}
-			m.GlobalStatus = 0
+			m.MinAge = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8584,7 +14047,45 @@ func (m *GlobalReportResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
+				m.MinAge |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -8610,7 +14111,7 @@ func (m *GlobalReportResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *SessionListResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8633,17 +14134,17 @@ func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionListResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
 			}
-			var stringLen uint64
+			m.ResultCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8653,29 +14154,16 @@ func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.ResultCode |= ResultCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
 			}
-			m.BranchID = 0
+			m.ExceptionCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8685,14 +14173,14 @@ func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BranchID |= int64(b&0x7F) << shift
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8720,13 +14208,13 @@ func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ResourceID = string(dAtA[iNdEx:postIndex])
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sessions", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8736,29 +14224,31 @@ func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LockKey = string(dAtA[iNdEx:postIndex])
+			m.Sessions = append(m.Sessions, &GlobalSession{})
+			if err := m.Sessions[len(m.Sessions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
 			}
-			m.BranchType = 0
+			m.Total = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8768,16 +14258,66 @@ func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BranchType |= BranchSession_BranchType(b&0x7F) << shift
+				m.Total |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SessionGetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SessionGetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SessionGetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplicationData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8787,25 +14327,23 @@ func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ApplicationData = append(m.ApplicationData[:0], dAtA[iNdEx:postIndex]...)
-			if m.ApplicationData == nil {
-				m.ApplicationData = []byte{}
-			}
+			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8828,7 +14366,7 @@ func (m *BranchCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchCommitResponse) Unmarshal(dAtA []byte) error {
+func (m *SessionGetResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8851,10 +14389,10 @@ func (m *BranchCommitResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchCommitResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionGetResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchCommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8929,9 +14467,9 @@ func (m *BranchCommitResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GlobalSession", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8941,29 +14479,33 @@ func (m *BranchCommitResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XID = string(dAtA[iNdEx:postIndex])
+			if m.GlobalSession == nil {
+				m.GlobalSession = &GlobalSession{}
+			}
+			if err := m.GlobalSession.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BranchSessions", wireType)
 			}
-			m.BranchID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -8973,30 +14515,26 @@ func (m *BranchCommitResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BranchID |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchStatus", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthSeata
 			}
-			m.BranchStatus = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BranchStatus |= BranchSession_BranchStatus(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.BranchSessions = append(m.BranchSessions, &BranchSession{})
+			if err := m.BranchSessions[len(m.BranchSessions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -9018,7 +14556,7 @@ func (m *BranchCommitResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchRollbackRequest) Unmarshal(dAtA []byte) error {
+func (m *SessionRetryRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9041,10 +14579,10 @@ func (m *BranchRollbackRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchRollbackRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionRetryRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchRollbackRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionRetryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -9080,59 +14618,8 @@ func (m *BranchRollbackRequest) Unmarshal(dAtA []byte) error {
 			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
-			}
-			m.BranchID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BranchID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResourceID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ResourceID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LockKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9160,60 +14647,7 @@ func (m *BranchRollbackRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LockKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchType", wireType)
-			}
-			m.BranchType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BranchType |= BranchSession_BranchType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApplicationData", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSeata
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthSeata
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSeata
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ApplicationData = append(m.ApplicationData[:0], dAtA[iNdEx:postIndex]...)
-			if m.ApplicationData == nil {
-				m.ApplicationData = []byte{}
-			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9236,7 +14670,7 @@ func (m *BranchRollbackRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchRollbackResponse) Unmarshal(dAtA []byte) error {
+func (m *SessionRetryResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9259,10 +14693,10 @@ func (m *BranchRollbackResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchRollbackResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionRetryResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchRollbackResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionRetryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -9336,6 +14770,75 @@ func (m *BranchRollbackResponse) Unmarshal(dAtA []byte) error {
 			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GlobalStatus", wireType)
+			}
+			m.GlobalStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GlobalStatus |= GlobalSession_GlobalStatus(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSeata(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SessionRemoveRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSeata
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SessionRemoveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SessionRemoveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field XID", wireType)
 			}
@@ -9367,11 +14870,11 @@ func (m *BranchRollbackResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.XID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
 			}
-			m.BranchID = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -9381,16 +14884,17 @@ func (m *BranchRollbackResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BranchID |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+			m.Force = bool(v != 0)
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchStatus", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReleaseLocks", wireType)
 			}
-			m.BranchStatus = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -9400,11 +14904,44 @@ func (m *BranchRollbackResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BranchStatus |= BranchSession_BranchStatus(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReleaseLocks = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSeata
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSeata
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSeata
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSeata(dAtA[iNdEx:])
@@ -9426,7 +14963,7 @@ func (m *BranchRollbackResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BranchMessage) Unmarshal(dAtA []byte) error {
+func (m *SessionRemoveResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9449,17 +14986,17 @@ func (m *BranchMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BranchMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: SessionRemoveResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BranchMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SessionRemoveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCode", wireType)
 			}
-			m.ID = 0
+			m.ResultCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -9469,16 +15006,16 @@ func (m *BranchMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= int64(b&0x7F) << shift
+				m.ResultCode |= ResultCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BranchMessageType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExceptionCode", wireType)
 			}
-			m.BranchMessageType = 0
+			m.ExceptionCode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -9488,7 +15025,7 @@ func (m *BranchMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BranchMessageType |= BranchMessageType(b&0x7F) << shift
+				m.ExceptionCode |= ExceptionCode(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -9497,7 +15034,7 @@ func (m *BranchMessage) Unmarshal(dAtA []byte) error {
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSeata
@@ -9507,27 +15044,23 @@ func (m *BranchMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSeata
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthSeata
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Message == nil {
-				m.Message = &types.Any{}
-			}
-			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
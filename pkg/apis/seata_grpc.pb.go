@@ -466,3 +466,339 @@ var ResourceManagerService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "seata.proto",
 }
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	ListLocks(ctx context.Context, in *LockListRequest, opts ...grpc.CallOption) (*LockListResponse, error)
+	QueryLockHolders(ctx context.Context, in *LockHolderQueryRequest, opts ...grpc.CallOption) (*LockHolderQueryResponse, error)
+	ReleaseLocks(ctx context.Context, in *LockReleaseRequest, opts ...grpc.CallOption) (*LockReleaseResponse, error)
+	ListSessions(ctx context.Context, in *SessionListRequest, opts ...grpc.CallOption) (*SessionListResponse, error)
+	GetSession(ctx context.Context, in *SessionGetRequest, opts ...grpc.CallOption) (*SessionGetResponse, error)
+	RetryCommit(ctx context.Context, in *SessionRetryRequest, opts ...grpc.CallOption) (*SessionRetryResponse, error)
+	RetryRollback(ctx context.Context, in *SessionRetryRequest, opts ...grpc.CallOption) (*SessionRetryResponse, error)
+	RemoveSession(ctx context.Context, in *SessionRemoveRequest, opts ...grpc.CallOption) (*SessionRemoveResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ListLocks(ctx context.Context, in *LockListRequest, opts ...grpc.CallOption) (*LockListResponse, error) {
+	out := new(LockListResponse)
+	err := c.cc.Invoke(ctx, "/apis.AdminService/ListLocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) QueryLockHolders(ctx context.Context, in *LockHolderQueryRequest, opts ...grpc.CallOption) (*LockHolderQueryResponse, error) {
+	out := new(LockHolderQueryResponse)
+	err := c.cc.Invoke(ctx, "/apis.AdminService/QueryLockHolders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReleaseLocks(ctx context.Context, in *LockReleaseRequest, opts ...grpc.CallOption) (*LockReleaseResponse, error) {
+	out := new(LockReleaseResponse)
+	err := c.cc.Invoke(ctx, "/apis.AdminService/ReleaseLocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListSessions(ctx context.Context, in *SessionListRequest, opts ...grpc.CallOption) (*SessionListResponse, error) {
+	out := new(SessionListResponse)
+	err := c.cc.Invoke(ctx, "/apis.AdminService/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetSession(ctx context.Context, in *SessionGetRequest, opts ...grpc.CallOption) (*SessionGetResponse, error) {
+	out := new(SessionGetResponse)
+	err := c.cc.Invoke(ctx, "/apis.AdminService/GetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RetryCommit(ctx context.Context, in *SessionRetryRequest, opts ...grpc.CallOption) (*SessionRetryResponse, error) {
+	out := new(SessionRetryResponse)
+	err := c.cc.Invoke(ctx, "/apis.AdminService/RetryCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RetryRollback(ctx context.Context, in *SessionRetryRequest, opts ...grpc.CallOption) (*SessionRetryResponse, error) {
+	out := new(SessionRetryResponse)
+	err := c.cc.Invoke(ctx, "/apis.AdminService/RetryRollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RemoveSession(ctx context.Context, in *SessionRemoveRequest, opts ...grpc.CallOption) (*SessionRemoveResponse, error) {
+	out := new(SessionRemoveResponse)
+	err := c.cc.Invoke(ctx, "/apis.AdminService/RemoveSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations should embed UnimplementedAdminServiceServer
+// for forward compatibility
+type AdminServiceServer interface {
+	ListLocks(context.Context, *LockListRequest) (*LockListResponse, error)
+	QueryLockHolders(context.Context, *LockHolderQueryRequest) (*LockHolderQueryResponse, error)
+	ReleaseLocks(context.Context, *LockReleaseRequest) (*LockReleaseResponse, error)
+	ListSessions(context.Context, *SessionListRequest) (*SessionListResponse, error)
+	GetSession(context.Context, *SessionGetRequest) (*SessionGetResponse, error)
+	RetryCommit(context.Context, *SessionRetryRequest) (*SessionRetryResponse, error)
+	RetryRollback(context.Context, *SessionRetryRequest) (*SessionRetryResponse, error)
+	RemoveSession(context.Context, *SessionRemoveRequest) (*SessionRemoveResponse, error)
+}
+
+// UnimplementedAdminServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (UnimplementedAdminServiceServer) ListLocks(context.Context, *LockListRequest) (*LockListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLocks not implemented")
+}
+func (UnimplementedAdminServiceServer) QueryLockHolders(context.Context, *LockHolderQueryRequest) (*LockHolderQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryLockHolders not implemented")
+}
+func (UnimplementedAdminServiceServer) ReleaseLocks(context.Context, *LockReleaseRequest) (*LockReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLocks not implemented")
+}
+func (UnimplementedAdminServiceServer) ListSessions(context.Context, *SessionListRequest) (*SessionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAdminServiceServer) GetSession(context.Context, *SessionGetRequest) (*SessionGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedAdminServiceServer) RetryCommit(context.Context, *SessionRetryRequest) (*SessionRetryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryCommit not implemented")
+}
+func (UnimplementedAdminServiceServer) RetryRollback(context.Context, *SessionRetryRequest) (*SessionRetryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryRollback not implemented")
+}
+func (UnimplementedAdminServiceServer) RemoveSession(context.Context, *SessionRemoveRequest) (*SessionRemoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSession not implemented")
+}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_ListLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apis.AdminService/ListLocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListLocks(ctx, req.(*LockListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_QueryLockHolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockHolderQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).QueryLockHolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apis.AdminService/QueryLockHolders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).QueryLockHolders(ctx, req.(*LockHolderQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReleaseLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReleaseLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apis.AdminService/ReleaseLocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReleaseLocks(ctx, req.(*LockReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apis.AdminService/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListSessions(ctx, req.(*SessionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apis.AdminService/GetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetSession(ctx, req.(*SessionGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RetryCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RetryCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apis.AdminService/RetryCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RetryCommit(ctx, req.(*SessionRetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RetryRollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RetryRollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apis.AdminService/RetryRollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RetryRollback(ctx, req.(*SessionRetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RemoveSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apis.AdminService/RemoveSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveSession(ctx, req.(*SessionRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apis.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListLocks",
+			Handler:    _AdminService_ListLocks_Handler,
+		},
+		{
+			MethodName: "QueryLockHolders",
+			Handler:    _AdminService_QueryLockHolders_Handler,
+		},
+		{
+			MethodName: "ReleaseLocks",
+			Handler:    _AdminService_ReleaseLocks_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _AdminService_ListSessions_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _AdminService_GetSession_Handler,
+		},
+		{
+			MethodName: "RetryCommit",
+			Handler:    _AdminService_RetryCommit_Handler,
+		},
+		{
+			MethodName: "RetryRollback",
+			Handler:    _AdminService_RetryRollback_Handler,
+		},
+		{
+			MethodName: "RemoveSession",
+			Handler:    _AdminService_RemoveSession_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "seata.proto",
+}
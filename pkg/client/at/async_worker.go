@@ -0,0 +1,108 @@
+package at
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/opentrx/seata-golang/v2/pkg/client/config"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/runtime"
+)
+
+const (
+	asyncWorkerQueueSize = 10000
+
+	defaultUndoLogPurgeBatchSize = 1000
+	defaultUndoLogPurgeInterval  = time.Second
+)
+
+// phaseTwoContext a committed branch whose undo log should be deleted
+type phaseTwoContext struct {
+	xid        string
+	branchID   int64
+	resourceID string
+}
+
+// asyncWorker deletes the undo logs of committed branches in batches, so phase two commit
+// returns without touching the database.
+type asyncWorker struct {
+	once     sync.Once
+	contexts chan *phaseTwoContext
+}
+
+func newAsyncWorker() *asyncWorker {
+	return &asyncWorker{contexts: make(chan *phaseTwoContext, asyncWorkerQueueSize)}
+}
+
+// offer queues the branch, it returns false if the queue is full.
+func (worker *asyncWorker) offer(ctx *phaseTwoContext) bool {
+	worker.once.Do(func() {
+		runtime.GoWithRecover(func() {
+			worker.run()
+		}, nil)
+	})
+	select {
+	case worker.contexts <- ctx:
+		return true
+	default:
+		return false
+	}
+}
+
+func (worker *asyncWorker) run() {
+	conf := config.GetATConfig()
+	batchSize, interval := conf.UndoLogPurgeBatchSize, conf.UndoLogPurgeInterval
+	if batchSize <= 0 {
+		batchSize = defaultUndoLogPurgeBatchSize
+	}
+	if interval <= 0 {
+		interval = defaultUndoLogPurgeInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]*phaseTwoContext, 0, batchSize)
+	for {
+		select {
+		case ctx := <-worker.contexts:
+			batch = append(batch, ctx)
+			if len(batch) < batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		worker.purge(batch)
+		batch = batch[:0]
+	}
+}
+
+func (worker *asyncWorker) purge(batch []*phaseTwoContext) {
+	contextsByResource := make(map[string][]*phaseTwoContext)
+	for _, ctx := range batch {
+		contextsByResource[ctx.resourceID] = append(contextsByResource[ctx.resourceID], ctx)
+	}
+
+	for resourceID, contexts := range contextsByResource {
+		resource := atResourceManager.getResource(resourceID)
+		if resource == nil {
+			log.Errorf("AT resource is not exist, resourceID: %s, undo logs of %d branches are not deleted",
+				resourceID, len(contexts))
+			continue
+		}
+
+		xids := make([]string, 0, len(contexts))
+		branchIDs := make([]int64, 0, len(contexts))
+		for _, ctx := range contexts {
+			xids = append(xids, ctx.xid)
+			branchIDs = append(branchIDs, ctx.branchID)
+		}
+		err := resource.undoLogManager.BatchDeleteUndoLogs(context.Background(), resource.db, xids, branchIDs)
+		if err != nil {
+			log.Errorf("failed to batch delete undo log, resourceID: %s, err: %v", resourceID, err)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package at
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/pkg/errors"
+
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/recognizer"
+)
+
+// Conn wraps a connection of the underlying driver
+type Conn struct {
+	target   driver.Conn
+	resource *DataSourceResource
+
+	// txCtx is not nil while a local transaction is open on the connection
+	txCtx *connectionContext
+}
+
+func (conn *Conn) Prepare(query string) (driver.Stmt, error) {
+	return conn.PrepareContext(context.Background(), query)
+}
+
+func (conn *Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := prepareContext(ctx, conn.target, query)
+	if err != nil {
+		return nil, err
+	}
+	return &Stmt{conn: conn, target: stmt, query: query}, nil
+}
+
+func (conn *Conn) Close() error {
+	return conn.target.Close()
+}
+
+func (conn *Conn) Begin() (driver.Tx, error) {
+	return conn.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (conn *Conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := beginTx(ctx, conn.target, opts)
+	if err != nil {
+		return nil, err
+	}
+	conn.txCtx = newConnectionContext(ctx)
+	return &Tx{conn: conn, target: tx}, nil
+}
+
+func (conn *Conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	r, err := conn.needProxy(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		return conn.executeDML(ctx, r, query, args, func() (driver.Result, error) {
+			return targetConn{conn.target}.ExecContext(ctx, query, args)
+		})
+	}
+	execer, ok := conn.target.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (conn *Conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	r, err := conn.needLockCheck(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		return newExecutor(conn, r, query, args).executeQuery(ctx, func() (driver.Rows, error) {
+			return targetConn{conn.target}.QueryContext(ctx, query, args)
+		})
+	}
+	queryer, ok := conn.target.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (conn *Conn) Ping(ctx context.Context) error {
+	pinger, ok := conn.target.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+	return pinger.Ping(ctx)
+}
+
+func (conn *Conn) ResetSession(ctx context.Context) error {
+	resetter, ok := conn.target.(driver.SessionResetter)
+	if !ok {
+		return nil
+	}
+	return resetter.ResetSession(ctx)
+}
+
+func (conn *Conn) CheckNamedValue(value *driver.NamedValue) error {
+	checker, ok := conn.target.(driver.NamedValueChecker)
+	if !ok {
+		return driver.ErrSkip
+	}
+	return checker.CheckNamedValue(value)
+}
+
+// needProxy return the parsed statement if it should generate undo log or lock keys, so that the
+// executor does not parse it again, nil if it runs on the target connection as it is.
+func (conn *Conn) needProxy(ctx context.Context, query string) (recognizer.Recognizer, error) {
+	if !conn.inGlobalTransaction(ctx) {
+		return nil, nil
+	}
+	r, err := conn.recognize(query)
+	if err != nil || r == nil || r.SQLType() == recognizer.SQLTypeSelectForUpdate {
+		return nil, err
+	}
+	return r, nil
+}
+
+// needLockCheck return the parsed statement if it is a SELECT ... FOR UPDATE which should wait for
+// the global locks of the rows, nil if it runs on the target connection as it is. A DML statement
+// is refused, its rows would not be rolled back by the global transaction when run by a query.
+func (conn *Conn) needLockCheck(ctx context.Context, query string) (recognizer.Recognizer, error) {
+	if !conn.inGlobalTransaction(ctx) {
+		return nil, nil
+	}
+	r, err := conn.recognize(query)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	if r.SQLType() != recognizer.SQLTypeSelectForUpdate {
+		return nil, errors.Errorf("%s by query is not supported in AT mode: %s", r.SQLType(), query)
+	}
+	return r, nil
+}
+
+// recognize parses a statement of a global transaction, nil is returned for the statements which
+// are not recognized. A DML statement not recognized is refused, it would change rows the global
+// transaction can not roll back.
+func (conn *Conn) recognize(query string) (recognizer.Recognizer, error) {
+	r, err := conn.resource.recognize(query)
+	if err == nil {
+		return r, nil
+	}
+	if errors.Is(err, recognizer.ErrUnsupportedSQL) && recognizer.IsDML(query, conn.resource.dialect.recognizer) {
+		return nil, errors.WithMessage(err, "the statement can not be rolled back in AT mode")
+	}
+	return nil, nil
+}
+
+func (conn *Conn) inGlobalTransaction(ctx context.Context) bool {
+	xid, globalLock := conn.globalTransactionContext(ctx)
+	return xid != "" || globalLock
+}
+
+// globalTransactionContext return the xid and the global lock flag of the local transaction,
+// or of the context in auto commit mode.
+func (conn *Conn) globalTransactionContext(ctx context.Context) (string, bool) {
+	if conn.txCtx != nil {
+		return conn.txCtx.xid, conn.txCtx.isGlobalLockRequire
+	}
+	return globalTransactionContext(ctx)
+}
+
+func (conn *Conn) executeDML(ctx context.Context, r recognizer.Recognizer, query string, args []driver.NamedValue,
+	exec func() (driver.Result, error)) (driver.Result, error) {
+	if conn.txCtx != nil {
+		return newExecutor(conn, r, query, args).execute(ctx, exec)
+	}
+
+	// auto commit: the statement runs in its own local transaction which will be
+	// registered as a branch on commit.
+	tx, err := conn.BeginTx(ctx, driver.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	result, err := newExecutor(conn, r, query, args).execute(ctx, exec)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return nil, rollbackErr
+		}
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (conn *Conn) resetContext() {
+	conn.txCtx = nil
+}
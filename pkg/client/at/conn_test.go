@@ -0,0 +1,403 @@
+package at
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/recognizer"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+	ctx2 "github.com/opentrx/seata-golang/v2/pkg/client/base/context"
+	"github.com/opentrx/seata-golang/v2/pkg/client/config"
+	"github.com/opentrx/seata-golang/v2/pkg/client/rm"
+)
+
+func TestConn_NeedProxy(t *testing.T) {
+	conn := &Conn{resource: &DataSourceResource{dialect: dialects[PostgresDBType]}}
+	ctx := context.Background()
+
+	r, err := conn.needProxy(ctx, "UPDATE product SET stock = $1 WHERE id = $2")
+	assert.Nil(t, err)
+	assert.Nil(t, r)
+
+	conn.txCtx = &connectionContext{xid: "localhost:8091:1"}
+	r, err = conn.needProxy(ctx, "UPDATE product SET stock = $1 WHERE id = $2")
+	assert.Nil(t, err)
+	assert.Equal(t, recognizer.SQLTypeUpdate, r.SQLType())
+	r, err = conn.needProxy(ctx, "SELECT id FROM product WHERE id = $1 FOR UPDATE")
+	assert.Nil(t, err)
+	assert.Nil(t, r)
+	r, err = conn.needProxy(ctx, "CREATE TABLE product (id INT)")
+	assert.Nil(t, err)
+	assert.Nil(t, r)
+
+	r, err = conn.needLockCheck(ctx, "SELECT id FROM product WHERE id = $1 FOR UPDATE")
+	assert.Nil(t, err)
+	assert.IsType(t, &recognizer.SelectForUpdateRecognizer{}, r)
+	r, err = conn.needLockCheck(ctx, "SELECT id FROM product WHERE id = $1")
+	assert.Nil(t, err)
+	assert.Nil(t, r)
+	_, err = conn.needLockCheck(ctx, "UPDATE product SET stock = $1 WHERE id = $2")
+	assert.Error(t, err)
+}
+
+func TestConn_NeedProxy_UnsupportedDML(t *testing.T) {
+	ctx := context.Background()
+	statements := []string{
+		"INSERT INTO product (id, name) SELECT id, name FROM draft",
+		"REPLACE INTO product (id, name) VALUES (?, ?)",
+		"UPDATE product p JOIN stock s ON p.id = s.product_id SET p.stock = s.amount",
+		"UPDATE product, stock SET product.stock = stock.amount WHERE product.id = stock.product_id",
+		"DELETE p FROM product p JOIN stock s ON p.id = s.product_id",
+		"WITH s AS (SELECT product_id FROM stock) UPDATE product SET stock = 0 WHERE id IN (SELECT product_id FROM s)",
+	}
+
+	// the statements run as they are out of a global transaction
+	conn := &Conn{resource: &DataSourceResource{dialect: dialects[MysqlDBType]}}
+	for _, query := range statements {
+		r, err := conn.needProxy(ctx, query)
+		assert.Nil(t, err, query)
+		assert.Nil(t, r, query)
+	}
+
+	for _, txCtx := range []*connectionContext{{xid: "localhost:8091:1"}, {isGlobalLockRequire: true}} {
+		conn.txCtx = txCtx
+		for _, query := range statements {
+			_, err := conn.needProxy(ctx, query)
+			assert.True(t, errors.Is(err, recognizer.ErrUnsupportedSQL), query)
+			_, err = conn.needLockCheck(ctx, query)
+			assert.True(t, errors.Is(err, recognizer.ErrUnsupportedSQL), query)
+		}
+	}
+}
+
+func TestConn_ExecContext(t *testing.T) {
+	conn, db, rmClient := newTestConn(t)
+	db.answer("FOR UPDATE", []string{"id", "name"}, []driver.Value{int64(1), []byte("apple")})
+	db.answer("WHERE id IN (?)", []string{"id", "name"}, []driver.Value{int64(1), []byte("banana")})
+
+	// out of a global transaction the statement runs as it is
+	_, err := conn.ExecContext(context.Background(), "UPDATE product SET name = ? WHERE id = ?",
+		valueToNamedValue([]driver.Value{"banana", int64(1)}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"UPDATE product SET name = ? WHERE id = ?"}, db.statements())
+	assert.Empty(t, rmClient.registers)
+
+	// in auto commit mode the statement is registered as a branch with its undo log
+	db.reset()
+	result, err := conn.ExecContext(newGlobalContext(), "UPDATE product SET name = ? WHERE id = ?",
+		valueToNamedValue([]driver.Value{"banana", int64(1)}))
+	assert.Nil(t, err)
+	affected, err := result.RowsAffected()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), affected)
+	assert.Equal(t, []string{
+		"SELECT id, name FROM product WHERE id = ? FOR UPDATE",
+		"UPDATE product SET name = ? WHERE id = ?",
+		"SELECT id, name FROM product WHERE id IN (?)",
+	}, db.statements()[:3])
+	assert.True(t, strings.HasPrefix(db.statements()[3], "INSERT INTO undo_log"))
+	assert.Equal(t, 1, db.commits)
+	assert.Len(t, rmClient.registers, 1)
+	assert.Equal(t, "localhost:8091:1", rmClient.registers[0].XID)
+	assert.Equal(t, "product:1", rmClient.registers[0].LockKey)
+	assert.Nil(t, conn.txCtx)
+
+	branchUndoLog, err := undo.Decode(db.undoLog())
+	assert.Nil(t, err)
+	assert.Equal(t, rmClient.branchID, branchUndoLog.BranchID)
+	assert.Len(t, branchUndoLog.SQLUndoLogs, 1)
+	assert.Equal(t, undo.SQLTypeUpdate, branchUndoLog.SQLUndoLogs[0].SQLType)
+	assert.Equal(t, "apple", branchUndoLog.SQLUndoLogs[0].BeforeImage.Rows[0].Fields[1].Value)
+	assert.Equal(t, "banana", branchUndoLog.SQLUndoLogs[0].AfterImage.Rows[0].Fields[1].Value)
+}
+
+func TestConn_ExecContext_Rollback(t *testing.T) {
+	conn, db, rmClient := newTestConn(t)
+	db.answer("FOR UPDATE", []string{"id", "name"}, []driver.Value{int64(1), []byte("apple")})
+	db.execErr = errors.New("deadlock found")
+
+	// the local transaction of the failed statement is rolled back without registering a branch
+	_, err := conn.ExecContext(newGlobalContext(), "DELETE FROM product WHERE id = ?",
+		valueToNamedValue([]driver.Value{int64(1)}))
+	assert.Equal(t, db.execErr, err)
+	assert.Equal(t, 1, db.rollbacks)
+	assert.Equal(t, 0, db.commits)
+	assert.Empty(t, rmClient.registers)
+	assert.Nil(t, conn.txCtx)
+}
+
+func TestConn_QueryContext(t *testing.T) {
+	conn, db, rmClient := newTestConn(t)
+	rmClient.lockable = []bool{false, true}
+	db.answer("FOR UPDATE", []string{"id"}, []driver.Value{int64(1)})
+
+	// the rows are read once the global locks of the selected rows are released
+	rows, err := conn.QueryContext(newGlobalContext(), "SELECT id FROM product WHERE id = ? FOR UPDATE",
+		valueToNamedValue([]driver.Value{int64(1)}))
+	assert.Nil(t, err)
+	assert.Nil(t, rows.Close())
+	assert.Len(t, rmClient.lockQueries, 2)
+	assert.Equal(t, "product:1", rmClient.lockQueries[0].LockKey)
+	assert.Equal(t, []string{
+		"SELECT id FROM product WHERE id = ? FOR UPDATE",
+		"SELECT id FROM product WHERE id = ? FOR UPDATE",
+		"SELECT id FROM product WHERE id = ? FOR UPDATE",
+	}, db.statements())
+
+	// the locks are still held once the retries run out
+	db.reset()
+	rmClient.lockable = []bool{false}
+	_, err = conn.QueryContext(newGlobalContext(), "SELECT id FROM product WHERE id = ? FOR UPDATE",
+		valueToNamedValue([]driver.Value{int64(1)}))
+	assert.True(t, isLockKeyConflict(err))
+	assert.Len(t, db.statements(), config.GetATConfig().LockRetryTimes+1)
+}
+
+// newGlobalContext return a context bound with the xid localhost:8091:1
+func newGlobalContext() context.Context {
+	rootContext := ctx2.NewRootContext(context.Background())
+	rootContext.Bind("localhost:8091:1")
+	return rootContext
+}
+
+var (
+	rmClientOnce sync.Once
+	rmClient     = &fakeRMClient{}
+)
+
+// newTestConn return a proxied mysql connection on a fake database, the table product has the
+// columns id and name, id is its primary key. The resource manager talks to the fake TC returned.
+func newTestConn(t *testing.T) (*Conn, *fakeDB, *fakeRMClient) {
+	config.SetConfiguration(&config.Configuration{ATConfig: config.ATConfig{
+		LockRetryInterval:        time.Millisecond,
+		LockRetryTimes:           3,
+		TableMetaRefreshInterval: -1,
+	}})
+	// the resource manager is a global one, it is initialized once
+	rmClientOnce.Do(func() {
+		rm.InitResourceManager("localhost", rmClient)
+	})
+	rmClient.reset()
+
+	db := &fakeDB{}
+	db.answer("INFORMATION_SCHEMA.COLUMNS", []string{"COLUMN_NAME", "DATA_TYPE", "IS_NULLABLE", "EXTRA"},
+		[]driver.Value{"id", "bigint", "NO", "auto_increment"}, []driver.Value{"name", "varchar", "YES", ""})
+	db.answer("INFORMATION_SCHEMA.STATISTICS", []string{"INDEX_NAME", "NON_UNIQUE", "COLUMN_NAME"},
+		[]driver.Value{"PRIMARY", int64(0), "id"})
+	resource := &DataSourceResource{
+		// the table meta cache is shared by the resources of a db type
+		ResourceID:     t.Name(),
+		DBType:         MysqlDBType,
+		db:             sql.OpenDB(db),
+		undoLogManager: undo.NewMysqlUndoLogManager(),
+		dialect:        dialects[MysqlDBType],
+	}
+	return &Conn{target: &fakeConn{db: db}, resource: resource}, db, rmClient
+}
+
+// fakeDB a database answering the queries containing a pattern with its rows, an empty result
+// otherwise; the statements are recorded.
+type fakeDB struct {
+	mu       sync.Mutex
+	patterns []string
+	columns  map[string][]string
+	rows     map[string][][]driver.Value
+
+	// execErr fails the statements executed but the undo log insertion
+	execErr error
+
+	executed  []string
+	undoArgs  []driver.NamedValue
+	commits   int
+	rollbacks int
+}
+
+func (db *fakeDB) answer(pattern string, columns []string, rows ...[]driver.Value) {
+	if db.columns == nil {
+		db.columns, db.rows = make(map[string][]string), make(map[string][][]driver.Value)
+	}
+	db.patterns = append(db.patterns, pattern)
+	db.columns[pattern], db.rows[pattern] = columns, rows
+}
+
+// reset forgets the statements recorded
+func (db *fakeDB) reset() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.executed, db.undoArgs, db.commits, db.rollbacks = nil, nil, 0, 0
+}
+
+// statements return the statements out of the table meta queries
+func (db *fakeDB) statements() []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	statements := make([]string, 0, len(db.executed))
+	for _, query := range db.executed {
+		if !strings.Contains(query, "INFORMATION_SCHEMA") {
+			statements = append(statements, query)
+		}
+	}
+	return statements
+}
+
+// undoLog return the rollback info of the undo log inserted
+func (db *fakeDB) undoLog() []byte {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.undoArgs[3].Value.([]byte)
+}
+
+func (db *fakeDB) Connect(_ context.Context) (driver.Conn, error) {
+	return &fakeConn{db: db}, nil
+}
+
+func (db *fakeDB) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.commits++
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.rollbacks++
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.executed = append(c.db.executed, query)
+	if strings.HasPrefix(query, "INSERT INTO undo_log") {
+		c.db.undoArgs = args
+		return driver.RowsAffected(1), nil
+	}
+	if c.db.execErr != nil {
+		return nil, c.db.execErr
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.executed = append(c.db.executed, query)
+	for _, pattern := range c.db.patterns {
+		if strings.Contains(query, pattern) {
+			rows := make([][]driver.Value, len(c.db.rows[pattern]))
+			copy(rows, c.db.rows[pattern])
+			return &fakeRows{columns: c.db.columns[pattern], values: rows}, nil
+		}
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (rows *fakeRows) Columns() []string {
+	return rows.columns
+}
+
+func (rows *fakeRows) Close() error {
+	return nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if len(rows.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, rows.values[0])
+	rows.values = rows.values[1:]
+	return nil
+}
+
+// fakeRMClient a TC answering the branch registrations and lock queries of the resource manager
+type fakeRMClient struct {
+	apis.ResourceManagerServiceClient
+
+	mu          sync.Mutex
+	branchID    int64
+	registers   []*apis.BranchRegisterRequest
+	lockQueries []*apis.GlobalLockQueryRequest
+
+	// conflicts the number of registrations failing with LockKeyConflict
+	conflicts int
+
+	// lockable the answers of the lock queries, the last one is repeated
+	lockable []bool
+}
+
+// reset forgets the requests and answers the registrations and lock queries successfully
+func (client *fakeRMClient) reset() {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.branchID, client.registers, client.lockQueries = 11, nil, nil
+	client.conflicts, client.lockable = 0, []bool{true}
+}
+
+func (client *fakeRMClient) BranchCommunicate(_ context.Context,
+	_ ...grpc.CallOption) (apis.ResourceManagerService_BranchCommunicateClient, error) {
+	return nil, errors.New("the branch stream is not supported")
+}
+
+func (client *fakeRMClient) BranchRegister(_ context.Context, in *apis.BranchRegisterRequest,
+	_ ...grpc.CallOption) (*apis.BranchRegisterResponse, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.registers = append(client.registers, in)
+	if len(client.registers) <= client.conflicts {
+		return &apis.BranchRegisterResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.LockKeyConflict,
+			Message:       "the row locks are held by other global transactions",
+		}, nil
+	}
+	return &apis.BranchRegisterResponse{ResultCode: apis.ResultCodeSuccess, BranchID: client.branchID}, nil
+}
+
+func (client *fakeRMClient) LockQuery(_ context.Context, in *apis.GlobalLockQueryRequest,
+	_ ...grpc.CallOption) (*apis.GlobalLockQueryResponse, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.lockQueries = append(client.lockQueries, in)
+	lockable := client.lockable[0]
+	if len(client.lockable) > 1 {
+		client.lockable = client.lockable[1:]
+	}
+	return &apis.GlobalLockQueryResponse{ResultCode: apis.ResultCodeSuccess, Lockable: lockable}, nil
+}
@@ -0,0 +1,77 @@
+package at
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+	ctx "github.com/opentrx/seata-golang/v2/pkg/client/base/context"
+)
+
+// connectionContext collects the undo logs and lock keys of a local transaction
+type connectionContext struct {
+	xid                 string
+	branchID            int64
+	isGlobalLockRequire bool
+
+	lockKeys           []string
+	lockKeySet         map[string]bool
+	sqlUndoItemsBuffer []*undo.SQLUndoLog
+}
+
+func newConnectionContext(c context.Context) *connectionContext {
+	xid, globalLock := globalTransactionContext(c)
+	return &connectionContext{
+		xid:                 xid,
+		isGlobalLockRequire: globalLock,
+		lockKeys:            make([]string, 0),
+		lockKeySet:          make(map[string]bool),
+		sqlUndoItemsBuffer:  make([]*undo.SQLUndoLog, 0),
+	}
+}
+
+// globalTransactionContext return the xid and the global lock flag bound with the context
+func globalTransactionContext(c context.Context) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	if rootContext, ok := c.(*ctx.RootContext); ok {
+		return rootContext.GetXID(), rootContext.RequireGlobalLock()
+	}
+	xid, _ := c.Value(ctx.KeyXID).(string)
+	_, globalLock := c.Value(ctx.KeyGlobalLockFlag).(string)
+	return xid, globalLock
+}
+
+func (c *connectionContext) inGlobalTransaction() bool {
+	return c.xid != ""
+}
+
+// needProxy return true if the statements of the transaction should be intercepted
+func (c *connectionContext) needProxy() bool {
+	return c.inGlobalTransaction() || c.isGlobalLockRequire
+}
+
+func (c *connectionContext) appendLockKey(lockKey string) {
+	if c.lockKeySet[lockKey] {
+		return
+	}
+	c.lockKeySet[lockKey] = true
+	c.lockKeys = append(c.lockKeys, lockKey)
+}
+
+func (c *connectionContext) appendUndoItem(sqlUndoLog *undo.SQLUndoLog) {
+	c.sqlUndoItemsBuffer = append(c.sqlUndoItemsBuffer, sqlUndoLog)
+}
+
+func (c *connectionContext) hasUndoLog() bool {
+	return len(c.sqlUndoItemsBuffer) > 0
+}
+
+func (c *connectionContext) hasLockKey() bool {
+	return len(c.lockKeys) > 0
+}
+
+func (c *connectionContext) buildLockKeys() string {
+	return strings.Join(c.lockKeys, ";")
+}
@@ -0,0 +1,76 @@
+package at
+
+import (
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/recognizer"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+	"github.com/opentrx/seata-golang/v2/pkg/util/mysql"
+	"github.com/opentrx/seata-golang/v2/pkg/util/pgsql"
+	sql2 "github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+// dialect describes how the statements of a database are recognized, and how the statements
+// capturing the images are written for it.
+type dialect struct {
+	// recognizer the dialect the business statements are parsed with
+	recognizer recognizer.Dialect
+
+	// escape quotes the table or column name if it is a keyword
+	escape func(name string) string
+
+	// rebind converts the `?` placeholders of the statement to the bind vars of the database
+	rebind func(query string) string
+
+	// returning is true if the primary keys generated by an insert are read with a RETURNING
+	// clause, the driver of the database does not support LastInsertId.
+	returning bool
+
+	// timeFormat the format the times of the images are written in, the undo log manager of the
+	// database compares the current rows with them in it
+	timeFormat string
+}
+
+var dialects = map[string]dialect{
+	MysqlDBType: {
+		recognizer: recognizer.MySQL,
+		escape:     mysql.CheckAndReplace,
+		rebind:     func(query string) string { return query },
+		timeFormat: undo.MysqlTimeFormat,
+	},
+	PostgresDBType: {
+		recognizer: recognizer.PostgreSQL,
+		escape:     pgsql.CheckAndReplace,
+		rebind:     sql2.PgsqlRebind,
+		returning:  true,
+		timeFormat: undo.PostgresTimeFormat,
+	},
+}
+
+func (d dialect) escapeColumns(columns []string) string {
+	escaped := make([]string, 0, len(columns))
+	for _, column := range columns {
+		escaped = append(escaped, d.escape(column))
+	}
+	return strings.Join(escaped, ", ")
+}
+
+// fieldValue copy the value read from the driver into a form which can be serialized and bound
+// back to a statement.
+func (d dialect) fieldValue(value driver.Value, sqlType int32) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		if undo.IsBinary(sqlType) {
+			b := make([]byte, len(v))
+			copy(b, v)
+			return b
+		}
+		return string(v)
+	case time.Time:
+		return v.Format(d.timeFormat)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,39 @@
+package at
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+func TestDialect_FieldValue(t *testing.T) {
+	modified := time.Date(2021, 1, 2, 3, 4, 5, 600000000, time.FixedZone("CST", 8*3600))
+	testCases := []struct {
+		dbType   string
+		value    driver.Value
+		sqlType  sql.Type
+		expected interface{}
+	}{
+		{MysqlDBType, []byte("apple"), sql.VARCHAR, "apple"},
+		{MysqlDBType, []byte{0x01, 0xff}, sql.VARBINARY, []byte{0x01, 0xff}},
+		{MysqlDBType, int64(1), sql.BIGINT, int64(1)},
+		{MysqlDBType, nil, sql.VARCHAR, nil},
+		// mysql keeps the wall clock of a DATETIME, postgresql keeps the offset of a timestamptz
+		{MysqlDBType, modified, sql.TIMESTAMP, "2021-01-02 03:04:05.6"},
+		{PostgresDBType, modified, sql.TIMESTAMP, "2021-01-02 03:04:05.6+08:00"},
+		{PostgresDBType, modified.UTC(), sql.TIMESTAMP, "2021-01-01 19:04:05.6+00:00"},
+	}
+	for _, c := range testCases {
+		assert.Equal(t, c.expected, dialects[c.dbType].fieldValue(c.value, int32(c.sqlType)), c.dbType)
+	}
+
+	// the binary value is copied, the driver reuses its buffer
+	buffer := []byte{0x01}
+	value := dialects[MysqlDBType].fieldValue(buffer, int32(sql.BLOB))
+	buffer[0] = 0x02
+	assert.Equal(t, []byte{0x01}, value)
+}
@@ -0,0 +1,206 @@
+package at
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+)
+
+const (
+	// MysqlDriverName the name of the AT proxy driver for mysql, use it with sql.Open
+	MysqlDriverName = "seata-mysql"
+
+	MysqlDBType = undo.MysqlDBType
+
+	// PostgresDriverName the name of the AT proxy driver for postgresql, use it with sql.Open
+	PostgresDriverName = "seata-postgres"
+
+	PostgresDBType = undo.PostgresDBType
+)
+
+func init() {
+	sql.Register(MysqlDriverName, &Driver{target: &mysql.MySQLDriver{}, dbType: MysqlDBType})
+	sql.Register(PostgresDriverName, &Driver{target: &pq.Driver{}, dbType: PostgresDBType})
+}
+
+// Driver wraps a database/sql driver, the statements executed in a global transaction
+// are intercepted to generate undo logs and register AT branches.
+type Driver struct {
+	target driver.Driver
+	dbType string
+}
+
+// Open returns a new connection to the database.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	c, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return c.Connect(context.Background())
+}
+
+// OpenConnector registers the database as an AT resource and returns a connector.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	var (
+		target driver.Connector
+		err    error
+	)
+	if dc, ok := d.target.(driver.DriverContext); ok {
+		target, err = dc.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		target = &dsnConnector{dsn: dsn, driver: d.target}
+	}
+
+	resourceID, err := d.resourceID(dsn)
+	if err != nil {
+		return nil, err
+	}
+	dialect, ok := dialects[d.dbType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported db type %s", d.dbType)
+	}
+
+	undoLogManager, err := undo.GetUndoLogManager(d.dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := atResourceManager.loadOrRegisterResource(&DataSourceResource{
+		ResourceID:     resourceID,
+		DBType:         d.dbType,
+		db:             sql.OpenDB(target),
+		undoLogManager: undoLogManager,
+		dialect:        dialect,
+	})
+	return &connector{target: target, driver: d, resource: resource}, nil
+}
+
+func (d *Driver) resourceID(dsn string) (string, error) {
+	switch d.dbType {
+	case MysqlDBType:
+		cfg, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s/%s", cfg.Addr, cfg.DBName), nil
+	case PostgresDBType:
+		settings, err := parsePostgresDSN(dsn)
+		if err != nil {
+			return "", err
+		}
+		dbName := settings["dbname"]
+		if dbName == "" {
+			dbName = settings["user"]
+		}
+		return fmt.Sprintf("%s:%s/%s", settings["host"], settings["port"], dbName), nil
+	default:
+		return "", fmt.Errorf("unsupported db type %s", d.dbType)
+	}
+}
+
+// parsePostgresDSN return the settings of a postgresql connection string, which is a URL or
+// space separated key=value settings, the host and port default to localhost:5432.
+func parsePostgresDSN(dsn string) (map[string]string, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		var err error
+		if dsn, err = pq.ParseURL(dsn); err != nil {
+			return nil, err
+		}
+	}
+
+	settings := map[string]string{"host": "localhost", "port": "5432"}
+	runes := []rune(dsn)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && runes[i] != '=' && !unicode.IsSpace(runes[i]) {
+			i++
+		}
+		key := string(runes[start:i])
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i == len(runes) || runes[i] != '=' {
+			return nil, fmt.Errorf("missing \"=\" after %q in connection string", key)
+		}
+		i++
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+
+		var (
+			value  []rune
+			quoted = i < len(runes) && runes[i] == '\''
+		)
+		if quoted {
+			i++
+		}
+		for ; i < len(runes); i++ {
+			r := runes[i]
+			if (quoted && r == '\'') || (!quoted && unicode.IsSpace(r)) {
+				break
+			}
+			if r == '\\' && i+1 < len(runes) {
+				i++
+				r = runes[i]
+			}
+			value = append(value, r)
+		}
+		if quoted {
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated quoted value of %q in connection string", key)
+			}
+			i++
+		}
+		if len(value) > 0 {
+			settings[key] = string(value)
+		}
+	}
+	return settings, nil
+}
+
+type connector struct {
+	target   driver.Connector
+	driver   *Driver
+	resource *DataSourceResource
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.target.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{target: conn, resource: c.resource}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// dsnConnector adapts drivers which do not implement driver.DriverContext
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c *dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
@@ -0,0 +1,395 @@
+package at
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/meta"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/recognizer"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+	"github.com/opentrx/seata-golang/v2/pkg/client/base/exception"
+	"github.com/opentrx/seata-golang/v2/pkg/client/config"
+	"github.com/opentrx/seata-golang/v2/pkg/client/rm"
+	sql2 "github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+// executor captures the before and after image of a DML statement
+type executor struct {
+	conn *Conn
+	// recognizer the statement parsed by the connection deciding to intercept it
+	recognizer recognizer.Recognizer
+	query      string
+	args       []driver.NamedValue
+}
+
+func newExecutor(conn *Conn, r recognizer.Recognizer, query string, args []driver.NamedValue) *executor {
+	return &executor{conn: conn, recognizer: r, query: query, args: args}
+}
+
+func (e *executor) execute(ctx context.Context, exec func() (driver.Result, error)) (driver.Result, error) {
+	r := e.recognizer
+	if insert, ok := r.(*recognizer.InsertRecognizer); ok && len(insert.OnDuplicateKeyUpdate) > 0 {
+		return nil, errors.Errorf("insert on duplicate key update is not supported in AT mode: %s", e.query)
+	}
+	tableMeta, err := e.conn.resource.getTableMeta(ctx, r.TableName())
+	if err != nil {
+		return nil, err
+	}
+
+	beforeImage, err := e.beforeImage(ctx, r, tableMeta)
+	if err != nil {
+		e.conn.resource.invalidateTableMeta(r.TableName(), err)
+		return nil, errors.WithStack(err)
+	}
+	var result driver.Result
+	if insert, ok := r.(*recognizer.InsertRecognizer); ok && e.conn.resource.dialect.returning {
+		result, err = e.execReturning(ctx, insert, tableMeta, exec)
+	} else {
+		result, err = exec()
+	}
+	if err != nil {
+		e.conn.resource.invalidateTableMeta(r.TableName(), err)
+		return nil, err
+	}
+	afterImage, err := e.afterImage(ctx, r, tableMeta, beforeImage, result)
+	if err != nil {
+		e.conn.resource.invalidateTableMeta(r.TableName(), err)
+		return nil, errors.WithStack(err)
+	}
+
+	e.prepareUndoLog(r, beforeImage, afterImage)
+	return result, nil
+}
+
+// executeQuery executes a SELECT ... FOR UPDATE after the global locks of the selected rows are
+// released by other global transactions.
+func (e *executor) executeQuery(ctx context.Context, query func() (driver.Rows, error)) (driver.Rows, error) {
+	sel := e.recognizer.(*recognizer.SelectForUpdateRecognizer)
+	tableMeta, err := e.conn.resource.getTableMeta(ctx, sel.TableName())
+	if err != nil {
+		return nil, err
+	}
+
+	xid, _ := e.conn.globalTransactionContext(ctx)
+	conf := config.GetATConfig()
+	for retry := 0; ; retry++ {
+		records, err := e.selectForUpdate(ctx, sel, sel.Condition, tableMeta, tableMeta.PrimaryKeys())
+		if err != nil {
+			e.conn.resource.invalidateTableMeta(sel.TableName(), err)
+			return nil, errors.WithStack(err)
+		}
+		if records.IsEmpty() {
+			return query()
+		}
+
+		lockKeys := records.LockKey()
+		lockable, err := rm.GetResourceManager().LockQuery(ctx, xid, e.conn.resource.GetResourceID(), apis.AT, lockKeys)
+		if err != nil {
+			return nil, err
+		}
+		if lockable {
+			return query()
+		}
+		if retry >= conf.LockRetryTimes {
+			return nil, &exception.TransactionException{
+				Code:    apis.LockKeyConflict,
+				Message: fmt.Sprintf("global lock wait timeout, lockKeys: %s", lockKeys),
+			}
+		}
+		time.Sleep(conf.LockRetryInterval)
+	}
+}
+
+func (e *executor) beforeImage(ctx context.Context, r recognizer.Recognizer, tableMeta *meta.TableMeta) (*undo.TableRecords, error) {
+	switch r := r.(type) {
+	case *recognizer.UpdateRecognizer:
+		return e.selectForUpdate(ctx, r, r.Condition, tableMeta, tableMeta.Columns)
+	case *recognizer.DeleteRecognizer:
+		return e.selectForUpdate(ctx, r, r.Condition, tableMeta, tableMeta.Columns)
+	default:
+		return &undo.TableRecords{TableName: r.TableName(), Rows: make([]*undo.Row, 0)}, nil
+	}
+}
+
+// selectForUpdate lock and read the columns of the rows matching the condition of the statement
+func (e *executor) selectForUpdate(ctx context.Context, r recognizer.Recognizer, condition recognizer.Condition,
+	tableMeta *meta.TableMeta, columns []string) (*undo.TableRecords, error) {
+	dialect := e.conn.resource.dialect
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "SELECT %s FROM %s", dialect.escapeColumns(columns), dialect.escape(r.TableName()))
+	if r.TableAlias() != "" {
+		fmt.Fprintf(&sb, " %s", dialect.escape(r.TableAlias()))
+	}
+	if clauses := condition.String(); clauses != "" {
+		fmt.Fprintf(&sb, " %s", clauses)
+	}
+	sb.WriteString(" FOR UPDATE")
+
+	args, err := e.params(condition.Params())
+	if err != nil {
+		return nil, err
+	}
+	return e.buildTableRecords(ctx, r.TableName(), tableMeta, sb.String(), args)
+}
+
+// params return the arguments of the ordinals, numbered from 1 in the order of the ordinals
+func (e *executor) params(ordinals []int) ([]driver.NamedValue, error) {
+	args := make([]driver.NamedValue, 0, len(ordinals))
+	for _, ordinal := range ordinals {
+		if ordinal < 1 || ordinal > len(e.args) {
+			return nil, errors.Errorf("not enough arguments for sql: %s", e.query)
+		}
+		args = append(args, driver.NamedValue{Ordinal: len(args) + 1, Value: e.args[ordinal-1].Value})
+	}
+	return args, nil
+}
+
+func (e *executor) afterImage(ctx context.Context, r recognizer.Recognizer, tableMeta *meta.TableMeta,
+	beforeImage *undo.TableRecords, result driver.Result) (*undo.TableRecords, error) {
+	switch r := r.(type) {
+	case *recognizer.InsertRecognizer:
+		pkValues, complete, err := e.insertPrimaryKeyValues(r, tableMeta)
+		if err != nil {
+			return nil, err
+		}
+		if !complete {
+			if pkValues, err = e.generatedPrimaryKeyValues(r, tableMeta, result); err != nil {
+				return nil, err
+			}
+		}
+		return e.buildTableRecordsByPKs(ctx, r.TableName(), tableMeta, pkValues)
+	case *recognizer.UpdateRecognizer:
+		pkValues := make([][]interface{}, 0, len(beforeImage.Rows))
+		for _, row := range beforeImage.Rows {
+			values := make([]interface{}, 0)
+			for _, field := range row.PrimaryKeys() {
+				values = append(values, field.Value)
+			}
+			pkValues = append(pkValues, values)
+		}
+		return e.buildTableRecordsByPKs(ctx, r.TableName(), tableMeta, pkValues)
+	default:
+		return &undo.TableRecords{TableName: r.TableName(), Rows: make([]*undo.Row, 0)}, nil
+	}
+}
+
+func (e *executor) prepareUndoLog(r recognizer.Recognizer, beforeImage, afterImage *undo.TableRecords) {
+	if beforeImage.IsEmpty() && afterImage.IsEmpty() {
+		return
+	}
+	sqlType := undo.SQLTypeDelete
+	lockKeyRecords := beforeImage
+	switch r.SQLType() {
+	case recognizer.SQLTypeInsert:
+		sqlType = undo.SQLTypeInsert
+		lockKeyRecords = afterImage
+	case recognizer.SQLTypeUpdate:
+		sqlType = undo.SQLTypeUpdate
+	}
+
+	txCtx := e.conn.txCtx
+	txCtx.appendLockKey(lockKeyRecords.LockKey())
+	txCtx.appendUndoItem(&undo.SQLUndoLog{
+		SQLType:     sqlType,
+		TableName:   r.TableName(),
+		BeforeImage: beforeImage,
+		AfterImage:  afterImage,
+	})
+}
+
+// insertPrimaryKeyValues return the primary key values of the inserted rows taken from the insert
+// values, complete is false if some of them are generated by the database.
+func (e *executor) insertPrimaryKeyValues(r *recognizer.InsertRecognizer,
+	tableMeta *meta.TableMeta) (pkValues [][]interface{}, complete bool, err error) {
+	columns := r.Columns
+	if len(columns) == 0 {
+		columns = tableMeta.Columns
+	}
+	pkIndexes := make([]int, 0, len(tableMeta.PrimaryKeys()))
+	for _, pk := range tableMeta.PrimaryKeys() {
+		for i, column := range columns {
+			if strings.EqualFold(pk, column) {
+				pkIndexes = append(pkIndexes, i)
+				break
+			}
+		}
+	}
+
+	pkValues = make([][]interface{}, 0, len(r.Rows))
+	complete = len(pkIndexes) == len(tableMeta.PrimaryKeys())
+	for _, row := range r.Rows {
+		if len(row) != len(columns) {
+			return nil, false, errors.Errorf("column count doesn't match value count in sql: %s", e.query)
+		}
+		rowValues := make([]interface{}, len(row))
+		for i, expression := range row {
+			switch {
+			case expression.IsPlaceholder():
+				args, err := e.params(expression.Params)
+				if err != nil {
+					return nil, false, err
+				}
+				rowValues[i] = args[0].Value
+			default:
+				literal, ok := expression.Literal()
+				if ok {
+					rowValues[i] = literal
+				}
+			}
+		}
+		values := make([]interface{}, 0, len(pkIndexes))
+		for _, index := range pkIndexes {
+			if rowValues[index] == nil {
+				// NULL, DEFAULT or an expression, the value is generated by the database
+				complete = false
+			}
+			values = append(values, rowValues[index])
+		}
+		pkValues = append(pkValues, values)
+	}
+	return pkValues, complete, nil
+}
+
+// generatedPrimaryKeyValues return the primary key values generated by the database for the
+// inserted rows, read by a RETURNING clause or from the auto increment id.
+func (e *executor) generatedPrimaryKeyValues(r *recognizer.InsertRecognizer, tableMeta *meta.TableMeta,
+	result driver.Result) ([][]interface{}, error) {
+	if returning, ok := result.(returningResult); ok {
+		return returning.pkValues, nil
+	}
+	if len(tableMeta.PrimaryKeys()) > 1 {
+		return nil, errors.Errorf("composite primary key of table %s must be provided in sql: %s",
+			tableMeta.TableName, e.query)
+	}
+	lastInsertID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	pkValues := make([][]interface{}, 0, len(r.Rows))
+	for i := range r.Rows {
+		pkValues = append(pkValues, []interface{}{lastInsertID + int64(i)})
+	}
+	return pkValues, nil
+}
+
+// returningResult the result of an insert executed with a RETURNING clause, it carries the
+// primary key values of the inserted rows.
+type returningResult struct {
+	driver.Result
+
+	pkValues [][]interface{}
+}
+
+// execReturning executes the insert with a RETURNING clause of the primary keys if some of them
+// are generated by the database, the driver can not report them with LastInsertId.
+func (e *executor) execReturning(ctx context.Context, r *recognizer.InsertRecognizer, tableMeta *meta.TableMeta,
+	exec func() (driver.Result, error)) (driver.Result, error) {
+	_, complete, err := e.insertPrimaryKeyValues(r, tableMeta)
+	if err != nil {
+		return nil, err
+	}
+	if complete {
+		return exec()
+	}
+
+	query := fmt.Sprintf("%s RETURNING %s", strings.TrimRight(strings.TrimSpace(e.query), "; \t\r\n"),
+		e.conn.resource.dialect.escapeColumns(tableMeta.PrimaryKeys()))
+	rows, err := targetConn{e.conn.target}.QueryContext(ctx, query, e.args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pkValues := make([][]interface{}, 0, len(r.Rows))
+	dest := make([]driver.Value, len(tableMeta.PrimaryKeys()))
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, 0, len(dest))
+		for i, pk := range tableMeta.PrimaryKeys() {
+			values = append(values, e.conn.resource.dialect.fieldValue(dest[i], tableMeta.ColumnType(pk)))
+		}
+		pkValues = append(pkValues, values)
+	}
+	return returningResult{Result: driver.RowsAffected(len(pkValues)), pkValues: pkValues}, nil
+}
+
+func (e *executor) buildTableRecordsByPKs(ctx context.Context, tableName string, tableMeta *meta.TableMeta,
+	pkValues [][]interface{}) (*undo.TableRecords, error) {
+	if len(pkValues) == 0 {
+		return &undo.TableRecords{TableName: tableName, Rows: make([]*undo.Row, 0)}, nil
+	}
+
+	var (
+		sb      strings.Builder
+		args    = make([]driver.NamedValue, 0)
+		dialect = e.conn.resource.dialect
+	)
+	fmt.Fprintf(&sb, "SELECT %s FROM %s WHERE ", dialect.escapeColumns(tableMeta.Columns), dialect.escape(tableName))
+	if len(tableMeta.PrimaryKeys()) == 1 {
+		fmt.Fprintf(&sb, "%s IN %s", dialect.escape(tableMeta.PrimaryKeys()[0]),
+			sql2.MysqlAppendInParam(len(pkValues)))
+	} else {
+		tuples := make([]string, 0, len(pkValues))
+		for range pkValues {
+			tuples = append(tuples, sql2.MysqlAppendInParam(len(tableMeta.PrimaryKeys())))
+		}
+		fmt.Fprintf(&sb, "(%s) IN (%s)", dialect.escapeColumns(tableMeta.PrimaryKeys()), strings.Join(tuples, ", "))
+	}
+	for _, values := range pkValues {
+		for _, value := range values {
+			args = append(args, driver.NamedValue{Ordinal: len(args) + 1, Value: value})
+		}
+	}
+	return e.buildTableRecords(ctx, tableName, tableMeta, sb.String(), args)
+}
+
+// buildTableRecords reads the image with the query, its placeholders are written as `?`
+func (e *executor) buildTableRecords(ctx context.Context, tableName string, tableMeta *meta.TableMeta, query string,
+	args []driver.NamedValue) (*undo.TableRecords, error) {
+	rows, err := targetConn{e.conn.target}.QueryContext(ctx, e.conn.resource.dialect.rebind(query), args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := &undo.TableRecords{TableName: tableName, Rows: make([]*undo.Row, 0)}
+	columns := rows.Columns()
+	dest := make([]driver.Value, len(columns))
+	for {
+		err = rows.Next(dest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := &undo.Row{Fields: make([]*undo.Field, 0, len(columns))}
+		for i, column := range columns {
+			field := &undo.Field{
+				Name:    column,
+				KeyType: undo.Null,
+				Type:    tableMeta.ColumnType(column),
+			}
+			if tableMeta.IsPrimaryKey(column) {
+				field.KeyType = undo.PrimaryKey
+			}
+			field.Value = e.conn.resource.dialect.fieldValue(dest[i], field.Type)
+			row.Fields = append(row.Fields, field)
+		}
+		records.Rows = append(records.Rows, row)
+	}
+	return records, nil
+}
@@ -0,0 +1,105 @@
+package meta
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	sql2 "github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+// MysqlDBType the db type of the mysql table meta fetcher
+const MysqlDBType = "mysql"
+
+const (
+	queryMysqlColumnsSQL = `SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, EXTRA FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = IFNULL(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION`
+
+	queryMysqlIndexesSQL = `SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME FROM INFORMATION_SCHEMA.STATISTICS
+		WHERE TABLE_SCHEMA = IFNULL(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? ORDER BY INDEX_NAME, SEQ_IN_INDEX`
+
+	// mysql error numbers of unknown column and unknown table
+	erBadFieldError = 1054
+	erNoSuchTable   = 1146
+)
+
+func init() {
+	registerFetcher(MysqlDBType, mysqlFetcher{})
+}
+
+type mysqlFetcher struct{}
+
+func (mysqlFetcher) fetchTableMeta(ctx context.Context, db *sql.DB, tableName string) (*TableMeta, error) {
+	schema, table := splitSchema(tableName)
+	meta := newTableMeta(tableName)
+
+	rows, err := db.QueryContext(ctx, queryMysqlColumnsSQL, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var columnName, dataType, isNullable, extra string
+		if err = rows.Scan(&columnName, &dataType, &isNullable, &extra); err != nil {
+			return nil, err
+		}
+		meta.addColumn(&ColumnMeta{
+			ColumnName:      columnName,
+			DataType:        mysqlColumnType(dataType),
+			ColumnTypeName:  dataType,
+			IsNullable:      isNullable == "YES",
+			IsAutoIncrement: strings.Contains(strings.ToLower(extra), "auto_increment"),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	indexRows, err := db.QueryContext(ctx, queryMysqlIndexesSQL, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer indexRows.Close()
+	for indexRows.Next() {
+		var (
+			indexName, columnName string
+			nonUnique             int
+		)
+		if err = indexRows.Scan(&indexName, &nonUnique, &columnName); err != nil {
+			return nil, err
+		}
+		indexType := IndexTypeNormal
+		switch {
+		case indexName == "PRIMARY":
+			indexType = IndexTypePrimary
+		case nonUnique == 0:
+			indexType = IndexTypeUnique
+		}
+		meta.addIndexColumn(indexName, indexType, columnName)
+	}
+	return meta, indexRows.Err()
+}
+
+func (mysqlFetcher) isSchemaChangeError(err error) bool {
+	mysqlErr, ok := err.(*mysql.MySQLError)
+	return ok && (mysqlErr.Number == erBadFieldError || mysqlErr.Number == erNoSuchTable)
+}
+
+func mysqlColumnType(dataType string) int32 {
+	switch strings.ToUpper(dataType) {
+	case "INT", "MEDIUMINT":
+		return sql2.INTEGER
+	case "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT", "JSON", "ENUM", "SET":
+		return sql2.LONGVARCHAR
+	case "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB":
+		return sql2.BLOB
+	case "DATETIME":
+		return sql2.TIMESTAMP
+	case "YEAR":
+		return sql2.DATE
+	default:
+		return sql2.GetSQLType(dataType)
+	}
+}
@@ -0,0 +1,136 @@
+package meta
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+
+	sql2 "github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+// PostgresDBType the db type of the postgresql table meta fetcher
+const PostgresDBType = "postgres"
+
+const (
+	queryPgsqlColumnsSQL = `SELECT a.attname, t.typname, NOT a.attnotnull,
+		COALESCE(pg_get_expr(d.adbin, d.adrelid), '') LIKE 'nextval(%' OR a.attidentity <> ''
+		FROM pg_catalog.pg_attribute a
+		JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_catalog.pg_type t ON t.oid = a.atttypid
+		LEFT JOIN pg_catalog.pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = COALESCE(NULLIF($1, ''), current_schema()) AND c.relname = $2
+		AND a.attnum > 0 AND NOT a.attisdropped ORDER BY a.attnum`
+
+	queryPgsqlIndexesSQL = `SELECT i.relname, ix.indisprimary, ix.indisunique, a.attname
+		FROM pg_catalog.pg_index ix
+		JOIN pg_catalog.pg_class c ON c.oid = ix.indrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+		JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid AND a.attnum = k.attnum
+		WHERE n.nspname = COALESCE(NULLIF($1, ''), current_schema()) AND c.relname = $2
+		ORDER BY i.relname, k.ord`
+
+	// postgresql error codes of undefined column and undefined table
+	undefinedColumn = "42703"
+	undefinedTable  = "42P01"
+)
+
+func init() {
+	registerFetcher(PostgresDBType, pgsqlFetcher{})
+}
+
+type pgsqlFetcher struct{}
+
+func (pgsqlFetcher) fetchTableMeta(ctx context.Context, db *sql.DB, tableName string) (*TableMeta, error) {
+	schema, table := splitSchema(tableName)
+	meta := newTableMeta(tableName)
+
+	rows, err := db.QueryContext(ctx, queryPgsqlColumnsSQL, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			columnName, typeName        string
+			isNullable, isAutoIncrement bool
+		)
+		if err = rows.Scan(&columnName, &typeName, &isNullable, &isAutoIncrement); err != nil {
+			return nil, err
+		}
+		meta.addColumn(&ColumnMeta{
+			ColumnName:      columnName,
+			DataType:        pgsqlColumnType(typeName),
+			ColumnTypeName:  typeName,
+			IsNullable:      isNullable,
+			IsAutoIncrement: isAutoIncrement,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	indexRows, err := db.QueryContext(ctx, queryPgsqlIndexesSQL, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	defer indexRows.Close()
+	for indexRows.Next() {
+		var (
+			indexName, columnName string
+			isPrimary, isUnique   bool
+		)
+		if err = indexRows.Scan(&indexName, &isPrimary, &isUnique, &columnName); err != nil {
+			return nil, err
+		}
+		indexType := IndexTypeNormal
+		switch {
+		case isPrimary:
+			indexType = IndexTypePrimary
+		case isUnique:
+			indexType = IndexTypeUnique
+		}
+		meta.addIndexColumn(indexName, indexType, columnName)
+	}
+	return meta, indexRows.Err()
+}
+
+func (pgsqlFetcher) isSchemaChangeError(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && (pqErr.Code == undefinedColumn || pqErr.Code == undefinedTable)
+}
+
+func pgsqlColumnType(typeName string) int32 {
+	switch strings.ToLower(typeName) {
+	case "int2":
+		return sql2.SMALLINT
+	case "int4":
+		return sql2.INTEGER
+	case "int8":
+		return sql2.BIGINT
+	case "float4":
+		return sql2.REAL
+	case "float8":
+		return sql2.DOUBLE
+	case "bool":
+		return sql2.BOOLEAN
+	case "bpchar":
+		return sql2.CHAR
+	case "text", "json", "jsonb", "xml":
+		return sql2.LONGVARCHAR
+	case "bytea":
+		return sql2.LONGVARBINARY
+	case "timetz":
+		return sql2.TimeWithTimezone
+	case "timestamptz":
+		return sql2.TimestampWithTimezone
+	case "uuid":
+		return sql2.OTHER
+	default:
+		return sql2.GetSQLType(typeName)
+	}
+}
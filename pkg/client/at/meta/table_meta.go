@@ -0,0 +1,122 @@
+package meta
+
+import (
+	"strings"
+)
+
+// IndexType the type of an index
+type IndexType byte
+
+const (
+	IndexTypePrimary IndexType = iota
+
+	IndexTypeUnique
+
+	IndexTypeNormal
+)
+
+// ColumnMeta the metadata of a column
+type ColumnMeta struct {
+	ColumnName string
+
+	// DataType the type mapped onto pkg/util/sql.Type
+	DataType int32
+
+	// ColumnTypeName the type name reported by the database, eg: varchar, int4
+	ColumnTypeName string
+
+	IsNullable      bool
+	IsAutoIncrement bool
+}
+
+// IndexMeta the metadata of an index
+type IndexMeta struct {
+	IndexName string
+	IndexType IndexType
+
+	// Columns the columns of the index in order
+	Columns []string
+}
+
+// TableMeta the metadata of a table
+type TableMeta struct {
+	TableName string
+
+	// Columns the column names in ordinal position
+	Columns []string
+
+	// ColumnMetas the column metadata keyed by upper case column name
+	ColumnMetas map[string]*ColumnMeta
+
+	// Indexes the indexes keyed by index name
+	Indexes map[string]*IndexMeta
+}
+
+func newTableMeta(tableName string) *TableMeta {
+	return &TableMeta{
+		TableName:   tableName,
+		Columns:     make([]string, 0),
+		ColumnMetas: make(map[string]*ColumnMeta),
+		Indexes:     make(map[string]*IndexMeta),
+	}
+}
+
+func (meta *TableMeta) addColumn(column *ColumnMeta) {
+	meta.Columns = append(meta.Columns, column.ColumnName)
+	meta.ColumnMetas[strings.ToUpper(column.ColumnName)] = column
+}
+
+func (meta *TableMeta) addIndexColumn(indexName string, indexType IndexType, column string) {
+	index, ok := meta.Indexes[indexName]
+	if !ok {
+		index = &IndexMeta{IndexName: indexName, IndexType: indexType, Columns: make([]string, 0)}
+		meta.Indexes[indexName] = index
+	}
+	index.Columns = append(index.Columns, column)
+}
+
+// PrimaryKeys return the columns of the primary key, composite primary key columns are in
+// index order.
+func (meta *TableMeta) PrimaryKeys() []string {
+	for _, index := range meta.Indexes {
+		if index.IndexType == IndexTypePrimary {
+			return index.Columns
+		}
+	}
+	return nil
+}
+
+// IsPrimaryKey return true if the column is part of the primary key
+func (meta *TableMeta) IsPrimaryKey(column string) bool {
+	for _, pk := range meta.PrimaryKeys() {
+		if strings.EqualFold(pk, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetColumnMeta return the metadata of the column, nil if the table has no such column
+func (meta *TableMeta) GetColumnMeta(column string) *ColumnMeta {
+	return meta.ColumnMetas[strings.ToUpper(column)]
+}
+
+// ColumnType return the pkg/util/sql.Type of the column
+func (meta *TableMeta) ColumnType(column string) int32 {
+	columnMeta := meta.GetColumnMeta(column)
+	if columnMeta == nil {
+		return 0
+	}
+	return columnMeta.DataType
+}
+
+// UniqueIndexes return the unique indexes other than the primary key
+func (meta *TableMeta) UniqueIndexes() []*IndexMeta {
+	indexes := make([]*IndexMeta, 0)
+	for _, index := range meta.Indexes {
+		if index.IndexType == IndexTypeUnique {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,174 @@
+package meta
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/runtime"
+)
+
+// DefaultRefreshInterval the interval the cached table metadata is refreshed if it is not configured
+const DefaultRefreshInterval = time.Minute
+
+// fetcher reads the metadata of a table from the catalog of a database
+type fetcher interface {
+	fetchTableMeta(ctx context.Context, db *sql.DB, tableName string) (*TableMeta, error)
+
+	// isSchemaChangeError return true if the error is caused by a missing table or column
+	isSchemaChangeError(err error) bool
+}
+
+var (
+	fetchersMu sync.RWMutex
+	fetchers   = make(map[string]fetcher)
+)
+
+func registerFetcher(dbType string, f fetcher) {
+	fetchersMu.Lock()
+	defer fetchersMu.Unlock()
+	fetchers[dbType] = f
+}
+
+// TableMetaCache caches the metadata of tables keyed by resource ID and table name
+type TableMetaCache struct {
+	fetcher         fetcher
+	refreshInterval time.Duration
+
+	tableMetas sync.Map // tableKey -> *TableMeta
+	resources  sync.Map // resourceID -> *sql.DB
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewTableMetaCache create the cache of the db type, the cached metadata is refreshed on the
+// interval; a zero interval means DefaultRefreshInterval, a negative one disables the refresh.
+func NewTableMetaCache(dbType string, refreshInterval time.Duration) (*TableMetaCache, error) {
+	fetchersMu.RLock()
+	f, ok := fetchers[dbType]
+	fetchersMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("table meta of db type %s is not supported", dbType)
+	}
+	if refreshInterval == 0 {
+		refreshInterval = DefaultRefreshInterval
+	}
+	cache := &TableMetaCache{
+		fetcher:         f,
+		refreshInterval: refreshInterval,
+		stop:            make(chan struct{}),
+	}
+	if refreshInterval > 0 {
+		runtime.GoWithRecover(func() {
+			cache.refreshLoop()
+		}, nil)
+	}
+	return cache, nil
+}
+
+// GetTableMeta return the cached metadata of the table, it is fetched from the database at the
+// first time.
+func (cache *TableMetaCache) GetTableMeta(ctx context.Context, db *sql.DB, resourceID, tableName string) (*TableMeta, error) {
+	if tableName == "" {
+		return nil, errors.New("table name is empty")
+	}
+	key := cacheKey(resourceID, tableName)
+	if meta, ok := cache.tableMetas.Load(key); ok {
+		return meta.(*TableMeta), nil
+	}
+
+	meta, err := cache.fetcher.fetchTableMeta(ctx, db, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(meta.Columns) == 0 {
+		return nil, errors.Errorf("could not find table meta of %s", tableName)
+	}
+	if len(meta.PrimaryKeys()) == 0 {
+		return nil, errors.Errorf("table %s has no primary key", tableName)
+	}
+	cache.resources.Store(resourceID, db)
+	actual, _ := cache.tableMetas.LoadOrStore(key, meta)
+	return actual.(*TableMeta), nil
+}
+
+// Invalidate drops the cached metadata of the table, it is fetched again at the next access.
+func (cache *TableMetaCache) Invalidate(resourceID, tableName string) {
+	cache.tableMetas.Delete(cacheKey(resourceID, tableName))
+}
+
+// InvalidateOnSchemaChange drops the cached metadata of the table if the error shows the table
+// or a column has been changed, it returns true if the metadata is dropped.
+func (cache *TableMetaCache) InvalidateOnSchemaChange(resourceID, tableName string, err error) bool {
+	if err == nil || !cache.fetcher.isSchemaChangeError(errors.Cause(err)) {
+		return false
+	}
+	log.Warnf("table %s of resource %s may be changed, refresh table meta, err: %v", tableName, resourceID, err)
+	cache.Invalidate(resourceID, tableName)
+	return true
+}
+
+// Refresh fetches the metadata of all cached tables again
+func (cache *TableMetaCache) Refresh(ctx context.Context) {
+	cache.tableMetas.Range(func(key, value interface{}) bool {
+		resourceID := key.(tableKey).resourceID
+		db, ok := cache.resources.Load(resourceID)
+		if !ok {
+			return true
+		}
+		meta := value.(*TableMeta)
+		fresh, err := cache.fetcher.fetchTableMeta(ctx, db.(*sql.DB), meta.TableName)
+		if err != nil {
+			log.Errorf("failed to refresh table meta of %s, resourceID: %s, err: %v", meta.TableName, resourceID, err)
+			return true
+		}
+		if len(fresh.Columns) == 0 {
+			cache.tableMetas.Delete(key)
+			return true
+		}
+		cache.tableMetas.Store(key, fresh)
+		return true
+	})
+}
+
+// Close stops refreshing the cache
+func (cache *TableMetaCache) Close() {
+	cache.stopOnce.Do(func() {
+		close(cache.stop)
+	})
+}
+
+func (cache *TableMetaCache) refreshLoop() {
+	ticker := time.NewTicker(cache.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			cache.Refresh(context.Background())
+		case <-cache.stop:
+			return
+		}
+	}
+}
+
+type tableKey struct {
+	resourceID string
+	tableName  string
+}
+
+func cacheKey(resourceID, tableName string) tableKey {
+	return tableKey{resourceID: resourceID, tableName: strings.ToUpper(tableName)}
+}
+
+// splitSchema return the schema and the table of a qualified table name
+func splitSchema(tableName string) (string, string) {
+	if i := strings.LastIndex(tableName, "."); i >= 0 {
+		return tableName[:i], tableName[i+1:]
+	}
+	return "", tableName
+}
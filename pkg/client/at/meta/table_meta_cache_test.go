@@ -0,0 +1,71 @@
+package meta
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	sql2 "github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+type countingFetcher struct {
+	mysqlFetcher
+	fetched int
+}
+
+func (f *countingFetcher) fetchTableMeta(_ context.Context, _ *sql.DB, tableName string) (*TableMeta, error) {
+	f.fetched++
+	meta := newTableMeta(tableName)
+	meta.addColumn(&ColumnMeta{ColumnName: "order_id", DataType: sql2.BIGINT})
+	meta.addColumn(&ColumnMeta{ColumnName: "line_no", DataType: sql2.INTEGER})
+	meta.addColumn(&ColumnMeta{ColumnName: "sku", DataType: sql2.VARCHAR})
+	meta.addIndexColumn("PRIMARY", IndexTypePrimary, "order_id")
+	meta.addIndexColumn("PRIMARY", IndexTypePrimary, "line_no")
+	meta.addIndexColumn("uk_sku", IndexTypeUnique, "sku")
+	return meta, nil
+}
+
+func TestTableMetaCache_GetTableMeta(t *testing.T) {
+	f := &countingFetcher{}
+	cache := &TableMetaCache{fetcher: f, stop: make(chan struct{})}
+
+	meta, err := cache.GetTableMeta(context.Background(), nil, "127.0.0.1:3306/db", "order_line")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"order_id", "line_no"}, meta.PrimaryKeys())
+	assert.True(t, meta.IsPrimaryKey("LINE_NO"))
+	assert.False(t, meta.IsPrimaryKey("sku"))
+	assert.Equal(t, int32(sql2.VARCHAR), meta.ColumnType("SKU"))
+	assert.Equal(t, 1, len(meta.UniqueIndexes()))
+
+	_, err = cache.GetTableMeta(context.Background(), nil, "127.0.0.1:3306/db", "ORDER_LINE")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, f.fetched)
+
+	assert.False(t, cache.InvalidateOnSchemaChange("127.0.0.1:3306/db", "order_line", errors.New("timeout")))
+	assert.True(t, cache.InvalidateOnSchemaChange("127.0.0.1:3306/db", "order_line",
+		errors.WithStack(&mysql.MySQLError{Number: erBadFieldError})))
+	_, err = cache.GetTableMeta(context.Background(), nil, "127.0.0.1:3306/db", "order_line")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, f.fetched)
+}
+
+func TestIsSchemaChangeError(t *testing.T) {
+	assert.True(t, mysqlFetcher{}.isSchemaChangeError(&mysql.MySQLError{Number: erNoSuchTable}))
+	assert.False(t, mysqlFetcher{}.isSchemaChangeError(&mysql.MySQLError{Number: 1062}))
+	assert.True(t, pgsqlFetcher{}.isSchemaChangeError(&pq.Error{Code: undefinedTable}))
+	assert.False(t, pgsqlFetcher{}.isSchemaChangeError(&pq.Error{Code: "23505"}))
+}
+
+func TestColumnType(t *testing.T) {
+	assert.Equal(t, int32(sql2.INTEGER), mysqlColumnType("int"))
+	assert.Equal(t, int32(sql2.TIMESTAMP), mysqlColumnType("datetime"))
+	assert.Equal(t, int32(sql2.VARCHAR), mysqlColumnType("varchar"))
+	assert.Equal(t, int32(sql2.BIGINT), pgsqlColumnType("int8"))
+	assert.Equal(t, int32(sql2.LONGVARBINARY), pgsqlColumnType("bytea"))
+	assert.Equal(t, int32(sql2.NUMERIC), pgsqlColumnType("numeric"))
+}
@@ -0,0 +1,235 @@
+package recognizer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+
+	// tokenIdent an unquoted identifier or keyword, folded to lower case in postgresql
+	tokenIdent
+
+	// tokenQuotedIdent an identifier quoted with backticks, or with double quotes in postgresql
+	tokenQuotedIdent
+
+	tokenString
+
+	tokenNumber
+
+	tokenPlaceholder
+
+	// tokenPunct one of ( ) , . ;
+	tokenPunct
+
+	tokenOperator
+)
+
+// token a lexical unit of the statement, pos and end are the offsets in the statement
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+	end   int
+
+	// ordinal the ordinal of the argument bound to a placeholder
+	ordinal int
+}
+
+// is return true if the token is the unquoted keyword or the punctuation
+func (t token) is(keywordOrPunct string) bool {
+	switch t.kind {
+	case tokenIdent:
+		return strings.EqualFold(t.value, keywordOrPunct)
+	case tokenPunct, tokenOperator:
+		return t.value == keywordOrPunct
+	default:
+		return false
+	}
+}
+
+func (t token) isIdent() bool {
+	return t.kind == tokenIdent || t.kind == tokenQuotedIdent
+}
+
+// tokenize splits the statement written in the dialect into tokens, comments are dropped. The
+// last token is always tokenEOF.
+func tokenize(sql string, dialect Dialect) ([]token, error) {
+	var (
+		tokens       = make([]token, 0)
+		i            = 0
+		placeholders = 0
+	)
+	for i < len(sql) {
+		c := sql[i]
+		switch {
+		case isSpace(c):
+			i++
+		case (c == '#' && dialect == MySQL) || (c == '-' && strings.HasPrefix(sql[i:], "--")):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 1
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.Errorf("unterminated comment at %d", i)
+			}
+			i += end + 4
+		case c == '\'' || (c == '"' && dialect == MySQL):
+			backslash := dialect == MySQL
+			end, err := scanQuoted(sql, i, c, backslash)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: unquoteString(sql[i:end], backslash), pos: i, end: end})
+			i = end
+		case c == '`' || c == '"':
+			end, err := scanQuoted(sql, i, c, false)
+			if err != nil {
+				return nil, err
+			}
+			value := strings.ReplaceAll(sql[i+1:end-1], string([]byte{c, c}), string(c))
+			tokens = append(tokens, token{kind: tokenQuotedIdent, value: value, pos: i, end: end})
+			i = end
+		case c == '$' && dialect == PostgreSQL && i+1 < len(sql) && isDigit(sql[i+1]):
+			end := i + 1
+			for end < len(sql) && isDigit(sql[end]) {
+				end++
+			}
+			ordinal, err := strconv.Atoi(sql[i+1 : end])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenPlaceholder, value: sql[i:end], pos: i, end: end, ordinal: ordinal})
+			i = end
+		case isDigit(c) || (c == '.' && i+1 < len(sql) && isDigit(sql[i+1])):
+			end := scanNumber(sql, i)
+			tokens = append(tokens, token{kind: tokenNumber, value: sql[i:end], pos: i, end: end})
+			i = end
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(sql) && isIdentPart(sql[end]) {
+				end++
+			}
+			value := sql[i:end]
+			if dialect == PostgreSQL {
+				value = strings.ToLower(value)
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: value, pos: i, end: end})
+			i = end
+		case c == '?' && dialect == MySQL:
+			placeholders++
+			tokens = append(tokens, token{kind: tokenPlaceholder, value: "?", pos: i, end: i + 1, ordinal: placeholders})
+			i++
+		case strings.IndexByte("(),.;", c) >= 0:
+			tokens = append(tokens, token{kind: tokenPunct, value: string(c), pos: i, end: i + 1})
+			i++
+		default:
+			end := i + 1
+			for end < len(sql) && strings.IndexByte("<>=!|&", sql[end]) >= 0 && strings.IndexByte("<>=!|&", c) >= 0 {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: sql[i:end], pos: i, end: end})
+			i = end
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(sql), end: len(sql)})
+	return tokens, nil
+}
+
+// scanQuoted return the offset after the closing quote, a doubled quote is kept in the
+// literal; backslash escapes are honored in the strings of mysql.
+func scanQuoted(sql string, start int, quote byte, backslash bool) (int, error) {
+	for i := start + 1; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1, nil
+		}
+	}
+	return 0, errors.Errorf("unterminated quoted literal at %d", start)
+}
+
+func unquoteString(literal string, backslash bool) string {
+	quote := literal[0]
+	body := literal[1 : len(literal)-1]
+	var sb strings.Builder
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && backslash && i+1 < len(body):
+			i++
+			switch body[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '0':
+				sb.WriteByte(0)
+			case 'Z':
+				sb.WriteByte(26)
+			default:
+				sb.WriteByte(body[i])
+			}
+		case c == quote && i+1 < len(body) && body[i+1] == quote:
+			sb.WriteByte(quote)
+			i++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+func scanNumber(sql string, start int) int {
+	i := start
+	for i < len(sql) && (isDigit(sql[i]) || sql[i] == '.') {
+		i++
+	}
+	if i < len(sql) && (sql[i] == 'e' || sql[i] == 'E') {
+		j := i + 1
+		if j < len(sql) && (sql[j] == '+' || sql[j] == '-') {
+			j++
+		}
+		if j < len(sql) && isDigit(sql[j]) {
+			i = j
+			for i < len(sql) && isDigit(sql[i]) {
+				i++
+			}
+		}
+	}
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || c == '@' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
@@ -0,0 +1,577 @@
+package recognizer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type parser struct {
+	sql    string
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokenEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the keyword or punctuation
+func (p *parser) accept(keywordOrPunct string) bool {
+	if p.peek().is(keywordOrPunct) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(keywordOrPunct string) error {
+	if !p.accept(keywordOrPunct) {
+		return p.unexpected(keywordOrPunct)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return errors.Errorf("expect %s but got end of statement", expected)
+	}
+	return errors.Errorf("expect %s but got %q at %d", expected, p.sql[t.pos:t.end], t.pos)
+}
+
+// atEnd return true if only a trailing semicolon is left
+func (p *parser) atEnd() bool {
+	p.accept(";")
+	return p.peek().kind == tokenEOF
+}
+
+func (p *parser) parse() (Recognizer, error) {
+	t := p.peek()
+	switch {
+	case t.is("INSERT"):
+		return p.parseInsert()
+	case t.is("UPDATE"):
+		return p.parseUpdate()
+	case t.is("DELETE"):
+		return p.parseDelete()
+	case t.is("SELECT"):
+		return p.parseSelectForUpdate()
+	default:
+		return nil, errors.Errorf("unsupported statement %q", t.value)
+	}
+}
+
+func (p *parser) parseInsert() (Recognizer, error) {
+	p.next()
+	p.skipModifiers("LOW_PRIORITY", "DELAYED", "HIGH_PRIORITY", "IGNORE")
+	p.accept("INTO")
+
+	r := &InsertRecognizer{baseRecognizer: baseRecognizer{sql: p.sql}}
+	tableName, err := p.parseTableName()
+	if err != nil {
+		return nil, err
+	}
+	r.tableName = tableName
+	if err = p.skipPartition(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.peek().is("SET"):
+		p.next()
+		assignments, err := p.parseAssignments()
+		if err != nil {
+			return nil, err
+		}
+		row := make([]Expression, 0, len(assignments))
+		for _, assignment := range assignments {
+			r.Columns = append(r.Columns, assignment.Column)
+			row = append(row, assignment.Value)
+		}
+		r.Rows = [][]Expression{row}
+	default:
+		if p.accept("(") {
+			if r.Columns, err = p.parseColumnList(); err != nil {
+				return nil, err
+			}
+		}
+		if !p.accept("VALUES") && !p.accept("VALUE") {
+			return nil, p.unexpected("VALUES")
+		}
+		for {
+			if err = p.expect("("); err != nil {
+				return nil, err
+			}
+			row, err := p.parseExpressionList()
+			if err != nil {
+				return nil, err
+			}
+			if len(r.Columns) > 0 && len(row) != len(r.Columns) {
+				return nil, errors.Errorf("column count %d doesn't match value count %d", len(r.Columns), len(row))
+			}
+			r.Rows = append(r.Rows, row)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	// row alias of mysql 8: VALUES (...) AS new
+	if p.accept("AS") {
+		if !p.next().isIdent() {
+			return nil, errors.New("expect row alias")
+		}
+		if p.accept("(") {
+			if _, err = p.parseColumnList(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if p.peek().is("ON") {
+		p.next()
+		for _, keyword := range []string{"DUPLICATE", "KEY", "UPDATE"} {
+			if err = p.expect(keyword); err != nil {
+				return nil, err
+			}
+		}
+		if r.OnDuplicateKeyUpdate, err = p.parseAssignments(); err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.atEnd() {
+		return nil, p.unexpected("end of statement")
+	}
+	return r, nil
+}
+
+func (p *parser) parseUpdate() (Recognizer, error) {
+	p.next()
+	p.skipModifiers("LOW_PRIORITY", "IGNORE")
+
+	r := &UpdateRecognizer{baseRecognizer: baseRecognizer{sql: p.sql}}
+	if err := p.parseTableReference(&r.baseRecognizer); err != nil {
+		return nil, err
+	}
+	if err := p.expect("SET"); err != nil {
+		return nil, err
+	}
+	assignments, err := p.parseAssignments()
+	if err != nil {
+		return nil, err
+	}
+	r.Assignments = assignments
+
+	if r.Condition, err = p.parseCondition(); err != nil {
+		return nil, err
+	}
+	if !p.atEnd() {
+		return nil, p.unexpected("end of statement")
+	}
+	return r, nil
+}
+
+func (p *parser) parseDelete() (Recognizer, error) {
+	p.next()
+	p.skipModifiers("LOW_PRIORITY", "QUICK", "IGNORE")
+	if err := p.expect("FROM"); err != nil {
+		return nil, err
+	}
+
+	r := &DeleteRecognizer{baseRecognizer: baseRecognizer{sql: p.sql}}
+	if err := p.parseTableReference(&r.baseRecognizer); err != nil {
+		return nil, err
+	}
+	if p.peek().is("USING") {
+		return nil, errors.New("multiple table delete is not supported")
+	}
+
+	var err error
+	if r.Condition, err = p.parseCondition(); err != nil {
+		return nil, err
+	}
+	if !p.atEnd() {
+		return nil, p.unexpected("end of statement")
+	}
+	return r, nil
+}
+
+func (p *parser) parseSelectForUpdate() (Recognizer, error) {
+	p.next()
+	p.skipModifiers("ALL", "DISTINCT", "DISTINCTROW", "HIGH_PRIORITY", "STRAIGHT_JOIN", "SQL_NO_CACHE")
+
+	r := &SelectForUpdateRecognizer{baseRecognizer: baseRecognizer{sql: p.sql}}
+	for {
+		column, err := p.parseExpression("FROM")
+		if err != nil {
+			return nil, err
+		}
+		r.Columns = append(r.Columns, column)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err := p.expect("FROM"); err != nil {
+		return nil, err
+	}
+	if err := p.parseTableReference(&r.baseRecognizer); err != nil {
+		return nil, err
+	}
+
+	where, err := p.parseClause("WHERE", false)
+	if err != nil {
+		return nil, err
+	}
+	r.Where = where
+	if p.peek().is("GROUP") || p.peek().is("HAVING") {
+		return nil, errors.New("select for update with group by is not supported")
+	}
+	if r.OrderBy, err = p.parseClause("ORDER", true); err != nil {
+		return nil, err
+	}
+	if r.Limit, err = p.parseClause("LIMIT", true); err != nil {
+		return nil, err
+	}
+
+	if !p.accept("FOR") {
+		return nil, errors.New("select without for update")
+	}
+	if err = p.expect("UPDATE"); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.accept("NOWAIT"):
+	case p.accept("SKIP"):
+		if err = p.expect("LOCKED"); err != nil {
+			return nil, err
+		}
+	}
+	if !p.atEnd() {
+		return nil, p.unexpected("end of statement")
+	}
+	return r, nil
+}
+
+// parseTableReference parses a single table with an optional alias, joins are rejected.
+func (p *parser) parseTableReference(r *baseRecognizer) error {
+	tableName, err := p.parseTableName()
+	if err != nil {
+		return err
+	}
+	r.tableName = tableName
+	if err = p.skipPartition(); err != nil {
+		return err
+	}
+
+	if p.accept("AS") {
+		if !p.peek().isIdent() {
+			return p.unexpected("table alias")
+		}
+		r.tableAlias = p.next().value
+	} else if t := p.peek(); t.kind == tokenQuotedIdent || (t.kind == tokenIdent && !isReserved(t.value)) {
+		r.tableAlias = p.next().value
+	}
+
+	if t := p.peek(); t.is(",") || t.is("JOIN") || t.is("INNER") || t.is("LEFT") || t.is("RIGHT") ||
+		t.is("CROSS") || t.is("STRAIGHT_JOIN") || t.is("NATURAL") {
+		return errors.New("multiple table statement is not supported")
+	}
+	return nil
+}
+
+// parseTableName return the unquoted table name, with the schema if qualified
+func (p *parser) parseTableName() (string, error) {
+	t := p.peek()
+	if !t.isIdent() || (t.kind == tokenIdent && isReserved(t.value)) {
+		return "", p.unexpected("table name")
+	}
+	p.next()
+	name := t.value
+	if p.peek().is(".") {
+		p.next()
+		t = p.next()
+		if !t.isIdent() {
+			return "", errors.New("expect table name after schema")
+		}
+		name = name + "." + t.value
+	}
+	return name, nil
+}
+
+func (p *parser) skipPartition() error {
+	if !p.peek().is("PARTITION") {
+		return nil
+	}
+	p.next()
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	_, err := p.parseExpressionList()
+	return err
+}
+
+func (p *parser) skipModifiers(modifiers ...string) {
+	for {
+		accepted := false
+		for _, modifier := range modifiers {
+			if p.accept(modifier) {
+				accepted = true
+			}
+		}
+		if !accepted {
+			return
+		}
+	}
+}
+
+// parseColumnList parses `a, b, c)`, the opening parenthesis has been consumed
+func (p *parser) parseColumnList() ([]string, error) {
+	columns := make([]string, 0)
+	for {
+		column, err := p.parseColumnName()
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+		if p.accept(")") {
+			return columns, nil
+		}
+		if err = p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseColumnName return the column name without the table qualifier and quotes
+func (p *parser) parseColumnName() (string, error) {
+	t := p.next()
+	if !t.isIdent() {
+		p.i--
+		return "", p.unexpected("column name")
+	}
+	name := t.value
+	for p.peek().is(".") {
+		p.next()
+		t = p.next()
+		if !t.isIdent() {
+			return "", errors.New("expect column name")
+		}
+		name = t.value
+	}
+	return name, nil
+}
+
+func (p *parser) parseAssignments() ([]Assignment, error) {
+	assignments := make([]Assignment, 0)
+	for {
+		column, err := p.parseColumnName()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect("="); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpression("WHERE", "ORDER", "LIMIT", "ON", "AS", "RETURNING")
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, Assignment{Column: column, Value: value})
+		if !p.accept(",") {
+			return assignments, nil
+		}
+	}
+}
+
+// parseExpressionList parses `expr, expr)`, the opening parenthesis has been consumed
+func (p *parser) parseExpressionList() ([]Expression, error) {
+	expressions := make([]Expression, 0)
+	for {
+		expression, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, expression)
+		if p.accept(")") {
+			return expressions, nil
+		}
+		if err = p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseExpression consumes tokens until a top level comma, closing parenthesis, semicolon
+// or one of the stop keywords.
+func (p *parser) parseExpression(stopKeywords ...string) (Expression, error) {
+	start := p.i
+	depth := 0
+loop:
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			break loop
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			if depth == 0 {
+				break loop
+			}
+			depth--
+		case depth == 0 && (t.is(",") || t.is(";")):
+			break loop
+		case depth == 0 && isOneOf(t, stopKeywords):
+			break loop
+		}
+		p.next()
+	}
+	if depth != 0 {
+		return Expression{}, errors.New("unbalanced parenthesis")
+	}
+	if p.i == start {
+		return Expression{}, p.unexpected("expression")
+	}
+	return p.expression(start, p.i), nil
+}
+
+// parseCondition parses the WHERE, ORDER BY and LIMIT clauses
+func (p *parser) parseCondition() (Condition, error) {
+	var (
+		condition Condition
+		err       error
+	)
+	if condition.Where, err = p.parseClause("WHERE", false); err != nil {
+		return condition, err
+	}
+	if condition.OrderBy, err = p.parseClause("ORDER", true); err != nil {
+		return condition, err
+	}
+	if condition.Limit, err = p.parseClause("LIMIT", true); err != nil {
+		return condition, err
+	}
+	return condition, nil
+}
+
+// parseClause parses the clause starting with the keyword until the next clause, the keyword
+// is kept in the expression if withKeyword is true.
+func (p *parser) parseClause(keyword string, withKeyword bool) (Expression, error) {
+	if !p.peek().is(keyword) {
+		return Expression{}, nil
+	}
+	start := p.i
+	p.next()
+	if keyword == "ORDER" {
+		if err := p.expect("BY"); err != nil {
+			return Expression{}, err
+		}
+	}
+	if !withKeyword {
+		start = p.i
+	}
+
+	depth := 0
+	for {
+		t := p.peek()
+		if t.kind == tokenEOF || (depth == 0 && t.is(";")) {
+			break
+		}
+		if depth == 0 && (t.is("GROUP") || t.is("HAVING") || t.is("ORDER") || t.is("LIMIT") || t.is("FOR") ||
+			t.is("LOCK") || t.is("RETURNING")) && !t.is(keyword) {
+			break
+		}
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+			if depth < 0 {
+				return Expression{}, p.unexpected("end of clause")
+			}
+		}
+		p.next()
+	}
+	if p.i == start || (withKeyword && p.i == start+1) {
+		return Expression{}, p.unexpected(keyword + " clause")
+	}
+	return p.expression(start, p.i), nil
+}
+
+// expression build the expression of the tokens in [start, end)
+func (p *parser) expression(start, end int) Expression {
+	tokens := p.tokens[start:end]
+	var (
+		e    Expression
+		text strings.Builder
+		pos  = tokens[0].pos
+	)
+	for _, t := range tokens {
+		if t.kind == tokenPlaceholder {
+			e.Placeholders++
+			e.Params = append(e.Params, t.ordinal)
+			text.WriteString(p.sql[pos:t.pos])
+			text.WriteString("?")
+			pos = t.end
+		}
+	}
+	text.WriteString(p.sql[pos:tokens[len(tokens)-1].end])
+	e.Text = strings.TrimSpace(text.String())
+
+	negative := len(tokens) == 2 && tokens[0].is("-") && tokens[1].kind == tokenNumber
+	if negative {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 1 {
+		t := tokens[0]
+		switch {
+		case t.kind == tokenString:
+			e.literal, e.isLiteral = t.value, true
+		case t.kind == tokenNumber:
+			if i, err := strconv.ParseInt(t.value, 10, 64); err == nil {
+				if negative {
+					i = -i
+				}
+				e.literal, e.isLiteral = i, true
+			} else if f, err := strconv.ParseFloat(t.value, 64); err == nil {
+				if negative {
+					f = -f
+				}
+				e.literal, e.isLiteral = f, true
+			}
+		case t.is("NULL"):
+			e.literal, e.isLiteral = nil, true
+		}
+	}
+	return e
+}
+
+func isOneOf(t token, keywords []string) bool {
+	for _, keyword := range keywords {
+		if t.is(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// reservedWords can not be used as an unquoted table alias
+var reservedWords = map[string]bool{
+	"SET": true, "WHERE": true, "ORDER": true, "LIMIT": true, "FOR": true, "LOCK": true, "GROUP": true,
+	"HAVING": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "CROSS": true, "NATURAL": true,
+	"STRAIGHT_JOIN": true, "USING": true, "ON": true, "VALUES": true, "VALUE": true, "SELECT": true,
+	"PARTITION": true, "AS": true, "FROM": true, "UNION": true,
+}
+
+func isReserved(word string) bool {
+	return reservedWords[strings.ToUpper(word)]
+}
@@ -0,0 +1,296 @@
+package recognizer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SQLType the type of a recognized statement
+type SQLType byte
+
+const (
+	SQLTypeInsert SQLType = iota
+
+	SQLTypeUpdate
+
+	SQLTypeDelete
+
+	SQLTypeSelectForUpdate
+)
+
+// String
+func (t SQLType) String() string {
+	switch t {
+	case SQLTypeInsert:
+		return "INSERT"
+	case SQLTypeUpdate:
+		return "UPDATE"
+	case SQLTypeDelete:
+		return "DELETE"
+	case SQLTypeSelectForUpdate:
+		return "SELECT_FOR_UPDATE"
+	default:
+		return strconv.Itoa(int(t))
+	}
+}
+
+// ErrUnsupportedSQL the statement is not a single table INSERT, UPDATE, DELETE or
+// SELECT ... FOR UPDATE.
+var ErrUnsupportedSQL = errors.New("unsupported sql")
+
+// Recognizer the structured form of a statement
+type Recognizer interface {
+	// SQLType return the type of the statement
+	SQLType() SQLType
+
+	// TableName return the table name without quotes, qualified with the schema if the
+	// statement does
+	TableName() string
+
+	// TableAlias return the alias of the table, empty if there is none
+	TableAlias() string
+
+	// OriginalSQL return the statement
+	OriginalSQL() string
+}
+
+// Dialect the sql dialect a statement is written in
+type Dialect byte
+
+const (
+	// MySQL `?` placeholders, identifiers quoted with backticks and strings with single or double
+	// quotes and backslash escapes
+	MySQL Dialect = iota
+
+	// PostgreSQL `$n` placeholders, identifiers quoted with double quotes and strings with single
+	// quotes, unquoted identifiers are folded to lower case
+	PostgreSQL
+)
+
+// Expression an expression of the statement
+type Expression struct {
+	// Text the expression as it is written in the statement, with its placeholders written as `?`
+	Text string
+
+	// Placeholders the number of placeholders in the expression
+	Placeholders int
+
+	// Params the ordinals of the arguments bound to the placeholders, in the order they appear
+	Params []int
+
+	literal   interface{}
+	isLiteral bool
+}
+
+// IsPlaceholder return true if the expression is a single `?`
+func (e Expression) IsPlaceholder() bool {
+	return e.Text == "?"
+}
+
+// Literal return the value of a string, number or NULL literal
+func (e Expression) Literal() (interface{}, bool) {
+	return e.literal, e.isLiteral
+}
+
+// Assignment a `column = expression` of SET or ON DUPLICATE KEY UPDATE
+type Assignment struct {
+	Column string
+	Value  Expression
+}
+
+// Condition the WHERE, ORDER BY and LIMIT clauses of a statement
+type Condition struct {
+	// Where the where condition without the WHERE keyword, empty if there is none
+	Where Expression
+
+	// OrderBy the order by clause with the ORDER BY keyword, empty if there is none
+	OrderBy Expression
+
+	// Limit the limit clause with the LIMIT keyword, empty if there is none
+	Limit Expression
+}
+
+// Placeholders return the number of `?` in the clauses
+func (c Condition) Placeholders() int {
+	return c.Where.Placeholders + c.OrderBy.Placeholders + c.Limit.Placeholders
+}
+
+// Params return the ordinals of the arguments bound to the placeholders of the clauses, in the
+// order of String.
+func (c Condition) Params() []int {
+	params := make([]int, 0, c.Placeholders())
+	params = append(params, c.Where.Params...)
+	params = append(params, c.OrderBy.Params...)
+	return append(params, c.Limit.Params...)
+}
+
+// String return the clauses which select the rows, eg: `WHERE id = ? ORDER BY id LIMIT 1`
+func (c Condition) String() string {
+	parts := make([]string, 0, 3)
+	if c.Where.Text != "" {
+		parts = append(parts, "WHERE "+c.Where.Text)
+	}
+	if c.OrderBy.Text != "" {
+		parts = append(parts, c.OrderBy.Text)
+	}
+	if c.Limit.Text != "" {
+		parts = append(parts, c.Limit.Text)
+	}
+	return strings.Join(parts, " ")
+}
+
+type baseRecognizer struct {
+	sql        string
+	tableName  string
+	tableAlias string
+}
+
+func (r *baseRecognizer) TableName() string {
+	return r.tableName
+}
+
+func (r *baseRecognizer) TableAlias() string {
+	return r.tableAlias
+}
+
+func (r *baseRecognizer) OriginalSQL() string {
+	return r.sql
+}
+
+// InsertRecognizer INSERT [IGNORE] [INTO] tbl [(columns)] VALUES (...), (...) [ON DUPLICATE KEY UPDATE ...],
+// INSERT ... SET is recognized as an insert of one row.
+type InsertRecognizer struct {
+	baseRecognizer
+
+	// Columns the insert columns, empty if the statement omits them
+	Columns []string
+
+	// Rows the values of each row
+	Rows [][]Expression
+
+	// OnDuplicateKeyUpdate the assignments of ON DUPLICATE KEY UPDATE
+	OnDuplicateKeyUpdate []Assignment
+}
+
+func (r *InsertRecognizer) SQLType() SQLType {
+	return SQLTypeInsert
+}
+
+// UpdateRecognizer UPDATE tbl [alias] SET ... [WHERE ...] [ORDER BY ...] [LIMIT ...]
+type UpdateRecognizer struct {
+	baseRecognizer
+	Condition
+
+	Assignments []Assignment
+}
+
+func (r *UpdateRecognizer) SQLType() SQLType {
+	return SQLTypeUpdate
+}
+
+// UpdateColumns return the updated columns
+func (r *UpdateRecognizer) UpdateColumns() []string {
+	columns := make([]string, 0, len(r.Assignments))
+	for _, assignment := range r.Assignments {
+		columns = append(columns, assignment.Column)
+	}
+	return columns
+}
+
+// PlaceholdersBeforeCondition return the number of `?` in the SET clause, the arguments of the
+// condition start after them.
+func (r *UpdateRecognizer) PlaceholdersBeforeCondition() int {
+	n := 0
+	for _, assignment := range r.Assignments {
+		n += assignment.Value.Placeholders
+	}
+	return n
+}
+
+// DeleteRecognizer DELETE FROM tbl [alias] [WHERE ...] [ORDER BY ...] [LIMIT ...]
+type DeleteRecognizer struct {
+	baseRecognizer
+	Condition
+}
+
+func (r *DeleteRecognizer) SQLType() SQLType {
+	return SQLTypeDelete
+}
+
+// PlaceholdersBeforeCondition always return 0
+func (r *DeleteRecognizer) PlaceholdersBeforeCondition() int {
+	return 0
+}
+
+// SelectForUpdateRecognizer SELECT columns FROM tbl [alias] [WHERE ...] [ORDER BY ...] [LIMIT ...] FOR UPDATE
+type SelectForUpdateRecognizer struct {
+	baseRecognizer
+	Condition
+
+	Columns []Expression
+}
+
+func (r *SelectForUpdateRecognizer) SQLType() SQLType {
+	return SQLTypeSelectForUpdate
+}
+
+// PlaceholdersBeforeCondition return the number of `?` in the select columns
+func (r *SelectForUpdateRecognizer) PlaceholdersBeforeCondition() int {
+	n := 0
+	for _, column := range r.Columns {
+		n += column.Placeholders
+	}
+	return n
+}
+
+// Recognize parses the mysql statement, ErrUnsupportedSQL is returned for statements which are
+// not recognized.
+func Recognize(sql string) (Recognizer, error) {
+	return RecognizeDialect(sql, MySQL)
+}
+
+// RecognizeDialect parses the statement written in the dialect, ErrUnsupportedSQL is returned for
+// statements which are not recognized.
+func RecognizeDialect(sql string, dialect Dialect) (Recognizer, error) {
+	tokens, err := tokenize(sql, dialect)
+	if err != nil {
+		return nil, errors.Wrapf(ErrUnsupportedSQL, "%v: %s", err, sql)
+	}
+	p := &parser{sql: sql, tokens: tokens}
+	r, err := p.parse()
+	if err != nil {
+		return nil, errors.Wrapf(ErrUnsupportedSQL, "%v: %s", err, sql)
+	}
+	return r, nil
+}
+
+// IsDML reports whether the statement written in the dialect changes the rows of a table: it starts
+// with INSERT, UPDATE, DELETE, REPLACE or MERGE, or it is a WITH statement changing rows in its main
+// statement or in a common table expression. It tells the statements which should not run past the
+// TC when they are not recognized.
+func IsDML(sql string, dialect Dialect) bool {
+	tokens, err := tokenize(sql, dialect)
+	if err != nil {
+		fields := strings.Fields(sql)
+		return len(fields) > 0 && isDMLKeyword(token{kind: tokenIdent, value: fields[0]})
+	}
+	if isDMLKeyword(tokens[0]) {
+		return true
+	}
+	if !tokens[0].is("WITH") {
+		return false
+	}
+	for i := 1; i < len(tokens); i++ {
+		// FOR [NO KEY] UPDATE locks rows, ON DUPLICATE KEY UPDATE and ON CONFLICT DO UPDATE belong to an insert
+		if isDMLKeyword(tokens[i]) && !tokens[i-1].is("FOR") && !tokens[i-1].is("KEY") && !tokens[i-1].is("DO") {
+			return true
+		}
+	}
+	return false
+}
+
+func isDMLKeyword(t token) bool {
+	return t.is("INSERT") || t.is("UPDATE") || t.is("DELETE") || t.is("REPLACE") || t.is("MERGE")
+}
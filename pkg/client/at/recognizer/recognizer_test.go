@@ -0,0 +1,150 @@
+package recognizer
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecognize_Insert(t *testing.T) {
+	r, err := Recognize("INSERT INTO `db`.`order` (`id`, name, amount) VALUES (?, 'a''b', -1), (?, ?, now()) " +
+		"ON DUPLICATE KEY UPDATE name = VALUES(name), amount = amount + ?")
+	assert.Nil(t, err)
+	insert := r.(*InsertRecognizer)
+	assert.Equal(t, SQLTypeInsert, insert.SQLType())
+	assert.Equal(t, "db.order", insert.TableName())
+	assert.Equal(t, []string{"id", "name", "amount"}, insert.Columns)
+	assert.Equal(t, 2, len(insert.Rows))
+	assert.True(t, insert.Rows[0][0].IsPlaceholder())
+	value, ok := insert.Rows[0][1].Literal()
+	assert.True(t, ok)
+	assert.Equal(t, "a'b", value)
+	value, ok = insert.Rows[0][2].Literal()
+	assert.True(t, ok)
+	assert.Equal(t, int64(-1), value)
+	assert.Equal(t, "now()", insert.Rows[1][2].Text)
+	assert.Equal(t, 2, len(insert.OnDuplicateKeyUpdate))
+	assert.Equal(t, "amount", insert.OnDuplicateKeyUpdate[1].Column)
+	assert.Equal(t, 1, insert.OnDuplicateKeyUpdate[1].Value.Placeholders)
+
+	r, err = Recognize("insert into t set id = ?, name = 'x'")
+	assert.Nil(t, err)
+	insert = r.(*InsertRecognizer)
+	assert.Equal(t, []string{"id", "name"}, insert.Columns)
+	assert.Equal(t, 1, len(insert.Rows))
+}
+
+func TestRecognize_Update(t *testing.T) {
+	r, err := Recognize("UPDATE /* hint */ account a SET a.balance = a.balance - ?, `status` = 'x;y' " +
+		"WHERE a.id IN (?, ?) AND name LIKE '%?%' ORDER BY a.id LIMIT ?;")
+	assert.Nil(t, err)
+	update := r.(*UpdateRecognizer)
+	assert.Equal(t, "account", update.TableName())
+	assert.Equal(t, "a", update.TableAlias())
+	assert.Equal(t, []string{"balance", "status"}, update.UpdateColumns())
+	assert.Equal(t, 1, update.PlaceholdersBeforeCondition())
+	assert.Equal(t, "a.id IN (?, ?) AND name LIKE '%?%'", update.Where.Text)
+	assert.Equal(t, 2, update.Where.Placeholders)
+	assert.Equal(t, "ORDER BY a.id", update.OrderBy.Text)
+	assert.Equal(t, "LIMIT ?", update.Limit.Text)
+	assert.Equal(t, 3, update.Placeholders())
+	assert.Equal(t, []int{2, 3, 4}, update.Params())
+	assert.Equal(t, "WHERE a.id IN (?, ?) AND name LIKE '%?%' ORDER BY a.id LIMIT ?", update.Condition.String())
+}
+
+func TestRecognize_Delete(t *testing.T) {
+	r, err := Recognize("delete from t where id = ? -- comment")
+	assert.Nil(t, err)
+	del := r.(*DeleteRecognizer)
+	assert.Equal(t, SQLTypeDelete, del.SQLType())
+	assert.Equal(t, "t", del.TableName())
+	assert.Equal(t, "", del.TableAlias())
+	assert.Equal(t, "id = ?", del.Where.Text)
+
+	r, err = Recognize("DELETE FROM t")
+	assert.Nil(t, err)
+	assert.Equal(t, "", r.(*DeleteRecognizer).Condition.String())
+}
+
+func TestRecognize_SelectForUpdate(t *testing.T) {
+	r, err := Recognize("SELECT id, name FROM t AS x WHERE x.id = ? FOR UPDATE")
+	assert.Nil(t, err)
+	sel := r.(*SelectForUpdateRecognizer)
+	assert.Equal(t, SQLTypeSelectForUpdate, sel.SQLType())
+	assert.Equal(t, "x", sel.TableAlias())
+	assert.Equal(t, 2, len(sel.Columns))
+	assert.Equal(t, "x.id = ?", sel.Where.Text)
+}
+
+func TestRecognizeDialect_PostgreSQL(t *testing.T) {
+	r, err := RecognizeDialect(`UPDATE public."Order" o SET amount = o.amount - $3, note = 'it''s \' `+
+		`WHERE o.id = $1 AND o."Status" = $2 AND o.tags ? 'x'`, PostgreSQL)
+	assert.Nil(t, err)
+	update := r.(*UpdateRecognizer)
+	assert.Equal(t, "public.Order", update.TableName())
+	assert.Equal(t, "o", update.TableAlias())
+	assert.Equal(t, []string{"amount", "note"}, update.UpdateColumns())
+	assert.Equal(t, []int{3}, update.Assignments[0].Value.Params)
+	value, ok := update.Assignments[1].Value.Literal()
+	assert.True(t, ok)
+	assert.Equal(t, `it's \`, value)
+	assert.Equal(t, `o.id = ? AND o."Status" = ? AND o.tags ? 'x'`, update.Where.Text)
+	assert.Equal(t, []int{1, 2}, update.Params())
+
+	r, err = RecognizeDialect(`INSERT INTO Product (ID, name) VALUES ($2, $1)`, PostgreSQL)
+	assert.Nil(t, err)
+	insert := r.(*InsertRecognizer)
+	assert.Equal(t, "product", insert.TableName())
+	assert.Equal(t, []string{"id", "name"}, insert.Columns)
+	assert.True(t, insert.Rows[0][0].IsPlaceholder())
+	assert.Equal(t, []int{2}, insert.Rows[0][0].Params)
+
+	for _, sql := range []string{
+		`DELETE FROM t WHERE id = $1 RETURNING id`,
+		`UPDATE t SET name = $1 RETURNING id`,
+		`INSERT INTO t (id) VALUES ($1) ON CONFLICT DO NOTHING`,
+	} {
+		_, err = RecognizeDialect(sql, PostgreSQL)
+		assert.True(t, errors.Is(err, ErrUnsupportedSQL), sql)
+	}
+}
+
+func TestRecognize_Unsupported(t *testing.T) {
+	for _, sql := range []string{
+		"SELECT * FROM t WHERE id = ?",
+		"UPDATE a, b SET a.x = b.x",
+		"DELETE t1 FROM t1 JOIN t2 ON t1.id = t2.id",
+		"INSERT INTO t SELECT * FROM s",
+		"CREATE TABLE t (id int)",
+		"UPDATE t SET name = 'unterminated",
+	} {
+		_, err := Recognize(sql)
+		assert.True(t, errors.Is(err, ErrUnsupportedSQL), sql)
+	}
+}
+
+func TestIsDML(t *testing.T) {
+	for _, sql := range []string{
+		"INSERT INTO t SELECT * FROM s",
+		"REPLACE INTO t (id) VALUES (?)",
+		"UPDATE a JOIN b ON a.id = b.id SET a.x = b.x",
+		"UPDATE a, b SET a.x = b.x",
+		"DELETE a FROM a JOIN b ON a.id = b.id",
+		"WITH s AS (SELECT id FROM b) UPDATE a SET x = 1 WHERE id IN (SELECT id FROM s)",
+		"update t set name = 'unterminated",
+	} {
+		assert.True(t, IsDML(sql, MySQL), sql)
+	}
+	assert.True(t, IsDML("WITH s AS (DELETE FROM a RETURNING id) SELECT * FROM s", PostgreSQL))
+
+	for _, sql := range []string{
+		"SELECT * FROM t WHERE id = ?",
+		"CREATE TABLE t (id int)",
+		"WITH s AS (SELECT id FROM b) SELECT * FROM s FOR UPDATE",
+		"",
+	} {
+		assert.False(t, IsDML(sql, MySQL), sql)
+	}
+	assert.False(t, IsDML("WITH s AS (SELECT id FROM b) SELECT * FROM s FOR NO KEY UPDATE", PostgreSQL))
+}
@@ -0,0 +1,82 @@
+package at
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/meta"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/recognizer"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+	"github.com/opentrx/seata-golang/v2/pkg/client/config"
+)
+
+var (
+	tableMetaCachesMu sync.Mutex
+	tableMetaCaches   = make(map[string]*meta.TableMetaCache)
+)
+
+// DataSourceResource a database managed by the AT resource manager
+type DataSourceResource struct {
+	ResourceID string
+	DBType     string
+
+	// db connects to the database without the AT proxy, used in phase two
+	db *sql.DB
+
+	undoLogManager undo.UndoLogManager
+
+	dialect dialect
+}
+
+func (resource *DataSourceResource) GetResourceID() string {
+	return resource.ResourceID
+}
+
+func (resource *DataSourceResource) GetBranchType() apis.BranchSession_BranchType {
+	return apis.AT
+}
+
+// GetDB return the database without AT proxy
+func (resource *DataSourceResource) GetDB() *sql.DB {
+	return resource.db
+}
+
+// recognize parses the statement in the dialect of the database
+func (resource *DataSourceResource) recognize(query string) (recognizer.Recognizer, error) {
+	return recognizer.RecognizeDialect(query, resource.dialect.recognizer)
+}
+
+func (resource *DataSourceResource) getTableMeta(ctx context.Context, tableName string) (*meta.TableMeta, error) {
+	cache, err := getTableMetaCache(resource.DBType)
+	if err != nil {
+		return nil, err
+	}
+	return cache.GetTableMeta(ctx, resource.db, resource.ResourceID, tableName)
+}
+
+// invalidateTableMeta drops the cached table meta if the error is caused by a schema change
+func (resource *DataSourceResource) invalidateTableMeta(tableName string, err error) {
+	cache, cacheErr := getTableMetaCache(resource.DBType)
+	if cacheErr != nil {
+		return
+	}
+	cache.InvalidateOnSchemaChange(resource.ResourceID, tableName, err)
+}
+
+// getTableMetaCache return the table meta cache shared by the resources of the db type, it is
+// created at the first access since it depends on the client configuration.
+func getTableMetaCache(dbType string) (*meta.TableMetaCache, error) {
+	tableMetaCachesMu.Lock()
+	defer tableMetaCachesMu.Unlock()
+	if cache, ok := tableMetaCaches[dbType]; ok {
+		return cache, nil
+	}
+	cache, err := meta.NewTableMetaCache(dbType, config.GetATConfig().TableMetaRefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	tableMetaCaches[dbType] = cache
+	return cache, nil
+}
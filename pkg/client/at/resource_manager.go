@@ -0,0 +1,129 @@
+package at
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+	"github.com/opentrx/seata-golang/v2/pkg/client/base/model"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+var atResourceManager ATResourceManager
+
+// ATResourceManager drives phase two of AT branches
+type ATResourceManager struct {
+	ResourceCache *sync.Map // string -> *DataSourceResource
+	asyncWorker   *asyncWorker
+}
+
+func init() {
+	atResourceManager = ATResourceManager{ResourceCache: &sync.Map{}, asyncWorker: newAsyncWorker()}
+}
+
+func GetATResourceManager() ATResourceManager {
+	return atResourceManager
+}
+
+func (resourceManager ATResourceManager) BranchCommit(ctx context.Context, request *apis.BranchCommitRequest) (*apis.BranchCommitResponse, error) {
+	resource := resourceManager.getResource(request.ResourceID)
+	if resource == nil {
+		log.Errorf("AT resource is not exist, resourceID: %s", request.ResourceID)
+		return &apis.BranchCommitResponse{
+			ResultCode: apis.ResultCodeFailed,
+			Message:    fmt.Sprintf("AT resource is not exist, resourceID: %s", request.ResourceID),
+		}, nil
+	}
+
+	// the undo log is deleted asynchronously, a full queue makes the tc retry later
+	ok := resourceManager.asyncWorker.offer(&phaseTwoContext{
+		xid:        request.XID,
+		branchID:   request.BranchID,
+		resourceID: request.ResourceID,
+	})
+	if !ok {
+		log.Warnf("async commit queue is full, xid: %s, branchID: %d", request.XID, request.BranchID)
+		return &apis.BranchCommitResponse{
+			ResultCode:   apis.ResultCodeSuccess,
+			XID:          request.XID,
+			BranchID:     request.BranchID,
+			BranchStatus: apis.PhaseTwoCommitFailedRetryable,
+		}, nil
+	}
+	return &apis.BranchCommitResponse{
+		ResultCode:   apis.ResultCodeSuccess,
+		XID:          request.XID,
+		BranchID:     request.BranchID,
+		BranchStatus: apis.PhaseTwoCommitted,
+	}, nil
+}
+
+func (resourceManager ATResourceManager) BranchRollback(ctx context.Context, request *apis.BranchRollbackRequest) (*apis.BranchRollbackResponse, error) {
+	resource := resourceManager.getResource(request.ResourceID)
+	if resource == nil {
+		log.Errorf("AT resource is not exist, resourceID: %s", request.ResourceID)
+		return &apis.BranchRollbackResponse{
+			ResultCode: apis.ResultCodeFailed,
+			Message:    fmt.Sprintf("AT resource is not exist, resourceID: %s", request.ResourceID),
+		}, nil
+	}
+
+	err := resource.undoLogManager.Undo(ctx, resource.db, request.XID, request.BranchID)
+	if err != nil {
+		branchStatus := apis.PhaseTwoRollbackFailedRetryable
+		if errors.Is(err, undo.ErrDirtyWrite) {
+			// the rows modified out of the global transaction are never restored by a retry, they
+			// should be fixed by hand
+			branchStatus = apis.PhaseTwoRollbackFailedCanNotRetry
+		}
+		log.Errorf("failed to undo branch, xid: %s, branchID: %d, err: %v", request.XID, request.BranchID, err)
+		return &apis.BranchRollbackResponse{
+			ResultCode:   apis.ResultCodeSuccess,
+			XID:          request.XID,
+			BranchID:     request.BranchID,
+			BranchStatus: branchStatus,
+		}, nil
+	}
+	return &apis.BranchRollbackResponse{
+		ResultCode:   apis.ResultCodeSuccess,
+		XID:          request.XID,
+		BranchID:     request.BranchID,
+		BranchStatus: apis.PhaseTwoRolledBack,
+	}, nil
+}
+
+func (resourceManager ATResourceManager) RegisterResource(resource model.Resource) {
+	resourceManager.ResourceCache.Store(resource.GetResourceID(), resource)
+}
+
+func (resourceManager ATResourceManager) UnregisterResource(resource model.Resource) {
+	resourceManager.ResourceCache.Delete(resource.GetResourceID())
+}
+
+func (resourceManager ATResourceManager) GetBranchType() apis.BranchSession_BranchType {
+	return apis.AT
+}
+
+func (resourceManager ATResourceManager) getResource(resourceID string) *DataSourceResource {
+	resource, ok := resourceManager.ResourceCache.Load(resourceID)
+	if !ok {
+		return nil
+	}
+	return resource.(*DataSourceResource)
+}
+
+// loadOrRegisterResource return the registered resource with the same id, a database opened with
+// different credentials on the same schema shares the resource.
+func (resourceManager ATResourceManager) loadOrRegisterResource(resource *DataSourceResource) *DataSourceResource {
+	actual, loaded := resourceManager.ResourceCache.LoadOrStore(resource.GetResourceID(), resource)
+	if loaded {
+		if err := resource.db.Close(); err != nil {
+			log.Error(err)
+		}
+	}
+	return actual.(*DataSourceResource)
+}
@@ -0,0 +1,54 @@
+package at
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+)
+
+// fakeUndoLogManager fails every undo with err
+type fakeUndoLogManager struct {
+	undo.UndoLogManager
+	err error
+}
+
+func (manager *fakeUndoLogManager) Undo(ctx context.Context, db *sql.DB, xid string, branchID int64) error {
+	return manager.err
+}
+
+func TestATResourceManager_BranchRollback(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		branchStatus apis.BranchSession_BranchStatus
+	}{
+		{"rolled back", nil, apis.PhaseTwoRolledBack},
+		{"retryable", errors.New("connection refused"), apis.PhaseTwoRollbackFailedRetryable},
+		{"dirty write", errors.Wrapf(undo.ErrDirtyWrite, "table %s", "product"), apis.PhaseTwoRollbackFailedCanNotRetry},
+	}
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			resourceManager := ATResourceManager{ResourceCache: &sync.Map{}}
+			resourceManager.ResourceCache.Store("db", &DataSourceResource{
+				ResourceID:     "db",
+				undoLogManager: &fakeUndoLogManager{err: c.err},
+			})
+
+			resp, err := resourceManager.BranchRollback(context.Background(), &apis.BranchRollbackRequest{
+				XID:        "localhost:8091:1",
+				BranchID:   2,
+				ResourceID: "db",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+			assert.Equal(t, c.branchStatus, resp.BranchStatus)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package at
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// Stmt wraps a prepared statement of the underlying driver
+type Stmt struct {
+	conn   *Conn
+	target driver.Stmt
+	query  string
+}
+
+func (stmt *Stmt) Close() error {
+	return stmt.target.Close()
+}
+
+func (stmt *Stmt) NumInput() int {
+	return stmt.target.NumInput()
+}
+
+func (stmt *Stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return stmt.ExecContext(context.Background(), valueToNamedValue(args))
+}
+
+func (stmt *Stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return stmt.QueryContext(context.Background(), valueToNamedValue(args))
+}
+
+func (stmt *Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	r, err := stmt.conn.needProxy(ctx, stmt.query)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		return stmt.conn.executeDML(ctx, r, stmt.query, args, func() (driver.Result, error) {
+			return stmtExecContext(ctx, stmt.target, args)
+		})
+	}
+	return stmtExecContext(ctx, stmt.target, args)
+}
+
+func (stmt *Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	r, err := stmt.conn.needLockCheck(ctx, stmt.query)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		return newExecutor(stmt.conn, r, stmt.query, args).executeQuery(ctx, func() (driver.Rows, error) {
+			return stmtQueryContext(ctx, stmt.target, args)
+		})
+	}
+	return stmtQueryContext(ctx, stmt.target, args)
+}
@@ -0,0 +1,104 @@
+package at
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// targetConn executes statements on the connection of the underlying driver, falling back to
+// prepared statements when the driver can not execute a query with arguments directly.
+type targetConn struct {
+	driver.Conn
+}
+
+func (c targetConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		result, err := execer.ExecContext(ctx, query, args)
+		if err != driver.ErrSkip {
+			return result, err
+		}
+	}
+	stmt, err := prepareContext(ctx, c.Conn, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	return stmtExecContext(ctx, stmt, args)
+}
+
+func (c targetConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
+		rows, err := queryer.QueryContext(ctx, query, args)
+		if err != driver.ErrSkip {
+			return rows, err
+		}
+	}
+	stmt, err := prepareContext(ctx, c.Conn, query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmtQueryContext(ctx, stmt, args)
+	if err != nil {
+		_ = stmt.Close()
+		return nil, err
+	}
+	return &stmtRows{Rows: rows, stmt: stmt}, nil
+}
+
+// stmtRows closes the statement together with the rows
+type stmtRows struct {
+	driver.Rows
+	stmt driver.Stmt
+}
+
+func (rows *stmtRows) Close() error {
+	err := rows.Rows.Close()
+	if stmtErr := rows.stmt.Close(); err == nil {
+		err = stmtErr
+	}
+	return err
+}
+
+func prepareContext(ctx context.Context, conn driver.Conn, query string) (driver.Stmt, error) {
+	if preparer, ok := conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return conn.Prepare(query)
+}
+
+func beginTx(ctx context.Context, conn driver.Conn, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return conn.Begin() // nolint: staticcheck
+}
+
+func stmtExecContext(ctx context.Context, stmt driver.Stmt, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	return stmt.Exec(namedValueToValue(args)) // nolint: staticcheck
+}
+
+func stmtQueryContext(ctx context.Context, stmt driver.Stmt, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+	return stmt.Query(namedValueToValue(args)) // nolint: staticcheck
+}
+
+func namedValueToValue(named []driver.NamedValue) []driver.Value {
+	args := make([]driver.Value, len(named))
+	for i, param := range named {
+		args[i] = param.Value
+	}
+	return args
+}
+
+func valueToNamedValue(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, value := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: value}
+	}
+	return named
+}
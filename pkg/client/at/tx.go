@@ -0,0 +1,152 @@
+package at
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at/undo"
+	"github.com/opentrx/seata-golang/v2/pkg/client/base/exception"
+	"github.com/opentrx/seata-golang/v2/pkg/client/config"
+	"github.com/opentrx/seata-golang/v2/pkg/client/rm"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// Tx wraps a local transaction of the underlying driver, the local transaction in a global
+// transaction is registered as an AT branch before it commits.
+type Tx struct {
+	conn   *Conn
+	target driver.Tx
+}
+
+func (tx *Tx) Commit() error {
+	txCtx := tx.conn.txCtx
+	defer tx.conn.resetContext()
+
+	if txCtx.inGlobalTransaction() {
+		return tx.processGlobalTransactionCommit(txCtx)
+	}
+	if txCtx.isGlobalLockRequire {
+		return tx.processLocalCommitWithGlobalLocks(txCtx)
+	}
+	return tx.target.Commit()
+}
+
+func (tx *Tx) Rollback() error {
+	defer tx.conn.resetContext()
+	return tx.target.Rollback()
+}
+
+func (tx *Tx) processGlobalTransactionCommit(txCtx *connectionContext) error {
+	if !txCtx.hasUndoLog() {
+		return tx.target.Commit()
+	}
+
+	branchID, err := tx.register(txCtx)
+	if err != nil {
+		tx.rollbackLocal()
+		return err
+	}
+	txCtx.branchID = branchID
+
+	err = tx.conn.resource.undoLogManager.FlushUndoLogs(context.Background(), targetConn{tx.conn.target},
+		&undo.BranchUndoLog{
+			XID:         txCtx.xid,
+			BranchID:    branchID,
+			SQLUndoLogs: txCtx.sqlUndoItemsBuffer,
+		})
+	if err != nil {
+		log.Errorf("failed to flush undo log, xid: %s, branchID: %d, err: %v", txCtx.xid, branchID, err)
+		tx.report(txCtx, apis.PhaseOneFailed)
+		tx.rollbackLocal()
+		return err
+	}
+
+	if err = tx.target.Commit(); err != nil {
+		log.Errorf("failed to commit local transaction, xid: %s, branchID: %d, err: %v", txCtx.xid, branchID, err)
+		tx.report(txCtx, apis.PhaseOneFailed)
+		return err
+	}
+	if config.GetATConfig().ReportSuccessEnable {
+		tx.report(txCtx, apis.PhaseOneDone)
+	}
+	return nil
+}
+
+func (tx *Tx) processLocalCommitWithGlobalLocks(txCtx *connectionContext) error {
+	if txCtx.hasLockKey() {
+		if err := tx.checkLock(txCtx); err != nil {
+			tx.rollbackLocal()
+			return err
+		}
+	}
+	return tx.target.Commit()
+}
+
+// register registers the local transaction as a branch, it is retried while the row locks
+// are held by other global transactions.
+func (tx *Tx) register(txCtx *connectionContext) (int64, error) {
+	conf := config.GetATConfig()
+	lockKeys := txCtx.buildLockKeys()
+	for retry := 0; ; retry++ {
+		branchID, err := rm.GetResourceManager().BranchRegister(context.Background(), txCtx.xid,
+			tx.conn.resource.GetResourceID(), apis.AT, nil, lockKeys, false)
+		if err == nil {
+			return branchID, nil
+		}
+		if !isLockKeyConflict(err) || retry >= conf.LockRetryTimes {
+			return 0, err
+		}
+		time.Sleep(conf.LockRetryInterval)
+	}
+}
+
+func (tx *Tx) checkLock(txCtx *connectionContext) error {
+	conf := config.GetATConfig()
+	lockKeys := txCtx.buildLockKeys()
+	for retry := 0; ; retry++ {
+		lockable, err := rm.GetResourceManager().LockQuery(context.Background(), txCtx.xid,
+			tx.conn.resource.GetResourceID(), apis.AT, lockKeys)
+		if err != nil {
+			return err
+		}
+		if lockable {
+			return nil
+		}
+		if retry >= conf.LockRetryTimes {
+			return &exception.TransactionException{
+				Code:    apis.LockKeyConflict,
+				Message: fmt.Sprintf("global lock wait timeout, lockKeys: %s", lockKeys),
+			}
+		}
+		time.Sleep(conf.LockRetryInterval)
+	}
+}
+
+func (tx *Tx) report(txCtx *connectionContext, status apis.BranchSession_BranchStatus) {
+	retry := config.GetATConfig().ReportRetryCount
+	for retry > 0 {
+		err := rm.GetResourceManager().BranchReport(context.Background(), txCtx.xid, txCtx.branchID, apis.AT,
+			status, nil)
+		if err == nil {
+			return
+		}
+		retry--
+		log.Errorf("failed to report [%d/%s] commit done [%s], Retry Countdown: %d, err: %v",
+			txCtx.branchID, txCtx.xid, status.String(), retry, err)
+	}
+}
+
+func (tx *Tx) rollbackLocal() {
+	if err := tx.target.Rollback(); err != nil {
+		log.Error(err)
+	}
+}
+
+func isLockKeyConflict(err error) bool {
+	var ex *exception.TransactionException
+	return errors.As(err, &ex) && ex.Code == apis.LockKeyConflict
+}
@@ -0,0 +1,46 @@
+package at
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/client/config"
+)
+
+func TestTx_Commit_RegisterRetry(t *testing.T) {
+	conn, db, rmClient := newTestConn(t)
+	db.answer("FOR UPDATE", []string{"id", "name"}, []driver.Value{int64(1), []byte("apple")})
+	update := func() {
+		tx, err := conn.BeginTx(newGlobalContext(), driver.TxOptions{})
+		assert.Nil(t, err)
+		_, err = conn.ExecContext(newGlobalContext(), "DELETE FROM product WHERE id = ?",
+			valueToNamedValue([]driver.Value{int64(1)}))
+		assert.Nil(t, err)
+		err = tx.Commit()
+		if err != nil {
+			assert.True(t, isLockKeyConflict(err))
+		}
+	}
+
+	// the registration is retried while the row locks are held by other global transactions
+	rmClient.conflicts = config.GetATConfig().LockRetryTimes
+	update()
+	assert.Len(t, rmClient.registers, config.GetATConfig().LockRetryTimes+1)
+	assert.Equal(t, "product:1", rmClient.registers[0].LockKey)
+	assert.Equal(t, 1, db.commits)
+	assert.Equal(t, 0, db.rollbacks)
+	assert.NotNil(t, db.undoLog())
+
+	// the local transaction is rolled back once the retries run out
+	db.reset()
+	rmClient.registers = nil
+	rmClient.conflicts = config.GetATConfig().LockRetryTimes + 1
+	update()
+	assert.Len(t, rmClient.registers, config.GetATConfig().LockRetryTimes+1)
+	assert.Equal(t, 0, db.commits)
+	assert.Equal(t, 1, db.rollbacks)
+	assert.Nil(t, db.undoArgs)
+	assert.Nil(t, conn.txCtx)
+}
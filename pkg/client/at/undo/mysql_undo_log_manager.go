@@ -0,0 +1,29 @@
+package undo
+
+import (
+	"github.com/opentrx/seata-golang/v2/pkg/util/mysql"
+)
+
+// MysqlDBType the db type of the mysql undo log manager
+const MysqlDBType = "mysql"
+
+// MysqlTimeFormat the format of the times in the images of mysql, DATETIME keeps no time zone and
+// the driver returns the wall clock it stores, so the offset is left out and the wall clock is kept.
+const MysqlTimeFormat = "2006-01-02 15:04:05.999999"
+
+func init() {
+	RegisterUndoLogManager(MysqlDBType, NewMysqlUndoLogManager())
+}
+
+// NewMysqlUndoLogManager return the UndoLogManager of mysql, identifiers which are keywords are
+// quoted with backticks.
+func NewMysqlUndoLogManager() UndoLogManager {
+	return undoLogManager{
+		dialect: dialect{
+			escape:     mysql.CheckAndReplace,
+			rebind:     func(query string) string { return query },
+			now:        "now(6)",
+			timeFormat: MysqlTimeFormat,
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package undo
+
+import (
+	"github.com/opentrx/seata-golang/v2/pkg/util/pgsql"
+	sql2 "github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+// PostgresDBType the db type of the postgresql undo log manager
+const PostgresDBType = "postgres"
+
+// PostgresTimeFormat the format of the times in the images of postgresql, the offset is kept so a
+// timestamptz is restored to the same instant whatever the TimeZone of the session, a timestamp
+// ignores it.
+const PostgresTimeFormat = "2006-01-02 15:04:05.999999-07:00"
+
+func init() {
+	RegisterUndoLogManager(PostgresDBType, NewPostgresUndoLogManager())
+}
+
+// NewPostgresUndoLogManager return the UndoLogManager of postgresql, identifiers which are keywords
+// or not lower case are quoted with double quotes and placeholders are rewritten to $n.
+func NewPostgresUndoLogManager() UndoLogManager {
+	return undoLogManager{
+		dialect: dialect{
+			escape:     pgsql.CheckAndReplace,
+			rebind:     sql2.PgsqlRebind,
+			now:        "now()",
+			timeFormat: PostgresTimeFormat,
+		},
+	}
+}
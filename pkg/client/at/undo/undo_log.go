@@ -0,0 +1,220 @@
+package undo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+// SQLType the type of the statement which generated an undo log
+type SQLType byte
+
+const (
+	SQLTypeInsert SQLType = iota
+
+	SQLTypeUpdate
+
+	SQLTypeDelete
+)
+
+// String
+func (t SQLType) String() string {
+	switch t {
+	case SQLTypeInsert:
+		return "INSERT"
+	case SQLTypeUpdate:
+		return "UPDATE"
+	case SQLTypeDelete:
+		return "DELETE"
+	default:
+		return fmt.Sprintf("%d", t)
+	}
+}
+
+// KeyType marks whether a field is part of the primary key
+type KeyType byte
+
+const (
+	Null KeyType = iota
+
+	PrimaryKey
+)
+
+// State the status of an undo log record
+type State int32
+
+const (
+	// Normal this state can be properly rolled back by services
+	Normal State = iota
+
+	// GlobalFinished this state prevents the branch transaction from inserting undo_log after the global
+	// transaction is rolled back.
+	GlobalFinished
+)
+
+const (
+	// SerializerKey key of the serializer in the undo log context
+	SerializerKey = "serializer"
+
+	// JSONSerializer the only supported serializer
+	JSONSerializer = "json"
+)
+
+// Field a column value of a row image
+type Field struct {
+	Name    string      `json:"name"`
+	KeyType KeyType     `json:"keyType"`
+	Type    int32       `json:"type"`
+	Value   interface{} `json:"value"`
+}
+
+// Row a row image
+type Row struct {
+	Fields []*Field `json:"fields"`
+}
+
+// PrimaryKeys return the primary key fields of the row
+func (row *Row) PrimaryKeys() []*Field {
+	fields := make([]*Field, 0)
+	for _, field := range row.Fields {
+		if field.KeyType == PrimaryKey {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// NonPrimaryKeys return the fields of the row which are not part of the primary key
+func (row *Row) NonPrimaryKeys() []*Field {
+	fields := make([]*Field, 0)
+	for _, field := range row.Fields {
+		if field.KeyType != PrimaryKey {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// TableRecords the image of the rows affected by a statement
+type TableRecords struct {
+	TableName string `json:"tableName"`
+	Rows      []*Row `json:"rows"`
+}
+
+// IsEmpty return true if no rows were captured
+func (records *TableRecords) IsEmpty() bool {
+	return records == nil || len(records.Rows) == 0
+}
+
+// LockKey build the lock key of the records, format: table:pk1,pk2. Composite primary
+// key values are joined with "_".
+func (records *TableRecords) LockKey() string {
+	if records.IsEmpty() {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(records.TableName)
+	sb.WriteString(":")
+	for i, row := range records.Rows {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		for j, field := range row.PrimaryKeys() {
+			if j > 0 {
+				sb.WriteString("_")
+			}
+			sb.WriteString(ValueString(field.Value))
+		}
+	}
+	return sb.String()
+}
+
+// SQLUndoLog the undo log of one statement
+type SQLUndoLog struct {
+	SQLType     SQLType       `json:"sqlType"`
+	TableName   string        `json:"tableName"`
+	BeforeImage *TableRecords `json:"beforeImage"`
+	AfterImage  *TableRecords `json:"afterImage"`
+}
+
+// BranchUndoLog the undo logs of one branch transaction
+type BranchUndoLog struct {
+	XID         string        `json:"xid"`
+	BranchID    int64         `json:"branchID"`
+	SQLUndoLogs []*SQLUndoLog `json:"sqlUndoLogs"`
+}
+
+// Encode serialize the branch undo log
+func Encode(branchUndoLog *BranchUndoLog) ([]byte, error) {
+	return json.Marshal(branchUndoLog)
+}
+
+// Decode deserialize the branch undo log, numbers are kept as json.Number and binary values
+// are restored from their base64 form so the values can be bound back to a statement.
+func Decode(data []byte) (*BranchUndoLog, error) {
+	branchUndoLog := &BranchUndoLog{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(branchUndoLog); err != nil {
+		return nil, err
+	}
+	for _, sqlUndoLog := range branchUndoLog.SQLUndoLogs {
+		if err := restore(sqlUndoLog.BeforeImage); err != nil {
+			return nil, err
+		}
+		if err := restore(sqlUndoLog.AfterImage); err != nil {
+			return nil, err
+		}
+	}
+	return branchUndoLog, nil
+}
+
+func restore(records *TableRecords) error {
+	if records == nil {
+		return nil
+	}
+	for _, row := range records.Rows {
+		for _, field := range row.Fields {
+			s, ok := field.Value.(string)
+			if ok && IsBinary(field.Type) {
+				b, err := base64.StdEncoding.DecodeString(s)
+				if err != nil {
+					return err
+				}
+				field.Value = b
+			}
+		}
+	}
+	return nil
+}
+
+// IsBinary return true if values of the sql type are kept as raw bytes
+func IsBinary(sqlType int32) bool {
+	switch sql.Type(sqlType) {
+	case sql.BINARY, sql.VARBINARY, sql.LONGVARBINARY, sql.BLOB:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValueString return the string form of a field value
+func ValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// UndoLogContext build the context column of an undo log record
+func UndoLogContext() string {
+	return fmt.Sprintf("%s=%s", SerializerKey, JSONSerializer)
+}
@@ -0,0 +1,453 @@
+package undo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	sql2 "github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+// ErrDirtyWrite the rows have been modified out of the global transaction after the
+// branch committed, the undo log can not be applied automatically.
+var ErrDirtyWrite = errors.New("undo log can not be applied, the rows have been modified by others")
+
+// UndoLogManager reads and writes the undo_log table of a resource and rolls back
+// branch transactions with it.
+type UndoLogManager interface {
+	// FlushUndoLogs insert the undo log of a branch within the local transaction of the business sql
+	FlushUndoLogs(ctx context.Context, conn driver.ExecerContext, branchUndoLog *BranchUndoLog) error
+
+	// Undo roll back the branch transaction with its undo log
+	Undo(ctx context.Context, db *sql.DB, xid string, branchID int64) error
+
+	// DeleteUndoLog delete the undo log of the branch after it has been committed
+	DeleteUndoLog(ctx context.Context, db *sql.DB, xid string, branchID int64) error
+
+	// BatchDeleteUndoLogs delete the undo logs of the branches after they have been committed
+	BatchDeleteUndoLogs(ctx context.Context, db *sql.DB, xids []string, branchIDs []int64) error
+}
+
+var (
+	managersMu sync.RWMutex
+	managers   = make(map[string]UndoLogManager)
+)
+
+// RegisterUndoLogManager makes an UndoLogManager available for the db type.
+// If Register is called twice with the same db type or if manager is nil, it panics.
+func RegisterUndoLogManager(dbType string, manager UndoLogManager) {
+	managersMu.Lock()
+	defer managersMu.Unlock()
+	if manager == nil {
+		panic("undo: register nil UndoLogManager")
+	}
+	if _, registered := managers[dbType]; registered {
+		panic(fmt.Sprintf("undo: RegisterUndoLogManager called twice for db type %s", dbType))
+	}
+	managers[dbType] = manager
+}
+
+// GetUndoLogManager return the UndoLogManager registered for the db type
+func GetUndoLogManager(dbType string) (UndoLogManager, error) {
+	managersMu.RLock()
+	defer managersMu.RUnlock()
+	manager, ok := managers[dbType]
+	if !ok {
+		return nil, errors.Errorf("undo: no UndoLogManager registered for db type %s", dbType)
+	}
+	return manager, nil
+}
+
+// dialect describes how the statements of an undo log manager are written for a database
+type dialect struct {
+	// escape quotes the table or column name if it is a keyword
+	escape func(name string) string
+
+	// rebind converts the `?` placeholders of the statement to the bind vars of the database
+	rebind func(query string) string
+
+	// now the function return the current timestamp
+	now string
+
+	// timeFormat the format the times of the images are written in, the current image is compared
+	// with them in it
+	timeFormat string
+}
+
+// undoLogManager implements UndoLogManager on top of a dialect
+type undoLogManager struct {
+	dialect dialect
+}
+
+func (manager undoLogManager) insertUndoLogSQL() string {
+	return manager.dialect.rebind(fmt.Sprintf(`INSERT INTO undo_log (branch_id, xid, context, rollback_info,
+		log_status, log_created, log_modified) VALUES (?, ?, ?, ?, ?, %s, %s)`, manager.dialect.now, manager.dialect.now))
+}
+
+func (manager undoLogManager) selectUndoLogSQL() string {
+	return manager.dialect.rebind(`SELECT branch_id, xid, context, rollback_info, log_status FROM undo_log
+		WHERE branch_id = ? AND xid = ? FOR UPDATE`)
+}
+
+func (manager undoLogManager) deleteUndoLogSQL() string {
+	return manager.dialect.rebind("DELETE FROM undo_log WHERE branch_id = ? AND xid = ?")
+}
+
+func (manager undoLogManager) FlushUndoLogs(ctx context.Context, conn driver.ExecerContext, branchUndoLog *BranchUndoLog) error {
+	rollbackInfo, err := Encode(branchUndoLog)
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, manager.insertUndoLogSQL(), namedValues(branchUndoLog.BranchID, branchUndoLog.XID,
+		UndoLogContext(), rollbackInfo, int32(Normal)))
+	return err
+}
+
+func (manager undoLogManager) Undo(ctx context.Context, db *sql.DB, xid string, branchID int64) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = manager.undo(ctx, tx, xid, branchID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Error(rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func (manager undoLogManager) undo(ctx context.Context, tx *sql.Tx, xid string, branchID int64) error {
+	rows, err := tx.QueryContext(ctx, manager.selectUndoLogSQL(), branchID, xid)
+	if err != nil {
+		return err
+	}
+
+	var (
+		exists       bool
+		state        int32
+		rollbackInfo []byte
+	)
+	for rows.Next() {
+		var (
+			id          int64
+			logXID      string
+			undoContext string
+		)
+		if err = rows.Scan(&id, &logXID, &undoContext, &rollbackInfo, &state); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		exists = true
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+
+	if !exists {
+		// Insert a record with GlobalFinished state, so that the branch transaction which has not
+		// finished phase one can not commit with its undo log.
+		_, err = tx.ExecContext(ctx, manager.insertUndoLogSQL(), branchID, xid, UndoLogContext(), []byte("{}"),
+			int32(GlobalFinished))
+		if err != nil {
+			return err
+		}
+		log.Infof("xid %s branch %d undo log not exists, insert global finished undo log", xid, branchID)
+		return nil
+	}
+
+	if State(state) == GlobalFinished {
+		log.Infof("xid %s branch %d undo log has been processed", xid, branchID)
+		return nil
+	}
+
+	branchUndoLog, err := Decode(rollbackInfo)
+	if err != nil {
+		return err
+	}
+	for i := len(branchUndoLog.SQLUndoLogs) - 1; i >= 0; i-- {
+		if err = manager.execute(ctx, tx, branchUndoLog.SQLUndoLogs[i]); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, manager.deleteUndoLogSQL(), branchID, xid)
+	if err != nil {
+		return err
+	}
+	log.Infof("xid %s branch %d undo log deleted", xid, branchID)
+	return nil
+}
+
+func (manager undoLogManager) DeleteUndoLog(ctx context.Context, db *sql.DB, xid string, branchID int64) error {
+	_, err := db.ExecContext(ctx, manager.deleteUndoLogSQL(), branchID, xid)
+	return err
+}
+
+func (manager undoLogManager) BatchDeleteUndoLogs(ctx context.Context, db *sql.DB, xids []string, branchIDs []int64) error {
+	if len(xids) == 0 || len(branchIDs) == 0 {
+		return nil
+	}
+	query := fmt.Sprintf("DELETE FROM undo_log WHERE branch_id IN %s AND xid IN %s",
+		sql2.MysqlAppendInParam(len(branchIDs)), sql2.MysqlAppendInParam(len(xids)))
+	args := make([]interface{}, 0, len(branchIDs)+len(xids))
+	for _, branchID := range branchIDs {
+		args = append(args, branchID)
+	}
+	for _, xid := range xids {
+		args = append(args, xid)
+	}
+	_, err := db.ExecContext(ctx, manager.dialect.rebind(query), args...)
+	return err
+}
+
+func (manager undoLogManager) execute(ctx context.Context, tx *sql.Tx, sqlUndoLog *SQLUndoLog) error {
+	var rows []*Row
+	switch sqlUndoLog.SQLType {
+	case SQLTypeInsert:
+		rows = sqlUndoLog.AfterImage.Rows
+	case SQLTypeUpdate, SQLTypeDelete:
+		rows = sqlUndoLog.BeforeImage.Rows
+	default:
+		return errors.Errorf("unsupported sql type %s", sqlUndoLog.SQLType)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	needUndo, err := manager.checkDirtyWrite(ctx, tx, sqlUndoLog)
+	if err != nil {
+		return err
+	}
+	if !needUndo {
+		return nil
+	}
+
+	for _, row := range rows {
+		var (
+			query string
+			args  []interface{}
+		)
+		switch sqlUndoLog.SQLType {
+		case SQLTypeInsert:
+			query, args = manager.buildDeleteSQL(sqlUndoLog.TableName, row)
+		case SQLTypeUpdate:
+			query, args = manager.buildUpdateSQL(sqlUndoLog.TableName, row)
+		case SQLTypeDelete:
+			query, args = manager.buildInsertSQL(sqlUndoLog.TableName, row)
+		}
+		if _, err := tx.ExecContext(ctx, manager.dialect.rebind(query), args...); err != nil {
+			return errors.Wrapf(err, "undo %s on table %s failed", sqlUndoLog.SQLType, sqlUndoLog.TableName)
+		}
+	}
+	return nil
+}
+
+// checkDirtyWrite compares the current rows with the after image. It returns false if the
+// rows already equal the before image, which means the undo log has been applied; and
+// ErrDirtyWrite if the rows equal neither image.
+func (manager undoLogManager) checkDirtyWrite(ctx context.Context, tx *sql.Tx, sqlUndoLog *SQLUndoLog) (bool, error) {
+	beforeImage, afterImage := sqlUndoLog.BeforeImage, sqlUndoLog.AfterImage
+	keyImage := beforeImage
+	if sqlUndoLog.SQLType == SQLTypeInsert {
+		keyImage = afterImage
+	}
+
+	currentImage, err := manager.queryCurrentImage(ctx, tx, sqlUndoLog.TableName, keyImage)
+	if err != nil {
+		return false, err
+	}
+	if equalRecords(afterImage, currentImage) {
+		return true, nil
+	}
+	if equalRecords(beforeImage, currentImage) {
+		log.Infof("table %s rows have been rolled back, skip undo", sqlUndoLog.TableName)
+		return false, nil
+	}
+	log.Errorf("table %s dirty write, after image: %s, current image: %s", sqlUndoLog.TableName,
+		recordsString(afterImage), recordsString(currentImage))
+	return false, errors.Wrapf(ErrDirtyWrite, "table %s", sqlUndoLog.TableName)
+}
+
+// queryCurrentImage lock and read the current rows with the primary keys of the image
+func (manager undoLogManager) queryCurrentImage(ctx context.Context, tx *sql.Tx, tableName string,
+	image *TableRecords) (*TableRecords, error) {
+	records := &TableRecords{TableName: tableName, Rows: make([]*Row, 0)}
+	if image.IsEmpty() {
+		return records, nil
+	}
+
+	columns := make([]string, 0, len(image.Rows[0].Fields))
+	for _, field := range image.Rows[0].Fields {
+		columns = append(columns, manager.dialect.escape(field.Name))
+	}
+	conditions := make([]string, 0, len(image.Rows))
+	args := make([]interface{}, 0)
+	for _, row := range image.Rows {
+		where, whereArgs := manager.buildWhereConditionByPKs(row)
+		conditions = append(conditions, "("+where+")")
+		args = append(args, whereArgs...)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s FOR UPDATE", strings.Join(columns, ", "),
+		manager.dialect.escape(tableName), strings.Join(conditions, " OR "))
+
+	rows, err := tx.QueryContext(ctx, manager.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	template := image.Rows[0].Fields
+	for rows.Next() {
+		values := make([]interface{}, len(template))
+		dest := make([]interface{}, len(template))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row := &Row{Fields: make([]*Field, 0, len(template))}
+		for i, field := range template {
+			value := values[i]
+			if t, ok := value.(time.Time); ok {
+				value = t.Format(manager.dialect.timeFormat)
+			}
+			row.Fields = append(row.Fields, &Field{
+				Name:    field.Name,
+				KeyType: field.KeyType,
+				Type:    field.Type,
+				Value:   value,
+			})
+		}
+		records.Rows = append(records.Rows, row)
+	}
+	return records, rows.Err()
+}
+
+func (manager undoLogManager) buildDeleteSQL(tableName string, row *Row) (string, []interface{}) {
+	where, args := manager.buildWhereConditionByPKs(row)
+	return fmt.Sprintf("DELETE FROM %s WHERE %s", manager.dialect.escape(tableName), where), args
+}
+
+func (manager undoLogManager) buildUpdateSQL(tableName string, row *Row) (string, []interface{}) {
+	var (
+		columns = make([]string, 0)
+		args    = make([]interface{}, 0)
+	)
+	for _, field := range row.NonPrimaryKeys() {
+		columns = append(columns, fmt.Sprintf("%s = ?", manager.dialect.escape(field.Name)))
+		args = append(args, field.Value)
+	}
+	where, whereArgs := manager.buildWhereConditionByPKs(row)
+	args = append(args, whereArgs...)
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s", manager.dialect.escape(tableName),
+		strings.Join(columns, ", "), where), args
+}
+
+func (manager undoLogManager) buildInsertSQL(tableName string, row *Row) (string, []interface{}) {
+	var (
+		columns = make([]string, 0, len(row.Fields))
+		args    = make([]interface{}, 0, len(row.Fields))
+	)
+	for _, field := range row.Fields {
+		columns = append(columns, manager.dialect.escape(field.Name))
+		args = append(args, field.Value)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", manager.dialect.escape(tableName),
+		strings.Join(columns, ", "), sql2.MysqlAppendInParam(len(columns))), args
+}
+
+func (manager undoLogManager) buildWhereConditionByPKs(row *Row) (string, []interface{}) {
+	var (
+		conditions = make([]string, 0)
+		args       = make([]interface{}, 0)
+	)
+	for _, field := range row.PrimaryKeys() {
+		conditions = append(conditions, fmt.Sprintf("%s = ?", manager.dialect.escape(field.Name)))
+		args = append(args, field.Value)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// equalRecords compares two images regardless of the order of the rows, values are compared
+// by their string form since they come from different decoders.
+func equalRecords(expected, actual *TableRecords) bool {
+	if expected.IsEmpty() || actual.IsEmpty() {
+		return expected.IsEmpty() && actual.IsEmpty()
+	}
+	if len(expected.Rows) != len(actual.Rows) {
+		return false
+	}
+	actualRows := make(map[string]*Row, len(actual.Rows))
+	for _, row := range actual.Rows {
+		actualRows[rowKey(row)] = row
+	}
+	for _, row := range expected.Rows {
+		actualRow, ok := actualRows[rowKey(row)]
+		if !ok || !equalRow(row, actualRow) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalRow(expected, actual *Row) bool {
+	actualFields := make(map[string]*Field, len(actual.Fields))
+	for _, field := range actual.Fields {
+		actualFields[strings.ToUpper(field.Name)] = field
+	}
+	for _, field := range expected.Fields {
+		actualField, ok := actualFields[strings.ToUpper(field.Name)]
+		if !ok || !equalValue(field, actualField) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalValue(expected, actual *Field) bool {
+	if expected.Value == nil || actual.Value == nil {
+		return expected.Value == nil && actual.Value == nil
+	}
+	return ValueString(expected.Value) == ValueString(actual.Value)
+}
+
+func rowKey(row *Row) string {
+	values := make([]string, 0)
+	for _, field := range row.PrimaryKeys() {
+		values = append(values, ValueString(field.Value))
+	}
+	return strings.Join(values, "_")
+}
+
+func recordsString(records *TableRecords) string {
+	if records.IsEmpty() {
+		return "[]"
+	}
+	rows := make([]string, 0, len(records.Rows))
+	for _, row := range records.Rows {
+		values := make([]string, 0, len(row.Fields))
+		for _, field := range row.Fields {
+			values = append(values, fmt.Sprintf("%s=%s", field.Name, ValueString(field.Value)))
+		}
+		rows = append(rows, "{"+strings.Join(values, ", ")+"}")
+	}
+	return "[" + strings.Join(rows, ", ") + "]"
+}
+
+func namedValues(args ...interface{}) []driver.NamedValue {
+	values := make([]driver.NamedValue, 0, len(args))
+	for i, arg := range args {
+		values = append(values, driver.NamedValue{Ordinal: i + 1, Value: arg})
+	}
+	return values
+}
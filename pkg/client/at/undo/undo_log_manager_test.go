@@ -0,0 +1,280 @@
+package undo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualRecords(t *testing.T) {
+	image := &TableRecords{
+		TableName: "t",
+		Rows: []*Row{
+			{Fields: []*Field{
+				{Name: "id", KeyType: PrimaryKey, Value: json.Number("1")},
+				{Name: "name", Value: "a"},
+			}},
+			{Fields: []*Field{
+				{Name: "id", KeyType: PrimaryKey, Value: json.Number("2")},
+				{Name: "name", Value: nil},
+			}},
+		},
+	}
+	current := &TableRecords{
+		TableName: "t",
+		Rows: []*Row{
+			{Fields: []*Field{
+				{Name: "ID", KeyType: PrimaryKey, Value: int64(2)},
+				{Name: "NAME", Value: nil},
+			}},
+			{Fields: []*Field{
+				{Name: "ID", KeyType: PrimaryKey, Value: int64(1)},
+				{Name: "NAME", Value: []byte("a")},
+			}},
+		},
+	}
+	assert.True(t, equalRecords(image, current))
+
+	current.Rows[1].Fields[1].Value = []byte("b")
+	assert.False(t, equalRecords(image, current))
+
+	assert.False(t, equalRecords(image, &TableRecords{TableName: "t"}))
+	assert.True(t, equalRecords(&TableRecords{TableName: "t"}, &TableRecords{TableName: "t"}))
+}
+
+func TestUndoLogManager_Undo(t *testing.T) {
+	testCases := []struct {
+		name    string
+		manager UndoLogManager
+		update  string
+	}{
+		{
+			name:    "mysql",
+			manager: NewMysqlUndoLogManager(),
+			update:  "UPDATE `order` SET amount = ? WHERE id = ?",
+		},
+		{
+			name:    "postgres",
+			manager: NewPostgresUndoLogManager(),
+			update:  `UPDATE "order" SET amount = $1 WHERE id = $2`,
+		},
+	}
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			db := newFakeDB(updateUndoLog(t))
+			db.current = [][]driver.Value{{int64(1), int64(8)}}
+
+			assert.Nil(t, c.manager.Undo(context.Background(), db.open(), "xid", 1))
+			assert.True(t, db.committed)
+			assert.Equal(t, 2, len(db.execs))
+			assert.Equal(t, c.update, db.execs[0].query)
+			assert.Equal(t, []driver.Value{"10", "1"}, db.execs[0].args)
+			assert.True(t, strings.HasPrefix(db.execs[1].query, "DELETE FROM undo_log"))
+		})
+	}
+}
+
+func TestUndoLogManager_Undo_DirtyWrite(t *testing.T) {
+	for _, manager := range []UndoLogManager{NewMysqlUndoLogManager(), NewPostgresUndoLogManager()} {
+		db := newFakeDB(updateUndoLog(t))
+		// the row has been modified out of the global transaction
+		db.current = [][]driver.Value{{int64(1), int64(5)}}
+
+		err := manager.Undo(context.Background(), db.open(), "xid", 1)
+		assert.True(t, errors.Is(err, ErrDirtyWrite))
+		assert.True(t, db.rolledBack)
+		assert.Empty(t, db.execs)
+	}
+}
+
+func TestUndoLogManager_Undo_Skip(t *testing.T) {
+	manager := NewPostgresUndoLogManager()
+
+	// the rows are already rolled back, only the undo log is deleted
+	db := newFakeDB(updateUndoLog(t))
+	db.current = [][]driver.Value{{int64(1), []byte("10")}}
+	assert.Nil(t, manager.Undo(context.Background(), db.open(), "xid", 1))
+	assert.Equal(t, 1, len(db.execs))
+	assert.Equal(t, "DELETE FROM undo_log WHERE branch_id = $1 AND xid = $2", db.execs[0].query)
+
+	// the undo log doesn't exist, a global finished one is inserted
+	db = newFakeDB(nil)
+	assert.Nil(t, manager.Undo(context.Background(), db.open(), "xid", 1))
+	assert.True(t, db.committed)
+	assert.Equal(t, 1, len(db.execs))
+	assert.True(t, strings.HasPrefix(db.execs[0].query, "INSERT INTO undo_log"))
+	assert.Equal(t, int64(GlobalFinished), db.execs[0].args[4])
+}
+
+func TestUndoLogManager_QueryCurrentImage_Time(t *testing.T) {
+	modified := time.Date(2021, 1, 2, 3, 4, 5, 600000000, time.FixedZone("CST", 8*3600))
+	image := &TableRecords{
+		TableName: "order",
+		Rows: []*Row{{Fields: []*Field{
+			{Name: "id", KeyType: PrimaryKey, Value: int64(1)},
+			{Name: "modified", Value: nil},
+		}}},
+	}
+	testCases := []struct {
+		manager  UndoLogManager
+		expected string
+	}{
+		{NewMysqlUndoLogManager(), "2021-01-02 03:04:05.6"},
+		{NewPostgresUndoLogManager(), "2021-01-02 03:04:05.6+08:00"},
+	}
+	for _, c := range testCases {
+		db := newFakeDB(nil)
+		db.current = [][]driver.Value{{int64(1), modified}}
+		tx, err := db.open().Begin()
+		assert.Nil(t, err)
+
+		// the times are compared with the images in the format they are written in
+		current, err := c.manager.(undoLogManager).queryCurrentImage(context.Background(), tx, "order", image)
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, current.Rows[0].Fields[1].Value)
+		assert.Nil(t, tx.Rollback())
+	}
+}
+
+// updateUndoLog return the undo log of `UPDATE order SET amount = 8 WHERE id = 1`, the amount was 10
+func updateUndoLog(t *testing.T) []byte {
+	image := func(amount int64) *TableRecords {
+		return &TableRecords{
+			TableName: "order",
+			Rows: []*Row{{Fields: []*Field{
+				{Name: "id", KeyType: PrimaryKey, Value: int64(1)},
+				{Name: "amount", Value: amount},
+			}}},
+		}
+	}
+	rollbackInfo, err := Encode(&BranchUndoLog{
+		XID:      "xid",
+		BranchID: 1,
+		SQLUndoLogs: []*SQLUndoLog{{
+			SQLType:     SQLTypeUpdate,
+			TableName:   "order",
+			BeforeImage: image(10),
+			AfterImage:  image(8),
+		}},
+	})
+	assert.Nil(t, err)
+	return rollbackInfo
+}
+
+// fakeDB a database answering the queries of the undo log manager, the undo log is returned by
+// the query of the undo_log table and the current rows by any other query; the other statements
+// are recorded.
+type fakeDB struct {
+	rollbackInfo []byte
+	current      [][]driver.Value
+
+	execs      []fakeExec
+	committed  bool
+	rolledBack bool
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+func newFakeDB(rollbackInfo []byte) *fakeDB {
+	return &fakeDB{rollbackInfo: rollbackInfo}
+}
+
+func (db *fakeDB) open() *sql.DB {
+	return sql.OpenDB(db)
+}
+
+func (db *fakeDB) Connect(_ context.Context) (driver.Conn, error) {
+	return fakeConn{db: db}, nil
+}
+
+func (db *fakeDB) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c fakeConn) Commit() error {
+	c.db.committed = true
+	return nil
+}
+
+func (c fakeConn) Rollback() error {
+	c.db.rolledBack = true
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.execs = append(s.db.execs, fakeExec{query: s.query, args: args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(_ []driver.Value) (driver.Rows, error) {
+	if strings.Contains(s.query, "FROM undo_log") {
+		rows := &fakeRows{columns: []string{"branch_id", "xid", "context", "rollback_info", "log_status"}}
+		if s.db.rollbackInfo != nil {
+			rows.values = [][]driver.Value{{int64(1), "xid", UndoLogContext(), s.db.rollbackInfo, int64(Normal)}}
+		}
+		return rows, nil
+	}
+	return &fakeRows{columns: []string{"id", "amount"}, values: s.db.current}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (rows *fakeRows) Columns() []string {
+	return rows.columns
+}
+
+func (rows *fakeRows) Close() error {
+	return nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if len(rows.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, rows.values[0])
+	rows.values = rows.values[1:]
+	return nil
+}
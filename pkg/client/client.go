@@ -1,15 +1,20 @@
 package client
 
 import (
+	"context"
 	"log"
 
 	"google.golang.org/grpc"
 
 	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/at"
 	"github.com/opentrx/seata-golang/v2/pkg/client/config"
 	"github.com/opentrx/seata-golang/v2/pkg/client/rm"
+	"github.com/opentrx/seata-golang/v2/pkg/client/saga"
 	"github.com/opentrx/seata-golang/v2/pkg/client/tcc"
 	"github.com/opentrx/seata-golang/v2/pkg/client/tm"
+	"github.com/opentrx/seata-golang/v2/pkg/client/xa"
+	"github.com/opentrx/seata-golang/v2/pkg/util/runtime"
 )
 
 // Init init resource manager，init transaction manager, expose a port to listen tc
@@ -36,4 +41,10 @@ func Init(config *config.Configuration) {
 	rm.InitResourceManager(config.Addressing, resourceManagerClient)
 	tm.InitTransactionManager(config.Addressing, transactionManagerClient)
 	rm.RegisterTransactionServiceServer(tcc.GetTCCResourceManager())
+	rm.RegisterTransactionServiceServer(at.GetATResourceManager())
+	rm.RegisterTransactionServiceServer(xa.GetXAResourceManager())
+	rm.RegisterTransactionServiceServer(saga.GetSagaResourceManager())
+	runtime.GoWithRecover(func() {
+		xa.GetXAResourceManager().Recover(context.Background())
+	}, nil)
 }
@@ -55,6 +55,12 @@ type ATConfig struct {
 	ReportSuccessEnable bool          `default:"false" yaml:"reportSuccessEnable" json:"reportSuccessEnable,omitempty"`
 	LockRetryInterval   time.Duration `default:"10ms" yaml:"lockRetryInterval" json:"lockRetryInterval,omitempty"`
 	LockRetryTimes      int           `default:"30" yaml:"lockRetryTimes" json:"lockRetryTimes,omitempty"`
+
+	UndoLogPurgeBatchSize int           `default:"1000" yaml:"undoLogPurgeBatchSize" json:"undoLogPurgeBatchSize,omitempty"`
+	UndoLogPurgeInterval  time.Duration `default:"1s" yaml:"undoLogPurgeInterval" json:"undoLogPurgeInterval,omitempty"`
+
+	// TableMetaRefreshInterval a negative value disables refreshing the table meta cache
+	TableMetaRefreshInterval time.Duration `default:"1m" yaml:"tableMetaRefreshInterval" json:"tableMetaRefreshInterval,omitempty"`
 }
 
 // GetTMConfig return TMConfig
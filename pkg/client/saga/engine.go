@@ -0,0 +1,784 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	ctx "github.com/opentrx/seata-golang/v2/pkg/client/base/context"
+	"github.com/opentrx/seata-golang/v2/pkg/client/proxy"
+	"github.com/opentrx/seata-golang/v2/pkg/client/rm"
+	"github.com/opentrx/seata-golang/v2/pkg/client/tm"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/time"
+	"github.com/opentrx/seata-golang/v2/pkg/util/uuid"
+)
+
+const (
+	// ErrorCodeCompensationTriggered the error code of an instance ended by a CompensationTrigger state
+	ErrorCodeCompensationTriggered = "CompensationTriggered"
+
+	// ErrorCodeInterrupted the error code of an instance interrupted by a crash and recovered by
+	// RecoverStrategyCompensate
+	ErrorCodeInterrupted = "Interrupted"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// branchManager the part of rm.ResourceManager used by the engine
+type branchManager interface {
+	BranchRegister(ctx context.Context, xid string, resourceID string, branchType apis.BranchSession_BranchType,
+		applicationData []byte, lockKeys string, asyncCommit bool) (int64, error)
+
+	BranchReport(ctx context.Context, xid string, branchID int64, branchType apis.BranchSession_BranchType,
+		status apis.BranchSession_BranchStatus, applicationData []byte) error
+}
+
+// branchApplicationData the application data of a saga branch, it locates the execution to
+// compensate when the TC rolls back the branch
+type branchApplicationData struct {
+	InstanceID string `json:"instanceID"`
+	StateName  string `json:"stateName"`
+}
+
+// StateMachineEngine runs saga state machines. Every execution of a service task is registered
+// as a saga branch, the TC commits the global transaction once all the branches are done, or
+// calls back the engine to compensate the executed service tasks in reverse order.
+type StateMachineEngine struct {
+	store         Store
+	stateMachines sync.Map // string -> *StateMachine
+	services      sync.Map // string -> interface{}
+
+	// running the instances being executed in this process, string -> *Instance
+	running sync.Map
+
+	transactionManager tm.TransactionManagerInterface
+	resourceManager    branchManager
+}
+
+// NewStateMachineEngine create an engine which persists instances in the store
+func NewStateMachineEngine(store Store) *StateMachineEngine {
+	return &StateMachineEngine{store: store}
+}
+
+// RegisterStateMachine registers the state machine to the engine and its resource to the saga
+// resource manager, so that the branches of the state machine can be compensated.
+func (engine *StateMachineEngine) RegisterStateMachine(stateMachine *StateMachine) error {
+	if err := stateMachine.validate(); err != nil {
+		return err
+	}
+	engine.stateMachines.Store(stateMachine.Name, stateMachine)
+	sagaResourceManager.RegisterResource(&StateMachineResource{
+		StateMachine: stateMachine,
+		engine:       engine,
+	})
+	return nil
+}
+
+// RegisterService registers the service whose methods are invoked by the service tasks with
+// the ServiceName. The methods are registered by proxy.Register, an argument of type
+// context.Context receives the root context bound with the xid, the others receive the Input
+// of the state in order. A method fails if its last return value is a non-nil error, or it
+// only returns a false bool.
+func (engine *StateMachineEngine) RegisterService(name string, service interface{}) {
+	engine.services.Store(name, service)
+}
+
+// Start runs the state machine with the start parameters. If the root context is not in a
+// global transaction, the engine begins one and commits it if the instance succeeds or rolls
+// it back if the instance fails; otherwise the instance joins the global transaction and its
+// launcher decides the result. The instance is returned even if it fails, the error is only
+// returned if the instance can not be executed or persisted.
+func (engine *StateMachineEngine) Start(rootContext *ctx.RootContext, stateMachineName string,
+	params map[string]interface{}) (*Instance, error) {
+	stateMachine := engine.getStateMachine(stateMachineName)
+	if stateMachine == nil {
+		return nil, errors.Errorf("state machine %s is not registered", stateMachineName)
+	}
+
+	context := make(map[string]interface{}, len(params))
+	for key, value := range params {
+		context[key] = toContextValue(value)
+	}
+
+	instance := engine.newInstance(stateMachine, context)
+	if rootContext.InGlobalTransaction() {
+		instance.XID = rootContext.GetXID()
+	} else {
+		timeout := stateMachine.Timeout
+		if timeout <= 0 {
+			timeout = tm.DefaultGlobalTxTimeout
+		}
+		xid, err := engine.getTransactionManager().Begin(rootContext, stateMachine.Name, timeout)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		log.Infof("begin new global transaction [%s] for state machine %s", xid, stateMachine.Name)
+		rootContext.Bind(xid)
+		instance.XID = xid
+		instance.IsLauncher = true
+	}
+
+	engine.running.Store(instance.ID, instance)
+	defer engine.running.Delete(instance.ID)
+
+	if err := engine.store.Save(instance); err != nil {
+		if instance.IsLauncher {
+			engine.abort(rootContext, instance)
+		}
+		return nil, err
+	}
+	if err := engine.run(rootContext, stateMachine, instance, false); err != nil {
+		return instance, err
+	}
+	if instance.IsLauncher {
+		return instance, engine.end(rootContext, instance)
+	}
+	return instance, nil
+}
+
+// Recover resumes or compensates the instances left by a previous process, it should be called
+// once after the client is initialized and all the state machines and services are registered.
+// An interrupted instance whose global transaction is still running is recovered by the
+// RecoverStrategy of its state machine, the instances whose global transaction is finished are
+// removed from the store.
+func (engine *StateMachineEngine) Recover(ctx context.Context) {
+	instances, err := engine.store.List()
+	if err != nil {
+		log.Errorf("failed to list state machine instances, err: %v", err)
+		return
+	}
+
+	for _, instance := range instances {
+		if instance.ParentID != "" {
+			continue
+		}
+		if _, ok := engine.running.Load(instance.ID); ok {
+			continue
+		}
+
+		status, err := engine.getTransactionManager().GetStatus(ctx, instance.XID)
+		if err != nil {
+			log.Errorf("failed to get global status, xid: %s, err: %v", instance.XID, err)
+			continue
+		}
+		if isFinalGlobalStatus(status) {
+			if err = engine.deleteInstance(instance); err != nil {
+				log.Errorf("failed to delete state machine instance %s, err: %v", instance.ID, err)
+			}
+			continue
+		}
+		if status != apis.Begin {
+			// the global transaction is being committed or compensated by the TC
+			continue
+		}
+
+		stateMachine := engine.getStateMachine(instance.StateMachineName)
+		if stateMachine == nil {
+			log.Errorf("failed to recover state machine instance %s, state machine %s is not registered",
+				instance.ID, instance.StateMachineName)
+			continue
+		}
+		if err = engine.resume(ctx, stateMachine, instance); err != nil {
+			log.Errorf("failed to recover state machine instance %s, xid: %s, err: %v", instance.ID, instance.XID, err)
+			continue
+		}
+		log.Infof("recovered state machine instance %s, xid: %s, status: %s", instance.ID, instance.XID, instance.Status)
+	}
+}
+
+func (engine *StateMachineEngine) resume(context context.Context, stateMachine *StateMachine, instance *Instance) error {
+	rootContext := ctx.NewRootContext(context)
+	rootContext.Bind(instance.XID)
+
+	engine.running.Store(instance.ID, instance)
+	defer engine.running.Delete(instance.ID)
+
+	if !instance.IsFinished() {
+		if stateMachine.recoverStrategy() == RecoverStrategyCompensate {
+			err := engine.finish(instance, InstanceStatusFailed, ErrorCodeInterrupted,
+				fmt.Sprintf("interrupted at state %s", instance.CurrentState))
+			if err != nil {
+				return err
+			}
+		} else if err := engine.run(rootContext, stateMachine, instance, true); err != nil {
+			return err
+		}
+	}
+	if instance.IsLauncher {
+		return engine.end(rootContext, instance)
+	}
+	return nil
+}
+
+// run executes the instance from its current state until it reaches a Succeed or Fail state,
+// it only returns the error failed to persist the instance. If resuming, the current state may
+// have been executed before the crash and its execution is resumed instead of starting a new one.
+func (engine *StateMachineEngine) run(rootContext *ctx.RootContext, stateMachine *StateMachine, instance *Instance,
+	resuming bool) error {
+	for !instance.IsFinished() {
+		resumed := resuming
+		resuming = false
+		state := stateMachine.GetState(instance.CurrentState)
+		if state == nil {
+			return engine.finish(instance, InstanceStatusFailed, "",
+				fmt.Sprintf("state %s does not exist in state machine %s", instance.CurrentState, stateMachine.Name))
+		}
+
+		var (
+			next     string
+			stateErr error
+		)
+		switch state.Type {
+		case StateTypeServiceTask:
+			next, stateErr = state.Next, engine.executeServiceTask(rootContext, stateMachine, instance, state, resumed)
+		case StateTypeSubStateMachine:
+			next, stateErr = state.Next, engine.executeSubStateMachine(rootContext, instance, state, resumed)
+		case StateTypeChoice:
+			next, stateErr = choose(instance.Context, state)
+		case StateTypeCompensationTrigger:
+			if err := engine.finish(instance, InstanceStatusFailed, ErrorCodeCompensationTriggered,
+				fmt.Sprintf("compensation triggered by state %s", state.Name)); err != nil {
+				return err
+			}
+			continue
+		case StateTypeSucceed:
+			if err := engine.finish(instance, InstanceStatusSucceed, "", ""); err != nil {
+				return err
+			}
+			continue
+		case StateTypeFail:
+			if err := engine.finish(instance, InstanceStatusFailed, state.ErrorCode, state.Message); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if stateErr != nil {
+			log.Warnf("state %s of state machine instance %s failed, xid: %s, err: %v",
+				state.Name, instance.ID, instance.XID, stateErr)
+			catch := state.catch(stateErr)
+			if catch == nil {
+				if err := engine.finish(instance, InstanceStatusFailed, "", stateErr.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			next = catch.Next
+		}
+
+		if next == "" {
+			if err := engine.finish(instance, InstanceStatusSucceed, "", ""); err != nil {
+				return err
+			}
+			continue
+		}
+		err := engine.update(instance, func() {
+			instance.CurrentState = next
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeServiceTask registers a saga branch and invokes the service method. When resumed, an
+// execution left running by a crash is retried with the same branch, and the result of a
+// finished execution is reported again.
+func (engine *StateMachineEngine) executeServiceTask(rootContext *ctx.RootContext, stateMachine *StateMachine,
+	instance *Instance, state *State, resumed bool) error {
+	var execution *StateExecution
+	if resumed {
+		execution = instance.lastExecution(state.Name)
+	}
+	if execution != nil && execution.Status != ExecutionStatusRunning {
+		return engine.reportExecution(rootContext, instance, execution)
+	}
+
+	methodDesc, err := engine.getMethod(state.ServiceName, state.ServiceMethod)
+	if err != nil {
+		return err
+	}
+
+	if execution == nil {
+		applicationData, err := json.Marshal(&branchApplicationData{InstanceID: instance.ID, StateName: state.Name})
+		if err != nil {
+			return err
+		}
+		branchID, err := engine.getResourceManager().BranchRegister(rootContext, instance.XID, stateMachine.Name,
+			apis.SAGA, applicationData, "", false)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		execution = &StateExecution{
+			StateName: state.Name,
+			BranchID:  branchID,
+			Status:    ExecutionStatusRunning,
+		}
+		err = engine.update(instance, func() {
+			instance.Executions = append(instance.Executions, execution)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	result, invokeErr := invoke(rootContext, methodDesc, state.Input, instance.Context)
+	err = engine.update(instance, func() {
+		if invokeErr != nil {
+			execution.Status = ExecutionStatusFailed
+			execution.Error = invokeErr.Error()
+			return
+		}
+		execution.Status = ExecutionStatusSucceed
+		if state.Output != "" {
+			instance.Context[state.Output] = toContextValue(result)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return engine.reportExecution(rootContext, instance, execution)
+}
+
+// reportExecution reports the result of a finished service task to the TC, it returns the error
+// of the service method if the execution failed.
+func (engine *StateMachineEngine) reportExecution(rootContext *ctx.RootContext, instance *Instance, execution *StateExecution) error {
+	var executionErr error
+	branchStatus := apis.PhaseOneDone
+	if execution.Status != ExecutionStatusSucceed {
+		executionErr = errors.New(execution.Error)
+		branchStatus = apis.PhaseOneFailed
+	}
+	err := engine.getResourceManager().BranchReport(rootContext, instance.XID, execution.BranchID, apis.SAGA, branchStatus, nil)
+	if err != nil {
+		log.Errorf("failed to report saga branch, xid: %s, branchID: %d, err: %v", instance.XID, execution.BranchID, err)
+		if executionErr == nil {
+			return errors.WithStack(err)
+		}
+	}
+	return executionErr
+}
+
+// executeSubStateMachine runs a child instance in the same global transaction. When resumed, a
+// child left running by a crash is resumed too.
+func (engine *StateMachineEngine) executeSubStateMachine(rootContext *ctx.RootContext, instance *Instance, state *State,
+	resumed bool) error {
+	stateMachine := engine.getStateMachine(state.StateMachineName)
+	if stateMachine == nil {
+		return errors.Errorf("state machine %s is not registered", state.StateMachineName)
+	}
+
+	var (
+		execution *StateExecution
+		child     *Instance
+	)
+	if resumed {
+		execution = instance.lastExecution(state.Name)
+	}
+	if execution != nil {
+		switch execution.Status {
+		case ExecutionStatusSucceed:
+			return nil
+		case ExecutionStatusRunning:
+			loaded, err := engine.store.Load(execution.ChildID)
+			if err != nil && err != ErrInstanceNotFound {
+				return err
+			}
+			child = loaded
+		default:
+			return errors.New(execution.Error)
+		}
+	}
+
+	// the execution is recorded before the child is saved, a child which is not found has never
+	// been executed.
+	if child == nil {
+		context := make(map[string]interface{}, len(instance.Context))
+		for key, value := range instance.Context {
+			context[key] = value
+		}
+		child = engine.newInstance(stateMachine, context)
+		child.ParentID = instance.ID
+		child.XID = instance.XID
+
+		err := engine.update(instance, func() {
+			if execution == nil {
+				execution = &StateExecution{StateName: state.Name, Status: ExecutionStatusRunning}
+				instance.Executions = append(instance.Executions, execution)
+			}
+			execution.ChildID = child.ID
+		})
+		if err != nil {
+			return err
+		}
+		if err = engine.store.Save(child); err != nil {
+			return err
+		}
+		resumed = false
+	}
+
+	engine.running.Store(child.ID, child)
+	defer engine.running.Delete(child.ID)
+	if err := engine.run(rootContext, stateMachine, child, resumed); err != nil {
+		return err
+	}
+
+	var childErr error
+	if child.Status != InstanceStatusSucceed {
+		childErr = errors.Errorf("sub state machine %s failed: %s %s", stateMachine.Name, child.ErrorCode, child.Message)
+	}
+	err := engine.update(instance, func() {
+		if childErr != nil {
+			execution.Status = ExecutionStatusFailed
+			execution.Error = childErr.Error()
+			return
+		}
+		execution.Status = ExecutionStatusSucceed
+		if state.Output != "" {
+			instance.Context[state.Output] = toContextValue(child.Context)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return childErr
+}
+
+// compensate invokes the compensate state of the execution of the branch, it returns the status
+// the branch is in after the compensation.
+func (engine *StateMachineEngine) compensate(context context.Context, xid string, branchID int64,
+	applicationData []byte) apis.BranchSession_BranchStatus {
+	data := &branchApplicationData{}
+	if err := json.Unmarshal(applicationData, data); err != nil {
+		log.Errorf("failed to unmarshal saga branch application data, xid: %s, branchID: %d, err: %v", xid, branchID, err)
+		return apis.PhaseTwoRollbackFailedCanNotRetry
+	}
+
+	instance, err := engine.loadInstance(data.InstanceID)
+	if err == ErrInstanceNotFound {
+		log.Errorf("state machine instance %s of saga branch is lost, xid: %s, branchID: %d", data.InstanceID, xid, branchID)
+		return apis.PhaseTwoRollbackFailedCanNotRetry
+	}
+	if err != nil {
+		log.Errorf("failed to load state machine instance %s, xid: %s, branchID: %d, err: %v", data.InstanceID, xid, branchID, err)
+		return apis.PhaseTwoRollbackFailedRetryable
+	}
+
+	// the process crashed after registering the branch and before recording it, the service
+	// method has never been invoked.
+	execution := instance.getExecution(branchID)
+	if execution == nil || execution.Status == ExecutionStatusFailed || execution.Status == ExecutionStatusCompensated {
+		return apis.PhaseTwoRolledBack
+	}
+
+	stateMachine := engine.getStateMachine(instance.StateMachineName)
+	if stateMachine == nil {
+		log.Errorf("failed to compensate saga branch, state machine %s is not registered, xid: %s, branchID: %d",
+			instance.StateMachineName, xid, branchID)
+		return apis.PhaseTwoRollbackFailedRetryable
+	}
+	state := stateMachine.GetState(execution.StateName)
+	if state == nil {
+		log.Errorf("failed to compensate saga branch, state %s does not exist in state machine %s, xid: %s, branchID: %d",
+			execution.StateName, stateMachine.Name, xid, branchID)
+		return apis.PhaseTwoRollbackFailedCanNotRetry
+	}
+
+	var compensateErr error
+	if state.CompensateState != "" {
+		compensateState := stateMachine.GetState(state.CompensateState)
+		methodDesc, err := engine.getMethod(compensateState.ServiceName, compensateState.ServiceMethod)
+		if err != nil {
+			compensateErr = err
+		} else {
+			rootContext := ctx.NewRootContext(context)
+			rootContext.Bind(xid)
+			_, compensateErr = invoke(rootContext, methodDesc, compensateState.Input, instance.Context)
+		}
+	}
+
+	err = engine.update(instance, func() {
+		if compensateErr != nil {
+			execution.Status = ExecutionStatusCompensateFailed
+			execution.Error = compensateErr.Error()
+			return
+		}
+		execution.Status = ExecutionStatusCompensated
+		if instance.Status == InstanceStatusFailed && instance.isSettled() {
+			instance.Status = InstanceStatusCompensated
+		}
+	})
+	if err != nil {
+		log.Errorf("failed to save state machine instance %s, xid: %s, err: %v", instance.ID, xid, err)
+	}
+	if compensateErr != nil {
+		log.Errorf("failed to compensate saga branch, xid: %s, branchID: %d, state: %s, err: %v",
+			xid, branchID, state.Name, compensateErr)
+		return apis.PhaseTwoRollbackFailedRetryable
+	}
+	return apis.PhaseTwoRolledBack
+}
+
+// end commits the global transaction if the instance succeeds, otherwise rolls it back, the
+// instance is removed from the store once the global transaction is finished.
+func (engine *StateMachineEngine) end(rootContext *ctx.RootContext, instance *Instance) error {
+	defer rootContext.Unbind()
+
+	var (
+		status apis.GlobalSession_GlobalStatus
+		err    error
+	)
+	if instance.Status == InstanceStatusSucceed {
+		status, err = engine.getTransactionManager().Commit(rootContext, instance.XID)
+	} else {
+		status, err = engine.getTransactionManager().Rollback(rootContext, instance.XID)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	log.Infof("[%s] state machine instance %s status: %s, global status: %s", instance.XID, instance.ID,
+		instance.Status, status.String())
+
+	if status == apis.RolledBack || status == apis.TimeoutRolledBack {
+		err = engine.update(instance, func() {
+			instance.Status = InstanceStatusCompensated
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if isFinalGlobalStatus(status) {
+		return engine.deleteInstance(instance)
+	}
+	return nil
+}
+
+// abort rolls back the global transaction begun for an instance which can not be executed
+func (engine *StateMachineEngine) abort(rootContext *ctx.RootContext, instance *Instance) {
+	defer rootContext.Unbind()
+	if _, err := engine.getTransactionManager().Rollback(rootContext, instance.XID); err != nil {
+		log.Errorf("failed to rollback global transaction [%s], err: %v", instance.XID, err)
+	}
+}
+
+func (engine *StateMachineEngine) finish(instance *Instance, status InstanceStatus, errorCode, message string) error {
+	return engine.update(instance, func() {
+		instance.Status = status
+		instance.ErrorCode = errorCode
+		instance.Message = message
+		instance.EndTime = int64(time.CurrentTimeMillis())
+	})
+}
+
+// update changes the instance with the lock held and persists it
+func (engine *StateMachineEngine) update(instance *Instance, change func()) error {
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+	change()
+	return engine.store.Save(instance)
+}
+
+// loadInstance return the running instance if there is one, so that it is not overwritten by
+// a stale copy from the store
+func (engine *StateMachineEngine) loadInstance(id string) (*Instance, error) {
+	if instance, ok := engine.running.Load(id); ok {
+		return instance.(*Instance), nil
+	}
+	return engine.store.Load(id)
+}
+
+// deleteInstance removes the instance and its sub state machine instances from the store
+func (engine *StateMachineEngine) deleteInstance(instance *Instance) error {
+	for _, execution := range instance.Executions {
+		if execution.ChildID == "" {
+			continue
+		}
+		child, err := engine.store.Load(execution.ChildID)
+		if err == ErrInstanceNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = engine.deleteInstance(child); err != nil {
+			return err
+		}
+	}
+	return engine.store.Delete(instance.ID)
+}
+
+func (engine *StateMachineEngine) newInstance(stateMachine *StateMachine, context map[string]interface{}) *Instance {
+	return &Instance{
+		ID:               strconv.FormatInt(uuid.NextID(), 10),
+		StateMachineName: stateMachine.Name,
+		Status:           InstanceStatusRunning,
+		CurrentState:     stateMachine.StartState,
+		Context:          context,
+		Executions:       make([]*StateExecution, 0),
+		BeginTime:        int64(time.CurrentTimeMillis()),
+	}
+}
+
+func (engine *StateMachineEngine) getStateMachine(name string) *StateMachine {
+	stateMachine, ok := engine.stateMachines.Load(name)
+	if !ok {
+		return nil
+	}
+	return stateMachine.(*StateMachine)
+}
+
+func (engine *StateMachineEngine) getMethod(serviceName, methodName string) (*proxy.MethodDescriptor, error) {
+	service, ok := engine.services.Load(serviceName)
+	if !ok {
+		return nil, errors.Errorf("service %s is not registered", serviceName)
+	}
+	methodDesc := proxy.Register(service, methodName)
+	if methodDesc == nil {
+		return nil, errors.Errorf("service %s has no exported method %s", serviceName, methodName)
+	}
+	return methodDesc, nil
+}
+
+func (engine *StateMachineEngine) getTransactionManager() tm.TransactionManagerInterface {
+	if engine.transactionManager != nil {
+		return engine.transactionManager
+	}
+	return tm.GetTransactionManager()
+}
+
+func (engine *StateMachineEngine) getResourceManager() branchManager {
+	if engine.resourceManager != nil {
+		return engine.resourceManager
+	}
+	return rm.GetResourceManager()
+}
+
+// catch return the first catch which matches the error, nil if there is none
+func (state *State) catch(err error) *Catch {
+	for _, catch := range state.Catch {
+		if len(catch.Exceptions) == 0 {
+			return catch
+		}
+		for _, exception := range catch.Exceptions {
+			if strings.Contains(err.Error(), exception) {
+				return catch
+			}
+		}
+	}
+	return nil
+}
+
+// choose return the next state of the first choice whose expression is true, or the default
+func choose(context map[string]interface{}, state *State) (string, error) {
+	for _, choice := range state.Choices {
+		ok, err := evaluateExpression(choice.Expression, context)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return choice.Next, nil
+		}
+	}
+	if state.Default == "" {
+		return "", errors.Errorf("no choice of %s matches and there is no default", state.Name)
+	}
+	return state.Default, nil
+}
+
+// invoke calls the service method with the input resolved from the instance context, it
+// return the first return value which is not an error
+func invoke(rootContext *ctx.RootContext, methodDesc *proxy.MethodDescriptor, input []interface{},
+	context map[string]interface{}) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("service method %s panic: %v", methodDesc.Method.Name, r)
+		}
+	}()
+
+	args := make([]interface{}, 0, len(methodDesc.ArgsType))
+	i := 0
+	for _, argType := range methodDesc.ArgsType {
+		if argType == methodDesc.CtxType {
+			args = append(args, rootContext)
+			continue
+		}
+		if i >= len(input) {
+			return nil, errors.Errorf("service method %s needs more than %d inputs", methodDesc.Method.Name, len(input))
+		}
+		arg, err := convert(resolve(context, input[i]), argType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert input %d of service method %s", i, methodDesc.Method.Name)
+		}
+		args = append(args, arg)
+		i++
+	}
+
+	returnValues := proxy.Invoke(methodDesc, rootContext, args)
+	n := len(returnValues)
+	if n > 0 && methodDesc.ReturnValuesType[n-1] == errorType {
+		if errValue := returnValues[n-1]; !errValue.IsNil() {
+			return nil, errValue.Interface().(error)
+		}
+		n--
+	}
+	if n == 1 && returnValues[0].Kind() == reflect.Bool && !returnValues[0].Bool() {
+		return nil, errors.Errorf("service method %s returns false", methodDesc.Method.Name)
+	}
+	if n > 0 {
+		result = returnValues[0].Interface()
+	}
+	return result, nil
+}
+
+// convert converts a value of the instance context to the argument type by json
+func convert(value interface{}, argType reflect.Type) (interface{}, error) {
+	if value == nil {
+		return reflect.Zero(argType).Interface(), nil
+	}
+	if reflect.TypeOf(value).AssignableTo(argType) {
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	arg := reflect.New(argType)
+	if err = json.Unmarshal(data, arg.Interface()); err != nil {
+		return nil, err
+	}
+	return arg.Elem().Interface(), nil
+}
+
+// toContextValue converts the value to what it is after the instance is persisted and loaded,
+// so that a resumed instance sees the same context.
+func toContextValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Warnf("failed to marshal %v to the state machine context, err: %v", value, err)
+		return value
+	}
+	var contextValue interface{}
+	if err = json.Unmarshal(data, &contextValue); err != nil {
+		return value
+	}
+	return contextValue
+}
+
+func isFinalGlobalStatus(status apis.GlobalSession_GlobalStatus) bool {
+	switch status {
+	case apis.Committed, apis.CommitFailed, apis.RolledBack, apis.RollbackFailed, apis.TimeoutRolledBack,
+		apis.TimeoutRollbackFailed, apis.Finished:
+		return true
+	default:
+		return false
+	}
+}
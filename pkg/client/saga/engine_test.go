@@ -0,0 +1,251 @@
+package saga
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	ctx "github.com/opentrx/seata-golang/v2/pkg/client/base/context"
+)
+
+type InventoryService struct {
+	reduced     []string
+	compensated []string
+}
+
+type Reduction struct {
+	Count int `json:"count"`
+}
+
+// inventoryService is shared by the tests, proxy.Register keeps the first service of a type
+var inventoryService = &InventoryService{}
+
+func (svc *InventoryService) Reduce(ctx context.Context, productID string, reduction *Reduction) (int, error) {
+	if reduction.Count > 10 {
+		return 0, errors.New("not enough inventory")
+	}
+	svc.reduced = append(svc.reduced, productID)
+	return 10 - reduction.Count, nil
+}
+
+func (svc *InventoryService) Compensate(ctx context.Context, productID string) bool {
+	svc.compensated = append(svc.compensated, productID)
+	return true
+}
+
+// fakeCoordinator plays the TC, it compensates the registered branches in reverse order when
+// the global transaction is rolled back
+type fakeCoordinator struct {
+	branches []*apis.BranchRegisterRequest
+	statuses map[int64]apis.BranchSession_BranchStatus
+}
+
+func newFakeCoordinator() *fakeCoordinator {
+	return &fakeCoordinator{statuses: make(map[int64]apis.BranchSession_BranchStatus)}
+}
+
+func (tc *fakeCoordinator) Begin(ctx context.Context, name string, timeout int32) (string, error) {
+	return "localhost:123", nil
+}
+
+func (tc *fakeCoordinator) Commit(ctx context.Context, xid string) (apis.GlobalSession_GlobalStatus, error) {
+	return apis.Committed, nil
+}
+
+func (tc *fakeCoordinator) Rollback(ctx context.Context, xid string) (apis.GlobalSession_GlobalStatus, error) {
+	for i := len(tc.branches) - 1; i >= 0; i-- {
+		branch := tc.branches[i]
+		branchID := int64(i + 1)
+		if tc.statuses[branchID] == apis.PhaseOneFailed {
+			continue
+		}
+		response, err := GetSagaResourceManager().BranchRollback(ctx, &apis.BranchRollbackRequest{
+			XID:             xid,
+			BranchID:        branchID,
+			ResourceID:      branch.ResourceID,
+			BranchType:      apis.SAGA,
+			ApplicationData: branch.ApplicationData,
+		})
+		if err != nil || response.BranchStatus != apis.PhaseTwoRolledBack {
+			return apis.RollbackRetrying, err
+		}
+	}
+	return apis.RolledBack, nil
+}
+
+func (tc *fakeCoordinator) GetStatus(ctx context.Context, xid string) (apis.GlobalSession_GlobalStatus, error) {
+	return apis.Begin, nil
+}
+
+func (tc *fakeCoordinator) GlobalReport(ctx context.Context, xid string,
+	globalStatus apis.GlobalSession_GlobalStatus) (apis.GlobalSession_GlobalStatus, error) {
+	return globalStatus, nil
+}
+
+func (tc *fakeCoordinator) BranchRegister(ctx context.Context, xid string, resourceID string,
+	branchType apis.BranchSession_BranchType, applicationData []byte, lockKeys string, asyncCommit bool) (int64, error) {
+	tc.branches = append(tc.branches, &apis.BranchRegisterRequest{
+		XID:             xid,
+		ResourceID:      resourceID,
+		BranchType:      branchType,
+		ApplicationData: applicationData,
+	})
+	return int64(len(tc.branches)), nil
+}
+
+func (tc *fakeCoordinator) BranchReport(ctx context.Context, xid string, branchID int64,
+	branchType apis.BranchSession_BranchType, status apis.BranchSession_BranchStatus, applicationData []byte) error {
+	tc.statuses[branchID] = status
+	return nil
+}
+
+func newTestEngine(t *testing.T, store Store) (*StateMachineEngine, *InventoryService, *fakeCoordinator) {
+	tc := newFakeCoordinator()
+	engine := NewStateMachineEngine(store)
+	engine.transactionManager = tc
+	engine.resourceManager = tc
+
+	service := inventoryService
+	service.reduced, service.compensated = nil, nil
+	engine.RegisterService("inventory", service)
+
+	stateMachine, err := ParseStateMachine([]byte(`
+Name: reduceTwice
+StartState: ReduceFirst
+States:
+  ReduceFirst:
+    Type: ServiceTask
+    ServiceName: inventory
+    ServiceMethod: Reduce
+    Input: ["$.first", {count: "$.count"}]
+    Output: left
+    CompensateState: CompensateFirst
+    Next: ReduceSecond
+  CompensateFirst:
+    Type: ServiceTask
+    ServiceName: inventory
+    ServiceMethod: Compensate
+    Input: ["$.first"]
+    IsForCompensation: true
+  ReduceSecond:
+    Type: ServiceTask
+    ServiceName: inventory
+    ServiceMethod: Reduce
+    Input: ["$.second", {count: "$.count"}]
+    CompensateState: CompensateSecond
+    Next: CheckLeft
+  CompensateSecond:
+    Type: ServiceTask
+    ServiceName: inventory
+    ServiceMethod: Compensate
+    Input: ["$.second"]
+    IsForCompensation: true
+  CheckLeft:
+    Type: Choice
+    Choices:
+      - Expression: "$.left < 5"
+        Next: Compensate
+    Default: Done
+  Compensate:
+    Type: CompensationTrigger
+  Done:
+    Type: Succeed
+`))
+	assert.NoError(t, err)
+	assert.NoError(t, engine.RegisterStateMachine(stateMachine))
+	return engine, service, tc
+}
+
+func TestStateMachineEngine_Start(t *testing.T) {
+	t.Run("succeed", func(t *testing.T) {
+		store := NewMemoryStore()
+		engine, service, tc := newTestEngine(t, store)
+
+		instance, err := engine.Start(ctx.NewRootContext(context.Background()), "reduceTwice",
+			map[string]interface{}{"first": "apple", "second": "pear", "count": 2})
+		assert.NoError(t, err)
+		assert.Equal(t, InstanceStatusSucceed, instance.Status)
+		assert.Equal(t, float64(8), instance.Context["left"])
+		assert.Equal(t, []string{"apple", "pear"}, service.reduced)
+		assert.Len(t, tc.branches, 2)
+		assert.Equal(t, apis.PhaseOneDone, tc.statuses[1])
+		assert.Equal(t, apis.PhaseOneDone, tc.statuses[2])
+
+		_, err = store.Load(instance.ID)
+		assert.Equal(t, ErrInstanceNotFound, err)
+	})
+
+	t.Run("service task failed", func(t *testing.T) {
+		engine, service, tc := newTestEngine(t, NewMemoryStore())
+
+		instance, err := engine.Start(ctx.NewRootContext(context.Background()), "reduceTwice",
+			map[string]interface{}{"first": "apple", "second": "pear", "count": 20})
+		assert.NoError(t, err)
+		assert.Equal(t, InstanceStatusCompensated, instance.Status)
+		assert.Contains(t, instance.Message, "not enough inventory")
+		assert.Empty(t, service.reduced)
+		assert.Empty(t, service.compensated)
+		assert.Equal(t, apis.PhaseOneFailed, tc.statuses[1])
+	})
+
+	t.Run("compensation triggered", func(t *testing.T) {
+		engine, service, _ := newTestEngine(t, NewMemoryStore())
+
+		instance, err := engine.Start(ctx.NewRootContext(context.Background()), "reduceTwice",
+			map[string]interface{}{"first": "apple", "second": "pear", "count": 6})
+		assert.NoError(t, err)
+		assert.Equal(t, InstanceStatusCompensated, instance.Status)
+		assert.Equal(t, ErrorCodeCompensationTriggered, instance.ErrorCode)
+		assert.Equal(t, []string{"pear", "apple"}, service.compensated)
+		for _, execution := range instance.Executions {
+			assert.Equal(t, ExecutionStatusCompensated, execution.Status)
+		}
+	})
+}
+
+func TestStateMachineEngine_Recover(t *testing.T) {
+	store := NewMemoryStore()
+	engine, service, tc := newTestEngine(t, store)
+	stateMachine := engine.getStateMachine("reduceTwice")
+
+	// the process crashed while executing ReduceSecond
+	instance := engine.newInstance(stateMachine, map[string]interface{}{"first": "apple", "second": "pear", "count": float64(2)})
+	instance.XID = "localhost:123"
+	instance.IsLauncher = true
+	instance.Context["left"] = float64(8)
+	instance.CurrentState = "ReduceSecond"
+	instance.Executions = append(instance.Executions,
+		&StateExecution{StateName: "ReduceFirst", BranchID: 1, Status: ExecutionStatusSucceed},
+		&StateExecution{StateName: "ReduceSecond", BranchID: 2, Status: ExecutionStatusRunning})
+	tc.branches = append(tc.branches,
+		&apis.BranchRegisterRequest{ResourceID: "reduceTwice", ApplicationData: []byte(`{"instanceID":"` + instance.ID + `","stateName":"ReduceFirst"}`)},
+		&apis.BranchRegisterRequest{ResourceID: "reduceTwice", ApplicationData: []byte(`{"instanceID":"` + instance.ID + `","stateName":"ReduceSecond"}`)})
+	assert.NoError(t, store.Save(instance))
+
+	t.Run("compensate", func(t *testing.T) {
+		engine.Recover(context.Background())
+		assert.Equal(t, []string{"pear", "apple"}, service.compensated)
+		_, err := store.Load(instance.ID)
+		assert.Equal(t, ErrInstanceNotFound, err)
+	})
+
+	t.Run("forward", func(t *testing.T) {
+		stateMachine.RecoverStrategy = RecoverStrategyForward
+		defer func() {
+			stateMachine.RecoverStrategy = ""
+		}()
+		service.reduced, service.compensated = nil, nil
+		assert.NoError(t, store.Save(instance))
+
+		engine.Recover(context.Background())
+		assert.Empty(t, service.compensated)
+		assert.Equal(t, []string{"pear"}, service.reduced)
+		assert.Len(t, tc.branches, 2)
+		assert.Equal(t, apis.PhaseOneDone, tc.statuses[2])
+		_, err := store.Load(instance.ID)
+		assert.Equal(t, ErrInstanceNotFound, err)
+	})
+}
@@ -0,0 +1,437 @@
+package saga
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const variablePrefix = "$."
+
+// isVariable return true if the value refers to a variable of the instance context
+func isVariable(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, variablePrefix)
+}
+
+// resolve return the variable the value refers to, or the value itself if it is not a variable
+func resolve(context map[string]interface{}, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, variablePrefix) {
+			return lookup(context, v[len(variablePrefix):])
+		}
+		return v
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[key] = resolve(context, val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, val := range v {
+			s[i] = resolve(context, val)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// lookup return the value of a dotted path like `order.amount`, nil if it does not exist
+func lookup(context map[string]interface{}, path string) interface{} {
+	var current interface{} = context
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
+
+func toString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// expression a boolean expression of a choice, it supports variables, string, number, boolean
+// and null literals, the comparison operators ==, !=, >, >=, <, <=, the logical operators
+// &&, ||, ! and parentheses.
+type expression interface {
+	evaluate(context map[string]interface{}) interface{}
+}
+
+type literalExpression struct {
+	value interface{}
+}
+
+func (e *literalExpression) evaluate(map[string]interface{}) interface{} {
+	return e.value
+}
+
+type variableExpression struct {
+	path string
+}
+
+func (e *variableExpression) evaluate(context map[string]interface{}) interface{} {
+	return lookup(context, e.path)
+}
+
+type notExpression struct {
+	operand expression
+}
+
+func (e *notExpression) evaluate(context map[string]interface{}) interface{} {
+	return !truthy(e.operand.evaluate(context))
+}
+
+type binaryExpression struct {
+	operator    string
+	left, right expression
+}
+
+func (e *binaryExpression) evaluate(context map[string]interface{}) interface{} {
+	switch e.operator {
+	case "&&":
+		return truthy(e.left.evaluate(context)) && truthy(e.right.evaluate(context))
+	case "||":
+		return truthy(e.left.evaluate(context)) || truthy(e.right.evaluate(context))
+	}
+
+	left, right := e.left.evaluate(context), e.right.evaluate(context)
+	switch e.operator {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	}
+
+	c, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch e.operator {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	default:
+		if f, ok := toFloat(v); ok {
+			return f != 0
+		}
+		return true
+	}
+}
+
+func equal(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	if l, ok := toFloat(left); ok {
+		if r, ok := toFloat(right); ok {
+			return l == r
+		}
+	}
+	return toString(left) == toString(right)
+}
+
+func compare(left, right interface{}) (int, bool) {
+	if l, ok := toFloat(left); ok {
+		if r, ok := toFloat(right); ok {
+			switch {
+			case l < r:
+				return -1, true
+			case l > r:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+		return 0, false
+	}
+	l, ok1 := left.(string)
+	r, ok2 := right.(string)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return strings.Compare(l, r), true
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// evaluateExpression return the boolean value of the expression
+func evaluateExpression(text string, context map[string]interface{}) (bool, error) {
+	e, err := parseExpression(text)
+	if err != nil {
+		return false, err
+	}
+	return truthy(e.evaluate(context)), nil
+}
+
+func parseExpression(text string) (expression, error) {
+	tokens, err := tokenizeExpression(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, errors.Errorf("expression is empty")
+	}
+	p := &expressionParser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid expression %q", text)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errors.Errorf("invalid expression %q: unexpected %s", text, p.tokens[p.pos].text)
+	}
+	return e, nil
+}
+
+type expressionTokenKind byte
+
+const (
+	tokenOperator expressionTokenKind = iota
+	tokenVariable
+	tokenString
+	tokenNumber
+	tokenIdentifier
+)
+
+type expressionToken struct {
+	kind expressionTokenKind
+	text string
+}
+
+func tokenizeExpression(text string) ([]expressionToken, error) {
+	tokens := make([]expressionToken, 0)
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'' || c == '"':
+			j := i + 1
+			var sb strings.Builder
+			for ; j < len(text) && text[j] != c; j++ {
+				if text[j] == '\\' && j+1 < len(text) {
+					j++
+				}
+				sb.WriteByte(text[j])
+			}
+			if j >= len(text) {
+				return nil, errors.Errorf("unterminated string in expression %q", text)
+			}
+			tokens = append(tokens, expressionToken{kind: tokenString, text: sb.String()})
+			i = j + 1
+		case c == '$':
+			j := i + 1
+			for j < len(text) && (isIdentifierChar(text[j]) || text[j] == '.') {
+				j++
+			}
+			if !strings.HasPrefix(text[i:j], variablePrefix) || j == i+len(variablePrefix) {
+				return nil, errors.Errorf("invalid variable %q in expression %q", text[i:j], text)
+			}
+			tokens = append(tokens, expressionToken{kind: tokenVariable, text: text[i+len(variablePrefix) : j]})
+			i = j
+		case c >= '0' && c <= '9' || c == '-' && i+1 < len(text) && text[i+1] >= '0' && text[i+1] <= '9':
+			j := i + 1
+			for j < len(text) && (text[j] >= '0' && text[j] <= '9' || text[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, expressionToken{kind: tokenNumber, text: text[i:j]})
+			i = j
+		case isIdentifierChar(c):
+			j := i + 1
+			for j < len(text) && isIdentifierChar(text[j]) {
+				j++
+			}
+			tokens = append(tokens, expressionToken{kind: tokenIdentifier, text: text[i:j]})
+			i = j
+		default:
+			op := ""
+			for _, candidate := range []string{"==", "!=", ">=", "<=", "&&", "||", ">", "<", "!", "(", ")"} {
+				if strings.HasPrefix(text[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, errors.Errorf("unexpected %q in expression %q", c, text)
+			}
+			tokens = append(tokens, expressionToken{kind: tokenOperator, text: op})
+			i += len(op)
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+type expressionParser struct {
+	tokens []expressionToken
+	pos    int
+}
+
+func (p *expressionParser) peekOperator(ops ...string) string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOperator {
+		return ""
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *expressionParser) parseOr() (expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOperator("||") != "" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpression{operator: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseAnd() (expression, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekOperator("&&") != "" {
+		p.pos++
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpression{operator: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseComparison() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if op := p.peekOperator("==", "!=", ">=", "<=", ">", "<"); op != "" {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryExpression{operator: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseUnary() (expression, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("unexpected end")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	switch token.kind {
+	case tokenVariable:
+		return &variableExpression{path: token.text}, nil
+	case tokenString:
+		return &literalExpression{value: token.text}, nil
+	case tokenNumber:
+		f, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &literalExpression{value: f}, nil
+	case tokenIdentifier:
+		switch token.text {
+		case "true":
+			return &literalExpression{value: true}, nil
+		case "false":
+			return &literalExpression{value: false}, nil
+		case "null", "nil":
+			return &literalExpression{value: nil}, nil
+		}
+		return nil, errors.Errorf("unexpected %s", token.text)
+	default:
+		switch token.text {
+		case "!":
+			operand, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return &notExpression{operand: operand}, nil
+		case "(":
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if p.peekOperator(")") == "" {
+				return nil, errors.New("missing )")
+			}
+			p.pos++
+			return e, nil
+		}
+		return nil, errors.Errorf("unexpected %s", token.text)
+	}
+}
@@ -0,0 +1,116 @@
+package saga
+
+import (
+	"sync"
+)
+
+// InstanceStatus the status of a state machine instance
+type InstanceStatus string
+
+const (
+	InstanceStatusRunning InstanceStatus = "Running"
+
+	InstanceStatusSucceed InstanceStatus = "Succeed"
+
+	InstanceStatusFailed InstanceStatus = "Failed"
+
+	// InstanceStatusCompensated all the executed states of a failed instance have been compensated
+	InstanceStatusCompensated InstanceStatus = "Compensated"
+)
+
+// ExecutionStatus the status of a state execution
+type ExecutionStatus string
+
+const (
+	ExecutionStatusRunning ExecutionStatus = "Running"
+
+	ExecutionStatusSucceed ExecutionStatus = "Succeed"
+
+	ExecutionStatusFailed ExecutionStatus = "Failed"
+
+	ExecutionStatusCompensated ExecutionStatus = "Compensated"
+
+	// ExecutionStatusCompensateFailed the compensation failed and will be retried
+	ExecutionStatusCompensateFailed ExecutionStatus = "CompensateFailed"
+)
+
+// Instance an execution of a state machine, it is persisted in the Store after every step so
+// that it can be resumed or compensated after a crash.
+type Instance struct {
+	ID               string `json:"id"`
+	StateMachineName string `json:"stateMachineName"`
+
+	// ParentID the id of the instance which runs this one as a sub state machine
+	ParentID string `json:"parentID,omitempty"`
+
+	XID string `json:"xid"`
+
+	// IsLauncher true if the instance begins the global transaction and commits or rolls it back
+	IsLauncher bool `json:"isLauncher"`
+
+	Status       InstanceStatus `json:"status"`
+	CurrentState string         `json:"currentState"`
+
+	// Context the variables of the instance, it starts with the start parameters
+	Context map[string]interface{} `json:"context"`
+
+	// Executions the executed service tasks and sub state machines in order
+	Executions []*StateExecution `json:"executions"`
+
+	ErrorCode string `json:"errorCode,omitempty"`
+	Message   string `json:"message,omitempty"`
+
+	BeginTime int64 `json:"beginTime"`
+	EndTime   int64 `json:"endTime,omitempty"`
+
+	mu sync.Mutex
+}
+
+// StateExecution an execution of a service task or a sub state machine
+type StateExecution struct {
+	StateName string `json:"stateName"`
+
+	// BranchID the saga branch of a service task
+	BranchID int64 `json:"branchID,omitempty"`
+
+	// ChildID the instance id of a sub state machine
+	ChildID string `json:"childID,omitempty"`
+
+	Status ExecutionStatus `json:"status"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// IsFinished return true if the instance has reached a Succeed or Fail state
+func (instance *Instance) IsFinished() bool {
+	return instance.Status != InstanceStatusRunning
+}
+
+func (instance *Instance) lastExecution(stateName string) *StateExecution {
+	if len(instance.Executions) == 0 {
+		return nil
+	}
+	execution := instance.Executions[len(instance.Executions)-1]
+	if execution.StateName != stateName {
+		return nil
+	}
+	return execution
+}
+
+func (instance *Instance) getExecution(branchID int64) *StateExecution {
+	for _, execution := range instance.Executions {
+		if execution.BranchID == branchID {
+			return execution
+		}
+	}
+	return nil
+}
+
+// isSettled return true if no executed state is left to compensate
+func (instance *Instance) isSettled() bool {
+	for _, execution := range instance.Executions {
+		if execution.Status != ExecutionStatusCompensated && execution.Status != ExecutionStatusFailed {
+			return false
+		}
+	}
+	return true
+}
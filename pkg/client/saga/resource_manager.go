@@ -0,0 +1,90 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/base/model"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+var sagaResourceManager SagaResourceManager
+
+// StateMachineResource the resource of the saga branches registered by a state machine
+type StateMachineResource struct {
+	StateMachine *StateMachine
+	engine       *StateMachineEngine
+}
+
+func (resource *StateMachineResource) GetResourceID() string {
+	return resource.StateMachine.Name
+}
+
+func (resource *StateMachineResource) GetBranchType() apis.BranchSession_BranchType {
+	return apis.SAGA
+}
+
+// SagaResourceManager compensates saga branches by the engine which runs the state machine
+type SagaResourceManager struct {
+	ResourceCache *sync.Map // string -> *StateMachineResource
+}
+
+func init() {
+	sagaResourceManager = SagaResourceManager{ResourceCache: &sync.Map{}}
+}
+
+func GetSagaResourceManager() SagaResourceManager {
+	return sagaResourceManager
+}
+
+// BranchCommit saga branches are committed forward only in phase one, the TC never calls back
+// the client to commit them.
+func (resourceManager SagaResourceManager) BranchCommit(ctx context.Context, request *apis.BranchCommitRequest) (*apis.BranchCommitResponse, error) {
+	return &apis.BranchCommitResponse{
+		ResultCode:   apis.ResultCodeSuccess,
+		XID:          request.XID,
+		BranchID:     request.BranchID,
+		BranchStatus: apis.PhaseTwoCommitted,
+	}, nil
+}
+
+func (resourceManager SagaResourceManager) BranchRollback(ctx context.Context, request *apis.BranchRollbackRequest) (*apis.BranchRollbackResponse, error) {
+	resource := resourceManager.getResource(request.ResourceID)
+	if resource == nil {
+		log.Errorf("saga resource is not exist, resourceID: %s", request.ResourceID)
+		return &apis.BranchRollbackResponse{
+			ResultCode: apis.ResultCodeFailed,
+			Message:    fmt.Sprintf("saga resource is not exist, resourceID: %s", request.ResourceID),
+		}, nil
+	}
+
+	branchStatus := resource.engine.compensate(ctx, request.XID, request.BranchID, request.ApplicationData)
+	return &apis.BranchRollbackResponse{
+		ResultCode:   apis.ResultCodeSuccess,
+		XID:          request.XID,
+		BranchID:     request.BranchID,
+		BranchStatus: branchStatus,
+	}, nil
+}
+
+func (resourceManager SagaResourceManager) RegisterResource(resource model.Resource) {
+	resourceManager.ResourceCache.Store(resource.GetResourceID(), resource)
+}
+
+func (resourceManager SagaResourceManager) UnregisterResource(resource model.Resource) {
+	resourceManager.ResourceCache.Delete(resource.GetResourceID())
+}
+
+func (resourceManager SagaResourceManager) GetBranchType() apis.BranchSession_BranchType {
+	return apis.SAGA
+}
+
+func (resourceManager SagaResourceManager) getResource(resourceID string) *StateMachineResource {
+	resource, ok := resourceManager.ResourceCache.Load(resourceID)
+	if !ok {
+		return nil
+	}
+	return resource.(*StateMachineResource)
+}
@@ -0,0 +1,277 @@
+package saga
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// StateType the type of a state
+type StateType string
+
+const (
+	// StateTypeServiceTask invokes a registered service method, each execution is a saga branch
+	StateTypeServiceTask StateType = "ServiceTask"
+
+	// StateTypeChoice selects the next state by the first choice whose expression is true
+	StateTypeChoice StateType = "Choice"
+
+	// StateTypeSubStateMachine runs another state machine in the same global transaction
+	StateTypeSubStateMachine StateType = "SubStateMachine"
+
+	// StateTypeCompensationTrigger compensates the executed states in reverse order
+	StateTypeCompensationTrigger StateType = "CompensationTrigger"
+
+	// StateTypeSucceed ends the state machine successfully
+	StateTypeSucceed StateType = "Succeed"
+
+	// StateTypeFail ends the state machine with failure
+	StateTypeFail StateType = "Fail"
+)
+
+// RecoverStrategy how an instance interrupted by a crash is recovered while its global
+// transaction is still running
+type RecoverStrategy string
+
+const (
+	// RecoverStrategyCompensate rolls back the global transaction, the executed states are compensated
+	RecoverStrategyCompensate RecoverStrategy = "Compensate"
+
+	// RecoverStrategyForward retries the interrupted state and goes on executing the state machine
+	RecoverStrategyForward RecoverStrategy = "Forward"
+)
+
+// StateMachine the definition of a saga state machine
+type StateMachine struct {
+	Name       string `json:"Name" yaml:"Name"`
+	Comment    string `json:"Comment,omitempty" yaml:"Comment,omitempty"`
+	StartState string `json:"StartState" yaml:"StartState"`
+
+	// RecoverStrategy default is RecoverStrategyCompensate
+	RecoverStrategy RecoverStrategy `json:"RecoverStrategy,omitempty" yaml:"RecoverStrategy,omitempty"`
+
+	// Timeout the timeout of the global transaction begun by the state machine in milliseconds
+	Timeout int32 `json:"Timeout,omitempty" yaml:"Timeout,omitempty"`
+
+	States map[string]*State `json:"States" yaml:"States"`
+}
+
+// State a state of a state machine
+type State struct {
+	// Name the key of the state in StateMachine.States
+	Name string `json:"-" yaml:"-"`
+
+	Type    StateType `json:"Type" yaml:"Type"`
+	Comment string    `json:"Comment,omitempty" yaml:"Comment,omitempty"`
+
+	// Next the state executed after this one, the state machine succeeds if it is empty
+	Next string `json:"Next,omitempty" yaml:"Next,omitempty"`
+
+	// ServiceName the name the service is registered with by StateMachineEngine.RegisterService
+	ServiceName   string `json:"ServiceName,omitempty" yaml:"ServiceName,omitempty"`
+	ServiceMethod string `json:"ServiceMethod,omitempty" yaml:"ServiceMethod,omitempty"`
+
+	// Input the arguments of the service method other than context.Context, a string like
+	// `$.orderID` or `$.order.amount` refers to a variable of the instance context, any other
+	// value is passed as it is.
+	Input []interface{} `json:"Input,omitempty" yaml:"Input,omitempty"`
+
+	// Output the variable of the instance context the result is stored in, the result of a
+	// service task is the first return value of the method, the result of a sub state machine
+	// is its instance context.
+	Output string `json:"Output,omitempty" yaml:"Output,omitempty"`
+
+	// CompensateState the state which compensates this one
+	CompensateState string `json:"CompensateState,omitempty" yaml:"CompensateState,omitempty"`
+
+	// IsForCompensation true if the state is only used to compensate other states
+	IsForCompensation bool `json:"IsForCompensation,omitempty" yaml:"IsForCompensation,omitempty"`
+
+	// Catch routes a failed service task or sub state machine to another state
+	Catch []*Catch `json:"Catch,omitempty" yaml:"Catch,omitempty"`
+
+	Choices []*Choice `json:"Choices,omitempty" yaml:"Choices,omitempty"`
+	Default string    `json:"Default,omitempty" yaml:"Default,omitempty"`
+
+	// StateMachineName the state machine run by a SubStateMachine state, it starts with a copy
+	// of the instance context.
+	StateMachineName string `json:"StateMachineName,omitempty" yaml:"StateMachineName,omitempty"`
+
+	ErrorCode string `json:"ErrorCode,omitempty" yaml:"ErrorCode,omitempty"`
+	Message   string `json:"Message,omitempty" yaml:"Message,omitempty"`
+}
+
+// Catch goes to Next if the error message contains one of the Exceptions, an empty Exceptions
+// catches all errors.
+type Catch struct {
+	Exceptions []string `json:"Exceptions,omitempty" yaml:"Exceptions,omitempty"`
+	Next       string   `json:"Next" yaml:"Next"`
+}
+
+// Choice goes to Next if the Expression is true, eg: `$.amount > 100 && $.vip == true`
+type Choice struct {
+	Expression string `json:"Expression" yaml:"Expression"`
+	Next       string `json:"Next" yaml:"Next"`
+}
+
+// ParseStateMachine parses a JSON or YAML state machine definition
+func ParseStateMachine(data []byte) (*StateMachine, error) {
+	stateMachine := &StateMachine{}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, stateMachine); err != nil {
+			return nil, errors.Wrap(err, "failed to parse state machine json")
+		}
+	} else {
+		if err := yaml.Unmarshal(trimmed, stateMachine); err != nil {
+			return nil, errors.Wrap(err, "failed to parse state machine yaml")
+		}
+	}
+
+	for name, state := range stateMachine.States {
+		if state == nil {
+			return nil, errors.Errorf("state machine %s: state %s is empty", stateMachine.Name, name)
+		}
+		state.Name = name
+		for i, input := range state.Input {
+			state.Input[i] = normalize(input)
+		}
+	}
+	if err := stateMachine.validate(); err != nil {
+		return nil, err
+	}
+	return stateMachine, nil
+}
+
+// LoadStateMachine reads and parses a JSON or YAML state machine definition file
+func LoadStateMachine(path string) (*StateMachine, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStateMachine(data)
+}
+
+// GetState return the state of the name, nil if there is none
+func (stateMachine *StateMachine) GetState(name string) *State {
+	return stateMachine.States[name]
+}
+
+func (stateMachine *StateMachine) recoverStrategy() RecoverStrategy {
+	if stateMachine.RecoverStrategy == "" {
+		return RecoverStrategyCompensate
+	}
+	return stateMachine.RecoverStrategy
+}
+
+func (stateMachine *StateMachine) validate() error {
+	if stateMachine.Name == "" {
+		return errors.New("state machine name is empty")
+	}
+	if stateMachine.RecoverStrategy != "" && stateMachine.RecoverStrategy != RecoverStrategyCompensate &&
+		stateMachine.RecoverStrategy != RecoverStrategyForward {
+		return errors.Errorf("state machine %s: unknown recover strategy %s", stateMachine.Name, stateMachine.RecoverStrategy)
+	}
+	start := stateMachine.GetState(stateMachine.StartState)
+	if start == nil {
+		return errors.Errorf("state machine %s: start state %s does not exist", stateMachine.Name, stateMachine.StartState)
+	}
+	if start.IsForCompensation {
+		return errors.Errorf("state machine %s: start state %s is for compensation", stateMachine.Name, start.Name)
+	}
+
+	checkTarget := func(state *State, target string) error {
+		if target == "" {
+			return nil
+		}
+		next := stateMachine.GetState(target)
+		if next == nil {
+			return errors.Errorf("state machine %s: state %s goes to %s which does not exist", stateMachine.Name, state.Name, target)
+		}
+		if next.IsForCompensation {
+			return errors.Errorf("state machine %s: state %s goes to %s which is for compensation", stateMachine.Name, state.Name, target)
+		}
+		return nil
+	}
+
+	for _, state := range stateMachine.States {
+		if err := checkTarget(state, state.Next); err != nil {
+			return err
+		}
+		for _, catch := range state.Catch {
+			if catch == nil || catch.Next == "" {
+				return errors.Errorf("state machine %s: state %s has a catch without next", stateMachine.Name, state.Name)
+			}
+			if err := checkTarget(state, catch.Next); err != nil {
+				return err
+			}
+		}
+
+		switch state.Type {
+		case StateTypeServiceTask:
+			if state.ServiceName == "" || state.ServiceMethod == "" {
+				return errors.Errorf("state machine %s: service task %s has no service name or method", stateMachine.Name, state.Name)
+			}
+			if state.CompensateState != "" {
+				compensation := stateMachine.GetState(state.CompensateState)
+				if compensation == nil || compensation.Type != StateTypeServiceTask || !compensation.IsForCompensation {
+					return errors.Errorf("state machine %s: compensate state %s of %s is not a service task for compensation",
+						stateMachine.Name, state.CompensateState, state.Name)
+				}
+			}
+		case StateTypeChoice:
+			if len(state.Choices) == 0 {
+				return errors.Errorf("state machine %s: choice %s has no choices", stateMachine.Name, state.Name)
+			}
+			for _, choice := range state.Choices {
+				if choice == nil || choice.Next == "" {
+					return errors.Errorf("state machine %s: choice %s has a choice without next", stateMachine.Name, state.Name)
+				}
+				if _, err := parseExpression(choice.Expression); err != nil {
+					return errors.Wrapf(err, "state machine %s: choice %s", stateMachine.Name, state.Name)
+				}
+				if err := checkTarget(state, choice.Next); err != nil {
+					return err
+				}
+			}
+			if err := checkTarget(state, state.Default); err != nil {
+				return err
+			}
+		case StateTypeSubStateMachine:
+			if state.StateMachineName == "" {
+				return errors.Errorf("state machine %s: sub state machine %s has no state machine name", stateMachine.Name, state.Name)
+			}
+		case StateTypeCompensationTrigger, StateTypeSucceed, StateTypeFail:
+		default:
+			return errors.Errorf("state machine %s: state %s has unknown type %s", stateMachine.Name, state.Name, state.Type)
+		}
+	}
+	return nil
+}
+
+// normalize converts the map[interface{}]interface{} decoded by yaml to map[string]interface{}
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			m[toString(key)] = normalize(val)
+		}
+		return m
+	case map[string]interface{}:
+		for key, val := range v {
+			v[key] = normalize(val)
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalize(val)
+		}
+		return v
+	default:
+		return value
+	}
+}
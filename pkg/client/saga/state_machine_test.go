@@ -0,0 +1,118 @@
+package saga
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const orderStateMachineYAML = `
+Name: createOrder
+StartState: ReduceInventory
+States:
+  ReduceInventory:
+    Type: ServiceTask
+    ServiceName: inventory
+    ServiceMethod: Reduce
+    Input: ["$.productID", {count: "$.count"}]
+    Output: inventory
+    CompensateState: CompensateInventory
+    Catch:
+      - Exceptions: ["not enough"]
+        Next: Fail
+    Next: CheckAmount
+  CompensateInventory:
+    Type: ServiceTask
+    ServiceName: inventory
+    ServiceMethod: Compensate
+    IsForCompensation: true
+  CheckAmount:
+    Type: Choice
+    Choices:
+      - Expression: "$.amount > 100"
+        Next: Fail
+    Default: Succeed
+  Succeed:
+    Type: Succeed
+  Fail:
+    Type: Fail
+    ErrorCode: OrderFailed
+`
+
+func TestParseStateMachine(t *testing.T) {
+	stateMachine, err := ParseStateMachine([]byte(orderStateMachineYAML))
+	assert.NoError(t, err)
+	assert.Equal(t, "createOrder", stateMachine.Name)
+	assert.Equal(t, RecoverStrategyCompensate, stateMachine.recoverStrategy())
+
+	state := stateMachine.GetState("ReduceInventory")
+	assert.Equal(t, "ReduceInventory", state.Name)
+	assert.Equal(t, StateTypeServiceTask, state.Type)
+	assert.Equal(t, []interface{}{"$.productID", map[string]interface{}{"count": "$.count"}}, state.Input)
+	assert.Equal(t, "Fail", state.Catch[0].Next)
+
+	stateMachine, err = ParseStateMachine([]byte(`{"Name": "empty", "StartState": "Done", "States": {"Done": {"Type": "Succeed"}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, StateTypeSucceed, stateMachine.GetState("Done").Type)
+}
+
+func TestParseStateMachine_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "start state does not exist",
+			data: `{"Name": "a", "StartState": "B", "States": {"A": {"Type": "Succeed"}}}`,
+		},
+		{
+			name: "next does not exist",
+			data: `{"Name": "a", "StartState": "A", "States": {"A": {"Type": "Choice", "Choices": [{"Expression": "true", "Next": "B"}]}}}`,
+		},
+		{
+			name: "service task without method",
+			data: `{"Name": "a", "StartState": "A", "States": {"A": {"Type": "ServiceTask", "ServiceName": "s"}}}`,
+		},
+		{
+			name: "invalid expression",
+			data: `{"Name": "a", "StartState": "A", "States": {"A": {"Type": "Choice", "Choices": [{"Expression": "$.a >", "Next": "A"}]}}}`,
+		},
+		{
+			name: "unknown type",
+			data: `{"Name": "a", "StartState": "A", "States": {"A": {"Type": "Parallel"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStateMachine([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEvaluateExpression(t *testing.T) {
+	context := map[string]interface{}{
+		"amount": float64(150),
+		"vip":    true,
+		"order":  map[string]interface{}{"status": "paid"},
+	}
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{"$.amount > 100", true},
+		{"$.amount <= 100", false},
+		{"$.amount > 100 && $.vip == true", true},
+		{"!$.vip || $.amount == 150", true},
+		{"$.order.status == 'paid'", true},
+		{"($.order.status != \"paid\" || $.missing != null) && $.vip", false},
+		{"$.missing == null", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			result, err := evaluateExpression(tt.expression, context)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package saga
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInstanceNotFound the instance does not exist in the store
+var ErrInstanceNotFound = errors.New("state machine instance not found")
+
+// Store persists state machine instances, an instance is removed once its global transaction
+// is finished, so all the instances in the store may need to be recovered.
+type Store interface {
+	// Save creates or replaces the instance
+	Save(instance *Instance) error
+
+	// Load return ErrInstanceNotFound if the instance does not exist
+	Load(id string) (*Instance, error)
+
+	Delete(id string) error
+
+	// List return all the instances in the store
+	List() ([]*Instance, error)
+}
+
+type memoryStore struct {
+	instances sync.Map // string -> []byte
+}
+
+// NewMemoryStore create a store which keeps the instances in memory, they can not be recovered
+// after the process restarts.
+func NewMemoryStore() Store {
+	return &memoryStore{}
+}
+
+func (store *memoryStore) Save(instance *Instance) error {
+	data, err := json.Marshal(instance)
+	if err != nil {
+		return err
+	}
+	store.instances.Store(instance.ID, data)
+	return nil
+}
+
+func (store *memoryStore) Load(id string) (*Instance, error) {
+	data, ok := store.instances.Load(id)
+	if !ok {
+		return nil, ErrInstanceNotFound
+	}
+	return unmarshalInstance(data.([]byte))
+}
+
+func (store *memoryStore) Delete(id string) error {
+	store.instances.Delete(id)
+	return nil
+}
+
+func (store *memoryStore) List() ([]*Instance, error) {
+	instances := make([]*Instance, 0)
+	var err error
+	store.instances.Range(func(key, value interface{}) bool {
+		var instance *Instance
+		instance, err = unmarshalInstance(value.([]byte))
+		if err != nil {
+			return false
+		}
+		instances = append(instances, instance)
+		return true
+	})
+	return instances, err
+}
+
+type fileStore struct {
+	dir string
+}
+
+// NewFileStore create a store which writes every instance to a json file in the directory
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (store *fileStore) path(id string) string {
+	return filepath.Join(store.dir, id+".json")
+}
+
+// Save writes a temporary file and renames it, so that a crash never leaves a partial instance
+func (store *fileStore) Save(instance *Instance) error {
+	data, err := json.Marshal(instance)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(store.dir, instance.ID+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), store.path(instance.ID))
+}
+
+func (store *fileStore) Load(id string) (*Instance, error) {
+	data, err := ioutil.ReadFile(store.path(id))
+	if os.IsNotExist(err) {
+		return nil, ErrInstanceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalInstance(data)
+}
+
+func (store *fileStore) Delete(id string) error {
+	err := os.Remove(store.path(id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (store *fileStore) List() ([]*Instance, error) {
+	files, err := ioutil.ReadDir(store.dir)
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]*Instance, 0)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		instance, err := store.Load(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+func unmarshalInstance(data []byte) (*Instance, error) {
+	instance := &Instance{}
+	if err := json.Unmarshal(data, instance); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal state machine instance")
+	}
+	if instance.Context == nil {
+		instance.Context = make(map[string]interface{})
+	}
+	return instance, nil
+}
@@ -0,0 +1,173 @@
+package xa
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	ctx "github.com/opentrx/seata-golang/v2/pkg/client/base/context"
+	"github.com/opentrx/seata-golang/v2/pkg/client/rm"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// Conn wraps a connection of the mysql driver
+type Conn struct {
+	target   driver.Conn
+	resource *DataSourceResource
+
+	// xaTx is not nil while an XA transaction is open on the connection
+	xaTx *Tx
+}
+
+func (conn *Conn) Prepare(query string) (driver.Stmt, error) {
+	return conn.PrepareContext(context.Background(), query)
+}
+
+func (conn *Conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if preparer, ok := conn.target.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = conn.target.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Stmt{conn: conn, target: stmt}, nil
+}
+
+func (conn *Conn) Close() error {
+	return conn.target.Close()
+}
+
+func (conn *Conn) Begin() (driver.Tx, error) {
+	return conn.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx starts an XA transaction as a branch of the global transaction bound with the
+// context, or a local transaction out of global transactions.
+func (conn *Conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	xid := globalXID(ctx)
+	if xid == "" {
+		if beginner, ok := conn.target.(driver.ConnBeginTx); ok {
+			return beginner.BeginTx(ctx, opts)
+		}
+		return conn.target.Begin() // nolint: staticcheck
+	}
+	return conn.begin(ctx, xid)
+}
+
+func (conn *Conn) begin(ctx context.Context, xid string) (*Tx, error) {
+	branchID, err := rm.GetResourceManager().BranchRegister(ctx, xid, conn.resource.GetResourceID(), apis.XA,
+		nil, "", false)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Tx{conn: conn, xid: xid, branchID: branchID}
+	if err = conn.exec(ctx, "XA START "+xaID(xid, branchID)); err != nil {
+		tx.report(apis.PhaseOneFailed)
+		return nil, err
+	}
+	conn.xaTx = tx
+	return tx, nil
+}
+
+// ExecContext executes the statement in its own XA transaction if it runs in a global
+// transaction without a local transaction.
+func (conn *Conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := conn.target.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return conn.autoCommit(ctx, func() (driver.Result, error) {
+		return execer.ExecContext(ctx, query, args)
+	})
+}
+
+func (conn *Conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := conn.target.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (conn *Conn) Ping(ctx context.Context) error {
+	pinger, ok := conn.target.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+	return pinger.Ping(ctx)
+}
+
+func (conn *Conn) ResetSession(ctx context.Context) error {
+	resetter, ok := conn.target.(driver.SessionResetter)
+	if !ok {
+		return nil
+	}
+	return resetter.ResetSession(ctx)
+}
+
+func (conn *Conn) CheckNamedValue(value *driver.NamedValue) error {
+	checker, ok := conn.target.(driver.NamedValueChecker)
+	if !ok {
+		return driver.ErrSkip
+	}
+	return checker.CheckNamedValue(value)
+}
+
+func (conn *Conn) autoCommit(ctx context.Context, exec func() (driver.Result, error)) (driver.Result, error) {
+	xid := globalXID(ctx)
+	if conn.xaTx != nil || xid == "" {
+		return exec()
+	}
+
+	tx, err := conn.begin(ctx, xid)
+	if err != nil {
+		return nil, err
+	}
+	result, err := exec()
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Error(rollbackErr)
+		}
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (conn *Conn) exec(ctx context.Context, query string) error {
+	if execer, ok := conn.target.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, query, nil)
+		return err
+	}
+	stmt, err := conn.target.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(nil) // nolint: staticcheck
+	return err
+}
+
+// globalXID return the xid bound with the context
+func globalXID(c context.Context) string {
+	if c == nil {
+		return ""
+	}
+	if rootContext, ok := c.(*ctx.RootContext); ok {
+		return rootContext.GetXID()
+	}
+	xid, _ := c.Value(ctx.KeyXID).(string)
+	return xid
+}
+
+func (conn *Conn) resetContext() {
+	conn.xaTx = nil
+}
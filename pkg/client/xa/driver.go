@@ -0,0 +1,68 @@
+package xa
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MysqlDriverName the name of the XA proxy driver for mysql, use it with sql.Open
+const MysqlDriverName = "seata-xa-mysql"
+
+func init() {
+	sql.Register(MysqlDriverName, &Driver{target: &mysql.MySQLDriver{}})
+}
+
+// Driver wraps the mysql driver, the local transactions in a global transaction are run as
+// XA transactions which are prepared in phase one and committed or rolled back by the TC.
+type Driver struct {
+	target *mysql.MySQLDriver
+}
+
+// Open returns a new connection to the database.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	c, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return c.Connect(context.Background())
+}
+
+// OpenConnector registers the database as an XA resource and returns a connector.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	target, err := d.target.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := xaResourceManager.loadOrRegisterResource(&DataSourceResource{
+		ResourceID: fmt.Sprintf("%s/%s", cfg.Addr, cfg.DBName),
+		db:         sql.OpenDB(target),
+	})
+	return &connector{target: target, driver: d, resource: resource}, nil
+}
+
+type connector struct {
+	target   driver.Connector
+	driver   *Driver
+	resource *DataSourceResource
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.target.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{target: conn, resource: c.resource}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
@@ -0,0 +1,68 @@
+package xa
+
+import (
+	"context"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/tm"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// recover lists the prepared XA transactions of the database with XA RECOVER, the ones whose
+// global transaction has been committed are committed, the ones whose global transaction has
+// been rolled back or no longer exists are rolled back; the others are left to the TC.
+func (resource *DataSourceResource) recover(ctx context.Context) error {
+	rows, err := resource.db.QueryContext(ctx, "XA RECOVER")
+	if err != nil {
+		return err
+	}
+
+	type preparedBranch struct {
+		xid      string
+		branchID int64
+	}
+	branches := make([]preparedBranch, 0)
+	for rows.Next() {
+		var (
+			formatID, gtridLength, bqualLength int
+			data                               string
+		)
+		if err = rows.Scan(&formatID, &gtridLength, &bqualLength, &data); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		xid, branchID, ok := parseXAID(data, gtridLength, bqualLength)
+		if !ok {
+			continue
+		}
+		branches = append(branches, preparedBranch{xid: xid, branchID: branchID})
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+
+	for _, branch := range branches {
+		status, err := tm.GetTransactionManager().GetStatus(ctx, branch.xid)
+		if err != nil {
+			log.Errorf("failed to get global status, xid: %s, err: %v", branch.xid, err)
+			continue
+		}
+		switch status {
+		case apis.Committed, apis.Committing, apis.CommitRetrying, apis.AsyncCommitting:
+			err = resource.commit(ctx, branch.xid, branch.branchID)
+		case apis.RollbackFailed, apis.RollingBack, apis.RollbackRetrying, apis.RolledBack, apis.TimeoutRollbackFailed,
+			apis.TimeoutRollingBack, apis.TimeoutRollbackRetrying, apis.TimeoutRolledBack, apis.Finished:
+			err = resource.rollback(ctx, branch.xid, branch.branchID)
+		default:
+			continue
+		}
+		if err != nil {
+			log.Errorf("failed to recover xa transaction, xid: %s, branchID: %d, global status: %s, err: %v",
+				branch.xid, branch.branchID, status.String(), err)
+			continue
+		}
+		log.Infof("recovered xa transaction, xid: %s, branchID: %d, global status: %s",
+			branch.xid, branch.branchID, status.String())
+	}
+	return nil
+}
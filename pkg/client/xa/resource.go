@@ -0,0 +1,79 @@
+package xa
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+)
+
+// mysql error number of XAER_NOTA: unknown XID
+const erXAERNota = 1397
+
+// DataSourceResource a database managed by the XA resource manager
+type DataSourceResource struct {
+	ResourceID string
+
+	// db connects to the database without the XA proxy, used in phase two and recovery
+	db *sql.DB
+}
+
+func (resource *DataSourceResource) GetResourceID() string {
+	return resource.ResourceID
+}
+
+func (resource *DataSourceResource) GetBranchType() apis.BranchSession_BranchType {
+	return apis.XA
+}
+
+// GetDB return the database without XA proxy
+func (resource *DataSourceResource) GetDB() *sql.DB {
+	return resource.db
+}
+
+// commit commits the prepared XA transaction of the branch
+func (resource *DataSourceResource) commit(ctx context.Context, xid string, branchID int64) error {
+	_, err := resource.db.ExecContext(ctx, "XA COMMIT "+xaID(xid, branchID))
+	if isXAERNota(err) {
+		return nil
+	}
+	return err
+}
+
+// rollback rolls back the prepared XA transaction of the branch
+func (resource *DataSourceResource) rollback(ctx context.Context, xid string, branchID int64) error {
+	_, err := resource.db.ExecContext(ctx, "XA ROLLBACK "+xaID(xid, branchID))
+	if isXAERNota(err) {
+		return nil
+	}
+	return err
+}
+
+// xaID build the XA transaction id, the xid is the gtrid and the branch id is the bqual
+func xaID(xid string, branchID int64) string {
+	return fmt.Sprintf("'%s','%d'", strings.ReplaceAll(xid, "'", "''"), branchID)
+}
+
+// parseXAID parses the data column of XA RECOVER, false if it is not created by this driver
+func parseXAID(data string, gtridLength, bqualLength int) (string, int64, bool) {
+	if gtridLength+bqualLength != len(data) || gtridLength == 0 {
+		return "", 0, false
+	}
+	branchID, err := strconv.ParseInt(data[gtridLength:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return data[:gtridLength], branchID, true
+}
+
+// isXAERNota return true if the XA transaction does not exist, it has been committed or
+// rolled back already.
+func isXAERNota(err error) bool {
+	mysqlErr, ok := err.(*mysql.MySQLError)
+	return ok && mysqlErr.Number == erXAERNota
+}
@@ -0,0 +1,126 @@
+package xa
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/base/model"
+	"github.com/opentrx/seata-golang/v2/pkg/client/tm"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/runtime"
+)
+
+var xaResourceManager XAResourceManager
+
+// XAResourceManager commits or rolls back the prepared XA transactions of XA branches
+type XAResourceManager struct {
+	ResourceCache *sync.Map // string -> *DataSourceResource
+}
+
+func init() {
+	xaResourceManager = XAResourceManager{ResourceCache: &sync.Map{}}
+}
+
+func GetXAResourceManager() XAResourceManager {
+	return xaResourceManager
+}
+
+func (resourceManager XAResourceManager) BranchCommit(ctx context.Context, request *apis.BranchCommitRequest) (*apis.BranchCommitResponse, error) {
+	resource := resourceManager.getResource(request.ResourceID)
+	if resource == nil {
+		log.Errorf("XA resource is not exist, resourceID: %s", request.ResourceID)
+		return &apis.BranchCommitResponse{
+			ResultCode: apis.ResultCodeFailed,
+			Message:    fmt.Sprintf("XA resource is not exist, resourceID: %s", request.ResourceID),
+		}, nil
+	}
+
+	branchStatus := apis.PhaseTwoCommitted
+	if err := resource.commit(ctx, request.XID, request.BranchID); err != nil {
+		log.Errorf("failed to commit xa branch, xid: %s, branchID: %d, err: %v", request.XID, request.BranchID, err)
+		branchStatus = apis.PhaseTwoCommitFailedRetryable
+	}
+	return &apis.BranchCommitResponse{
+		ResultCode:   apis.ResultCodeSuccess,
+		XID:          request.XID,
+		BranchID:     request.BranchID,
+		BranchStatus: branchStatus,
+	}, nil
+}
+
+func (resourceManager XAResourceManager) BranchRollback(ctx context.Context, request *apis.BranchRollbackRequest) (*apis.BranchRollbackResponse, error) {
+	resource := resourceManager.getResource(request.ResourceID)
+	if resource == nil {
+		log.Errorf("XA resource is not exist, resourceID: %s", request.ResourceID)
+		return &apis.BranchRollbackResponse{
+			ResultCode: apis.ResultCodeFailed,
+			Message:    fmt.Sprintf("XA resource is not exist, resourceID: %s", request.ResourceID),
+		}, nil
+	}
+
+	branchStatus := apis.PhaseTwoRolledBack
+	if err := resource.rollback(ctx, request.XID, request.BranchID); err != nil {
+		log.Errorf("failed to rollback xa branch, xid: %s, branchID: %d, err: %v", request.XID, request.BranchID, err)
+		branchStatus = apis.PhaseTwoRollbackFailedRetryable
+	}
+	return &apis.BranchRollbackResponse{
+		ResultCode:   apis.ResultCodeSuccess,
+		XID:          request.XID,
+		BranchID:     request.BranchID,
+		BranchStatus: branchStatus,
+	}, nil
+}
+
+func (resourceManager XAResourceManager) RegisterResource(resource model.Resource) {
+	resourceManager.ResourceCache.Store(resource.GetResourceID(), resource)
+}
+
+func (resourceManager XAResourceManager) UnregisterResource(resource model.Resource) {
+	resourceManager.ResourceCache.Delete(resource.GetResourceID())
+}
+
+func (resourceManager XAResourceManager) GetBranchType() apis.BranchSession_BranchType {
+	return apis.XA
+}
+
+// Recover resolves the prepared XA transactions left by a previous process on all registered
+// resources, it should be called after the transaction manager is initialized.
+func (resourceManager XAResourceManager) Recover(ctx context.Context) {
+	resourceManager.ResourceCache.Range(func(key, value interface{}) bool {
+		resource := value.(*DataSourceResource)
+		if err := resource.recover(ctx); err != nil {
+			log.Errorf("failed to recover xa transactions, resourceID: %s, err: %v", resource.ResourceID, err)
+		}
+		return true
+	})
+}
+
+func (resourceManager XAResourceManager) getResource(resourceID string) *DataSourceResource {
+	resource, ok := resourceManager.ResourceCache.Load(resourceID)
+	if !ok {
+		return nil
+	}
+	return resource.(*DataSourceResource)
+}
+
+// loadOrRegisterResource return the registered resource with the same id, the prepared XA
+// transactions of a new resource are recovered if the client has been initialized.
+func (resourceManager XAResourceManager) loadOrRegisterResource(resource *DataSourceResource) *DataSourceResource {
+	actual, loaded := resourceManager.ResourceCache.LoadOrStore(resource.GetResourceID(), resource)
+	if loaded {
+		if err := resource.db.Close(); err != nil {
+			log.Error(err)
+		}
+		return actual.(*DataSourceResource)
+	}
+	if tm.GetTransactionManager() != nil {
+		runtime.GoWithRecover(func() {
+			if err := resource.recover(context.Background()); err != nil {
+				log.Errorf("failed to recover xa transactions, resourceID: %s, err: %v", resource.ResourceID, err)
+			}
+		}, nil)
+	}
+	return resource
+}
@@ -0,0 +1,28 @@
+package xa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseXAID(t *testing.T) {
+	xid := "127.0.0.1:8091:1234567"
+	data := xid + "7654321"
+
+	gotXID, branchID, ok := parseXAID(data, len(xid), 7)
+	assert.True(t, ok)
+	assert.Equal(t, xid, gotXID)
+	assert.Equal(t, int64(7654321), branchID)
+
+	_, _, ok = parseXAID(data, len(xid), 6)
+	assert.False(t, ok)
+
+	_, _, ok = parseXAID("foobar", 3, 3)
+	assert.False(t, ok)
+}
+
+func TestXAID(t *testing.T) {
+	assert.Equal(t, "'127.0.0.1:8091:1','2'", xaID("127.0.0.1:8091:1", 2))
+	assert.Equal(t, "'a''b','2'", xaID("a'b", 2))
+}
@@ -0,0 +1,40 @@
+package xa
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// Stmt wraps a prepared statement of the mysql driver
+type Stmt struct {
+	conn   *Conn
+	target driver.Stmt
+}
+
+func (stmt *Stmt) Close() error {
+	return stmt.target.Close()
+}
+
+func (stmt *Stmt) NumInput() int {
+	return stmt.target.NumInput()
+}
+
+func (stmt *Stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return stmt.conn.autoCommit(context.Background(), func() (driver.Result, error) {
+		return stmt.target.Exec(args) // nolint: staticcheck
+	})
+}
+
+func (stmt *Stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return stmt.target.Query(args) // nolint: staticcheck
+}
+
+func (stmt *Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return stmt.conn.autoCommit(ctx, func() (driver.Result, error) {
+		return stmt.target.(driver.StmtExecContext).ExecContext(ctx, args)
+	})
+}
+
+func (stmt *Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return stmt.target.(driver.StmtQueryContext).QueryContext(ctx, args)
+}
@@ -0,0 +1,63 @@
+package xa
+
+import (
+	"context"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/client/rm"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// Tx an XA transaction registered as a branch, it is ended and prepared on commit, the
+// prepared transaction is committed or rolled back in phase two.
+type Tx struct {
+	conn     *Conn
+	xid      string
+	branchID int64
+}
+
+func (tx *Tx) Commit() error {
+	defer tx.conn.resetContext()
+	ctx := context.Background()
+	id := xaID(tx.xid, tx.branchID)
+
+	if err := tx.conn.exec(ctx, "XA END "+id); err != nil {
+		tx.rollbackLocal(ctx, id)
+		return err
+	}
+	if err := tx.conn.exec(ctx, "XA PREPARE "+id); err != nil {
+		log.Errorf("failed to prepare xa transaction, xid: %s, branchID: %d, err: %v", tx.xid, tx.branchID, err)
+		tx.rollbackLocal(ctx, id)
+		return err
+	}
+	return nil
+}
+
+func (tx *Tx) Rollback() error {
+	defer tx.conn.resetContext()
+	ctx := context.Background()
+	id := xaID(tx.xid, tx.branchID)
+
+	if err := tx.conn.exec(ctx, "XA END "+id); err != nil {
+		log.Errorf("failed to end xa transaction, xid: %s, branchID: %d, err: %v", tx.xid, tx.branchID, err)
+	}
+	err := tx.conn.exec(ctx, "XA ROLLBACK "+id)
+	tx.report(apis.PhaseOneFailed)
+	return err
+}
+
+// rollbackLocal rolls back the XA transaction after phase one failed, the branch is reported
+// so that the TC does not roll it back again.
+func (tx *Tx) rollbackLocal(ctx context.Context, id string) {
+	if err := tx.conn.exec(ctx, "XA ROLLBACK "+id); err != nil && !isXAERNota(err) {
+		log.Errorf("failed to rollback xa transaction, xid: %s, branchID: %d, err: %v", tx.xid, tx.branchID, err)
+	}
+	tx.report(apis.PhaseOneFailed)
+}
+
+func (tx *Tx) report(status apis.BranchSession_BranchStatus) {
+	err := rm.GetResourceManager().BranchReport(context.Background(), tx.xid, tx.branchID, apis.XA, status, nil)
+	if err != nil {
+		log.Errorf("failed to report [%d/%s] [%s], err: %v", tx.branchID, tx.xid, status.String(), err)
+	}
+}
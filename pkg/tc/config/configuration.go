@@ -33,6 +33,25 @@ type Configuration struct {
 		TimeoutRetryPeriod         time.Duration `yaml:"timeoutRetryPeriod"`
 
 		StreamMessageTimeout time.Duration `yaml:"streamMessageTimeout"`
+
+		// LockWaitTimeout is how long a branch registration waits for the row locks held by other
+		// global transactions before LockKeyConflict is returned, 0 returns it at once.
+		LockWaitTimeout time.Duration `yaml:"lockWaitTimeout"`
+
+		// EnableAdmin serves the AdminService next to the transaction services, it is able to force
+		// release the row locks and remove the global sessions, so it should only be enabled with TLS
+		// or on a trusted network.
+		EnableAdmin bool `yaml:"enableAdmin"`
+
+		// EnableConsole serves the web console under /console/ next to /health, it takes the same
+		// actions as the AdminService and has no authentication, keep its port on a trusted network.
+		// The actions are only accepted from the console page, not from the other sites a browser
+		// on that network visits.
+		EnableConsole bool `yaml:"enableConsole"`
+
+		// ShutdownTimeout is how long the TC drains on SIGTERM, the calls in flight are given this
+		// long to finish before the connections are closed, 0 defaults to 30s.
+		ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
 	} `yaml:"server"`
 
 	EnforcementPolicy struct {
@@ -52,11 +71,21 @@ type Configuration struct {
 		Enable       bool   `yaml:"enable"`
 		CertFilePath string `yaml:"certFilePath"`
 		KeyFilePath  string `yaml:"keyFilePath"`
+
+		// ServerName is the name the admin clients verify the certificate against, the host they dial
+		// by default
+		ServerName string `yaml:"serverName"`
 	} `yaml:"serverTLS"`
 
 	// Storage is the configuration for the storage driver
 	Storage Storage `yaml:"storage"`
 
+	// Locker is the configuration for the global session locker
+	Locker Locker `yaml:"locker"`
+
+	// LockTable is the configuration for the in-memory lock table
+	LockTable LockTable `yaml:"lockTable"`
+
 	Log struct {
 		LogPath  string    `yaml:"logPath"`
 		LogLevel log.Level `yaml:"logLevel"`
@@ -112,6 +141,54 @@ func (configuration *Configuration) GetServerTLS() credentials.TransportCredenti
 	return cred
 }
 
+// GetAdminClientTLS returns the credentials the admin clients dial the host with, the certificate
+// of the server is trusted as its own certificate authority.
+func (configuration *Configuration) GetAdminClientTLS(host string) (credentials.TransportCredentials, error) {
+	if !configuration.ServerTLS.Enable {
+		return nil, nil
+	}
+	serverName := configuration.ServerTLS.ServerName
+	if serverName == "" {
+		serverName = host
+	}
+	return credentials.NewClientTLSFromFile(configuration.ServerTLS.CertFilePath, serverName)
+}
+
+// Locker defines how a global session is locked while it is committed, rolled back or a branch
+// is registered into it.
+type Locker struct {
+	// Type is one of memory and database, memory locks a global session in this TC only, database
+	// locks it across the TC nodes sharing the mysql or pgsql storage. The default is memory.
+	Type string `yaml:"type"`
+
+	// Lease is how long a lock of the database locker is held without renewal, the locks of a
+	// crashed TC are released when their leases expire.
+	Lease time.Duration `yaml:"lease"`
+
+	// RetryPeriod is how often the database locker retries to lock a global session held by others
+	RetryPeriod time.Duration `yaml:"retryPeriod"`
+}
+
+// LockTable defines the sharded in-memory lock table in front of the row locks of the storage
+// driver. It only excludes the branches registered by this TC, so it should not be enabled on TC
+// nodes sharing a storage.
+type LockTable struct {
+	// Enable puts the lock table in front of the storage driver, the default is false
+	Enable bool `yaml:"enable"`
+
+	// Shards is the number of shards the row keys are hashed to, the default is 64
+	Shards int `yaml:"shards"`
+
+	// Persistence is one of writethrough and background, writethrough persists a row lock before it
+	// is granted, background persists it asynchronously and the lock table is rebuilt from the branch
+	// sessions at startup. The default is writethrough.
+	Persistence string `yaml:"persistence"`
+
+	// QueueSize is the number of row lock changes waiting to be persisted in background before the
+	// branch registrations are blocked, the default is 1024
+	QueueSize int `yaml:"queueSize"`
+}
+
 // Parameters defines a key-value parameters mapping
 type Parameters map[string]interface{}
 
@@ -0,0 +1,284 @@
+package console
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc/peer"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// Path is the path the console is served under
+const Path = "/console/"
+
+// ActionHeader is the header the requests taking actions are sent with
+const ActionHeader = "X-Seata-Console"
+
+// Counter is a counter of the metrics shown by the console
+type Counter struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+	Value  int64             `json:"value"`
+}
+
+// Console serves a web page showing the global sessions, the row locks and the metrics of the
+// transaction coordinator, and the json api behind it. It is a thin layer over the AdminService,
+// the actions it offers are gated and audited the same way as the admin calls.
+type Console struct {
+	admin    apis.AdminServiceServer
+	counters func() []Counter
+
+	marshaler *jsonpb.Marshaler
+}
+
+func NewConsole(admin apis.AdminServiceServer, counters func() []Counter) *Console {
+	return &Console{
+		admin:     admin,
+		counters:  counters,
+		marshaler: &jsonpb.Marshaler{EmitDefaults: true, OrigName: true},
+	}
+}
+
+// Register registers the handlers of the console on the mux
+func (console *Console) Register(mux *http.ServeMux) {
+	mux.HandleFunc(Path, console.index)
+	mux.HandleFunc(Path+"api/sessions", console.get(console.listSessions))
+	mux.HandleFunc(Path+"api/session", console.get(console.getSession))
+	mux.HandleFunc(Path+"api/locks", console.get(console.listLocks))
+	mux.HandleFunc(Path+"api/lock-holders", console.get(console.queryLockHolders))
+	mux.HandleFunc(Path+"api/metrics", console.metrics)
+	mux.HandleFunc(Path+"api/session/retry-commit", console.post(console.retryCommit))
+	mux.HandleFunc(Path+"api/session/retry-rollback", console.post(console.retryRollback))
+	mux.HandleFunc(Path+"api/session/remove", console.post(console.removeSession))
+	mux.HandleFunc(Path+"api/lock/release", console.post(console.releaseLocks))
+}
+
+type handler func(ctx context.Context, request *http.Request) (proto.Message, error)
+
+func (console *Console) index(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Path != Path {
+		http.NotFound(writer, request)
+		return
+	}
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = writer.Write([]byte(indexPage))
+}
+
+func (console *Console) metrics(writer http.ResponseWriter, request *http.Request) {
+	counters := make([]Counter, 0)
+	if console.counters != nil {
+		counters = append(counters, console.counters()...)
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(counters); err != nil {
+		log.Error(err)
+	}
+}
+
+// get serves the handler to the GET requests
+func (console *Console) get(h handler) http.HandlerFunc {
+	return console.serve(http.MethodGet, h)
+}
+
+// post serves the handler to the POST requests, the actions are not taken on GET requests so that
+// a link or a prefetch never takes them. The parameters of an action are a json object sent with
+// the ActionHeader, which no other site is able to send without a CORS preflight the console never
+// allows, and the Origin of the request, if any, must be the console itself.
+func (console *Console) post(h handler) http.HandlerFunc {
+	return console.serve(http.MethodPost, h)
+}
+
+func (console *Console) serve(method string, h handler) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != method {
+			writer.Header().Set("Allow", method)
+			http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		var err error
+		if method == http.MethodPost {
+			err = parseAction(request)
+		} else {
+			err = request.ParseForm()
+		}
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// the admin calls audit the address of their peer
+		ctx := peer.NewContext(request.Context(), &peer.Peer{Addr: remoteAddr(request.RemoteAddr)})
+		response, err := h(ctx, request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		if err := console.marshaler.Marshal(writer, response); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func (console *Console) listSessions(ctx context.Context, request *http.Request) (proto.Message, error) {
+	var statuses []apis.GlobalSession_GlobalStatus
+	for _, s := range request.Form["status"] {
+		status, ok := apis.GlobalSession_GlobalStatus_value[s]
+		if !ok {
+			return nil, fmt.Errorf("unknown global status %s", s)
+		}
+		statuses = append(statuses, apis.GlobalSession_GlobalStatus(status))
+	}
+	minAge, err := formInt(request, "minAge")
+	if err != nil {
+		return nil, err
+	}
+	offset, err := formInt(request, "offset")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := formInt(request, "limit")
+	if err != nil {
+		return nil, err
+	}
+	return console.admin.ListSessions(ctx, &apis.SessionListRequest{
+		Statuses:        statuses,
+		Addressing:      request.Form.Get("addressing"),
+		TransactionName: request.Form.Get("name"),
+		MinAge:          minAge,
+		Offset:          int32(offset),
+		Limit:           int32(limit),
+	})
+}
+
+func (console *Console) getSession(ctx context.Context, request *http.Request) (proto.Message, error) {
+	return console.admin.GetSession(ctx, &apis.SessionGetRequest{XID: request.Form.Get("xid")})
+}
+
+func (console *Console) listLocks(ctx context.Context, request *http.Request) (proto.Message, error) {
+	branchID, err := formInt(request, "branchId")
+	if err != nil {
+		return nil, err
+	}
+	offset, err := formInt(request, "offset")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := formInt(request, "limit")
+	if err != nil {
+		return nil, err
+	}
+	return console.admin.ListLocks(ctx, &apis.LockListRequest{
+		ResourceID: request.Form.Get("resourceId"),
+		TableName:  request.Form.Get("table"),
+		XID:        request.Form.Get("xid"),
+		BranchID:   branchID,
+		Offset:     int32(offset),
+		Limit:      int32(limit),
+	})
+}
+
+func (console *Console) queryLockHolders(ctx context.Context, request *http.Request) (proto.Message, error) {
+	return console.admin.QueryLockHolders(ctx, &apis.LockHolderQueryRequest{
+		ResourceID: request.Form.Get("resourceId"),
+		LockKey:    request.Form.Get("lockKey"),
+	})
+}
+
+func (console *Console) retryCommit(ctx context.Context, request *http.Request) (proto.Message, error) {
+	return console.admin.RetryCommit(ctx, &apis.SessionRetryRequest{
+		XID:    request.Form.Get("xid"),
+		Reason: request.Form.Get("reason"),
+	})
+}
+
+func (console *Console) retryRollback(ctx context.Context, request *http.Request) (proto.Message, error) {
+	return console.admin.RetryRollback(ctx, &apis.SessionRetryRequest{
+		XID:    request.Form.Get("xid"),
+		Reason: request.Form.Get("reason"),
+	})
+}
+
+func (console *Console) removeSession(ctx context.Context, request *http.Request) (proto.Message, error) {
+	return console.admin.RemoveSession(ctx, &apis.SessionRemoveRequest{
+		XID:          request.Form.Get("xid"),
+		Force:        request.Form.Get("force") == "true",
+		ReleaseLocks: request.Form.Get("releaseLocks") == "true",
+		Reason:       request.Form.Get("reason"),
+	})
+}
+
+func (console *Console) releaseLocks(ctx context.Context, request *http.Request) (proto.Message, error) {
+	branchID, err := formInt(request, "branchId")
+	if err != nil {
+		return nil, err
+	}
+	return console.admin.ReleaseLocks(ctx, &apis.LockReleaseRequest{
+		XID:        request.Form.Get("xid"),
+		BranchID:   branchID,
+		ResourceID: request.Form.Get("resourceId"),
+		LockKey:    request.Form.Get("lockKey"),
+		Force:      request.Form.Get("force") == "true",
+		Reason:     request.Form.Get("reason"),
+	})
+}
+
+// parseAction checks that the request is sent by the console page and reads its json parameters
+// into request.Form.
+func parseAction(request *http.Request) error {
+	if request.Header.Get(ActionHeader) == "" {
+		return fmt.Errorf("missing header %s", ActionHeader)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type")); mediaType != "application/json" {
+		return fmt.Errorf("unsupported content type %s", request.Header.Get("Content-Type"))
+	}
+	if origin := request.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host != request.Host {
+			return fmt.Errorf("cross origin request from %s", origin)
+		}
+	}
+	params := make(map[string]interface{})
+	decoder := json.NewDecoder(request.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&params); err != nil {
+		return fmt.Errorf("invalid parameters: %v", err)
+	}
+	request.Form = make(url.Values, len(params))
+	for key, value := range params {
+		request.Form.Set(key, fmt.Sprint(value))
+	}
+	return nil
+}
+
+// formInt returns the int value of the form field, 0 if it is absent
+func formInt(request *http.Request, key string) (int64, error) {
+	value := request.Form.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s", key, value)
+	}
+	return i, nil
+}
+
+// remoteAddr is the address of the http client calling the console
+type remoteAddr string
+
+func (addr remoteAddr) Network() string {
+	return "tcp"
+}
+
+func (addr remoteAddr) String() string {
+	return string(addr)
+}
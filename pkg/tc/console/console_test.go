@@ -0,0 +1,183 @@
+package console
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+)
+
+type fakeAdmin struct {
+	apis.UnimplementedAdminServiceServer
+
+	listRequest   *apis.SessionListRequest
+	removeRequest *apis.SessionRemoveRequest
+	removePeer    string
+}
+
+func (admin *fakeAdmin) ListSessions(ctx context.Context, request *apis.SessionListRequest) (*apis.SessionListResponse, error) {
+	admin.listRequest = request
+	return &apis.SessionListResponse{
+		ResultCode: apis.ResultCodeSuccess,
+		Sessions: []*apis.GlobalSession{
+			{XID: "localhost:8091:1", Status: apis.CommitRetrying, BeginTime: 1000},
+		},
+		Total: 1,
+	}, nil
+}
+
+func (admin *fakeAdmin) RemoveSession(ctx context.Context, request *apis.SessionRemoveRequest) (*apis.SessionRemoveResponse, error) {
+	admin.removeRequest = request
+	if p, ok := peer.FromContext(ctx); ok {
+		admin.removePeer = p.Addr.String()
+	}
+	return &apis.SessionRemoveResponse{
+		ResultCode:    apis.ResultCodeFailed,
+		ExceptionCode: apis.GlobalTransactionStatusInvalid,
+		Message:       "force is required",
+	}, nil
+}
+
+func newTestServer(admin apis.AdminServiceServer) *httptest.Server {
+	mux := http.NewServeMux()
+	NewConsole(admin, func() []Counter {
+		return []Counter{{Name: "seata.transaction", Labels: map[string]string{"status": "active"}, Value: 3}}
+	}).Register(mux)
+	return httptest.NewServer(mux)
+}
+
+func TestConsole_Index(t *testing.T) {
+	server := newTestServer(&fakeAdmin{})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + Path)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	resp, err = http.Get(server.URL + Path + "missing")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestConsole_ListSessions(t *testing.T) {
+	admin := &fakeAdmin{}
+	server := newTestServer(admin)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + Path + "api/sessions?status=CommitRetrying&status=AsyncCommitting&name=createOrder&minAge=5000&limit=10")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []apis.GlobalSession_GlobalStatus{apis.CommitRetrying, apis.AsyncCommitting}, admin.listRequest.Statuses)
+	assert.Equal(t, "createOrder", admin.listRequest.TransactionName)
+	assert.Equal(t, int64(5000), admin.listRequest.MinAge)
+	assert.Equal(t, int32(10), admin.listRequest.Limit)
+
+	var body map[string]interface{}
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "ResultCodeSuccess", body["ResultCode"])
+	sessions := body["Sessions"].([]interface{})
+	if assert.Len(t, sessions, 1) {
+		session := sessions[0].(map[string]interface{})
+		assert.Equal(t, "localhost:8091:1", session["XID"])
+		assert.Equal(t, "CommitRetrying", session["Status"])
+		assert.Equal(t, "1000", session["BeginTime"])
+	}
+
+	resp, err = http.Get(server.URL + Path + "api/sessions?status=Stuck")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestConsole_RemoveSession(t *testing.T) {
+	admin := &fakeAdmin{}
+	server := newTestServer(admin)
+	defer server.Close()
+
+	// the actions are only taken on POST requests
+	resp, err := http.Get(server.URL + Path + "api/session/remove?xid=localhost:8091:1")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Nil(t, admin.removeRequest)
+
+	// a form post, which any site is able to send, is refused
+	resp, err = http.Post(server.URL+Path+"api/session/remove", "application/x-www-form-urlencoded",
+		strings.NewReader(url.Values{"xid": {"localhost:8091:1"}, "reason": {"stuck"}}.Encode()))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Nil(t, admin.removeRequest)
+
+	params := `{"xid": "localhost:8091:1", "releaseLocks": true, "reason": "stuck"}`
+	for _, header := range []http.Header{
+		{"Content-Type": {"application/json"}},
+		{"Content-Type": {"text/plain"}, ActionHeader: {"1"}},
+		{"Content-Type": {"application/json"}, ActionHeader: {"1"}, "Origin": {"http://evil.example.com"}},
+	} {
+		resp = postAction(t, server.URL+Path+"api/session/remove", header, params)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Nil(t, admin.removeRequest)
+	}
+
+	resp = postAction(t, server.URL+Path+"api/session/remove", http.Header{
+		"Content-Type": {"application/json; charset=utf-8"},
+		ActionHeader:   {"1"},
+		"Origin":       {server.URL},
+	}, params)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	if assert.NotNil(t, admin.removeRequest) {
+		assert.Equal(t, "localhost:8091:1", admin.removeRequest.XID)
+		assert.True(t, admin.removeRequest.ReleaseLocks)
+		assert.False(t, admin.removeRequest.Force)
+		assert.Equal(t, "stuck", admin.removeRequest.Reason)
+	}
+	assert.True(t, strings.HasPrefix(admin.removePeer, "127.0.0.1:"))
+
+	var body map[string]interface{}
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "ResultCodeFailed", body["ResultCode"])
+	assert.Equal(t, "GlobalTransactionStatusInvalid", body["ExceptionCode"])
+}
+
+func postAction(t *testing.T, url string, header http.Header, body string) *http.Response {
+	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	assert.Nil(t, err)
+	request.Header = header
+	resp, err := http.DefaultClient.Do(request)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		_ = resp.Body.Close()
+	})
+	return resp
+}
+
+func TestConsole_Metrics(t *testing.T) {
+	server := newTestServer(&fakeAdmin{})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + Path + "api/metrics")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+
+	var counters []Counter
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&counters))
+	if assert.Len(t, counters, 1) {
+		assert.Equal(t, "seata.transaction", counters[0].Name)
+		assert.Equal(t, int64(3), counters[0].Value)
+	}
+}
@@ -0,0 +1,370 @@
+package console
+
+// indexPage is the console page, it renders the json api of the console. The int64 fields are
+// strings in the json of the protobuf messages.
+const indexPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Seata-Golang TC Console</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 0; color: #222; }
+header { background: #2d3e50; color: #fff; padding: 10px 20px; }
+header h1 { font-size: 18px; margin: 0; display: inline-block; }
+nav { display: inline-block; margin-left: 30px; }
+nav a { color: #cfd8e3; margin-right: 16px; cursor: pointer; text-decoration: none; }
+nav a.active { color: #fff; font-weight: bold; }
+main { padding: 16px 20px; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 16px; }
+th, td { border-bottom: 1px solid #ddd; padding: 4px 8px; text-align: left; }
+th { background: #f3f5f7; }
+tr.selectable { cursor: pointer; }
+tr.selectable:hover { background: #eef4fb; }
+form { margin-bottom: 12px; }
+input, select { margin-right: 8px; }
+button { margin-right: 6px; }
+.error { color: #b00020; }
+.message { color: #2e7d32; }
+.hidden { display: none; }
+h2 { font-size: 16px; }
+</style>
+</head>
+<body>
+<header>
+  <h1>Seata-Golang TC</h1>
+  <nav>
+    <a data-tab="sessions" class="active">Transactions</a>
+    <a data-tab="queues">Retry Queues</a>
+    <a data-tab="locks">Locks</a>
+    <a data-tab="metrics">Metrics</a>
+  </nav>
+</header>
+<main>
+  <div id="notice"></div>
+
+  <section id="sessions">
+    <form id="session-filter">
+      <select name="status"><option value="">all statuses</option></select>
+      <input name="addressing" placeholder="addressing">
+      <input name="name" placeholder="transaction name">
+      <input name="minAge" placeholder="min age (ms)" size="12">
+      <button type="submit">Search</button>
+    </form>
+    <table id="session-table"></table>
+    <div>
+      <button id="prev-page">Previous</button><button id="next-page">Next</button>
+      <span id="page-info"></span>
+    </div>
+    <div id="session-detail" class="hidden">
+      <h2 id="detail-title"></h2>
+      <div>
+        <button id="retry-commit">Retry commit</button>
+        <button id="retry-rollback">Retry rollback</button>
+        <button id="abandon">Abandon</button>
+        <label><input type="checkbox" id="abandon-release">release row locks</label>
+        <label><input type="checkbox" id="abandon-force">force</label>
+      </div>
+      <h2>Branches</h2>
+      <table id="branch-table"></table>
+      <h2>Row Locks</h2>
+      <table id="detail-lock-table"></table>
+    </div>
+  </section>
+
+  <section id="queues" class="hidden">
+    <h2>Async Committing</h2><table id="queue-async"></table>
+    <h2>Commit Retrying</h2><table id="queue-commit"></table>
+    <h2>Rollback Retrying</h2><table id="queue-rollback"></table>
+  </section>
+
+  <section id="locks" class="hidden">
+    <form id="lock-filter">
+      <input name="resourceId" placeholder="resource id">
+      <input name="table" placeholder="table">
+      <input name="xid" placeholder="xid">
+      <button type="submit">Search</button>
+    </form>
+    <form id="holder-query">
+      <input name="resourceId" placeholder="resource id">
+      <input name="lockKey" placeholder="lock key, e.g. product:1,2">
+      <button type="submit">Find holders</button>
+    </form>
+    <table id="lock-table"></table>
+  </section>
+
+  <section id="metrics" class="hidden">
+    <table id="metric-table"></table>
+  </section>
+</main>
+<script>
+var api = "api/";
+var pageSize = 50;
+var sessionQuery = {};
+var sessionOffset = 0;
+var selected = null;
+
+var statuses = ["Begin", "Committing", "CommitRetrying", "RollingBack", "RollbackRetrying",
+  "TimeoutRollingBack", "TimeoutRollbackRetrying", "AsyncCommitting", "Committed", "CommitFailed",
+  "RolledBack", "RollbackFailed", "TimeoutRolledBack", "TimeoutRollbackFailed", "Finished"];
+
+function escape(value) {
+  return String(value === undefined || value === null ? "" : value).replace(/[&<>"']/g, function (c) {
+    return {"&": "&amp;", "<": "&lt;", ">": "&gt;", '"': "&quot;", "'": "&#39;"}[c];
+  });
+}
+
+function time(millis) {
+  return Number(millis) ? new Date(Number(millis)).toLocaleString() : "";
+}
+
+function age(millis) {
+  var seconds = Math.floor(Number(millis) / 1000);
+  if (seconds < 60) return seconds + "s";
+  if (seconds < 3600) return Math.floor(seconds / 60) + "m" + (seconds % 60) + "s";
+  return Math.floor(seconds / 3600) + "h" + Math.floor(seconds % 3600 / 60) + "m";
+}
+
+function notice(text, error) {
+  var el = document.getElementById("notice");
+  el.className = error ? "error" : "message";
+  el.textContent = text || "";
+}
+
+function query(params) {
+  var parts = [];
+  Object.keys(params).forEach(function (key) {
+    [].concat(params[key]).forEach(function (value) {
+      if (value !== "" && value !== undefined && value !== null) {
+        parts.push(encodeURIComponent(key) + "=" + encodeURIComponent(value));
+      }
+    });
+  });
+  return parts.join("&");
+}
+
+function call(method, path, params) {
+  var options = {method: method};
+  var url = api + path;
+  if (method === "GET") {
+    url += "?" + query(params || {});
+  } else {
+    options.headers = {"Content-Type": "application/json", "X-Seata-Console": "1"};
+    options.body = JSON.stringify(params || {});
+  }
+  return fetch(url, options).then(function (response) {
+    if (!response.ok) {
+      return response.text().then(function (text) { throw new Error(text); });
+    }
+    return response.json();
+  }).then(function (body) {
+    if (body.ResultCode === "ResultCodeFailed") {
+      throw new Error(body.ExceptionCode + ": " + body.Message);
+    }
+    return body;
+  });
+}
+
+function formParams(form) {
+  var params = {};
+  Array.prototype.forEach.call(form.elements, function (el) {
+    if (el.name) params[el.name] = el.value.trim();
+  });
+  return params;
+}
+
+function renderTable(id, columns, rows, onClick) {
+  var html = "<tr>" + columns.map(function (c) { return "<th>" + escape(c[0]) + "</th>"; }).join("") + "</tr>";
+  rows.forEach(function (row, i) {
+    html += "<tr data-row='" + i + "'" + (onClick ? " class='selectable'" : "") + ">" +
+      columns.map(function (c) { return "<td>" + escape(c[1](row)) + "</td>"; }).join("") + "</tr>";
+  });
+  if (rows.length === 0) {
+    html += "<tr><td colspan='" + columns.length + "'>none</td></tr>";
+  }
+  var table = document.getElementById(id);
+  table.innerHTML = html;
+  if (onClick) {
+    Array.prototype.forEach.call(table.querySelectorAll("tr.selectable"), function (tr) {
+      tr.onclick = function () { onClick(rows[Number(tr.dataset.row)]); };
+    });
+  }
+}
+
+var sessionColumns = [
+  ["XID", function (s) { return s.XID; }],
+  ["Name", function (s) { return s.TransactionName; }],
+  ["Addressing", function (s) { return s.Addressing; }],
+  ["Status", function (s) { return s.Status; }],
+  ["Active", function (s) { return s.Active; }],
+  ["Begin", function (s) { return time(s.BeginTime); }],
+  ["Age", function (s) { return age(Date.now() - Number(s.BeginTime)); }],
+  ["Timeout", function (s) { return s.Timeout + "ms"; }]
+];
+
+var lockColumns = [
+  ["XID", function (l) { return l.RowLock.XID; }],
+  ["Branch", function (l) { return l.RowLock.BranchID; }],
+  ["Resource", function (l) { return l.RowLock.ResourceID; }],
+  ["Table", function (l) { return l.RowLock.TableName; }],
+  ["PK", function (l) { return l.RowLock.PK; }],
+  ["Status", function (l) { return l.Status; }],
+  ["Age", function (l) { return Number(l.BeginTime) ? age(l.Age) : ""; }]
+];
+
+function loadSessions() {
+  var params = Object.assign({offset: sessionOffset, limit: pageSize}, sessionQuery);
+  return call("GET", "sessions", params).then(function (body) {
+    renderTable("session-table", sessionColumns, body.Sessions || [], showSession);
+    var end = sessionOffset + (body.Sessions || []).length;
+    document.getElementById("page-info").textContent =
+      (body.Total ? sessionOffset + 1 : 0) + " - " + end + " of " + body.Total;
+    document.getElementById("prev-page").disabled = sessionOffset === 0;
+    document.getElementById("next-page").disabled = end >= body.Total;
+  }).catch(function (err) { notice(err.message, true); });
+}
+
+function showSession(session) {
+  selected = session.XID;
+  return Promise.all([
+    call("GET", "session", {xid: selected}),
+    call("GET", "locks", {xid: selected})
+  ]).then(function (results) {
+    var gs = results[0].GlobalSession;
+    document.getElementById("detail-title").textContent =
+      gs.XID + " " + gs.TransactionName + " (" + gs.Status + ")";
+    renderTable("branch-table", [
+      ["Branch", function (b) { return b.BranchID; }],
+      ["Type", function (b) { return b.Type; }],
+      ["Resource", function (b) { return b.ResourceID; }],
+      ["Addressing", function (b) { return b.Addressing; }],
+      ["Status", function (b) { return b.Status; }],
+      ["Lock Key", function (b) { return b.LockKey; }],
+      ["Async Commit", function (b) { return b.AsyncCommit; }]
+    ], results[0].BranchSessions || []);
+    renderTable("detail-lock-table", lockColumns, results[1].Locks || []);
+    document.getElementById("session-detail").className = "";
+  }).catch(function (err) {
+    document.getElementById("session-detail").className = "hidden";
+    notice(err.message, true);
+  });
+}
+
+function act(path, params, confirmation) {
+  if (!selected || !window.confirm(confirmation + " " + selected + "?")) return;
+  var reason = window.prompt("Reason", "");
+  if (reason === null) return;
+  params.xid = selected;
+  params.reason = reason;
+  call("POST", path, params).then(function (body) {
+    notice(body.Message || (path + " " + selected + " done" + (body.GlobalStatus ? ", status " + body.GlobalStatus : "")));
+    return loadSessions().then(function () {
+      if (path === "session/remove") {
+        document.getElementById("session-detail").className = "hidden";
+        selected = null;
+        return;
+      }
+      return showSession({XID: selected});
+    });
+  }).catch(function (err) { notice(err.message, true); });
+}
+
+function loadQueues() {
+  var queues = [
+    ["queue-async", ["AsyncCommitting"]],
+    ["queue-commit", ["Committing", "CommitRetrying"]],
+    ["queue-rollback", ["RollingBack", "RollbackRetrying", "TimeoutRollingBack", "TimeoutRollbackRetrying"]]
+  ];
+  queues.forEach(function (queue) {
+    call("GET", "sessions", {status: queue[1], limit: pageSize}).then(function (body) {
+      renderTable(queue[0], sessionColumns, body.Sessions || [], function (session) {
+        switchTab("sessions");
+        showSession(session);
+      });
+    }).catch(function (err) { notice(err.message, true); });
+  });
+}
+
+function loadLocks(path, params) {
+  call("GET", path, Object.assign({limit: 200}, params)).then(function (body) {
+    renderTable("lock-table", lockColumns, body.Locks || body.Holders || []);
+  }).catch(function (err) { notice(err.message, true); });
+}
+
+function loadMetrics() {
+  fetch(api + "metrics").then(function (response) { return response.json(); }).then(function (counters) {
+    renderTable("metric-table", [
+      ["Name", function (c) { return c.name; }],
+      ["Labels", function (c) {
+        return Object.keys(c.labels || {}).sort().map(function (k) { return k + "=" + c.labels[k]; }).join(", ");
+      }],
+      ["Value", function (c) { return c.value; }]
+    ], counters);
+  }).catch(function (err) { notice(err.message, true); });
+}
+
+function switchTab(tab) {
+  notice("");
+  Array.prototype.forEach.call(document.querySelectorAll("nav a"), function (a) {
+    a.className = a.dataset.tab === tab ? "active" : "";
+    document.getElementById(a.dataset.tab).className = a.dataset.tab === tab ? "" : "hidden";
+  });
+  if (tab === "sessions") loadSessions();
+  if (tab === "queues") loadQueues();
+  if (tab === "locks") loadLocks("locks", {});
+  if (tab === "metrics") loadMetrics();
+}
+
+var statusSelect = document.querySelector("#session-filter select");
+statuses.forEach(function (s) {
+  var option = document.createElement("option");
+  option.value = option.textContent = s;
+  statusSelect.appendChild(option);
+});
+Array.prototype.forEach.call(document.querySelectorAll("nav a"), function (a) {
+  a.onclick = function () { switchTab(a.dataset.tab); };
+});
+document.getElementById("session-filter").onsubmit = function (e) {
+  e.preventDefault();
+  sessionQuery = formParams(e.target);
+  sessionOffset = 0;
+  loadSessions();
+};
+document.getElementById("prev-page").onclick = function () {
+  sessionOffset = Math.max(0, sessionOffset - pageSize);
+  loadSessions();
+};
+document.getElementById("next-page").onclick = function () {
+  sessionOffset += pageSize;
+  loadSessions();
+};
+document.getElementById("lock-filter").onsubmit = function (e) {
+  e.preventDefault();
+  loadLocks("locks", formParams(e.target));
+};
+document.getElementById("holder-query").onsubmit = function (e) {
+  e.preventDefault();
+  loadLocks("lock-holders", formParams(e.target));
+};
+document.getElementById("retry-commit").onclick = function () {
+  act("session/retry-commit", {}, "Retry the commit of");
+};
+document.getElementById("retry-rollback").onclick = function () {
+  act("session/retry-rollback", {}, "Retry the rollback of");
+};
+document.getElementById("abandon").onclick = function () {
+  act("session/remove", {
+    releaseLocks: document.getElementById("abandon-release").checked,
+    force: document.getElementById("abandon-force").checked
+  }, "Abandon");
+};
+setInterval(function () {
+  var active = document.querySelector("nav a.active").dataset.tab;
+  if (active === "sessions") loadSessions();
+  if (active === "queues") loadQueues();
+  if (active === "metrics") loadMetrics();
+}, 5000);
+switchTab("sessions");
+</script>
+</body>
+</html>
+`
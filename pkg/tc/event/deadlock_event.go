@@ -0,0 +1,23 @@
+package event
+
+// DeadlockEvent is published when the global transactions waiting for each other's row locks form
+// a cycle, the branch registration of the victim is failed to break it.
+type DeadlockEvent struct {
+	victim  string
+	xids    []string
+	rowKeys []string
+}
+
+func NewDeadlockEvent(victim string, xids []string, rowKeys []string) DeadlockEvent {
+	return DeadlockEvent{
+		victim,
+		xids,
+		rowKeys,
+	}
+}
+
+func (event DeadlockEvent) GetVictim() string { return event.victim }
+
+func (event DeadlockEvent) GetXIDs() []string { return event.xids }
+
+func (event DeadlockEvent) GetRowKeys() []string { return event.rowKeys }
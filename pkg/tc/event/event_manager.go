@@ -2,10 +2,14 @@ package event
 
 type EventManager struct {
 	GlobalTransactionEventChannel chan GlobalTransactionEvent
+	DeadlockEventChannel          chan DeadlockEvent
 }
 
 var EventBus EventManager
 
 func init() {
-	EventBus = EventManager{GlobalTransactionEventChannel: make(chan GlobalTransactionEvent)}
+	EventBus = EventManager{
+		GlobalTransactionEventChannel: make(chan GlobalTransactionEvent),
+		DeadlockEventChannel:          make(chan DeadlockEvent),
+	}
 }
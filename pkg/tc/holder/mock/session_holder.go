@@ -147,6 +147,20 @@ func (mr *MockSessionHolderInterfaceMockRecorder) AllSessions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllSessions", reflect.TypeOf((*MockSessionHolderInterface)(nil).AllSessions))
 }
 
+// AllGlobalTransactions mocks base method.
+func (m *MockSessionHolderInterface) AllGlobalTransactions() []*model.GlobalTransaction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllGlobalTransactions")
+	ret0, _ := ret[0].([]*model.GlobalTransaction)
+	return ret0
+}
+
+// AllGlobalTransactions indicates an expected call of AllGlobalTransactions.
+func (mr *MockSessionHolderInterfaceMockRecorder) AllGlobalTransactions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllGlobalTransactions", reflect.TypeOf((*MockSessionHolderInterface)(nil).AllGlobalTransactions))
+}
+
 // UpdateGlobalSessionStatus mocks base method.
 func (m *MockSessionHolderInterface) UpdateGlobalSessionStatus(session *apis.GlobalSession, status apis.GlobalSession_GlobalStatus) error {
 	m.ctrl.T.Helper()
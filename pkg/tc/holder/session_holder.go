@@ -15,6 +15,7 @@ type SessionHolderInterface interface {
 	FindRetryRollbackGlobalTransactions(addressingIdentities []string) []*model.GlobalTransaction
 	FindGlobalSessions(statuses []apis.GlobalSession_GlobalStatus) []*apis.GlobalSession
 	AllSessions() []*apis.GlobalSession
+	AllGlobalTransactions() []*model.GlobalTransaction
 	UpdateGlobalSessionStatus(session *apis.GlobalSession, status apis.GlobalSession_GlobalStatus) error
 	InactiveGlobalSession(session *apis.GlobalSession) error
 	RemoveGlobalSession(session *apis.GlobalSession) error
@@ -136,6 +137,11 @@ func (holder *SessionHolder) AllSessions() []*apis.GlobalSession {
 	return holder.manager.AllSessions()
 }
 
+// AllGlobalTransactions returns the global sessions of AllSessions with their branch sessions.
+func (holder *SessionHolder) AllGlobalTransactions() []*model.GlobalTransaction {
+	return holder.findGlobalTransactionsByGlobalSessions(holder.manager.AllSessions())
+}
+
 func (holder *SessionHolder) UpdateGlobalSessionStatus(session *apis.GlobalSession, status apis.GlobalSession_GlobalStatus) error {
 	session.Status = status
 	return holder.manager.UpdateGlobalSessionStatus(session, status)
@@ -150,18 +156,16 @@ func (holder *SessionHolder) RemoveGlobalSession(session *apis.GlobalSession) er
 	return holder.manager.RemoveGlobalSession(session)
 }
 
+// RemoveGlobalTransaction removes the branch sessions before the global session, the branch
+// sessions of some storages can not be found once their global session is gone.
 func (holder *SessionHolder) RemoveGlobalTransaction(globalTransaction *model.GlobalTransaction) error {
-	err := holder.manager.RemoveGlobalSession(globalTransaction.GlobalSession)
-	if err != nil {
-		return err
-	}
 	for bs := range globalTransaction.BranchSessions {
-		err = holder.manager.RemoveBranchSession(globalTransaction.GlobalSession, bs)
+		err := holder.manager.RemoveBranchSession(globalTransaction.GlobalSession, bs)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return holder.manager.RemoveGlobalSession(globalTransaction.GlobalSession)
 }
 
 func (holder *SessionHolder) AddBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
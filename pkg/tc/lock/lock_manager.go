@@ -2,54 +2,112 @@ package lock
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/event"
 	"github.com/opentrx/seata-golang/v2/pkg/tc/model"
 	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
 	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/runtime"
 )
 
 type LockManagerInterface interface {
 	AcquireLock(branchSession *apis.BranchSession) bool
+	AcquireLockWait(globalSession *apis.GlobalSession, branchSession *apis.BranchSession, deadline time.Time) error
 	ReleaseLock(branchSession *apis.BranchSession) bool
 	ReleaseGlobalSessionLock(globalTransaction *model.GlobalTransaction) bool
 	IsLockable(xid string, resourceID string, lockKey string) bool
+	ListRowLocks(query storage.RowLockQuery) ([]*apis.RowLock, error)
 }
 
+// ErrLockListUnsupported is returned by ListRowLocks if the storage can not list its row locks.
+var ErrLockListUnsupported = errors.New("the storage does not list its row locks")
+
 type LockManager struct {
-	manager storage.LockManager
+	manager   storage.LockManager
+	waitQueue *LockWaitQueue
+	graph     *WaitForGraph
 }
 
 func NewLockManager(manager storage.LockManager) *LockManager {
-	return &LockManager{manager: manager}
+	return &LockManager{manager: manager, waitQueue: NewLockWaitQueue(), graph: NewWaitForGraph()}
 }
 
+// AcquireLock acquires the row locks of the branch session without waiting, the LockWaitQueue does
+// not track them.
 func (locker *LockManager) AcquireLock(branchSession *apis.BranchSession) bool {
+	return locker.AcquireLockWait(nil, branchSession, time.Time{}) == nil
+}
+
+// AcquireLockWait acquires the row locks of the branch session, it waits until the deadline in the
+// LockWaitQueue of the conflicting row keys if they are held by other global transactions.
+// ErrLockConflict is returned if the row locks are not acquired, a DeadlockError if the global
+// session is chosen as the victim of a deadlock, a storage.LockKeyError if the lock key is malformed.
+func (locker *LockManager) AcquireLockWait(globalSession *apis.GlobalSession, branchSession *apis.BranchSession,
+	deadline time.Time) error {
 	if branchSession == nil {
 		log.Debug("branchSession can't be null for memory/file locker.")
-		return true
+		return nil
 	}
 
 	if branchSession.LockKey == "" {
-		return true
+		return nil
 	}
 
-	locks := storage.CollectBranchSessionRowLocks(branchSession)
+	locks, err := storage.ParseBranchSessionRowLocks(branchSession)
+	if err != nil {
+		return err
+	}
 	if len(locks) == 0 {
-		return true
+		return nil
+	}
+
+	var beginTime int64
+	if globalSession != nil {
+		beginTime = globalSession.BeginTime
 	}
 
+	skipCheckLock := false
 	applicationData := branchSession.ApplicationData
 	if applicationData != nil && branchSession.Type == apis.AT {
 		applicationDataMap := make(map[string]bool)
 		err := json.Unmarshal(applicationData, &applicationDataMap)
-		if err != nil {
-			return locker.manager.AcquireLock(locks, false)
+		if err == nil {
+			skipCheckLock = applicationDataMap["skipCheckLock"]
+		}
+	}
+
+	xid := branchSession.XID
+	err = locker.waitQueue.Acquire(xid, rowKeys(locks), deadline, func() (bool, []string, error) {
+		if deadlock := locker.graph.Victim(xid); deadlock != nil {
+			return false, nil, deadlock
+		}
+		if locker.manager.AcquireLock(locks, skipCheckLock) {
+			return true, nil, nil
+		}
+		var conflicts []string
+		for _, rowLock := range locks {
+			if !locker.manager.IsLockable(xid, rowLock.ResourceID, rowLock.TableName+":"+rowLock.PK) {
+				conflicts = append(conflicts, rowLock.RowKey)
+			}
 		}
-		skipCheckLock := applicationDataMap["skipCheckLock"]
-		return locker.manager.AcquireLock(locks, skipCheckLock)
+		if deadlock := locker.graph.Wait(xid, beginTime, locker.waitQueue.Owners(conflicts)); deadlock != nil {
+			reportDeadlock(deadlock)
+			if deadlock.Victim == xid {
+				locker.graph.Victim(xid)
+				return false, nil, deadlock
+			}
+			locker.waitQueue.Wake(deadlock.Victim)
+		}
+		return false, conflicts, nil
+	})
+	if err == nil {
+		locker.graph.Forget(xid)
 	}
-	return locker.manager.AcquireLock(locks, false)
+	return err
 }
 
 func (locker *LockManager) ReleaseLock(branchSession *apis.BranchSession) bool {
@@ -67,7 +125,11 @@ func (locker *LockManager) ReleaseLock(branchSession *apis.BranchSession) bool {
 		return true
 	}
 
-	return locker.manager.ReleaseLock(locks)
+	result := locker.manager.ReleaseLock(locks)
+	keys := rowKeys(locks)
+	locker.waitQueue.Release(branchSession.XID, keys)
+	locker.graph.Released(branchSession.XID, keys)
+	return result
 }
 
 func (locker *LockManager) ReleaseGlobalSessionLock(globalTransaction *model.GlobalTransaction) bool {
@@ -76,9 +138,40 @@ func (locker *LockManager) ReleaseGlobalSessionLock(globalTransaction *model.Glo
 		rowLocks := storage.CollectBranchSessionRowLocks(branchSession)
 		locks = append(locks, rowLocks...)
 	}
-	return locker.manager.ReleaseLock(locks)
+	result := locker.manager.ReleaseLock(locks)
+	keys := rowKeys(locks)
+	locker.waitQueue.Release(globalTransaction.XID, keys)
+	locker.graph.Released(globalTransaction.XID, keys)
+	locker.graph.Forget(globalTransaction.XID)
+	return result
 }
 
 func (locker *LockManager) IsLockable(xid string, resourceID string, lockKey string) bool {
 	return locker.manager.IsLockable(xid, resourceID, lockKey)
 }
+
+// ListRowLocks lists the row locks held in the storage matching the query.
+func (locker *LockManager) ListRowLocks(query storage.RowLockQuery) ([]*apis.RowLock, error) {
+	lister, ok := locker.manager.(storage.LockLister)
+	if !ok {
+		return nil, ErrLockListUnsupported
+	}
+	return lister.ListRowLocks(query)
+}
+
+func rowKeys(rowLocks []*apis.RowLock) []string {
+	keys := make([]string, 0, len(rowLocks))
+	for _, rowLock := range rowLocks {
+		keys = append(keys, rowLock.RowKey)
+	}
+	return keys
+}
+
+// reportDeadlock logs the deadlock and publishes it to the metrics
+func reportDeadlock(deadlock *DeadlockError) {
+	log.Warnf("deadlock detected between global transactions %s on row keys %s, %s is chosen as the victim",
+		strings.Join(deadlock.XIDs, ", "), strings.Join(deadlock.RowKeys, ", "), deadlock.Victim)
+	runtime.GoWithRecover(func() {
+		event.EventBus.DeadlockEventChannel <- event.NewDeadlockEvent(deadlock.Victim, deadlock.XIDs, deadlock.RowKeys)
+	}, nil)
+}
@@ -0,0 +1,332 @@
+package lock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+const (
+	// WriteThroughPersistence persists a row lock before AcquireLock returns, the storage is
+	// consulted whenever the lock table finds the rows free.
+	WriteThroughPersistence = "writethrough"
+
+	// BackgroundPersistence persists the row locks by a background goroutine, the lock table is the
+	// only source of truth for the conflicts.
+	BackgroundPersistence = "background"
+
+	defaultLockTableShards    = 64
+	defaultLockTableQueueSize = 1024
+)
+
+// LockTable is a sharded in-memory table of row locks in front of the row locks of a storage
+// driver, the conflicts are checked in memory so that a contended row is rejected without a round
+// trip to the storage. It has the semantics of storage.LockManager.
+//
+// The lock table assumes it is the only one acquiring and releasing the row locks of the storage,
+// so it should not be used by TC nodes sharing a storage.
+//
+// The table and pk range locks lock all the shards, so that the row locks of their tables can be
+// scanned for conflicts, their index is only changed while all the shards are locked.
+type LockTable struct {
+	store      storage.LockManager
+	shards     []*lockShard
+	background bool
+
+	// rangeLocks the table and pk range locks by row key by the table they lock
+	rangeLocks map[string]map[string]*apis.RowLock
+
+	writes    chan lockWrite
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type lockShard struct {
+	sync.Mutex
+	locks map[string]*apis.RowLock
+}
+
+// lockWrite is a change of the row locks waiting to be persisted in background
+type lockWrite struct {
+	rowLocks []*apis.RowLock
+	release  bool
+}
+
+// NewLockTable constructs a LockTable in front of the store.
+func NewLockTable(store storage.LockManager, conf config.LockTable) (*LockTable, error) {
+	shards := conf.Shards
+	if shards <= 0 {
+		shards = defaultLockTableShards
+	}
+	table := &LockTable{
+		store:      store,
+		shards:     make([]*lockShard, shards),
+		rangeLocks: make(map[string]map[string]*apis.RowLock),
+	}
+	for i := range table.shards {
+		table.shards[i] = &lockShard{locks: make(map[string]*apis.RowLock)}
+	}
+
+	switch conf.Persistence {
+	case "", WriteThroughPersistence:
+	case BackgroundPersistence:
+		queueSize := conf.QueueSize
+		if queueSize <= 0 {
+			queueSize = defaultLockTableQueueSize
+		}
+		table.background = true
+		table.writes = make(chan lockWrite, queueSize)
+		table.done = make(chan struct{})
+		go table.persist()
+	default:
+		return nil, fmt.Errorf("unknown lock table persistence: %s", conf.Persistence)
+	}
+	return table, nil
+}
+
+// Load puts the row locks of the branch sessions in the storage into the lock table, it should be
+// called before the lock table is used. Only the global sessions returned by AllSessions are
+// loaded, so the query limit of the storage driver should exceed the in-flight global sessions.
+func (table *LockTable) Load(manager storage.SessionManager) {
+	sessions := manager.AllSessions()
+	xids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		xids = append(xids, session.XID)
+	}
+	if len(xids) == 0 {
+		return
+	}
+
+	count := 0
+	for _, branchSession := range manager.FindBatchBranchSessions(xids) {
+		rowLocks := storage.CollectBranchSessionRowLocks(branchSession)
+		unlock := table.lockShards(rowLocks)
+		for _, rowLock := range rowLocks {
+			table.putLocked(rowLock)
+		}
+		unlock()
+		count += len(rowLocks)
+	}
+	log.Infof("loaded %d row locks into the lock table", count)
+}
+
+// AcquireLock acquires all the row locks or none of them.
+func (table *LockTable) AcquireLock(rowLocks []*apis.RowLock, skipCheckLock bool) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+
+	unlock := table.lockShards(rowLocks)
+	defer unlock()
+
+	pending := make([]*apis.RowLock, 0, len(rowLocks))
+	for _, rowLock := range rowLocks {
+		held, ok := table.shardOf(rowLock.RowKey).locks[rowLock.RowKey]
+		if ok && held.XID == rowLock.XID {
+			continue
+		}
+		if !ok && !skipCheckLock {
+			held, ok = table.conflictLocked(rowLock)
+		}
+		if ok && !skipCheckLock {
+			log.Infof("Global rowLock on [%s:%s] is holding by %s", rowLock.TableName, rowLock.PK, held.XID)
+			return false
+		}
+		pending = append(pending, rowLock)
+	}
+	if len(pending) == 0 {
+		return true
+	}
+
+	if table.background {
+		table.writes <- lockWrite{rowLocks: pending}
+	} else if !table.store.AcquireLock(pending, skipCheckLock) {
+		return false
+	}
+	for _, rowLock := range pending {
+		table.putLocked(rowLock)
+	}
+	return true
+}
+
+// ReleaseLock releases the row locks held by their xid.
+func (table *LockTable) ReleaseLock(rowLocks []*apis.RowLock) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+
+	unlock := table.lockShards(rowLocks)
+	defer unlock()
+
+	for _, rowLock := range rowLocks {
+		shard := table.shardOf(rowLock.RowKey)
+		if held, ok := shard.locks[rowLock.RowKey]; ok && held.XID == rowLock.XID {
+			delete(shard.locks, rowLock.RowKey)
+			if storage.IsRangeLock(rowLock) {
+				tableKey := lockTableKey(rowLock)
+				delete(table.rangeLocks[tableKey], rowLock.RowKey)
+				if len(table.rangeLocks[tableKey]) == 0 {
+					delete(table.rangeLocks, tableKey)
+				}
+			}
+		}
+	}
+
+	if table.background {
+		table.writes <- lockWrite{rowLocks: rowLocks, release: true}
+		return true
+	}
+	return table.store.ReleaseLock(rowLocks)
+}
+
+// IsLockable reports whether the rows are free or held by the xid.
+func (table *LockTable) IsLockable(xid string, resourceID string, lockKey string) bool {
+	rowLocks := storage.CollectRowLocks(lockKey, resourceID, xid)
+	unlock := table.lockShards(rowLocks)
+	for _, rowLock := range rowLocks {
+		held, ok := table.shardOf(rowLock.RowKey).locks[rowLock.RowKey]
+		if !ok {
+			held, ok = table.conflictLocked(rowLock)
+		}
+		if ok && held.XID != xid {
+			unlock()
+			return false
+		}
+	}
+	unlock()
+
+	if table.background {
+		return true
+	}
+	return table.store.IsLockable(xid, resourceID, lockKey)
+}
+
+// ListRowLocks lists the row locks of the lock table matching the query.
+func (table *LockTable) ListRowLocks(query storage.RowLockQuery) ([]*apis.RowLock, error) {
+	rowLocks := make([]*apis.RowLock, 0)
+	for _, shard := range table.shards {
+		shard.Lock()
+		for _, rowLock := range shard.locks {
+			if query.Matches(rowLock) {
+				rowLocks = append(rowLocks, rowLock)
+			}
+		}
+		shard.Unlock()
+	}
+	storage.SortRowLocks(rowLocks)
+	return rowLocks, nil
+}
+
+// Close persists the row locks waiting in background, the lock table must not be used afterwards.
+func (table *LockTable) Close() {
+	table.closeOnce.Do(func() {
+		if table.background {
+			close(table.writes)
+			<-table.done
+		}
+	})
+}
+
+// persist applies the writes to the store in the order the lock table made them
+func (table *LockTable) persist() {
+	defer close(table.done)
+	for write := range table.writes {
+		if write.release {
+			if !table.store.ReleaseLock(write.rowLocks) {
+				log.Errorf("failed to release %d row locks of %s in the storage", len(write.rowLocks),
+					write.rowLocks[0].XID)
+			}
+		} else if !table.store.AcquireLock(write.rowLocks, true) {
+			log.Errorf("failed to persist %d row locks of %s in the storage", len(write.rowLocks),
+				write.rowLocks[0].XID)
+		}
+	}
+}
+
+func (table *LockTable) shardOf(rowKey string) *lockShard {
+	return table.shards[table.shardIndex(rowKey)]
+}
+
+func (table *LockTable) shardIndex(rowKey string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(rowKey))
+	return int(h.Sum32() % uint32(len(table.shards)))
+}
+
+// putLocked puts the row lock into its shard and the table and pk range locks into their index,
+// the shards of the row lock must be locked
+func (table *LockTable) putLocked(rowLock *apis.RowLock) {
+	table.shardOf(rowLock.RowKey).locks[rowLock.RowKey] = rowLock
+	if storage.IsRangeLock(rowLock) {
+		tableKey := lockTableKey(rowLock)
+		locks, ok := table.rangeLocks[tableKey]
+		if !ok {
+			locks = make(map[string]*apis.RowLock)
+			table.rangeLocks[tableKey] = locks
+		}
+		locks[rowLock.RowKey] = rowLock
+	}
+}
+
+// conflictLocked returns a row lock of another xid overlapping the row lock under another row key,
+// all the row locks of the table are scanned for a table or pk range lock, the shards of the row
+// lock must be locked
+func (table *LockTable) conflictLocked(rowLock *apis.RowLock) (*apis.RowLock, bool) {
+	if !storage.IsRangeLock(rowLock) {
+		for _, held := range table.rangeLocks[lockTableKey(rowLock)] {
+			if held.XID != rowLock.XID && storage.RowLocksOverlap(held, rowLock) {
+				return held, true
+			}
+		}
+		return nil, false
+	}
+	for _, shard := range table.shards {
+		for _, held := range shard.locks {
+			if held.XID != rowLock.XID && storage.RowLocksOverlap(held, rowLock) {
+				return held, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// lockShards locks the shards of the row locks in ascending order, so that two batches sharing
+// shards never wait for each other, and returns the func unlocking them. All the shards are locked
+// if there is a table or pk range lock among the row locks.
+func (table *LockTable) lockShards(rowLocks []*apis.RowLock) func() {
+	indexes := make([]int, 0, len(rowLocks))
+	seen := make(map[int]bool, len(rowLocks))
+	for _, rowLock := range rowLocks {
+		if storage.IsRangeLock(rowLock) {
+			indexes = indexes[:0]
+			for index := range table.shards {
+				indexes = append(indexes, index)
+			}
+			break
+		}
+		index := table.shardIndex(rowLock.RowKey)
+		if !seen[index] {
+			seen[index] = true
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		table.shards[index].Lock()
+	}
+	return func() {
+		for i := len(indexes) - 1; i >= 0; i-- {
+			table.shards[indexes[i]].Unlock()
+		}
+	}
+}
+
+func lockTableKey(rowLock *apis.RowLock) string {
+	return rowLock.ResourceID + storage.LockSplit + rowLock.TableName
+}
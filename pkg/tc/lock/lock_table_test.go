@@ -0,0 +1,131 @@
+package lock
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/inmemory"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/storagetest"
+)
+
+// countingStore counts the calls reaching the storage
+type countingStore struct {
+	storage.Driver
+	mu       sync.Mutex
+	acquires int
+}
+
+func (store *countingStore) AcquireLock(rowLocks []*apis.RowLock, skipCheckLock bool) bool {
+	store.mu.Lock()
+	store.acquires++
+	store.mu.Unlock()
+	return store.Driver.AcquireLock(rowLocks, skipCheckLock)
+}
+
+func newTestStore(t *testing.T) *countingStore {
+	driver, err := factory.Create("inmemory", nil)
+	assert.Nil(t, err)
+	return &countingStore{Driver: driver}
+}
+
+func TestLockTable_DriverSuite(t *testing.T) {
+	for _, persistence := range []string{WriteThroughPersistence, BackgroundPersistence} {
+		persistence := persistence
+		t.Run(persistence, func(t *testing.T) {
+			factory := func(t *testing.T) storage.Driver {
+				store := newTestStore(t)
+				table, err := NewLockTable(store, config.LockTable{Shards: 4, Persistence: persistence})
+				assert.Nil(t, err)
+				t.Cleanup(table.Close)
+				return struct {
+					storage.SessionManager
+					*LockTable
+				}{store, table}
+			}
+			storagetest.RunDriverSuite(t, factory)
+			storagetest.RunRangeLockSuite(t, factory)
+		})
+	}
+}
+
+func TestLockTable_WriteThrough(t *testing.T) {
+	store := newTestStore(t)
+	table, err := NewLockTable(store, config.LockTable{})
+	assert.Nil(t, err)
+	xid1, xid2 := "localhost:8091:1", "localhost:8091:2"
+
+	assert.True(t, table.AcquireLock(storage.CollectRowLocks("product:1,2", "db", xid1), false))
+	assert.Equal(t, 1, store.acquires)
+	assert.False(t, store.IsLockable(xid2, "db", "product:1"))
+
+	// the conflicts and the reentrant locks are decided in memory
+	assert.False(t, table.AcquireLock(storage.CollectRowLocks("product:2,3", "db", xid2), false))
+	assert.True(t, table.AcquireLock(storage.CollectRowLocks("product:1", "db", xid1), false))
+	assert.Equal(t, 1, store.acquires)
+
+	// the rows locked in the storage before are still respected
+	assert.True(t, store.AcquireLock(storage.CollectRowLocks("product:4", "db", xid2), false))
+	assert.False(t, table.IsLockable(xid1, "db", "product:4"))
+	assert.False(t, table.AcquireLock(storage.CollectRowLocks("product:3,4", "db", xid1), false))
+	assert.True(t, table.IsLockable(xid1, "db", "product:3"))
+
+	assert.True(t, table.ReleaseLock(storage.CollectRowLocks("product:1,2", "db", xid1)))
+	assert.True(t, store.IsLockable(xid2, "db", "product:1,2"))
+	assert.True(t, table.AcquireLock(storage.CollectRowLocks("product:2,3", "db", xid2), false))
+}
+
+func TestLockTable_Background(t *testing.T) {
+	store := newTestStore(t)
+	table, err := NewLockTable(store, config.LockTable{Persistence: BackgroundPersistence, QueueSize: 1})
+	assert.Nil(t, err)
+	xid1, xid2 := "localhost:8091:1", "localhost:8091:2"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			lockKey := fmt.Sprintf("product:%d", i)
+			assert.True(t, table.AcquireLock(storage.CollectRowLocks(lockKey, "db", xid1), false))
+		}(i)
+	}
+	wg.Wait()
+	assert.False(t, table.AcquireLock(storage.CollectRowLocks("product:9,10", "db", xid2), false))
+	assert.True(t, table.ReleaseLock(storage.CollectRowLocks("product:9", "db", xid1)))
+	assert.True(t, table.AcquireLock(storage.CollectRowLocks("product:9,10", "db", xid2), false))
+
+	// the writes reach the storage in order once they are flushed
+	table.Close()
+	assert.False(t, store.IsLockable(xid2, "db", "product:0,8"))
+	assert.True(t, store.IsLockable(xid1, "db", "product:0,8"))
+	assert.False(t, store.IsLockable(xid1, "db", "product:9,10"))
+	assert.True(t, store.IsLockable(xid2, "db", "product:9,10"))
+}
+
+func TestLockTable_Load(t *testing.T) {
+	store := newTestStore(t)
+	gs := &apis.GlobalSession{XID: "localhost:8091:1", TransactionID: 1, Status: apis.Begin, Active: true}
+	bs := &apis.BranchSession{XID: gs.XID, BranchID: 2, TransactionID: 1, ResourceID: "db", LockKey: "product:1",
+		Type: apis.AT, Status: apis.Registered}
+	assert.Nil(t, store.AddGlobalSession(gs))
+	assert.Nil(t, store.AddBranchSession(gs, bs))
+
+	table, err := NewLockTable(store, config.LockTable{Persistence: BackgroundPersistence})
+	assert.Nil(t, err)
+	defer table.Close()
+	table.Load(store)
+	assert.False(t, table.IsLockable("localhost:8091:2", "db", "product:1"))
+	assert.True(t, table.IsLockable(gs.XID, "db", "product:1"))
+}
+
+func TestNewLockTable(t *testing.T) {
+	_, err := NewLockTable(newTestStore(t), config.LockTable{Persistence: "unknown"})
+	assert.NotNil(t, err)
+}
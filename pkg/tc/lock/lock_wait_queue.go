@@ -0,0 +1,245 @@
+package lock
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLockConflict is returned when the row keys are held by other global transactions.
+var ErrLockConflict = errors.New("the row locks are held by other global transactions")
+
+// lockWaitPollPeriod is how often the head of a queue retries without being woken, it covers the
+// row locks released without the LockWaitQueue knowing, e.g. by another TC node.
+const lockWaitPollPeriod = 100 * time.Millisecond
+
+// LockWaitQueue parks the lock acquisitions conflicting on row keys in a FIFO queue per row key,
+// and wakes the head of a queue when its row key is released. An acquisition does not overtake the
+// acquisitions waiting for the same row keys, unless its xid already holds the row key.
+type LockWaitQueue struct {
+	mu sync.Mutex
+
+	// waiters the waiters of a row key in the order they arrived
+	waiters map[string][]*lockWaiter
+
+	// owners the xids holding the row keys acquired through the queue
+	owners map[string]string
+
+	// releases counts the releases, a waiter misses no wakeup between its try and its enqueue
+	releases uint64
+}
+
+type lockWaiter struct {
+	xid     string
+	rowKeys map[string]bool
+	wake    chan struct{}
+}
+
+func NewLockWaitQueue() *LockWaitQueue {
+	return &LockWaitQueue{
+		waiters: make(map[string][]*lockWaiter),
+		owners:  make(map[string]string),
+	}
+}
+
+// Acquire calls try until it acquires the row keys or the deadline passes, try returns the
+// conflicting row keys when it fails, or an error to stop waiting. try is called once if the
+// deadline has passed already. ErrLockConflict is returned if the row keys are not acquired.
+// A zero deadline disables waiting, nobody queues for the row keys then, so their owner is not
+// tracked either.
+func (queue *LockWaitQueue) Acquire(xid string, rowKeys []string, deadline time.Time,
+	try func() (bool, []string, error)) error {
+	queue.mu.Lock()
+	var waiter *lockWaiter
+	if queued := queue.queuedKeysLocked(xid, rowKeys); len(queued) > 0 {
+		if !time.Now().Before(deadline) {
+			queue.mu.Unlock()
+			return ErrLockConflict
+		}
+		waiter = &lockWaiter{xid: xid, rowKeys: make(map[string]bool), wake: make(chan struct{}, 1)}
+		queue.enqueueLocked(waiter, queued)
+	}
+	releases := queue.releases
+	queue.mu.Unlock()
+
+	if waiter == nil {
+		acquired, conflicts, err := try()
+		if err != nil {
+			return err
+		}
+		if acquired {
+			if !deadline.IsZero() {
+				queue.grant(xid, rowKeys)
+			}
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return ErrLockConflict
+		}
+		waiter = &lockWaiter{xid: xid, rowKeys: make(map[string]bool), wake: make(chan struct{}, 1)}
+		queue.enqueue(waiter, conflicts, rowKeys, releases)
+	}
+	defer queue.leave(waiter)
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	ticker := time.NewTicker(lockWaitPollPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-waiter.wake:
+		case <-ticker.C:
+			if !queue.isHead(waiter) {
+				continue
+			}
+		case <-timer.C:
+			return ErrLockConflict
+		}
+
+		queue.mu.Lock()
+		releases = queue.releases
+		queue.mu.Unlock()
+		acquired, conflicts, err := try()
+		if err != nil {
+			return err
+		}
+		if acquired {
+			queue.grant(xid, rowKeys)
+			return nil
+		}
+		queue.enqueue(waiter, conflicts, rowKeys, releases)
+	}
+}
+
+// Release forgets the owner of the row keys and wakes the heads of their queues.
+func (queue *LockWaitQueue) Release(xid string, rowKeys []string) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	queue.releases++
+	for _, rowKey := range rowKeys {
+		if queue.owners[rowKey] == xid {
+			delete(queue.owners, rowKey)
+		}
+		if waiters := queue.waiters[rowKey]; len(waiters) > 0 {
+			waiters[0].notify()
+		}
+	}
+}
+
+// Wake wakes the waiters of the xid to retry at once.
+func (queue *LockWaitQueue) Wake(xid string) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	for _, waiters := range queue.waiters {
+		for _, waiter := range waiters {
+			if waiter.xid == xid {
+				waiter.notify()
+			}
+		}
+	}
+}
+
+// Owners groups the row keys by the xids holding them, the row keys acquired without the queue are
+// left out.
+func (queue *LockWaitQueue) Owners(rowKeys []string) map[string][]string {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	owners := make(map[string][]string)
+	for _, rowKey := range rowKeys {
+		if owner, ok := queue.owners[rowKey]; ok {
+			owners[owner] = append(owners[owner], rowKey)
+		}
+	}
+	return owners
+}
+
+// Waiters returns the number of acquisitions waiting for the row key.
+func (queue *LockWaitQueue) Waiters(rowKey string) int {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return len(queue.waiters[rowKey])
+}
+
+// queuedKeysLocked returns the row keys the xid has to queue for to not overtake other waiters
+func (queue *LockWaitQueue) queuedKeysLocked(xid string, rowKeys []string) []string {
+	var queued []string
+	for _, rowKey := range rowKeys {
+		if len(queue.waiters[rowKey]) > 0 && queue.owners[rowKey] != xid {
+			queued = append(queued, rowKey)
+		}
+	}
+	return queued
+}
+
+// enqueue queues the waiter for the conflicting row keys, or for all the row keys when try could
+// not tell the conflicts. The waiter is woken at once if a release happened since try was called.
+func (queue *LockWaitQueue) enqueue(waiter *lockWaiter, conflicts []string, rowKeys []string, releases uint64) {
+	if len(conflicts) == 0 {
+		conflicts = rowKeys
+	}
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	queue.enqueueLocked(waiter, conflicts)
+	if queue.releases != releases {
+		waiter.notify()
+	}
+}
+
+func (queue *LockWaitQueue) enqueueLocked(waiter *lockWaiter, rowKeys []string) {
+	for _, rowKey := range rowKeys {
+		if !waiter.rowKeys[rowKey] {
+			waiter.rowKeys[rowKey] = true
+			queue.waiters[rowKey] = append(queue.waiters[rowKey], waiter)
+		}
+	}
+}
+
+// leave removes the waiter from its queues, the new heads are woken to retry
+func (queue *LockWaitQueue) leave(waiter *lockWaiter) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	for rowKey := range waiter.rowKeys {
+		waiters := queue.waiters[rowKey]
+		for i, w := range waiters {
+			if w == waiter {
+				waiters = append(waiters[:i:i], waiters[i+1:]...)
+				if i == 0 && len(waiters) > 0 {
+					waiters[0].notify()
+				}
+				break
+			}
+		}
+		if len(waiters) == 0 {
+			delete(queue.waiters, rowKey)
+		} else {
+			queue.waiters[rowKey] = waiters
+		}
+	}
+}
+
+func (queue *LockWaitQueue) grant(xid string, rowKeys []string) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	for _, rowKey := range rowKeys {
+		queue.owners[rowKey] = xid
+	}
+}
+
+// isHead reports whether no other xid waits before the waiter in any of its queues
+func (queue *LockWaitQueue) isHead(waiter *lockWaiter) bool {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	for rowKey := range waiter.rowKeys {
+		if head := queue.waiters[rowKey][0]; head != waiter && queue.owners[rowKey] != waiter.xid {
+			return false
+		}
+	}
+	return true
+}
+
+func (waiter *lockWaiter) notify() {
+	select {
+	case waiter.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,112 @@
+package lock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/model"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/util/common"
+)
+
+func newBranchSession(xid string, lockKey string) *apis.BranchSession {
+	return &apis.BranchSession{XID: xid, TransactionID: common.GetTransactionID(xid), ResourceID: "db",
+		LockKey: lockKey, Type: apis.AT}
+}
+
+// waitForWaiters waits until the number of acquisitions waiting for the row key is reached
+func waitForWaiters(t *testing.T, queue *LockWaitQueue, rowKey string, count int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if queue.Waiters(rowKey) == count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d waiters expected for %s", count, rowKey)
+}
+
+func TestLockManager_AcquireLockWait_MalformedLockKey(t *testing.T) {
+	locker := NewLockManager(newTestStore(t))
+	err := locker.AcquireLockWait(nil, newBranchSession("localhost:8091:1", "product:[5~1]"), time.Time{})
+	_, ok := err.(*storage.LockKeyError)
+	assert.True(t, ok)
+	assert.False(t, locker.AcquireLock(newBranchSession("localhost:8091:1", "product:[1~5")))
+	assert.True(t, locker.IsLockable("localhost:8091:2", "db", "product:1"))
+}
+
+func TestLockManager_AcquireLockWait(t *testing.T) {
+	locker := NewLockManager(newTestStore(t))
+	holder := newBranchSession("localhost:8091:1", "product:1,2")
+	assert.Nil(t, locker.AcquireLockWait(nil, holder, time.Now().Add(time.Second)))
+
+	// the conflict fails at once without a deadline, and after the deadline with one
+	assert.False(t, locker.AcquireLock(newBranchSession("localhost:8091:2", "product:2")))
+	start := time.Now()
+	assert.Equal(t, ErrLockConflict, locker.AcquireLockWait(nil, newBranchSession("localhost:8091:2", "product:2"),
+		time.Now().Add(50*time.Millisecond)))
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.Equal(t, 0, locker.waitQueue.Waiters("db^^^product^^^2"))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- locker.AcquireLockWait(nil, newBranchSession("localhost:8091:2", "product:2,3"),
+			time.Now().Add(5*time.Second))
+	}()
+	waitForWaiters(t, locker.waitQueue, "db^^^product^^^2", 1)
+
+	// the holder registers another branch on its rows without queueing
+	assert.Nil(t, locker.AcquireLockWait(nil, newBranchSession(holder.XID, "product:2"), time.Now().Add(time.Second)))
+
+	assert.True(t, locker.ReleaseGlobalSessionLock(&model.GlobalTransaction{
+		GlobalSession:  &apis.GlobalSession{XID: holder.XID},
+		BranchSessions: map[*apis.BranchSession]bool{holder: true},
+	}))
+	assert.Nil(t, <-acquired)
+	assert.False(t, locker.IsLockable(holder.XID, "db", "product:3"))
+}
+
+func TestLockManager_AcquireLockWaitInOrder(t *testing.T) {
+	locker := NewLockManager(newTestStore(t))
+	holder := newBranchSession("localhost:8091:1", "product:1")
+	assert.True(t, locker.AcquireLock(holder))
+
+	order := make(chan string, 2)
+	for i, xid := range []string{"localhost:8091:2", "localhost:8091:3"} {
+		branchSession := newBranchSession(xid, "product:1")
+		go func() {
+			if locker.AcquireLockWait(nil, branchSession, time.Now().Add(5*time.Second)) == nil {
+				order <- branchSession.XID
+			}
+		}()
+		waitForWaiters(t, locker.waitQueue, "db^^^product^^^1", i+1)
+	}
+
+	// a newcomer does not overtake the waiters while the row is released
+	assert.True(t, locker.ReleaseLock(holder))
+	assert.False(t, locker.AcquireLock(newBranchSession("localhost:8091:4", "product:1")))
+	assert.Equal(t, "localhost:8091:2", <-order)
+
+	assert.True(t, locker.ReleaseLock(newBranchSession("localhost:8091:2", "product:1")))
+	assert.Equal(t, "localhost:8091:3", <-order)
+}
+
+func TestLockManager_AcquireLock_NotTracked(t *testing.T) {
+	locker := NewLockManager(newTestStore(t))
+	holder := newBranchSession("localhost:8091:1", "product:1,2")
+
+	// the owners are not tracked without waiting, nothing is left behind if the locks are dropped
+	// without a release
+	assert.True(t, locker.AcquireLock(holder))
+	assert.Empty(t, locker.waitQueue.Owners([]string{"db^^^product^^^1", "db^^^product^^^2"}))
+
+	assert.Nil(t, locker.AcquireLockWait(nil, newBranchSession("localhost:8091:2", "product:3"),
+		time.Now().Add(time.Second)))
+	assert.Equal(t, map[string][]string{"localhost:8091:2": {"db^^^product^^^3"}},
+		locker.waitQueue.Owners([]string{"db^^^product^^^3"}))
+	assert.True(t, locker.ReleaseLock(newBranchSession("localhost:8091:2", "product:3")))
+	assert.Empty(t, locker.waitQueue.owners)
+}
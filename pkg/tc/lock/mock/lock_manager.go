@@ -6,10 +6,12 @@ package mock_lock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	apis "github.com/opentrx/seata-golang/v2/pkg/apis"
 	model "github.com/opentrx/seata-golang/v2/pkg/tc/model"
+	storage "github.com/opentrx/seata-golang/v2/pkg/tc/storage"
 )
 
 // MockLockManagerInterface is a mock of LockManagerInterface interface.
@@ -49,18 +51,47 @@ func (mr *MockLockManagerInterfaceMockRecorder) AcquireLock(branchSession interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLock", reflect.TypeOf((*MockLockManagerInterface)(nil).AcquireLock), branchSession)
 }
 
-// ReleaseLock mocks base method.
-func (m *MockLockManagerInterface) ReleaseLock(branchSession *apis.BranchSession) bool {
+// AcquireLockWait mocks base method.
+func (m *MockLockManagerInterface) AcquireLockWait(globalSession *apis.GlobalSession, branchSession *apis.BranchSession, deadline time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReleaseLock", branchSession)
+	ret := m.ctrl.Call(m, "AcquireLockWait", globalSession, branchSession, deadline)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcquireLockWait indicates an expected call of AcquireLockWait.
+func (mr *MockLockManagerInterfaceMockRecorder) AcquireLockWait(globalSession, branchSession, deadline interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLockWait", reflect.TypeOf((*MockLockManagerInterface)(nil).AcquireLockWait), globalSession, branchSession, deadline)
+}
+
+// IsLockable mocks base method.
+func (m *MockLockManagerInterface) IsLockable(xid, resourceID, lockKey string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLockable", xid, resourceID, lockKey)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// ReleaseLock indicates an expected call of ReleaseLock.
-func (mr *MockLockManagerInterfaceMockRecorder) ReleaseLock(branchSession interface{}) *gomock.Call {
+// IsLockable indicates an expected call of IsLockable.
+func (mr *MockLockManagerInterfaceMockRecorder) IsLockable(xid, resourceID, lockKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLock", reflect.TypeOf((*MockLockManagerInterface)(nil).ReleaseLock), branchSession)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLockable", reflect.TypeOf((*MockLockManagerInterface)(nil).IsLockable), xid, resourceID, lockKey)
+}
+
+// ListRowLocks mocks base method.
+func (m *MockLockManagerInterface) ListRowLocks(query storage.RowLockQuery) ([]*apis.RowLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRowLocks", query)
+	ret0, _ := ret[0].([]*apis.RowLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRowLocks indicates an expected call of ListRowLocks.
+func (mr *MockLockManagerInterfaceMockRecorder) ListRowLocks(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRowLocks", reflect.TypeOf((*MockLockManagerInterface)(nil).ListRowLocks), query)
 }
 
 // ReleaseGlobalSessionLock mocks base method.
@@ -77,16 +108,16 @@ func (mr *MockLockManagerInterfaceMockRecorder) ReleaseGlobalSessionLock(globalT
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseGlobalSessionLock", reflect.TypeOf((*MockLockManagerInterface)(nil).ReleaseGlobalSessionLock), globalTransaction)
 }
 
-// IsLockable mocks base method.
-func (m *MockLockManagerInterface) IsLockable(xid, resourceID, lockKey string) bool {
+// ReleaseLock mocks base method.
+func (m *MockLockManagerInterface) ReleaseLock(branchSession *apis.BranchSession) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsLockable", xid, resourceID, lockKey)
+	ret := m.ctrl.Call(m, "ReleaseLock", branchSession)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// IsLockable indicates an expected call of IsLockable.
-func (mr *MockLockManagerInterfaceMockRecorder) IsLockable(xid, resourceID, lockKey interface{}) *gomock.Call {
+// ReleaseLock indicates an expected call of ReleaseLock.
+func (mr *MockLockManagerInterfaceMockRecorder) ReleaseLock(branchSession interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLockable", reflect.TypeOf((*MockLockManagerInterface)(nil).IsLockable), xid, resourceID, lockKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLock", reflect.TypeOf((*MockLockManagerInterface)(nil).ReleaseLock), branchSession)
 }
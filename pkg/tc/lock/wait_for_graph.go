@@ -0,0 +1,214 @@
+package lock
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// waitForEdgeTTL is how long a failed acquisition is remembered as waiting, it spans the retry
+// interval of the clients retrying a conflicting branch registration.
+const waitForEdgeTTL = time.Second
+
+// WaitForGraph records which global transactions wait for the row locks held by which others, a
+// cycle is a deadlock: none of them can acquire its row locks before the others time out.
+type WaitForGraph struct {
+	mu    sync.Mutex
+	nodes map[string]*waitNode
+}
+
+type waitNode struct {
+	beginTime int64
+
+	// waitsFor the row keys the xid waits for by the xids holding them
+	waitsFor map[string]*waitEdge
+
+	// victim is set once the xid is chosen to break a deadlock
+	victim *DeadlockError
+}
+
+type waitEdge struct {
+	rowKeys []string
+	expires time.Time
+}
+
+// DeadlockError fails the branch registration of the victim of a deadlock.
+type DeadlockError struct {
+	Victim string
+
+	// XIDs the global transactions of the cycle, each waits for the next and the last for the first
+	XIDs []string
+
+	// RowKeys the row keys the global transactions of the cycle wait for
+	RowKeys []string
+}
+
+func (err *DeadlockError) Error() string {
+	return fmt.Sprintf("global transaction %s is the victim of a deadlock between %s on row keys %s",
+		err.Victim, strings.Join(err.XIDs, " -> "), strings.Join(err.RowKeys, ", "))
+}
+
+func NewWaitForGraph() *WaitForGraph {
+	return &WaitForGraph{nodes: make(map[string]*waitNode)}
+}
+
+// Wait records that the xid waits for the row keys of the holders, and detects the deadlock it
+// closes. The youngest global transaction of the cycle is chosen as the victim, ties are broken by
+// the greater xid.
+func (graph *WaitForGraph) Wait(xid string, beginTime int64, holders map[string][]string) *DeadlockError {
+	graph.mu.Lock()
+	defer graph.mu.Unlock()
+
+	node := graph.node(xid)
+	node.beginTime = beginTime
+	expires := time.Now().Add(waitForEdgeTTL)
+	for holder, rowKeys := range holders {
+		if holder != xid {
+			node.waitsFor[holder] = &waitEdge{rowKeys: rowKeys, expires: expires}
+		}
+	}
+
+	cycle := graph.findCycle(xid)
+	if cycle == nil {
+		return nil
+	}
+
+	victim := cycle[0]
+	var rowKeys []string
+	for i, x := range cycle {
+		next := cycle[(i+1)%len(cycle)]
+		rowKeys = append(rowKeys, graph.nodes[x].waitsFor[next].rowKeys...)
+		if v := graph.nodes[victim]; graph.nodes[x].beginTime > v.beginTime ||
+			graph.nodes[x].beginTime == v.beginTime && x > victim {
+			victim = x
+		}
+	}
+	deadlock := &DeadlockError{Victim: victim, XIDs: cycle, RowKeys: rowKeys}
+	graph.nodes[victim].victim = deadlock
+	graph.nodes[victim].waitsFor = make(map[string]*waitEdge)
+	return deadlock
+}
+
+// Victim returns the deadlock the xid is chosen to break, the victim is reported once.
+func (graph *WaitForGraph) Victim(xid string) *DeadlockError {
+	graph.mu.Lock()
+	defer graph.mu.Unlock()
+	node, ok := graph.nodes[xid]
+	if !ok || node.victim == nil {
+		return nil
+	}
+	deadlock := node.victim
+	node.victim = nil
+	graph.prune(xid, node)
+	return deadlock
+}
+
+// Forget removes the xid once it acquired its row locks or finished.
+func (graph *WaitForGraph) Forget(xid string) {
+	graph.mu.Lock()
+	defer graph.mu.Unlock()
+	delete(graph.nodes, xid)
+}
+
+// Released forgets the waits for the row keys the holder released.
+func (graph *WaitForGraph) Released(holder string, rowKeys []string) {
+	released := make(map[string]bool, len(rowKeys))
+	for _, rowKey := range rowKeys {
+		released[rowKey] = true
+	}
+
+	graph.mu.Lock()
+	defer graph.mu.Unlock()
+	for xid, node := range graph.nodes {
+		edge, ok := node.waitsFor[holder]
+		if !ok {
+			continue
+		}
+		remaining := edge.rowKeys[:0:0]
+		for _, rowKey := range edge.rowKeys {
+			if !released[rowKey] {
+				remaining = append(remaining, rowKey)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(node.waitsFor, holder)
+			graph.prune(xid, node)
+		} else {
+			edge.rowKeys = remaining
+		}
+	}
+}
+
+// Waits returns the xids the xid waits for.
+func (graph *WaitForGraph) Waits(xid string) []string {
+	graph.mu.Lock()
+	defer graph.mu.Unlock()
+	var holders []string
+	if node, ok := graph.nodes[xid]; ok {
+		for holder, edge := range node.waitsFor {
+			if time.Now().Before(edge.expires) {
+				holders = append(holders, holder)
+			}
+		}
+	}
+	sort.Strings(holders)
+	return holders
+}
+
+func (graph *WaitForGraph) node(xid string) *waitNode {
+	node, ok := graph.nodes[xid]
+	if !ok {
+		node = &waitNode{waitsFor: make(map[string]*waitEdge)}
+		graph.nodes[xid] = node
+	}
+	return node
+}
+
+// prune removes the node once it neither waits nor is to be told it is a victim
+func (graph *WaitForGraph) prune(xid string, node *waitNode) {
+	if len(node.waitsFor) == 0 && node.victim == nil {
+		delete(graph.nodes, xid)
+	}
+}
+
+// findCycle returns the path of the unexpired waits leading from the xid back to it
+func (graph *WaitForGraph) findCycle(xid string) []string {
+	now := time.Now()
+	visited := make(map[string]bool)
+	var path []string
+	var visit func(current string) bool
+	visit = func(current string) bool {
+		path = append(path, current)
+		node, ok := graph.nodes[current]
+		if ok {
+			holders := make([]string, 0, len(node.waitsFor))
+			for holder, edge := range node.waitsFor {
+				if now.Before(edge.expires) {
+					holders = append(holders, holder)
+				} else {
+					delete(node.waitsFor, holder)
+				}
+			}
+			sort.Strings(holders)
+			for _, holder := range holders {
+				if holder == xid {
+					return true
+				}
+				if !visited[holder] {
+					visited[holder] = true
+					if visit(holder) {
+						return true
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if visit(xid) {
+		return path
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package lock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+)
+
+func TestWaitForGraph_Wait(t *testing.T) {
+	graph := NewWaitForGraph()
+	xid1, xid2, xid3 := "localhost:8091:1", "localhost:8091:2", "localhost:8091:3"
+
+	assert.Nil(t, graph.Wait(xid1, 100, map[string][]string{xid2: {"db^^^product^^^2"}}))
+	assert.Nil(t, graph.Wait(xid2, 300, map[string][]string{xid3: {"db^^^product^^^3"}}))
+	assert.Equal(t, []string{xid2}, graph.Waits(xid1))
+
+	// the youngest global transaction of the cycle is the victim
+	deadlock := graph.Wait(xid3, 200, map[string][]string{xid1: {"db^^^product^^^1"}})
+	assert.NotNil(t, deadlock)
+	assert.Equal(t, xid2, deadlock.Victim)
+	assert.Equal(t, []string{xid3, xid1, xid2}, deadlock.XIDs)
+	assert.Equal(t, []string{"db^^^product^^^1", "db^^^product^^^2", "db^^^product^^^3"}, deadlock.RowKeys)
+	assert.Empty(t, graph.Waits(xid2))
+
+	// the victim is told once
+	assert.Equal(t, deadlock, graph.Victim(xid2))
+	assert.Nil(t, graph.Victim(xid2))
+	assert.Nil(t, graph.Victim(xid1))
+}
+
+func TestWaitForGraph_Released(t *testing.T) {
+	graph := NewWaitForGraph()
+	xid1, xid2 := "localhost:8091:1", "localhost:8091:2"
+
+	assert.Nil(t, graph.Wait(xid1, 100, map[string][]string{xid2: {"db^^^product^^^1", "db^^^product^^^2"}}))
+	graph.Released(xid2, []string{"db^^^product^^^1"})
+	assert.Equal(t, []string{xid2}, graph.Waits(xid1))
+	graph.Released(xid2, []string{"db^^^product^^^2"})
+	assert.Empty(t, graph.Waits(xid1))
+	assert.Nil(t, graph.Wait(xid2, 200, map[string][]string{xid1: {"db^^^product^^^3"}}))
+
+	graph.Forget(xid2)
+	assert.Empty(t, graph.Waits(xid2))
+}
+
+func TestWaitForGraph_Expired(t *testing.T) {
+	graph := NewWaitForGraph()
+	xid1, xid2 := "localhost:8091:1", "localhost:8091:2"
+
+	assert.Nil(t, graph.Wait(xid1, 100, map[string][]string{xid2: {"db^^^product^^^2"}}))
+	graph.nodes[xid1].waitsFor[xid2].expires = time.Now()
+	assert.Nil(t, graph.Wait(xid2, 200, map[string][]string{xid1: {"db^^^product^^^1"}}))
+}
+
+func TestLockManager_AcquireLockWaitDeadlock(t *testing.T) {
+	locker := NewLockManager(newTestStore(t))
+	gs1 := &apis.GlobalSession{XID: "localhost:8091:1", BeginTime: 100}
+	gs2 := &apis.GlobalSession{XID: "localhost:8091:2", BeginTime: 200}
+	assert.Nil(t, locker.AcquireLockWait(gs1, newBranchSession(gs1.XID, "product:1"), time.Now().Add(time.Second)))
+	assert.Nil(t, locker.AcquireLockWait(gs2, newBranchSession(gs2.XID, "product:2"), time.Now().Add(time.Second)))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- locker.AcquireLockWait(gs1, newBranchSession(gs1.XID, "product:2"), time.Now().Add(5*time.Second))
+	}()
+	waitForWaiters(t, locker.waitQueue, "db^^^product^^^2", 1)
+
+	// the younger global transaction closes the cycle and is chosen as the victim
+	err := locker.AcquireLockWait(gs2, newBranchSession(gs2.XID, "product:1"), time.Now().Add(5*time.Second))
+	deadlock, ok := err.(*DeadlockError)
+	assert.True(t, ok)
+	assert.Equal(t, gs2.XID, deadlock.Victim)
+	assert.Equal(t, []string{gs2.XID, gs1.XID}, deadlock.XIDs)
+
+	// the victim rolls back and the other acquires
+	assert.True(t, locker.ReleaseLock(newBranchSession(gs2.XID, "product:2")))
+	assert.Nil(t, <-acquired)
+}
+
+func TestLockManager_AcquireLockWaitDeadlockWaitingVictim(t *testing.T) {
+	locker := NewLockManager(newTestStore(t))
+	gs1 := &apis.GlobalSession{XID: "localhost:8091:1", BeginTime: 200}
+	gs2 := &apis.GlobalSession{XID: "localhost:8091:2", BeginTime: 100}
+	assert.Nil(t, locker.AcquireLockWait(gs1, newBranchSession(gs1.XID, "product:1"), time.Now().Add(time.Second)))
+	assert.Nil(t, locker.AcquireLockWait(gs2, newBranchSession(gs2.XID, "product:2"), time.Now().Add(time.Second)))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- locker.AcquireLockWait(gs1, newBranchSession(gs1.XID, "product:2"), time.Now().Add(5*time.Second))
+	}()
+	waitForWaiters(t, locker.waitQueue, "db^^^product^^^2", 1)
+
+	// the younger global transaction closes the cycle, the waiting victim is woken to fail
+	waiting := make(chan error)
+	go func() {
+		waiting <- locker.AcquireLockWait(gs2, newBranchSession(gs2.XID, "product:1"), time.Now().Add(5*time.Second))
+	}()
+	err := <-acquired
+	deadlock, ok := err.(*DeadlockError)
+	assert.True(t, ok)
+	assert.Equal(t, gs1.XID, deadlock.Victim)
+
+	assert.True(t, locker.ReleaseLock(newBranchSession(gs1.XID, "product:1")))
+	assert.Nil(t, <-waiting)
+}
@@ -13,6 +13,8 @@ import (
 var (
 	SeataTransaction = "seata.transaction"
 
+	SeataLock = "seata.lock"
+
 	NameKey = "name"
 
 	RoleKey = "role"
@@ -52,6 +54,8 @@ var (
 	StatusValueCommitted = "committed"
 
 	StatusValueRollbacked = "rollbacked"
+
+	StatusValueDeadlock = "deadlock"
 )
 
 type Counter struct {
@@ -149,6 +153,15 @@ var (
 			StatusKey: StatusValueRollbacked,
 		},
 	}
+	CounterDeadlock = &Counter{
+		Counter: metrics.NewCounter(),
+		Name:    SeataLock,
+		Labels: map[string]string{
+			RoleKey:   RoleValueTc,
+			MeterKey:  MeterValueCounter,
+			StatusKey: StatusValueDeadlock,
+		},
+	}
 	SummaryCommitted = &Summary{
 		Meter: metrics.NewMeter(),
 		Name:  SeataTransaction,
@@ -187,6 +200,11 @@ var (
 	}
 )
 
+// AllCounters returns the counters of the transaction coordinator
+func AllCounters() []*Counter {
+	return []*Counter{CounterActive, CounterCommitted, CounterRollbacked, CounterDeadlock}
+}
+
 type Subscriber struct {
 }
 
@@ -220,9 +238,19 @@ func (subscriber *Subscriber) ProcessGlobalTransactionEvent() {
 	}
 }
 
+func (subscriber *Subscriber) ProcessDeadlockEvent() {
+	for {
+		<-event.EventBus.DeadlockEventChannel
+		CounterDeadlock.Inc(1)
+	}
+}
+
 func init() {
 	subscriber := &Subscriber{}
 	runtime.GoWithRecover(func() {
 		subscriber.ProcessGlobalTransactionEvent()
 	}, nil)
+	runtime.GoWithRecover(func() {
+		subscriber.ProcessDeadlockEvent()
+	}, nil)
 }
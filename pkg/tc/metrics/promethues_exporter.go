@@ -27,9 +27,9 @@ func (exporter *promHTTPExporter) Flush(writer io.Writer) {
 	var sb strings.Builder
 	tracker := make(map[string]bool)
 
-	flushCounter(tracker, &sb, CounterActive)
-	flushCounter(tracker, &sb, CounterCommitted)
-	flushCounter(tracker, &sb, CounterRollbacked)
+	for _, counter := range AllCounters() {
+		flushCounter(tracker, &sb, counter)
+	}
 
 	flushHistogram(tracker, &sb, TimerCommitted)
 	flushHistogram(tracker, &sb, TimerRollback)
@@ -101,7 +101,9 @@ func makeLabelStr(keys, values []string) (out string) {
 	return
 }
 
-func init() {
+// StartExporter serves the metrics to prometheus on :9898/metrics, it is started by the tc server
+// only, so that the admin commands importing this package do not take the port.
+func StartExporter() {
 	promReg := prometheus.NewRegistry()
 	// register process and  go metrics
 	promReg.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"sort"
+
 	"github.com/opentrx/seata-golang/v2/pkg/apis"
 	"github.com/opentrx/seata-golang/v2/pkg/util/time"
 )
@@ -47,6 +49,19 @@ func (gt *GlobalTransaction) IsSaga() bool {
 	return false
 }
 
+// SortedBranchSessions return the branch sessions in the order they are registered, branch ids
+// are generated in increasing order.
+func (gt *GlobalTransaction) SortedBranchSessions() []*apis.BranchSession {
+	branchSessions := make([]*apis.BranchSession, 0, len(gt.BranchSessions))
+	for branchSession := range gt.BranchSessions {
+		branchSessions = append(branchSessions, branchSession)
+	}
+	sort.Slice(branchSessions, func(i, j int) bool {
+		return branchSessions[i].BranchID < branchSessions[j].BranchID
+	})
+	return branchSessions
+}
+
 func (gt *GlobalTransaction) IsTimeout() bool {
 	return (time.CurrentTimeMillis() - uint64(gt.BeginTime)) > uint64(gt.Timeout)
 }
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql" // register mysql
+	_ "github.com/lib/pq"              // register pg
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/runtime"
+	time2 "github.com/opentrx/seata-golang/v2/pkg/util/time"
+	"github.com/opentrx/seata-golang/v2/pkg/util/uuid"
+)
+
+const (
+	DefaultGlobalSessionLockTable       = "global_session_lock"
+	DefaultGlobalSessionLockLease       = 30 * time.Second
+	DefaultGlobalSessionLockRetryPeriod = 100 * time.Millisecond
+)
+
+// globalSessionLockStatements the statements of the database locker in the sql dialect
+type globalSessionLockStatements struct {
+	insert   string
+	takeover string
+	renew    string
+	delete   string
+}
+
+var (
+	mysqlGlobalSessionLockStatements = globalSessionLockStatements{
+		insert:   "insert into %s (xid, owner, expire_time) values (?, ?, ?)",
+		takeover: "update %s set owner = ?, expire_time = ? where xid = ? and expire_time < ?",
+		renew:    "update %s set expire_time = ? where xid = ? and owner = ?",
+		delete:   "delete from %s where xid = ? and owner = ?",
+	}
+
+	pgsqlGlobalSessionLockStatements = globalSessionLockStatements{
+		insert:   "insert into %s (xid, owner, expire_time) values ($1, $2, $3)",
+		takeover: "update %s set owner = $1, expire_time = $2 where xid = $3 and expire_time < $4",
+		renew:    "update %s set expire_time = $1 where xid = $2 and owner = $3",
+		delete:   "delete from %s where xid = $1 and owner = $2",
+	}
+)
+
+// DatabaseGlobalSessionLocker locks global sessions across the TC nodes sharing a mysql or pgsql
+// storage. A lock is a row of the lock table with a lease, the lease is renewed while the lock is
+// held, so the locks of a crashed TC are taken over by the others once their leases expire.
+type DatabaseGlobalSessionLocker struct {
+	db          *sql.DB
+	statements  globalSessionLockStatements
+	owner       string
+	lease       time.Duration
+	retryPeriod time.Duration
+
+	// local excludes the goroutines of this TC, which share the same owner
+	local *MemoryGlobalSessionLocker
+
+	// renewals the held locks, string -> *heldLock
+	renewals *sync.Map
+}
+
+// heldLock a lock held by this TC
+type heldLock struct {
+	// stop stops renewing the lease
+	stop chan struct{}
+
+	// expireTime the time in milliseconds the lease expires at, 0 once the lock is taken over
+	expireTime int64
+}
+
+// lost reports whether the lease has expired or the lock has been taken over by another TC
+func (lock *heldLock) lost() bool {
+	expireTime := atomic.LoadInt64(&lock.expireTime)
+	return expireTime == 0 || int64(time2.CurrentTimeMillis()) >= expireTime
+}
+
+// NewDatabaseGlobalSessionLocker creates a database locker on the database of the storage, the lock
+// table is the globalsessionlocktable parameter of the storage.
+func NewDatabaseGlobalSessionLocker(storageType string, parameters config.Parameters,
+	conf config.Locker) (*DatabaseGlobalSessionLocker, error) {
+	var (
+		driverName string
+		statements globalSessionLockStatements
+	)
+	switch storageType {
+	case "mysql":
+		driverName, statements = "mysql", mysqlGlobalSessionLockStatements
+	case "pgsql":
+		driverName, statements = "postgres", pgsqlGlobalSessionLockStatements
+	default:
+		return nil, fmt.Errorf("the database global session locker does not support %s storage", storageType)
+	}
+
+	dsn := parameters["dsn"]
+	if dsn == nil || fmt.Sprint(dsn) == "" {
+		return nil, fmt.Errorf("the dsn parameter should not be empty")
+	}
+	db, err := sql.Open(driverName, fmt.Sprint(dsn))
+	if err != nil {
+		return nil, err
+	}
+
+	// the table is created by the schema migrations of the storage
+	table := DefaultGlobalSessionLockTable
+	if t := parameters["globalsessionlocktable"]; t != nil && fmt.Sprint(t) != "" {
+		table = fmt.Sprint(t)
+	}
+
+	lease := conf.Lease
+	if lease <= 0 {
+		lease = DefaultGlobalSessionLockLease
+	}
+	retryPeriod := conf.RetryPeriod
+	if retryPeriod <= 0 {
+		retryPeriod = DefaultGlobalSessionLockRetryPeriod
+	}
+	hostname, _ := os.Hostname()
+
+	return &DatabaseGlobalSessionLocker{
+		db: db,
+		statements: globalSessionLockStatements{
+			insert:   fmt.Sprintf(statements.insert, table),
+			takeover: fmt.Sprintf(statements.takeover, table),
+			renew:    fmt.Sprintf(statements.renew, table),
+			delete:   fmt.Sprintf(statements.delete, table),
+		},
+		owner:       fmt.Sprintf("%s:%d:%d", hostname, os.Getpid(), uuid.NextID()),
+		lease:       lease,
+		retryPeriod: retryPeriod,
+		local:       NewMemoryGlobalSessionLocker(),
+		renewals:    &sync.Map{},
+	}, nil
+}
+
+// Close closes the database, the locks left are taken over by the other TC nodes once their leases
+// expire.
+func (locker *DatabaseGlobalSessionLocker) Close() error {
+	return locker.db.Close()
+}
+
+// TryLock waits at most timeout for the lock, it retries every retry period while the lock is
+// held by another TC.
+func (locker *DatabaseGlobalSessionLocker) TryLock(session *apis.GlobalSession, timeout time.Duration) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	result, err := locker.local.TryLock(session, timeout)
+	if err != nil || !result {
+		return result, err
+	}
+
+	var expireTime int64
+	for {
+		expireTime, result, err = locker.acquire(session.XID)
+		if err != nil || result {
+			break
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		if remaining > locker.retryPeriod {
+			remaining = locker.retryPeriod
+		}
+		time.Sleep(remaining)
+	}
+	if err != nil || !result {
+		locker.local.Unlock(session)
+		return false, err
+	}
+
+	lock := &heldLock{stop: make(chan struct{}), expireTime: expireTime}
+	locker.renewals.Store(session.XID, lock)
+	runtime.GoWithRecover(func() {
+		locker.renew(session.XID, lock)
+	}, nil)
+	return true, nil
+}
+
+// Unlock deletes the lock row, it returns an error if the lease expired or the lock was taken over
+// by another TC before.
+func (locker *DatabaseGlobalSessionLocker) Unlock(session *apis.GlobalSession) error {
+	value, ok := locker.renewals.LoadAndDelete(session.XID)
+	if !ok {
+		return nil
+	}
+	lock := value.(*heldLock)
+	close(lock.stop)
+	defer locker.local.Unlock(session)
+
+	lost := lock.lost()
+	result, err := locker.db.Exec(locker.statements.delete, session.XID, locker.owner)
+	if err != nil {
+		log.Errorf("failed to unlock global session xid = %s, the lock will be released when its lease expires, err: %v",
+			session.XID, err)
+	} else if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		lost = true
+	}
+	if lost {
+		return fmt.Errorf("the lock of global session xid = %s was lost while it was held", session.XID)
+	}
+	return nil
+}
+
+// acquire inserts the lock row, or takes it over if its lease has expired, it returns the time the
+// lease expires at.
+func (locker *DatabaseGlobalSessionLocker) acquire(xid string) (int64, bool, error) {
+	now := int64(time2.CurrentTimeMillis())
+	expireTime := now + locker.lease.Milliseconds()
+	_, err := locker.db.Exec(locker.statements.insert, xid, locker.owner, expireTime)
+	if err == nil {
+		return expireTime, true, nil
+	}
+
+	// the insert fails if the lock is held, the takeover fails too if the database is unavailable
+	result, err := locker.db.Exec(locker.statements.takeover, locker.owner, expireTime, xid, now)
+	if err != nil {
+		return 0, false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, false, err
+	}
+	if affected == 1 {
+		log.Warnf("take over the expired lock of global session xid = %s", xid)
+		return expireTime, true, nil
+	}
+	return 0, false, nil
+}
+
+// renew extends the lease every third of it until the lock is released. The lock is lost if it is
+// taken over by another TC, or if the lease expires before a renewal succeeds.
+func (locker *DatabaseGlobalSessionLocker) renew(xid string, lock *heldLock) {
+	ticker := time.NewTicker(locker.lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lock.stop:
+			return
+		case <-ticker.C:
+			if lock.lost() {
+				log.Errorf("the lease of the lock of global session xid = %s has expired", xid)
+				return
+			}
+			expireTime := int64(time2.CurrentTimeMillis()) + locker.lease.Milliseconds()
+			result, err := locker.db.Exec(locker.statements.renew, expireTime, xid, locker.owner)
+			if err != nil {
+				log.Errorf("failed to renew the lock of global session xid = %s, err: %v", xid, err)
+				continue
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				log.Errorf("failed to renew the lock of global session xid = %s, err: %v", xid, err)
+				continue
+			}
+			if affected == 0 {
+				log.Errorf("the lock of global session xid = %s has been taken over by another TC", xid)
+				atomic.StoreInt64(&lock.expireTime, 0)
+				return
+			}
+			atomic.StoreInt64(&lock.expireTime, expireTime)
+		}
+	}
+}
@@ -1,15 +1,37 @@
 package server
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+)
+
+const (
+	MemoryGlobalSessionLockerType   = "memory"
+	DatabaseGlobalSessionLockerType = "database"
 )
 
 type GlobalSessionLocker interface {
 	TryLock(session *apis.GlobalSession, timeout time.Duration) (bool, error)
 
-	Unlock(session *apis.GlobalSession)
+	// Unlock releases the lock, it returns an error if the lock was lost while it was held, then
+	// another TC may have changed the global session meanwhile.
+	Unlock(session *apis.GlobalSession) error
+}
+
+// NewGlobalSessionLocker creates the global session locker of the configured type
+func NewGlobalSessionLocker(conf *config.Configuration) (GlobalSessionLocker, error) {
+	switch conf.Locker.Type {
+	case "", MemoryGlobalSessionLockerType:
+		return NewMemoryGlobalSessionLocker(), nil
+	case DatabaseGlobalSessionLockerType:
+		return NewDatabaseGlobalSessionLocker(conf.Storage.Type(), conf.Storage.Parameters(), conf.Locker)
+	default:
+		return nil, fmt.Errorf("unknown global session locker type: %s", conf.Locker.Type)
+	}
 }
 
 type UnimplementedGlobalSessionLocker struct {
@@ -19,6 +41,82 @@ func (locker *UnimplementedGlobalSessionLocker) TryLock(session *apis.GlobalSess
 	return true, nil
 }
 
-func (locker *UnimplementedGlobalSessionLocker) Unlock(session *apis.GlobalSession) {
+func (locker *UnimplementedGlobalSessionLocker) Unlock(session *apis.GlobalSession) error {
+	return nil
+}
+
+// MemoryGlobalSessionLocker locks global sessions by a mutex per xid, it only excludes the
+// goroutines of this TC, so it should not be used by TC nodes sharing a storage.
+type MemoryGlobalSessionLocker struct {
+	mu    sync.Mutex
+	locks map[string]*sessionLock
+}
+
+type sessionLock struct {
+	// held has a value while the lock is held
+	held chan struct{}
+
+	// refs the number of goroutines holding or waiting for the lock
+	refs int
+}
+
+func NewMemoryGlobalSessionLocker() *MemoryGlobalSessionLocker {
+	return &MemoryGlobalSessionLocker{locks: make(map[string]*sessionLock)}
+}
+
+// TryLock waits at most timeout for the lock, a non-positive timeout does not wait.
+func (locker *MemoryGlobalSessionLocker) TryLock(session *apis.GlobalSession, timeout time.Duration) (bool, error) {
+	locker.mu.Lock()
+	lock, ok := locker.locks[session.XID]
+	if !ok {
+		lock = &sessionLock{held: make(chan struct{}, 1)}
+		locker.locks[session.XID] = lock
+	}
+	lock.refs++
+	locker.mu.Unlock()
+
+	if timeout <= 0 {
+		select {
+		case lock.held <- struct{}{}:
+			return true, nil
+		default:
+			locker.release(session.XID, lock)
+			return false, nil
+		}
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case lock.held <- struct{}{}:
+		return true, nil
+	case <-timer.C:
+		locker.release(session.XID, lock)
+		return false, nil
+	}
+}
+
+func (locker *MemoryGlobalSessionLocker) Unlock(session *apis.GlobalSession) error {
+	locker.mu.Lock()
+	lock, ok := locker.locks[session.XID]
+	locker.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	select {
+	case <-lock.held:
+		locker.release(session.XID, lock)
+	default:
+	}
+	return nil
+}
 
+// release drops a reference to the lock, the lock is removed once nobody holds or waits for it
+func (locker *MemoryGlobalSessionLocker) release(xid string, lock *sessionLock) {
+	locker.mu.Lock()
+	defer locker.mu.Unlock()
+	lock.refs--
+	if lock.refs == 0 {
+		delete(locker.locks, xid)
+	}
 }
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/mysql" // register the mysql migrations
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/migration"
+)
+
+func TestNewGlobalSessionLocker(t *testing.T) {
+	locker, err := NewGlobalSessionLocker(&config.Configuration{})
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryGlobalSessionLocker{}, locker)
+
+	conf := &config.Configuration{Storage: config.Storage{"inmemory": config.Parameters{}}}
+	conf.Locker.Type = DatabaseGlobalSessionLockerType
+	_, err = NewGlobalSessionLocker(conf)
+	assert.Error(t, err)
+
+	conf.Locker.Type = "zookeeper"
+	_, err = NewGlobalSessionLocker(conf)
+	assert.Error(t, err)
+}
+
+func TestMemoryGlobalSessionLocker(t *testing.T) {
+	locker := NewMemoryGlobalSessionLocker()
+	session := &apis.GlobalSession{XID: "localhost:123"}
+	other := &apis.GlobalSession{XID: "localhost:456"}
+
+	result, err := locker.TryLock(session, time.Second)
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	result, err = locker.TryLock(other, 0)
+	assert.NoError(t, err)
+	assert.True(t, result)
+	locker.Unlock(other)
+
+	result, err = locker.TryLock(session, 0)
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	start := time.Now()
+	result, err = locker.TryLock(session, 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.False(t, result)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		result, err := locker.TryLock(session, time.Second)
+		assert.NoError(t, err)
+		assert.True(t, result)
+		locker.Unlock(session)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	locker.Unlock(session)
+	wg.Wait()
+
+	assert.Empty(t, locker.locks)
+}
+
+// TestDatabaseGlobalSessionLocker runs on the database of the MYSQL_DSN environment variable
+func TestDatabaseGlobalSessionLocker(t *testing.T) {
+	dsn := os.Getenv("MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_DSN is not set")
+	}
+	tables := []string{"global_table_lock_test", "branch_table_lock_test", "lock_table_lock_test",
+		"global_session_lock_test", "schema_version_lock_test"}
+	parameters := config.Parameters{
+		"dsn":                    dsn,
+		"globaltable":            tables[0],
+		"branchtable":            tables[1],
+		"locktable":              tables[2],
+		"globalsessionlocktable": tables[3],
+		"versiontable":           tables[4],
+	}
+	migrator, err := migration.Create("mysql", parameters)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	_, err = migrator.Up()
+	assert.NoError(t, err)
+	assert.NoError(t, migrator.Close())
+
+	conf := config.Locker{Lease: time.Second, RetryPeriod: 10 * time.Millisecond}
+	locker, err := NewDatabaseGlobalSessionLocker("mysql", parameters, conf)
+	assert.NoError(t, err)
+	defer func() {
+		for _, table := range tables {
+			_, err := locker.db.Exec("DROP TABLE " + table)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, locker.Close())
+	}()
+	other, err := NewDatabaseGlobalSessionLocker("mysql", parameters, conf)
+	assert.NoError(t, err)
+	defer other.Close()
+	session := &apis.GlobalSession{XID: "localhost:123"}
+
+	result, err := locker.TryLock(session, 0)
+	assert.NoError(t, err)
+	assert.True(t, result)
+	result, err = other.TryLock(session, 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.False(t, result)
+	assert.NoError(t, locker.Unlock(session))
+
+	// the lock is lost once another TC takes it over after the lease expires
+	result, err = locker.TryLock(session, 0)
+	assert.NoError(t, err)
+	assert.True(t, result)
+	_, err = locker.db.Exec("UPDATE global_session_lock_test SET expire_time = 0 WHERE xid = ?", session.XID)
+	assert.NoError(t, err)
+	result, err = other.TryLock(session, 0)
+	assert.NoError(t, err)
+	assert.True(t, result)
+	assert.Error(t, locker.Unlock(session))
+	assert.NoError(t, other.Unlock(session))
+}
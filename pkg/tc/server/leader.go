@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+)
+
+// leaderCheckPeriod is how often a branch stream checks that its tc is still the leader
+const leaderCheckPeriod = time.Second
+
+// errNotLeader is returned to the calls arriving on a follower of a replicated storage. The global
+// session locks and the branch streams are kept in the memory of the tc, so the clients are served
+// by the leader only and retry the calls until they reach it.
+var errNotLeader = status.Error(codes.Unavailable, "tc is not the leader of the replicated storage")
+
+// IsLeader reports whether the storage is not replicated, or this TC is the leader of the nodes
+// replicating it.
+func (tc *TransactionCoordinator) IsLeader() bool {
+	elector, ok := tc.driver.(storage.LeaderElector)
+	return !ok || elector.IsLeader()
+}
+
+// UnaryInterceptor refuses the unary calls of the clients on a follower of a replicated storage.
+func (tc *TransactionCoordinator) UnaryInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !tc.IsLeader() {
+		return nil, errNotLeader
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor refuses the branch streams of the clients on a follower of a replicated
+// storage, a stream accepted by the leader is ended by BranchCommunicate once the leadership is
+// lost.
+func (tc *TransactionCoordinator) StreamInterceptor(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !tc.IsLeader() {
+		return errNotLeader
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+)
+
+// electedDriver a replicated storage driver whose leadership is set by the test
+type electedDriver struct {
+	storage.Driver
+	leader atomic.Bool
+}
+
+func (driver *electedDriver) IsLeader() bool {
+	return driver.leader.Load()
+}
+
+func TestTransactionCoordinator_IsLeader(t *testing.T) {
+	assert.True(t, (&TransactionCoordinator{}).IsLeader())
+
+	driver := &electedDriver{}
+	tc := &TransactionCoordinator{driver: driver}
+	assert.False(t, tc.IsLeader())
+	driver.leader.Store(true)
+	assert.True(t, tc.IsLeader())
+}
+
+func TestTransactionCoordinator_Follower(t *testing.T) {
+	tc, conn := serveCoordinator(t)
+	driver := &electedDriver{Driver: tc.driver}
+	tc.driver = driver
+	tc.enableAdmin = true
+
+	// the leader serves the clients
+	driver.leader.Store(true)
+	resp, err := apis.NewTransactionManagerServiceClient(conn).Begin(context.Background(),
+		&apis.GlobalBeginRequest{Addressing: "app1", Timeout: 60000})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "addressing", "app1")
+	stream, err := apis.NewResourceManagerServiceClient(conn).BranchCommunicate(ctx)
+	assert.Nil(t, err)
+
+	// the stream is ended once the leadership is lost
+	driver.leader.Store(false)
+	received := make(chan error, 1)
+	go func() {
+		_, err := stream.Recv()
+		received <- err
+	}()
+	select {
+	case err := <-received:
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	case <-time.After(3 * leaderCheckPeriod):
+		t.Fatal("the stream should be ended on a follower")
+	}
+
+	// the follower refuses the clients
+	_, err = apis.NewTransactionManagerServiceClient(conn).Commit(context.Background(),
+		&apis.GlobalCommitRequest{XID: resp.XID})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	stream, err = apis.NewResourceManagerServiceClient(conn).BranchCommunicate(ctx)
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// the admin calls changing the sessions are refused also when the console calls them directly
+	_, err = tc.RemoveSession(context.Background(), &apis.SessionRemoveRequest{XID: resp.XID, Force: true})
+	assert.Equal(t, errNotLeader, err)
+	_, err = tc.RetryCommit(context.Background(), &apis.SessionRetryRequest{XID: resp.XID})
+	assert.Equal(t, errNotLeader, err)
+	_, err = tc.ReleaseLocks(context.Background(), &apis.LockReleaseRequest{XID: resp.XID, Force: true})
+	assert.Equal(t, errNotLeader, err)
+}
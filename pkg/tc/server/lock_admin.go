@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/lock"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/model"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/util/common"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	time2 "github.com/opentrx/seata-golang/v2/pkg/util/time"
+)
+
+const defaultAdminListLimit = 100
+
+// ListLocks lists the row locks held in the storage in the order of their xids and row keys. The
+// row locks are collected from the lock keys of the branch sessions if the storage can not list
+// them, the row locks left behind by the removed branch sessions are missed then.
+func (tc *TransactionCoordinator) ListLocks(ctx context.Context, request *apis.LockListRequest) (*apis.LockListResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	query := storage.RowLockQuery{
+		ResourceID: request.ResourceID,
+		TableName:  request.TableName,
+		XID:        request.XID,
+		BranchID:   request.BranchID,
+	}
+	rowLocks, err := tc.listRowLocks(query)
+	if err != nil {
+		return &apis.LockListResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedStore,
+			Message:       fmt.Sprintf("failed to list row locks, err: %s", err.Error()),
+		}, nil
+	}
+
+	start, end := page(len(rowLocks), request.Offset, request.Limit)
+	return &apis.LockListResponse{
+		ResultCode: apis.ResultCodeSuccess,
+		Locks:      tc.rowLockHolders(rowLocks[start:end]),
+		Total:      int32(len(rowLocks)),
+	}, nil
+}
+
+// QueryLockHolders returns the row locks of the other global transactions overlapping the rows of
+// the lock key, with the global sessions holding them.
+func (tc *TransactionCoordinator) QueryLockHolders(ctx context.Context, request *apis.LockHolderQueryRequest) (*apis.LockHolderQueryResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	wanted := storage.CollectRowLocks(request.LockKey, request.ResourceID, "")
+	if len(wanted) == 0 {
+		return &apis.LockHolderQueryResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.LockableCheckFailed,
+			Message:       fmt.Sprintf("invalid lock key %s", request.LockKey),
+		}, nil
+	}
+
+	rowLocks, err := tc.listRowLocks(storage.RowLockQuery{ResourceID: request.ResourceID})
+	if err != nil {
+		return &apis.LockHolderQueryResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedStore,
+			Message:       fmt.Sprintf("failed to list row locks, err: %s", err.Error()),
+		}, nil
+	}
+
+	holders := make([]*apis.RowLock, 0)
+	for _, rowLock := range rowLocks {
+		for _, w := range wanted {
+			if storage.RowLocksOverlap(rowLock, w) {
+				holders = append(holders, rowLock)
+				break
+			}
+		}
+	}
+	return &apis.LockHolderQueryResponse{
+		ResultCode: apis.ResultCodeSuccess,
+		Holders:    tc.rowLockHolders(holders),
+	}, nil
+}
+
+// ReleaseLocks force releases the row locks of a global transaction which is not able to release
+// them itself, e.g. its application is gone. The release is written to the audit log.
+func (tc *TransactionCoordinator) ReleaseLocks(ctx context.Context, request *apis.LockReleaseRequest) (resp *apis.LockReleaseResponse, err error) {
+	if !tc.IsLeader() {
+		return nil, errNotLeader
+	}
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	gt := tc.holder.FindGlobalTransaction(request.XID)
+	if gt == nil && request.LockKey == "" {
+		return &apis.LockReleaseResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionNotExist,
+			Message:       fmt.Sprintf("could not find global transaction xid = %s", request.XID),
+		}, nil
+	}
+
+	if gt != nil {
+		if !request.Force && gt.Status == apis.Begin && !gt.IsTimeout() {
+			return &apis.LockReleaseResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.GlobalTransactionStatusInvalid,
+				Message: fmt.Sprintf("global transaction xid = %s has not timed out, force is required to release its row locks",
+					gt.XID),
+			}, nil
+		}
+
+		if err := tc.lockGlobalSession(gt.GlobalSession); err != nil {
+			return &apis.LockReleaseResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       err.Error(),
+			}, nil
+		}
+		defer func() {
+			if unlockErr := tc.locker.Unlock(gt.GlobalSession); unlockErr != nil && resp.ResultCode == apis.ResultCodeSuccess {
+				resp = &apis.LockReleaseResponse{
+					ResultCode:    apis.ResultCodeFailed,
+					ExceptionCode: apis.FailedLockGlobalTransaction,
+					Message:       unlockErr.Error(),
+				}
+			}
+		}()
+	}
+
+	var (
+		released int
+		result   bool
+	)
+	switch {
+	case request.LockKey != "":
+		bs := &apis.BranchSession{
+			XID:           request.XID,
+			TransactionID: common.GetTransactionID(request.XID),
+			BranchID:      request.BranchID,
+			ResourceID:    request.ResourceID,
+			LockKey:       request.LockKey,
+		}
+		if gt != nil {
+			bs.TransactionID = gt.TransactionID
+		}
+		released = len(storage.CollectBranchSessionRowLocks(bs))
+		result = tc.resourceDataLocker.ReleaseLock(bs)
+	case request.BranchID != 0:
+		bs := gt.GetBranch(request.BranchID)
+		if bs == nil {
+			return &apis.LockReleaseResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.BranchTransactionNotExist,
+				Message:       fmt.Sprintf("could not find branch session xid = %s branchID = %d", gt.XID, request.BranchID),
+			}, nil
+		}
+		released = len(storage.CollectBranchSessionRowLocks(bs))
+		result = tc.resourceDataLocker.ReleaseLock(bs)
+	default:
+		for bs := range gt.BranchSessions {
+			released += len(storage.CollectBranchSessionRowLocks(bs))
+		}
+		result = tc.resourceDataLocker.ReleaseGlobalSessionLock(gt)
+	}
+	if !result {
+		return &apis.LockReleaseResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedStore,
+			Message:       fmt.Sprintf("failed to release row locks of xid = %s", request.XID),
+		}, nil
+	}
+
+	log.Warnf("audit: %s force released %d row locks of xid = %s branchID = %d resourceId = %s lockKey = %s, reason: %s",
+		operator(ctx), released, request.XID, request.BranchID, request.ResourceID, request.LockKey, request.Reason)
+	return &apis.LockReleaseResponse{
+		ResultCode: apis.ResultCodeSuccess,
+		Released:   int32(released),
+	}, nil
+}
+
+// listRowLocks lists the row locks of the storage, or of the branch sessions if the storage can not
+func (tc *TransactionCoordinator) listRowLocks(query storage.RowLockQuery) ([]*apis.RowLock, error) {
+	rowLocks, err := tc.resourceDataLocker.ListRowLocks(query)
+	if err != lock.ErrLockListUnsupported {
+		return rowLocks, err
+	}
+
+	var transactions []*model.GlobalTransaction
+	if query.XID != "" {
+		if gt := tc.holder.FindGlobalTransaction(query.XID); gt != nil {
+			transactions = append(transactions, gt)
+		}
+	} else {
+		transactions = tc.holder.AllGlobalTransactions()
+	}
+	rowLocks = make([]*apis.RowLock, 0)
+	for _, gt := range transactions {
+		for bs := range gt.BranchSessions {
+			for _, rowLock := range storage.CollectBranchSessionRowLocks(bs) {
+				if query.Matches(rowLock) {
+					rowLocks = append(rowLocks, rowLock)
+				}
+			}
+		}
+	}
+	storage.SortRowLocks(rowLocks)
+	return rowLocks, nil
+}
+
+// rowLockHolders joins the row locks with the global sessions holding them, the status of a row
+// lock whose global session is gone is UnknownGlobalStatus
+func (tc *TransactionCoordinator) rowLockHolders(rowLocks []*apis.RowLock) []*apis.RowLockHolder {
+	now := int64(time2.CurrentTimeMillis())
+	sessions := make(map[string]*apis.GlobalSession)
+	holders := make([]*apis.RowLockHolder, 0, len(rowLocks))
+	for _, rowLock := range rowLocks {
+		gs, ok := sessions[rowLock.XID]
+		if !ok {
+			gs = tc.holder.FindGlobalSession(rowLock.XID)
+			sessions[rowLock.XID] = gs
+		}
+		holder := &apis.RowLockHolder{RowLock: rowLock}
+		if gs != nil {
+			holder.Status = gs.Status
+			holder.BeginTime = gs.BeginTime
+			holder.Age = now - gs.BeginTime
+		}
+		holders = append(holders, holder)
+	}
+	return holders
+}
+
+// lockGlobalSession locks the global session against the coordinator and the other admin calls
+// before it is changed by an admin call
+func (tc *TransactionCoordinator) lockGlobalSession(gs *apis.GlobalSession) error {
+	result, err := tc.locker.TryLock(gs, time.Duration(gs.Timeout)*time.Millisecond)
+	if err == nil && !result {
+		err = fmt.Errorf("failed to lock global transaction xid = %s", gs.XID)
+	}
+	return err
+}
+
+// page returns the bounds of the page of a list of total items
+func page(total int, offset, limit int32) (int, int) {
+	start := int(offset)
+	if start < 0 {
+		start = 0
+	}
+	if start > total {
+		start = total
+	}
+	if limit <= 0 {
+		limit = defaultAdminListLimit
+	}
+	end := start + int(limit)
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// operator returns the address of the peer calling the admin api
+func operator(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
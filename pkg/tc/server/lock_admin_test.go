@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/holder"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/lock"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/inmemory"
+	time2 "github.com/opentrx/seata-golang/v2/pkg/util/time"
+)
+
+// newLockAdminCoordinator returns a transaction coordinator holding the row locks of a global
+// transaction in progress and of a timed out one
+func newLockAdminCoordinator(t *testing.T, lister bool) *TransactionCoordinator {
+	driver, err := factory.Create("inmemory", nil)
+	assert.Nil(t, err)
+	if !lister {
+		// hides the ListRowLocks of the driver
+		driver = struct{ storage.Driver }{driver}
+	}
+	tc := &TransactionCoordinator{
+		holder:             holder.NewSessionHolder(driver),
+		resourceDataLocker: lock.NewLockManager(driver),
+		locker:             NewMemoryGlobalSessionLocker(),
+	}
+
+	now := int64(time2.CurrentTimeMillis())
+	sessions := []*apis.GlobalSession{
+		{XID: "localhost:8091:1", TransactionID: 1, Status: apis.Begin, Active: true, BeginTime: now, Timeout: 60000},
+		{XID: "localhost:8091:2", TransactionID: 2, Status: apis.Begin, Active: true, BeginTime: now - 120000, Timeout: 1000},
+	}
+	branches := []*apis.BranchSession{
+		{XID: "localhost:8091:1", TransactionID: 1, BranchID: 11, ResourceID: "db", LockKey: "product:1,2", Type: apis.AT},
+		{XID: "localhost:8091:2", TransactionID: 2, BranchID: 21, ResourceID: "db", LockKey: "order:1", Type: apis.AT},
+	}
+	for i := range sessions {
+		assert.Nil(t, tc.holder.AddGlobalSession(sessions[i]))
+		assert.Nil(t, tc.holder.AddBranchSession(sessions[i], branches[i]))
+		assert.True(t, tc.resourceDataLocker.AcquireLock(branches[i]))
+	}
+	return tc
+}
+
+func TestTransactionCoordinator_ListLocks(t *testing.T) {
+	for _, lister := range []bool{true, false} {
+		tc := newLockAdminCoordinator(t, lister)
+
+		resp, err := tc.ListLocks(context.Background(), &apis.LockListRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+		assert.Equal(t, int32(3), resp.Total)
+		if assert.Len(t, resp.Locks, 3) {
+			assert.Equal(t, "db^^^product^^^1", resp.Locks[0].RowLock.RowKey)
+			assert.Equal(t, int64(11), resp.Locks[0].RowLock.BranchID)
+			assert.Equal(t, apis.Begin, resp.Locks[0].Status)
+			assert.True(t, resp.Locks[2].Age >= 120000)
+		}
+
+		resp, err = tc.ListLocks(context.Background(), &apis.LockListRequest{TableName: "product", Offset: 1, Limit: 5})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), resp.Total)
+		if assert.Len(t, resp.Locks, 1) {
+			assert.Equal(t, "db^^^product^^^2", resp.Locks[0].RowLock.RowKey)
+		}
+
+		resp, err = tc.ListLocks(context.Background(), &apis.LockListRequest{XID: "localhost:8091:2", BranchID: 21})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), resp.Total)
+	}
+}
+
+func TestTransactionCoordinator_QueryLockHolders(t *testing.T) {
+	tc := newLockAdminCoordinator(t, true)
+
+	resp, err := tc.QueryLockHolders(context.Background(), &apis.LockHolderQueryRequest{ResourceID: "db", LockKey: "product:[2~];order:5"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	if assert.Len(t, resp.Holders, 1) {
+		assert.Equal(t, "localhost:8091:1", resp.Holders[0].RowLock.XID)
+		assert.Equal(t, "2", resp.Holders[0].RowLock.PK)
+	}
+
+	resp, err = tc.QueryLockHolders(context.Background(), &apis.LockHolderQueryRequest{ResourceID: "db", LockKey: "product"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.LockableCheckFailed, resp.ExceptionCode)
+}
+
+func TestTransactionCoordinator_ReleaseLocks(t *testing.T) {
+	tc := newLockAdminCoordinator(t, true)
+
+	// the global transaction in progress is only released by force
+	resp, err := tc.ReleaseLocks(context.Background(), &apis.LockReleaseRequest{XID: "localhost:8091:1"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.GlobalTransactionStatusInvalid, resp.ExceptionCode)
+	assert.False(t, tc.resourceDataLocker.IsLockable("localhost:8091:3", "db", "product:1"))
+
+	resp, err = tc.ReleaseLocks(context.Background(), &apis.LockReleaseRequest{XID: "localhost:8091:1", BranchID: 12, Force: true})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.BranchTransactionNotExist, resp.ExceptionCode)
+
+	resp, err = tc.ReleaseLocks(context.Background(), &apis.LockReleaseRequest{XID: "localhost:8091:1", Force: true, Reason: "test"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.Equal(t, int32(2), resp.Released)
+	assert.True(t, tc.resourceDataLocker.IsLockable("localhost:8091:3", "db", "product:1,2"))
+
+	resp, err = tc.ReleaseLocks(context.Background(), &apis.LockReleaseRequest{XID: "localhost:8091:2", BranchID: 21})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.Equal(t, int32(1), resp.Released)
+	assert.True(t, tc.resourceDataLocker.IsLockable("localhost:8091:3", "db", "order:1"))
+
+	// the row locks left behind without a global session are released by their lock key
+	assert.True(t, tc.resourceDataLocker.AcquireLock(&apis.BranchSession{XID: "localhost:8091:4", TransactionID: 4,
+		ResourceID: "db", LockKey: "stock:1", Type: apis.AT}))
+	resp, err = tc.ReleaseLocks(context.Background(), &apis.LockReleaseRequest{XID: "localhost:8091:4"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.GlobalTransactionNotExist, resp.ExceptionCode)
+	resp, err = tc.ReleaseLocks(context.Background(), &apis.LockReleaseRequest{XID: "localhost:8091:4", ResourceID: "db",
+		LockKey: "stock:1"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.True(t, tc.resourceDataLocker.IsLockable("localhost:8091:3", "db", "stock:1"))
+}
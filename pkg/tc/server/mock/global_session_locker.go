@@ -51,9 +51,11 @@ func (mr *MockGlobalSessionLockerMockRecorder) TryLock(session, timeout interfac
 }
 
 // Unlock mocks base method.
-func (m *MockGlobalSessionLocker) Unlock(session *apis.GlobalSession) {
+func (m *MockGlobalSessionLocker) Unlock(session *apis.GlobalSession) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Unlock", session)
+	ret := m.ctrl.Call(m, "Unlock", session)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Unlock indicates an expected call of Unlock.
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/event"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/model"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/runtime"
+	time2 "github.com/opentrx/seata-golang/v2/pkg/util/time"
+)
+
+// doSagaCommit commits a saga global transaction. The forward actions of saga branches have
+// taken effect in phase one and report their result by BranchReport, so the commit is forward
+// only: no branch is called back, the global transaction is finished once every branch has
+// reported PhaseOneDone. If a branch is still running, the commit is retried later; if a branch
+// has reported PhaseOneFailed, the saga can not go forward and the done branches are compensated.
+// It returns the status the global transaction is in.
+func (tc *TransactionCoordinator) doSagaCommit(gt *model.GlobalTransaction, retrying bool) (apis.GlobalSession_GlobalStatus, error) {
+	var running *apis.BranchSession
+	for _, bs := range gt.SortedBranchSessions() {
+		switch bs.Status {
+		case apis.PhaseOneDone, apis.PhaseTwoCommitted:
+			continue
+		case apis.PhaseOneFailed:
+			log.Warnf("saga branch xid=%s branchID=%d failed, compensate global transaction instead of committing",
+				gt.XID, bs.BranchID)
+			err := tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, apis.RollingBack)
+			if err != nil {
+				return gt.Status, err
+			}
+			return tc.doSagaRollback(gt, false)
+		default:
+			if running == nil {
+				running = bs
+			}
+		}
+	}
+
+	if running != nil {
+		log.Infof("saga branch xid=%s branchID=%d is still running, will retry committing later", gt.XID, running.BranchID)
+		if !retrying {
+			err := tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, apis.CommitRetrying)
+			if err != nil {
+				return gt.Status, err
+			}
+		}
+		return apis.CommitRetrying, nil
+	}
+
+	for _, bs := range gt.SortedBranchSessions() {
+		delete(gt.BranchSessions, bs)
+		err := tc.holder.RemoveBranchSession(gt.GlobalSession, bs)
+		if err != nil {
+			return gt.Status, err
+		}
+	}
+
+	err := tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, apis.Committed)
+	if err != nil {
+		return gt.Status, err
+	}
+	err = tc.holder.RemoveGlobalTransaction(gt)
+	if err != nil {
+		return apis.Committed, err
+	}
+	runtime.GoWithRecover(func() {
+		evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime,
+			int64(time2.CurrentTimeMillis()), apis.Committed)
+		event.EventBus.GlobalTransactionEventChannel <- evt
+	}, nil)
+	log.Infof("saga global[%s] committing is successfully done.", gt.XID)
+
+	return apis.Committed, nil
+}
+
+// doSagaRollback compensates a saga global transaction, the branches are compensated one by one
+// in the reverse order they are registered, a branch is compensated only after all the branches
+// registered after it have been compensated. Branches which reported PhaseOneFailed have no
+// effect to compensate. It returns the status the global transaction is in.
+func (tc *TransactionCoordinator) doSagaRollback(gt *model.GlobalTransaction, retrying bool) (apis.GlobalSession_GlobalStatus, error) {
+	retryingStatus, failedStatus, rolledBackStatus := apis.RollbackRetrying, apis.RollbackFailed, apis.RolledBack
+	if gt.IsTimeoutGlobalStatus() {
+		retryingStatus, failedStatus, rolledBackStatus = apis.TimeoutRollbackRetrying, apis.TimeoutRollbackFailed, apis.TimeoutRolledBack
+	}
+
+	branchSessions := gt.SortedBranchSessions()
+	for i := len(branchSessions) - 1; i >= 0; i-- {
+		bs := branchSessions[i]
+		if bs.Status != apis.PhaseOneFailed {
+			branchStatus, err := tc.branchRollback(bs)
+			if err != nil {
+				log.Errorf("exception compensating saga branch xid=%s branchID=%d, err: %v", gt.XID, bs.BranchID, err)
+				if !retrying {
+					if err1 := tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, retryingStatus); err1 != nil {
+						return gt.Status, err1
+					}
+				}
+				return retryingStatus, err
+			}
+
+			switch branchStatus {
+			case apis.PhaseTwoRolledBack:
+				log.Infof("successfully compensate saga branch xid=%s branchID=%d", gt.XID, bs.BranchID)
+			case apis.PhaseTwoRollbackFailedCanNotRetry:
+				err = tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, failedStatus)
+				if err != nil {
+					return gt.Status, err
+				}
+				err = tc.holder.RemoveGlobalTransaction(gt)
+				if err != nil {
+					return failedStatus, err
+				}
+				log.Errorf("failed to compensate saga branch and stop retry xid=%s branchID=%d", gt.XID, bs.BranchID)
+				return failedStatus, nil
+			default:
+				log.Infof("failed to compensate saga branch xid=%s branchID=%d, will retry later", gt.XID, bs.BranchID)
+				if !retrying {
+					err = tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, retryingStatus)
+					if err != nil {
+						return gt.Status, err
+					}
+				}
+				return retryingStatus, nil
+			}
+		}
+
+		delete(gt.BranchSessions, bs)
+		err := tc.holder.RemoveBranchSession(gt.GlobalSession, bs)
+		if err != nil {
+			return gt.Status, err
+		}
+	}
+
+	err := tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, rolledBackStatus)
+	if err != nil {
+		return gt.Status, err
+	}
+	err = tc.holder.RemoveGlobalTransaction(gt)
+	if err != nil {
+		return rolledBackStatus, err
+	}
+	runtime.GoWithRecover(func() {
+		evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime,
+			int64(time2.CurrentTimeMillis()), rolledBackStatus)
+		event.EventBus.GlobalTransactionEventChannel <- evt
+	}, nil)
+	log.Infof("successfully compensate saga global, xid = %s", gt.XID)
+
+	return rolledBackStatus, nil
+}
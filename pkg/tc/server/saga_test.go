@@ -0,0 +1,79 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	mockholder "github.com/opentrx/seata-golang/v2/pkg/tc/holder/mock"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/model"
+)
+
+func newSagaTransaction(statuses ...apis.BranchSession_BranchStatus) *model.GlobalTransaction {
+	gt := &model.GlobalTransaction{
+		GlobalSession:  &apis.GlobalSession{XID: "localhost:123", Status: apis.Committing},
+		BranchSessions: map[*apis.BranchSession]bool{},
+	}
+	for i, status := range statuses {
+		gt.BranchSessions[&apis.BranchSession{
+			XID:      "localhost:123",
+			BranchID: int64(i + 1),
+			Type:     apis.SAGA,
+			Status:   status,
+		}] = true
+	}
+	return gt
+}
+
+func TestTransactionCoordinator_doSagaCommit(t *testing.T) {
+	t.Run("all branches done", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		gt := newSagaTransaction(apis.PhaseOneDone, apis.PhaseOneDone)
+		holder := mockholder.NewMockSessionHolderInterface(ctrl)
+		holder.EXPECT().RemoveBranchSession(gt.GlobalSession, gomock.Any()).Return(nil).Times(2)
+		holder.EXPECT().UpdateGlobalSessionStatus(gt.GlobalSession, apis.Committed).Return(nil)
+		holder.EXPECT().RemoveGlobalTransaction(gt).Return(nil)
+
+		tc := &TransactionCoordinator{holder: holder}
+		globalStatus, err := tc.doSagaCommit(gt, false)
+		assert.NoError(t, err)
+		assert.Equal(t, apis.Committed, globalStatus)
+		assert.False(t, gt.HasBranch())
+	})
+
+	t.Run("branch still running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		gt := newSagaTransaction(apis.PhaseOneDone, apis.Registered)
+		holder := mockholder.NewMockSessionHolderInterface(ctrl)
+		holder.EXPECT().UpdateGlobalSessionStatus(gt.GlobalSession, apis.CommitRetrying).Return(nil)
+
+		tc := &TransactionCoordinator{holder: holder}
+		globalStatus, err := tc.doSagaCommit(gt, false)
+		assert.NoError(t, err)
+		assert.Equal(t, apis.CommitRetrying, globalStatus)
+		assert.Len(t, gt.BranchSessions, 2)
+	})
+}
+
+func TestTransactionCoordinator_doSagaRollback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gt := newSagaTransaction(apis.PhaseOneFailed)
+	gt.Status = apis.RollingBack
+	holder := mockholder.NewMockSessionHolderInterface(ctrl)
+	holder.EXPECT().RemoveBranchSession(gt.GlobalSession, gomock.Any()).Return(nil)
+	holder.EXPECT().UpdateGlobalSessionStatus(gt.GlobalSession, apis.RolledBack).Return(nil)
+	holder.EXPECT().RemoveGlobalTransaction(gt).Return(nil)
+
+	tc := &TransactionCoordinator{holder: holder}
+	globalStatus, err := tc.doSagaRollback(gt, false)
+	assert.NoError(t, err)
+	assert.Equal(t, apis.RolledBack, globalStatus)
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/model"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	time2 "github.com/opentrx/seata-golang/v2/pkg/util/time"
+)
+
+// ListSessions lists the global sessions matching the filters in the order they began.
+func (tc *TransactionCoordinator) ListSessions(ctx context.Context, request *apis.SessionListRequest) (*apis.SessionListResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	var sessions []*apis.GlobalSession
+	if len(request.Statuses) != 0 {
+		sessions = tc.holder.FindGlobalSessions(request.Statuses)
+	} else {
+		sessions = tc.holder.AllSessions()
+	}
+
+	now := int64(time2.CurrentTimeMillis())
+	matched := make([]*apis.GlobalSession, 0, len(sessions))
+	for _, gs := range sessions {
+		if request.Addressing != "" && gs.Addressing != request.Addressing {
+			continue
+		}
+		if request.TransactionName != "" && gs.TransactionName != request.TransactionName {
+			continue
+		}
+		if request.MinAge > 0 && now-gs.BeginTime < request.MinAge {
+			continue
+		}
+		matched = append(matched, gs)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].BeginTime != matched[j].BeginTime {
+			return matched[i].BeginTime < matched[j].BeginTime
+		}
+		return matched[i].XID < matched[j].XID
+	})
+
+	start, end := page(len(matched), request.Offset, request.Limit)
+	return &apis.SessionListResponse{
+		ResultCode: apis.ResultCodeSuccess,
+		Sessions:   matched[start:end],
+		Total:      int32(len(matched)),
+	}, nil
+}
+
+// GetSession returns the global session with its branch sessions in the order they registered.
+func (tc *TransactionCoordinator) GetSession(ctx context.Context, request *apis.SessionGetRequest) (*apis.SessionGetResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	gt := tc.holder.FindGlobalTransaction(request.XID)
+	if gt == nil {
+		return &apis.SessionGetResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionNotExist,
+			Message:       fmt.Sprintf("could not find global transaction xid = %s", request.XID),
+		}, nil
+	}
+	return &apis.SessionGetResponse{
+		ResultCode:     apis.ResultCodeSuccess,
+		GlobalSession:  gt.GlobalSession,
+		BranchSessions: gt.SortedBranchSessions(),
+	}, nil
+}
+
+// RetryCommit retries the commit of a global transaction waiting to be committed at once.
+func (tc *TransactionCoordinator) RetryCommit(ctx context.Context, request *apis.SessionRetryRequest) (*apis.SessionRetryResponse, error) {
+	return tc.retry(ctx, request, "commit", []apis.GlobalSession_GlobalStatus{
+		apis.Committing, apis.CommitRetrying, apis.AsyncCommitting,
+	}, tc.doGlobalCommit)
+}
+
+// RetryRollback retries the rollback of a global transaction waiting to be rolled back at once.
+func (tc *TransactionCoordinator) RetryRollback(ctx context.Context, request *apis.SessionRetryRequest) (*apis.SessionRetryResponse, error) {
+	return tc.retry(ctx, request, "rollback", []apis.GlobalSession_GlobalStatus{
+		apis.RollingBack, apis.RollbackRetrying, apis.TimeoutRollingBack, apis.TimeoutRollbackRetrying,
+	}, tc.doGlobalRollback)
+}
+
+func (tc *TransactionCoordinator) retry(ctx context.Context, request *apis.SessionRetryRequest, action string,
+	statuses []apis.GlobalSession_GlobalStatus,
+	do func(gt *model.GlobalTransaction, retrying bool) (bool, error)) (resp *apis.SessionRetryResponse, err error) {
+	// the admin calls changing the sessions lock them in the memory of the leader, also when they
+	// come from the console which bypasses the interceptors
+	if !tc.IsLeader() {
+		return nil, errNotLeader
+	}
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	gt := tc.holder.FindGlobalTransaction(request.XID)
+	if gt == nil {
+		return &apis.SessionRetryResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionNotExist,
+			Message:       fmt.Sprintf("could not find global transaction xid = %s", request.XID),
+		}, nil
+	}
+	if err := tc.lockGlobalSession(gt.GlobalSession); err != nil {
+		return &apis.SessionRetryResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedLockGlobalTransaction,
+			Message:       err.Error(),
+		}, nil
+	}
+	defer func() {
+		if unlockErr := tc.locker.Unlock(gt.GlobalSession); unlockErr != nil && resp.ResultCode == apis.ResultCodeSuccess {
+			resp = &apis.SessionRetryResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       unlockErr.Error(),
+				GlobalStatus:  gt.Status,
+			}
+		}
+	}()
+
+	if !containsGlobalStatus(statuses, gt.Status) {
+		return &apis.SessionRetryResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionStatusInvalid,
+			Message:       fmt.Sprintf("could not %s global transaction xid = %s in status %s", action, gt.XID, gt.Status),
+			GlobalStatus:  gt.Status,
+		}, nil
+	}
+
+	log.Warnf("audit: %s retried %s of xid = %s, reason: %s", operator(ctx), action, gt.XID, request.Reason)
+	done, err := do(gt, true)
+	if err != nil {
+		return &apis.SessionRetryResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedStore,
+			Message:       fmt.Sprintf("failed to %s global transaction xid = %s, err: %s", action, gt.XID, err.Error()),
+			GlobalStatus:  gt.Status,
+		}, nil
+	}
+	if !done {
+		return &apis.SessionRetryResponse{
+			ResultCode:   apis.ResultCodeSuccess,
+			Message:      fmt.Sprintf("%s of global transaction xid = %s is not done, it will be retried", action, gt.XID),
+			GlobalStatus: gt.Status,
+		}, nil
+	}
+	return &apis.SessionRetryResponse{
+		ResultCode:   apis.ResultCodeSuccess,
+		GlobalStatus: gt.Status,
+	}, nil
+}
+
+// RemoveSession removes a stuck global transaction with its branch sessions, e.g. one whose
+// retries keep failing. The row locks are only released on request, since they still protect the
+// rows the branches left unfinished. The removal is written to the audit log.
+func (tc *TransactionCoordinator) RemoveSession(ctx context.Context, request *apis.SessionRemoveRequest) (resp *apis.SessionRemoveResponse, err error) {
+	if !tc.IsLeader() {
+		return nil, errNotLeader
+	}
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	gt := tc.holder.FindGlobalTransaction(request.XID)
+	if gt == nil {
+		return &apis.SessionRemoveResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionNotExist,
+			Message:       fmt.Sprintf("could not find global transaction xid = %s", request.XID),
+		}, nil
+	}
+	if !request.Force && gt.Status == apis.Begin && !gt.IsTimeout() {
+		return &apis.SessionRemoveResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionStatusInvalid,
+			Message:       fmt.Sprintf("global transaction xid = %s has not timed out, force is required to remove it", gt.XID),
+		}, nil
+	}
+
+	if err := tc.lockGlobalSession(gt.GlobalSession); err != nil {
+		return &apis.SessionRemoveResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedLockGlobalTransaction,
+			Message:       err.Error(),
+		}, nil
+	}
+	defer func() {
+		if unlockErr := tc.locker.Unlock(gt.GlobalSession); unlockErr != nil && resp.ResultCode == apis.ResultCodeSuccess {
+			resp = &apis.SessionRemoveResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       unlockErr.Error(),
+			}
+		}
+	}()
+
+	if request.ReleaseLocks && !tc.resourceDataLocker.ReleaseGlobalSessionLock(gt) {
+		return &apis.SessionRemoveResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedStore,
+			Message:       fmt.Sprintf("failed to release row locks of xid = %s", gt.XID),
+		}, nil
+	}
+	if err := tc.holder.RemoveGlobalTransaction(gt); err != nil {
+		return &apis.SessionRemoveResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedStore,
+			Message:       fmt.Sprintf("failed to remove global transaction xid = %s, err: %s", gt.XID, err.Error()),
+		}, nil
+	}
+
+	log.Warnf("audit: %s removed xid = %s in status %s with %d branches, row locks released: %t, reason: %s",
+		operator(ctx), gt.XID, gt.Status, len(gt.BranchSessions), request.ReleaseLocks, request.Reason)
+	return &apis.SessionRemoveResponse{ResultCode: apis.ResultCodeSuccess}, nil
+}
+
+func containsGlobalStatus(statuses []apis.GlobalSession_GlobalStatus, status apis.GlobalSession_GlobalStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/holder"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/lock"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	time2 "github.com/opentrx/seata-golang/v2/pkg/util/time"
+)
+
+// newSessionAdminCoordinator returns a transaction coordinator holding a global transaction in
+// progress, one waiting to be committed and one waiting to be rolled back
+func newSessionAdminCoordinator(t *testing.T) *TransactionCoordinator {
+	driver, err := factory.Create("inmemory", nil)
+	assert.Nil(t, err)
+	tc := &TransactionCoordinator{
+		holder:               holder.NewSessionHolder(driver),
+		resourceDataLocker:   lock.NewLockManager(driver),
+		locker:               NewMemoryGlobalSessionLocker(),
+		idGenerator:          &atomic.Uint64{},
+		futures:              &sync.Map{},
+		callBackMessages:     &sync.Map{},
+		streamMessageTimeout: time.Millisecond,
+	}
+
+	now := int64(time2.CurrentTimeMillis())
+	sessions := []*apis.GlobalSession{
+		{Addressing: "app1", XID: "localhost:8091:1", TransactionID: 1, TransactionName: "createOrder",
+			Status: apis.Begin, Active: true, BeginTime: now, Timeout: 60000},
+		{Addressing: "app1", XID: "localhost:8091:2", TransactionID: 2, TransactionName: "createOrder",
+			Status: apis.CommitRetrying, BeginTime: now - 120000, Timeout: 60000},
+		{Addressing: "app2", XID: "localhost:8091:3", TransactionID: 3, TransactionName: "cancelOrder",
+			Status: apis.RollbackRetrying, BeginTime: now - 60000, Timeout: 60000},
+	}
+	for _, gs := range sessions {
+		assert.Nil(t, tc.holder.AddGlobalSession(gs))
+	}
+	branches := []*apis.BranchSession{
+		{Addressing: "app1", XID: "localhost:8091:1", TransactionID: 1, BranchID: 11, ResourceID: "db",
+			LockKey: "product:1", Type: apis.AT},
+		{Addressing: "app1", XID: "localhost:8091:1", TransactionID: 1, BranchID: 12, ResourceID: "db",
+			LockKey: "product:2", Type: apis.AT},
+		{Addressing: "app2", XID: "localhost:8091:3", TransactionID: 3, BranchID: 31, ResourceID: "db",
+			LockKey: "order:1", Type: apis.AT},
+	}
+	for _, bs := range branches {
+		assert.Nil(t, tc.holder.AddBranchSession(tc.holder.FindGlobalSession(bs.XID), bs))
+		assert.True(t, tc.resourceDataLocker.AcquireLock(bs))
+	}
+	return tc
+}
+
+func TestTransactionCoordinator_ListSessions(t *testing.T) {
+	tc := newSessionAdminCoordinator(t)
+
+	resp, err := tc.ListSessions(context.Background(), &apis.SessionListRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.Equal(t, int32(3), resp.Total)
+	if assert.Len(t, resp.Sessions, 3) {
+		assert.Equal(t, "localhost:8091:2", resp.Sessions[0].XID)
+		assert.Equal(t, "localhost:8091:3", resp.Sessions[1].XID)
+		assert.Equal(t, "localhost:8091:1", resp.Sessions[2].XID)
+	}
+
+	resp, err = tc.ListSessions(context.Background(), &apis.SessionListRequest{
+		Statuses: []apis.GlobalSession_GlobalStatus{apis.Begin, apis.CommitRetrying},
+		Offset:   1,
+		Limit:    1,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), resp.Total)
+	if assert.Len(t, resp.Sessions, 1) {
+		assert.Equal(t, "localhost:8091:1", resp.Sessions[0].XID)
+	}
+
+	resp, err = tc.ListSessions(context.Background(), &apis.SessionListRequest{Addressing: "app1", MinAge: 30000})
+	assert.Nil(t, err)
+	if assert.Len(t, resp.Sessions, 1) {
+		assert.Equal(t, "localhost:8091:2", resp.Sessions[0].XID)
+	}
+
+	resp, err = tc.ListSessions(context.Background(), &apis.SessionListRequest{TransactionName: "cancelOrder"})
+	assert.Nil(t, err)
+	if assert.Len(t, resp.Sessions, 1) {
+		assert.Equal(t, "localhost:8091:3", resp.Sessions[0].XID)
+	}
+}
+
+func TestTransactionCoordinator_GetSession(t *testing.T) {
+	tc := newSessionAdminCoordinator(t)
+
+	resp, err := tc.GetSession(context.Background(), &apis.SessionGetRequest{XID: "localhost:8091:1"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.Equal(t, "createOrder", resp.GlobalSession.TransactionName)
+	if assert.Len(t, resp.BranchSessions, 2) {
+		assert.Equal(t, int64(11), resp.BranchSessions[0].BranchID)
+		assert.Equal(t, int64(12), resp.BranchSessions[1].BranchID)
+	}
+
+	resp, err = tc.GetSession(context.Background(), &apis.SessionGetRequest{XID: "localhost:8091:4"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.GlobalTransactionNotExist, resp.ExceptionCode)
+}
+
+func TestTransactionCoordinator_RetryCommit(t *testing.T) {
+	tc := newSessionAdminCoordinator(t)
+
+	resp, err := tc.RetryCommit(context.Background(), &apis.SessionRetryRequest{XID: "localhost:8091:1"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.GlobalTransactionStatusInvalid, resp.ExceptionCode)
+	assert.Equal(t, apis.Begin, resp.GlobalStatus)
+
+	resp, err = tc.RetryCommit(context.Background(), &apis.SessionRetryRequest{XID: "localhost:8091:2", Reason: "test"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.Equal(t, apis.Committed, resp.GlobalStatus)
+	assert.Nil(t, tc.holder.FindGlobalSession("localhost:8091:2"))
+}
+
+func TestTransactionCoordinator_RetryRollback(t *testing.T) {
+	tc := newSessionAdminCoordinator(t)
+
+	resp, err := tc.RetryRollback(context.Background(), &apis.SessionRetryRequest{XID: "localhost:8091:2"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.GlobalTransactionStatusInvalid, resp.ExceptionCode)
+
+	// the branch is not rolled back while its application is not connected
+	resp, err = tc.RetryRollback(context.Background(), &apis.SessionRetryRequest{XID: "localhost:8091:3"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.Equal(t, apis.RollbackRetrying, resp.GlobalStatus)
+	assert.NotNil(t, tc.holder.FindGlobalSession("localhost:8091:3"))
+}
+
+func TestTransactionCoordinator_RemoveSession(t *testing.T) {
+	tc := newSessionAdminCoordinator(t)
+
+	resp, err := tc.RemoveSession(context.Background(), &apis.SessionRemoveRequest{XID: "localhost:8091:1"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.GlobalTransactionStatusInvalid, resp.ExceptionCode)
+	assert.NotNil(t, tc.holder.FindGlobalSession("localhost:8091:1"))
+
+	resp, err = tc.RemoveSession(context.Background(), &apis.SessionRemoveRequest{XID: "localhost:8091:1", Force: true,
+		ReleaseLocks: true})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.Nil(t, tc.holder.FindGlobalSession("localhost:8091:1"))
+	assert.Len(t, tc.holder.FindBranchSession("localhost:8091:1"), 0)
+	assert.True(t, tc.resourceDataLocker.IsLockable("localhost:8091:4", "db", "product:1,2"))
+
+	// the row locks are kept unless they are asked to be released
+	resp, err = tc.RemoveSession(context.Background(), &apis.SessionRemoveRequest{XID: "localhost:8091:3"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeSuccess, resp.ResultCode)
+	assert.Nil(t, tc.holder.FindGlobalSession("localhost:8091:3"))
+	assert.False(t, tc.resourceDataLocker.IsLockable("localhost:8091:4", "db", "order:1"))
+
+	resp, err = tc.RemoveSession(context.Background(), &apis.SessionRemoveRequest{XID: "localhost:8091:3"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.GlobalTransactionNotExist, resp.ExceptionCode)
+}
+
+// lostGlobalSessionLocker a global session locker which loses every lock it holds
+type lostGlobalSessionLocker struct {
+	GlobalSessionLocker
+}
+
+func (locker *lostGlobalSessionLocker) Unlock(session *apis.GlobalSession) error {
+	if err := locker.GlobalSessionLocker.Unlock(session); err != nil {
+		return err
+	}
+	return fmt.Errorf("the lock of global session %s is lost", session.XID)
+}
+
+func TestTransactionCoordinator_RemoveSession_LockLost(t *testing.T) {
+	tc := newSessionAdminCoordinator(t)
+	tc.locker = &lostGlobalSessionLocker{GlobalSessionLocker: tc.locker}
+
+	resp, err := tc.RemoveSession(context.Background(), &apis.SessionRemoveRequest{XID: "localhost:8091:3"})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeFailed, resp.ResultCode)
+	assert.Equal(t, apis.FailedLockGlobalTransaction, resp.ExceptionCode)
+	assert.Equal(t, "the lock of global session localhost:8091:3 is lost", resp.Message)
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// errShuttingDown is returned to the calls arriving while the tc drains, the clients retry them on
+// another tc like the calls refused by the graceful stop of the grpc server.
+var errShuttingDown = status.Error(codes.Unavailable, "tc is shutting down")
+
+// Register registers the services of the tc on the grpc server, the server is stopped gracefully
+// by Stop.
+func (tc *TransactionCoordinator) Register(s *grpc.Server) {
+	apis.RegisterTransactionManagerServiceServer(s, tc)
+	apis.RegisterResourceManagerServiceServer(s, tc)
+	if tc.enableAdmin {
+		apis.RegisterAdminServiceServer(s, tc)
+	}
+	tc.server = s
+}
+
+// Stop drains the tc before it exits. The new global transactions are refused and the retry loops
+// stopped at once, the calls in flight are finished, the commits and rollbacks over the branch
+// streams, then the streams are ended, the grpc server stopped gracefully and the storage closed.
+// If ctx is done first, the connections are closed without waiting and ctx.Err() is returned, the
+// storage is left to the calls still running and closed after them. The global transactions cut
+// in half are retried by the other tc nodes from their status in the storage.
+func (tc *TransactionCoordinator) Stop(ctx context.Context) error {
+	if !tc.draining.CAS(false, true) {
+		return nil
+	}
+	log.Info("tc is shutting down, new global transactions are refused")
+
+	close(tc.stopLoops)
+	err := wait(ctx, tc.loops.Wait)
+
+	stopped := make(chan struct{})
+	if tc.server != nil {
+		// refuses the new calls while the ones in flight go on
+		go func() {
+			tc.server.GracefulStop()
+			close(stopped)
+		}()
+	} else {
+		close(stopped)
+	}
+
+	tc.stateMu.Lock()
+	tc.stopped = true
+	tc.stateMu.Unlock()
+	if err == nil {
+		err = wait(ctx, tc.inflight.Wait)
+	}
+
+	close(tc.stopStreams)
+	if err == nil {
+		err = wait(ctx, func() { <-stopped })
+	}
+	if err != nil {
+		log.Errorf("tc is not drained: %v", err)
+		if tc.server != nil {
+			tc.server.Stop()
+		}
+		// the retry loops and the calls in flight may still use the storage, it is closed once
+		// they return
+		go func() {
+			tc.loops.Wait()
+			tc.inflight.Wait()
+			tc.closeStorage()
+			log.Info("tc storage is closed")
+		}()
+		return err
+	}
+
+	tc.closeStorage()
+	log.Info("tc is stopped")
+	return nil
+}
+
+// enter counts a call touching the storage as in flight, it returns false once the tc no longer
+// waits for them. The caller calls tc.inflight.Done when it returns.
+func (tc *TransactionCoordinator) enter() bool {
+	tc.stateMu.RLock()
+	defer tc.stateMu.RUnlock()
+	if tc.stopped {
+		return false
+	}
+	tc.inflight.Add(1)
+	return true
+}
+
+// closeStorage persists the row locks of the lock table, then closes the storage driver and the
+// global session locker.
+func (tc *TransactionCoordinator) closeStorage() {
+	if tc.lockTable != nil {
+		tc.lockTable.Close()
+	}
+	for _, c := range []interface{}{tc.driver, tc.locker} {
+		if closer, ok := c.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Error(err)
+			}
+		}
+	}
+}
+
+// wait calls fn and returns once it returns, or when ctx is done.
+func wait(ctx context.Context, fn func()) error {
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
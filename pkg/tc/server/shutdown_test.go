@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/config"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+)
+
+// serveCoordinator serves a transaction coordinator on the inmemory storage, it returns the
+// coordinator and a connection to it
+func serveCoordinator(t *testing.T) (*TransactionCoordinator, *grpc.ClientConn) {
+	conf := &config.Configuration{Storage: config.Storage{"inmemory": config.Parameters{}}}
+	conf.Server.AsyncCommittingRetryPeriod = 10 * time.Millisecond
+	conf.Server.CommittingRetryPeriod = 10 * time.Millisecond
+	conf.Server.RollingBackRetryPeriod = 10 * time.Millisecond
+	conf.Server.TimeoutRetryPeriod = 10 * time.Millisecond
+	tc := NewTransactionCoordinator(conf)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	s := grpc.NewServer(grpc.UnaryInterceptor(tc.UnaryInterceptor), grpc.StreamInterceptor(tc.StreamInterceptor))
+	tc.Register(s)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return tc, conn
+}
+
+func TestTransactionCoordinator_Stop(t *testing.T) {
+	tc, conn := serveCoordinator(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "addressing", "app1")
+	stream, err := apis.NewResourceManagerServiceClient(conn).BranchCommunicate(ctx)
+	assert.Nil(t, err)
+
+	// a commit in flight holds the stop
+	assert.True(t, tc.enter())
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- tc.Stop(context.Background())
+	}()
+
+	assert.Eventually(t, tc.draining.Load, time.Second, time.Millisecond)
+	resp, err := tc.Begin(context.Background(), &apis.GlobalBeginRequest{Addressing: "app1", Timeout: 60000})
+	assert.Nil(t, err)
+	assert.Equal(t, apis.ResultCodeFailed, resp.ResultCode)
+	assert.Equal(t, apis.BeginFailed, resp.ExceptionCode)
+
+	select {
+	case <-stopped:
+		t.Fatal("stop should wait for the commit in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	tc.inflight.Done()
+	select {
+	case err := <-stopped:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("stop should return once the commit in flight is finished")
+	}
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = tc.Commit(context.Background(), &apis.GlobalCommitRequest{XID: "localhost:8091:1"})
+	assert.Equal(t, errShuttingDown, err)
+	// the other calls touching the storage are refused as well once the storage is closed
+	_, err = tc.BranchRegister(context.Background(), &apis.BranchRegisterRequest{XID: "localhost:8091:1"})
+	assert.Equal(t, errShuttingDown, err)
+	_, err = tc.LockQuery(context.Background(), &apis.GlobalLockQueryRequest{XID: "localhost:8091:1"})
+	assert.Equal(t, errShuttingDown, err)
+	_, err = tc.ListSessions(context.Background(), &apis.SessionListRequest{})
+	assert.Equal(t, errShuttingDown, err)
+	_, err = tc.ReleaseLocks(context.Background(), &apis.LockReleaseRequest{XID: "localhost:8091:1"})
+	assert.Equal(t, errShuttingDown, err)
+	assert.Nil(t, tc.Stop(context.Background()))
+}
+
+func TestTransactionCoordinator_Stop_Timeout(t *testing.T) {
+	tc, _ := serveCoordinator(t)
+	driver := &closeRecordingDriver{Driver: tc.driver}
+	tc.driver = driver
+
+	assert.True(t, tc.enter())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, tc.Stop(ctx))
+
+	// the storage is closed only once the call in flight returns
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, driver.closed.Load())
+	tc.inflight.Done()
+	assert.Eventually(t, driver.closed.Load, time.Second, time.Millisecond)
+}
+
+// closeRecordingDriver records whether the storage driver is closed
+type closeRecordingDriver struct {
+	storage.Driver
+	closed atomic.Bool
+}
+
+func (driver *closeRecordingDriver) Close() error {
+	driver.closed.Store(true)
+	return nil
+}
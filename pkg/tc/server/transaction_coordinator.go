@@ -10,9 +10,8 @@ import (
 
 	"github.com/gogo/protobuf/types"
 	"go.uber.org/atomic"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 
 	"github.com/opentrx/seata-golang/v2/pkg/apis"
 	common2 "github.com/opentrx/seata-golang/v2/pkg/common"
@@ -21,6 +20,7 @@ import (
 	"github.com/opentrx/seata-golang/v2/pkg/tc/holder"
 	"github.com/opentrx/seata-golang/v2/pkg/tc/lock"
 	"github.com/opentrx/seata-golang/v2/pkg/tc/model"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
 	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
 	"github.com/opentrx/seata-golang/v2/pkg/util/common"
 	"github.com/opentrx/seata-golang/v2/pkg/util/log"
@@ -44,6 +44,7 @@ type TransactionCoordinator struct {
 	timeoutRetryPeriod         time.Duration
 
 	streamMessageTimeout time.Duration
+	lockWaitTimeout      time.Duration
 
 	holder             holder.SessionHolderInterface
 	resourceDataLocker lock.LockManagerInterface
@@ -53,6 +54,23 @@ type TransactionCoordinator struct {
 	futures            *sync.Map
 	activeApplications *sync.Map
 	callBackMessages   *sync.Map
+
+	enableAdmin bool
+	driver      storage.Driver
+	lockTable   *lock.LockTable
+	server      *grpc.Server
+
+	// draining refuses the new global transactions once Stop is called
+	draining atomic.Bool
+	// stateMu guards stopped, the calls touching the storage are counted by inflight until it is set
+	stateMu  sync.RWMutex
+	stopped  bool
+	inflight sync.WaitGroup
+	// stopLoops stops the retry loops counted by loops
+	stopLoops chan struct{}
+	loops     sync.WaitGroup
+	// stopStreams ends the branch communicate streams
+	stopStreams chan struct{}
 }
 
 func NewTransactionCoordinator(conf *config.Configuration) *TransactionCoordinator {
@@ -61,6 +79,24 @@ func NewTransactionCoordinator(conf *config.Configuration) *TransactionCoordinat
 		log.Fatalf("failed to construct %s driver: %v", conf.Storage.Type(), err)
 		os.Exit(1)
 	}
+	var (
+		lockManager storage.LockManager = driver
+		lockTable   *lock.LockTable
+	)
+	if conf.LockTable.Enable {
+		lockTable, err = lock.NewLockTable(driver, conf.LockTable)
+		if err != nil {
+			log.Fatalf("failed to construct lock table: %v", err)
+			os.Exit(1)
+		}
+		lockTable.Load(driver)
+		lockManager = lockTable
+	}
+	locker, err := NewGlobalSessionLocker(conf)
+	if err != nil {
+		log.Fatalf("failed to construct %s global session locker: %v", conf.Locker.Type, err)
+		os.Exit(1)
+	}
 	tc := &TransactionCoordinator{
 		maxCommitRetryTimeout:            conf.Server.MaxCommitRetryTimeout,
 		maxRollbackRetryTimeout:          conf.Server.MaxRollbackRetryTimeout,
@@ -73,16 +109,25 @@ func NewTransactionCoordinator(conf *config.Configuration) *TransactionCoordinat
 		timeoutRetryPeriod:         conf.Server.TimeoutRetryPeriod,
 
 		streamMessageTimeout: conf.Server.StreamMessageTimeout,
+		lockWaitTimeout:      conf.Server.LockWaitTimeout,
 
 		holder:             holder.NewSessionHolder(driver),
-		resourceDataLocker: lock.NewLockManager(driver),
-		locker:             new(UnimplementedGlobalSessionLocker),
+		resourceDataLocker: lock.NewLockManager(lockManager),
+		locker:             locker,
 
 		idGenerator:        &atomic.Uint64{},
 		futures:            &sync.Map{},
 		activeApplications: &sync.Map{},
 		callBackMessages:   &sync.Map{},
+
+		enableAdmin: conf.Server.EnableAdmin,
+		driver:      driver,
+		lockTable:   lockTable,
+
+		stopLoops:   make(chan struct{}),
+		stopStreams: make(chan struct{}),
 	}
+	tc.loops.Add(4)
 	go tc.processTimeoutCheck()
 	go tc.processAsyncCommitting()
 	go tc.processRetryCommitting()
@@ -92,6 +137,17 @@ func NewTransactionCoordinator(conf *config.Configuration) *TransactionCoordinat
 }
 
 func (tc *TransactionCoordinator) Begin(ctx context.Context, request *apis.GlobalBeginRequest) (*apis.GlobalBeginResponse, error) {
+	if tc.draining.Load() {
+		return &apis.GlobalBeginResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.BeginFailed,
+			Message:       "tc is shutting down",
+		}, nil
+	}
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
 	transactionID := uuid.NextID()
 	xid := common.GenerateXID(request.Addressing, transactionID)
 	gt := model.GlobalTransaction{
@@ -113,8 +169,8 @@ func (tc *TransactionCoordinator) Begin(ctx context.Context, request *apis.Globa
 		}, nil
 	}
 
+	evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime, 0, gt.Status)
 	runtime.GoWithRecover(func() {
-		evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime, 0, gt.Status)
 		event.EventBus.GlobalTransactionEventChannel <- evt
 	}, nil)
 
@@ -126,6 +182,10 @@ func (tc *TransactionCoordinator) Begin(ctx context.Context, request *apis.Globa
 }
 
 func (tc *TransactionCoordinator) GetStatus(ctx context.Context, request *apis.GlobalStatusRequest) (*apis.GlobalStatusResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
 	gs := tc.holder.FindGlobalSession(request.XID)
 	if gs != nil {
 		return &apis.GlobalStatusResponse{
@@ -139,11 +199,164 @@ func (tc *TransactionCoordinator) GetStatus(ctx context.Context, request *apis.G
 	}, nil
 }
 
-func (tc *TransactionCoordinator) GlobalReport(ctx context.Context, request *apis.GlobalReportRequest) (*apis.GlobalReportResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GlobalReport not implemented")
+// GlobalReport accepts the final status of a global transaction driven by the client, such as
+// a saga whose branches are committed or compensated by the client itself. The global session
+// is finished with the reported status: its row locks are released and its sessions removed.
+// The branches needing phase two, such as AT and TCC branches, are not reportable, the global
+// transaction holding them is committed or rolled back instead.
+func (tc *TransactionCoordinator) GlobalReport(ctx context.Context, request *apis.GlobalReportRequest) (resp *apis.GlobalReportResponse, err error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	gt := tc.holder.FindGlobalTransaction(request.XID)
+	if gt == nil {
+		return &apis.GlobalReportResponse{
+			ResultCode:   apis.ResultCodeSuccess,
+			GlobalStatus: apis.Finished,
+		}, nil
+	}
+	if resp = checkGlobalReport(gt, request.GlobalStatus); resp != nil {
+		return resp, nil
+	}
+
+	session := gt.GlobalSession
+	result, err := tc.locker.TryLock(session, time.Duration(gt.Timeout)*time.Millisecond)
+	if err == nil && !result {
+		err = fmt.Errorf("failed to lock global transaction xid = %s", request.XID)
+	}
+	if err != nil {
+		return &apis.GlobalReportResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedLockGlobalTransaction,
+			Message:       err.Error(),
+			GlobalStatus:  gt.Status,
+		}, nil
+	}
+	defer func() {
+		// the global transaction may have been finished by another TC too if the lock was lost
+		if unlockErr := tc.locker.Unlock(session); unlockErr != nil && resp.ResultCode == apis.ResultCodeSuccess {
+			resp = &apis.GlobalReportResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       unlockErr.Error(),
+				GlobalStatus:  session.Status,
+			}
+		}
+	}()
+
+	// a commit or rollback may have changed the global transaction before the lock is taken
+	gt = tc.holder.FindGlobalTransaction(request.XID)
+	if gt == nil {
+		return &apis.GlobalReportResponse{
+			ResultCode:   apis.ResultCodeSuccess,
+			GlobalStatus: apis.Finished,
+		}, nil
+	}
+	if resp = checkGlobalReport(gt, request.GlobalStatus); resp != nil {
+		return resp, nil
+	}
+
+	err = tc.doGlobalReport(gt, request.GlobalStatus)
+	if err != nil {
+		return &apis.GlobalReportResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedStore,
+			Message:       err.Error(),
+			GlobalStatus:  gt.Status,
+		}, nil
+	}
+	log.Infof("global[%s] is reported %s", gt.XID, request.GlobalStatus.String())
+	return &apis.GlobalReportResponse{
+		ResultCode:   apis.ResultCodeSuccess,
+		GlobalStatus: request.GlobalStatus,
+	}, nil
+}
+
+// checkGlobalReport returns the failed response if the global transaction can not be finished with
+// the reported status, or nil.
+func checkGlobalReport(gt *model.GlobalTransaction, reported apis.GlobalSession_GlobalStatus) *apis.GlobalReportResponse {
+	if !canReportGlobalStatus(gt.Status, reported) {
+		return &apis.GlobalReportResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionStatusInvalid,
+			Message: fmt.Sprintf("could not report status %s of global transaction xid = %s, its status is %s",
+				reported.String(), gt.XID, gt.Status.String()),
+			GlobalStatus: gt.Status,
+		}
+	}
+	for _, bs := range gt.SortedBranchSessions() {
+		if bs.Type != apis.SAGA {
+			return &apis.GlobalReportResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.GlobalTransactionStatusInvalid,
+				Message: fmt.Sprintf("could not report status %s of global transaction xid = %s, its %s branch %d needs phase two",
+					reported.String(), gt.XID, bs.Type.String(), bs.BranchID),
+				GlobalStatus: gt.Status,
+			}
+		}
+	}
+	return nil
+}
+
+func (tc *TransactionCoordinator) doGlobalReport(gt *model.GlobalTransaction, globalStatus apis.GlobalSession_GlobalStatus) error {
+	if gt.Active {
+		// Highlight: Firstly, close the session, then no more branch can be registered.
+		err := tc.holder.InactiveGlobalSession(gt.GlobalSession)
+		if err != nil {
+			return err
+		}
+	}
+
+	// change status first, if need retention global session data,
+	// might not remove global session, then, the status is very important.
+	err := tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, globalStatus)
+	if err != nil {
+		return err
+	}
+	tc.resourceDataLocker.ReleaseGlobalSessionLock(gt)
+	for bs := range gt.BranchSessions {
+		delete(gt.BranchSessions, bs)
+		err = tc.holder.RemoveBranchSession(gt.GlobalSession, bs)
+		if err != nil {
+			return err
+		}
+	}
+	err = tc.holder.RemoveGlobalTransaction(gt)
+	if err != nil {
+		return err
+	}
+
+	evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime,
+		int64(time2.CurrentTimeMillis()), globalStatus)
+	runtime.GoWithRecover(func() {
+		event.EventBus.GlobalTransactionEventChannel <- evt
+	}, nil)
+	return nil
+}
+
+// canReportGlobalStatus return true if a global transaction in the current status can be
+// finished with the reported status, only final statuses can be reported and a global
+// transaction being committed can not be reported rolled back, and vice versa.
+func canReportGlobalStatus(current, reported apis.GlobalSession_GlobalStatus) bool {
+	switch reported {
+	case apis.Committed, apis.CommitFailed:
+		return current == apis.Begin || current == apis.Committing || current == apis.CommitRetrying ||
+			current == apis.AsyncCommitting
+	case apis.RolledBack, apis.RollbackFailed:
+		return current == apis.Begin || current == apis.RollingBack || current == apis.RollbackRetrying
+	case apis.TimeoutRolledBack, apis.TimeoutRollbackFailed:
+		return current == apis.Begin || current == apis.TimeoutRollingBack || current == apis.TimeoutRollbackRetrying
+	default:
+		return false
+	}
 }
 
 func (tc *TransactionCoordinator) Commit(ctx context.Context, request *apis.GlobalCommitRequest) (*apis.GlobalCommitResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
 	gt := tc.holder.FindGlobalTransaction(request.XID)
 	if gt == nil {
 		return &apis.GlobalCommitResponse{
@@ -151,13 +364,18 @@ func (tc *TransactionCoordinator) Commit(ctx context.Context, request *apis.Glob
 			GlobalStatus: apis.Finished,
 		}, nil
 	}
-	shouldCommit, err := func(gt *model.GlobalTransaction) (bool, error) {
+	shouldCommit, err := func(gt *model.GlobalTransaction) (shouldCommit bool, err error) {
 		result, err := tc.locker.TryLock(gt.GlobalSession, time.Duration(gt.Timeout)*time.Millisecond)
 		if err != nil {
 			return false, err
 		}
 		if result {
-			defer tc.locker.Unlock(gt.GlobalSession)
+			defer func() {
+				// another TC may drive the global transaction if the lock was lost, so the commit is aborted
+				if unlockErr := tc.locker.Unlock(gt.GlobalSession); unlockErr != nil && err == nil {
+					shouldCommit, err = false, unlockErr
+				}
+			}()
 			if gt.Active {
 				// Active need persistence
 				// Highlight: Firstly, close the session, then no more branch can be registered.
@@ -201,6 +419,22 @@ func (tc *TransactionCoordinator) Commit(ctx context.Context, request *apis.Glob
 		}, nil
 	}
 
+	if gt.IsSaga() {
+		globalStatus, err := tc.doSagaCommit(gt, false)
+		if err != nil {
+			return &apis.GlobalCommitResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.UnknownErr,
+				Message:       err.Error(),
+				GlobalStatus:  globalStatus,
+			}, nil
+		}
+		return &apis.GlobalCommitResponse{
+			ResultCode:   apis.ResultCodeSuccess,
+			GlobalStatus: globalStatus,
+		}, nil
+	}
+
 	if gt.CanBeCommittedAsync() {
 		err = tc.holder.UpdateGlobalSessionStatus(gt.GlobalSession, apis.AsyncCommitting)
 		if err != nil {
@@ -230,13 +464,15 @@ func (tc *TransactionCoordinator) Commit(ctx context.Context, request *apis.Glob
 func (tc *TransactionCoordinator) doGlobalCommit(gt *model.GlobalTransaction, retrying bool) (bool, error) {
 	var err error
 
+	// the event is built before the status changes
+	evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime, 0, gt.Status)
 	runtime.GoWithRecover(func() {
-		evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime, 0, gt.Status)
 		event.EventBus.GlobalTransactionEventChannel <- evt
 	}, nil)
 
 	if gt.IsSaga() {
-		return false, status.Errorf(codes.Unimplemented, "method Commit not supported saga mode")
+		globalStatus, err := tc.doSagaCommit(gt, retrying)
+		return globalStatus == apis.Committed, err
 	}
 
 	for bs := range gt.BranchSessions {
@@ -317,10 +553,10 @@ func (tc *TransactionCoordinator) doGlobalCommit(gt *model.GlobalTransaction, re
 	if err != nil {
 		return false, err
 	}
+	finishEvt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime,
+		int64(time2.CurrentTimeMillis()), gt.Status)
 	runtime.GoWithRecover(func() {
-		evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime,
-			int64(time2.CurrentTimeMillis()), gt.Status)
-		event.EventBus.GlobalTransactionEventChannel <- evt
+		event.EventBus.GlobalTransactionEventChannel <- finishEvt
 	}, nil)
 	log.Infof("global[%d] committing is successfully done.", gt.XID)
 
@@ -380,6 +616,10 @@ func (tc *TransactionCoordinator) branchCommit(bs *apis.BranchSession) (apis.Bra
 }
 
 func (tc *TransactionCoordinator) Rollback(ctx context.Context, request *apis.GlobalRollbackRequest) (*apis.GlobalRollbackResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
 	gt := tc.holder.FindGlobalTransaction(request.XID)
 	if gt == nil {
 		return &apis.GlobalRollbackResponse{
@@ -387,13 +627,18 @@ func (tc *TransactionCoordinator) Rollback(ctx context.Context, request *apis.Gl
 			GlobalStatus: apis.Finished,
 		}, nil
 	}
-	shouldRollBack, err := func(gt *model.GlobalTransaction) (bool, error) {
+	shouldRollBack, err := func(gt *model.GlobalTransaction) (shouldRollBack bool, err error) {
 		result, err := tc.locker.TryLock(gt.GlobalSession, time.Duration(gt.Timeout)*time.Millisecond)
 		if err != nil {
 			return false, err
 		}
 		if result {
-			defer tc.locker.Unlock(gt.GlobalSession)
+			defer func() {
+				// another TC may drive the global transaction if the lock was lost, so the rollback is aborted
+				if unlockErr := tc.locker.Unlock(gt.GlobalSession); unlockErr != nil && err == nil {
+					shouldRollBack, err = false, unlockErr
+				}
+			}()
 			if gt.Active {
 				// Active need persistence
 				// Highlight: Firstly, close the session, then no more branch can be registered.
@@ -443,13 +688,15 @@ func (tc *TransactionCoordinator) Rollback(ctx context.Context, request *apis.Gl
 func (tc *TransactionCoordinator) doGlobalRollback(gt *model.GlobalTransaction, retrying bool) (bool, error) {
 	var err error
 
+	// the event is built before the status changes
+	evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime, 0, gt.Status)
 	runtime.GoWithRecover(func() {
-		evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime, 0, gt.Status)
 		event.EventBus.GlobalTransactionEventChannel <- evt
 	}, nil)
 
 	if gt.IsSaga() {
-		return false, status.Errorf(codes.Unimplemented, "method Commit not supported saga mode")
+		globalStatus, err := tc.doSagaRollback(gt, retrying)
+		return globalStatus == apis.RolledBack || globalStatus == apis.TimeoutRolledBack, err
 	}
 
 	for bs := range gt.BranchSessions {
@@ -556,10 +803,10 @@ func (tc *TransactionCoordinator) doGlobalRollback(gt *model.GlobalTransaction,
 	if err != nil {
 		return false, err
 	}
+	finishEvt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime,
+		int64(time2.CurrentTimeMillis()), gt.Status)
 	runtime.GoWithRecover(func() {
-		evt := event.NewGlobalTransactionEvent(gt.TransactionID, event.RoleTC, gt.TransactionName, gt.BeginTime,
-			int64(time2.CurrentTimeMillis()), gt.Status)
-		event.EventBus.GlobalTransactionEventChannel <- evt
+		event.EventBus.GlobalTransactionEventChannel <- finishEvt
 	}, nil)
 	log.Infof("successfully rollback global, xid = %d", gt.XID)
 
@@ -655,150 +902,284 @@ func (tc *TransactionCoordinator) BranchCommunicate(stream apis.ResourceManagerS
 		}
 	}, nil)
 
+	// the stream is received apart, so that it is ended once the tc stops even though no message
+	// arrives
+	received := make(chan error, 1)
+	runtime.GoWithRecover(func() {
+		received <- tc.receiveBranchMessages(stream)
+	}, nil)
+	defer close(done)
+
+	// the stream is ended once the tc is no longer the leader, the client reconnects to the new one
+	ticker := time.NewTicker(leaderCheckPeriod)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
-			close(done)
 			return ctx.Err()
-		default:
-			branchMessage, err := stream.Recv()
+		case err := <-received:
 			if err == io.EOF {
-				close(done)
 				return nil
 			}
+			return err
+		case <-tc.stopStreams:
+			return errShuttingDown
+		case <-ticker.C:
+			if !tc.IsLeader() {
+				return errNotLeader
+			}
+		}
+	}
+}
+
+// receiveBranchMessages completes the futures of the branch commits and rollbacks with the results
+// received on the stream, until the stream fails.
+func (tc *TransactionCoordinator) receiveBranchMessages(stream apis.ResourceManagerService_BranchCommunicateServer) error {
+	for {
+		branchMessage, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		switch branchMessage.GetBranchMessageType() {
+		case apis.TypeBranchCommitResult:
+			response := &apis.BranchCommitResponse{}
+			data := branchMessage.GetMessage().GetValue()
+			err := response.Unmarshal(data)
 			if err != nil {
-				close(done)
-				return err
+				log.Error(err)
+				continue
 			}
-			switch branchMessage.GetBranchMessageType() {
-			case apis.TypeBranchCommitResult:
-				response := &apis.BranchCommitResponse{}
-				data := branchMessage.GetMessage().GetValue()
-				err := response.Unmarshal(data)
-				if err != nil {
-					log.Error(err)
-					continue
-				}
-				resp, loaded := tc.futures.Load(branchMessage.ID)
-				if loaded {
-					future := resp.(*common2.MessageFuture)
-					future.Response = response
-					future.Done <- true
-					tc.futures.Delete(branchMessage.ID)
-				}
-			case apis.TypeBranchRollBackResult:
-				response := &apis.BranchRollbackResponse{}
-				data := branchMessage.GetMessage().GetValue()
-				err := response.Unmarshal(data)
-				if err != nil {
-					log.Error(err)
-					continue
-				}
-				resp, loaded := tc.futures.Load(branchMessage.ID)
-				if loaded {
-					future := resp.(*common2.MessageFuture)
-					future.Response = response
-					future.Done <- true
-					tc.futures.Delete(branchMessage.ID)
-				}
+			resp, loaded := tc.futures.Load(branchMessage.ID)
+			if loaded {
+				future := resp.(*common2.MessageFuture)
+				future.Response = response
+				future.Done <- true
+				tc.futures.Delete(branchMessage.ID)
+			}
+		case apis.TypeBranchRollBackResult:
+			response := &apis.BranchRollbackResponse{}
+			data := branchMessage.GetMessage().GetValue()
+			err := response.Unmarshal(data)
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			resp, loaded := tc.futures.Load(branchMessage.ID)
+			if loaded {
+				future := resp.(*common2.MessageFuture)
+				future.Response = response
+				future.Done <- true
+				tc.futures.Delete(branchMessage.ID)
 			}
 		}
 	}
 }
 
-func (tc *TransactionCoordinator) BranchRegister(ctx context.Context, request *apis.BranchRegisterRequest) (*apis.BranchRegisterResponse, error) {
-	gt := tc.holder.FindGlobalTransaction(request.XID)
-	if gt == nil {
-		log.Errorf("could not find global transaction xid = %s", request.XID)
-		return &apis.BranchRegisterResponse{
-			ResultCode:    apis.ResultCodeFailed,
-			ExceptionCode: apis.GlobalTransactionNotExist,
-			Message:       fmt.Sprintf("could not find global transaction xid = %s", request.XID),
-		}, nil
+// lockWaitDeadline returns how long a branch registration waits for the row locks, it never waits
+// beyond the deadline of the request or the timeout of the global transaction.
+func (tc *TransactionCoordinator) lockWaitDeadline(ctx context.Context, gt *model.GlobalTransaction) time.Time {
+	if tc.lockWaitTimeout <= 0 {
+		return time.Time{}
+	}
+	deadline := time.Now().Add(tc.lockWaitTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	timeout := time.Unix(0, (gt.BeginTime+int64(gt.Timeout))*int64(time.Millisecond))
+	if timeout.Before(deadline) {
+		deadline = timeout
 	}
+	return deadline
+}
 
-	result, err := tc.locker.TryLock(gt.GlobalSession, time.Duration(gt.Timeout)*time.Millisecond)
-	if err != nil {
-		return &apis.BranchRegisterResponse{
+// BranchRegister registers a branch into its global session. The row locks of an AT branch are
+// waited for without holding the global session lock, so the commit, the rollback and the other
+// branch registrations of the global transaction are not blocked meanwhile; the global session
+// is checked again under the lock before the branch is added.
+func (tc *TransactionCoordinator) BranchRegister(ctx context.Context, request *apis.BranchRegisterRequest) (*apis.BranchRegisterResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
+	gt, resp := tc.lockBranchRegister(request)
+	if resp != nil {
+		return resp, nil
+	}
+	resp = checkBranchRegister(gt, request)
+	if err := tc.locker.Unlock(gt.GlobalSession); err != nil && resp == nil {
+		resp = &apis.BranchRegisterResponse{
 			ResultCode:    apis.ResultCodeFailed,
 			ExceptionCode: apis.FailedLockGlobalTransaction,
-			Message:       fmt.Sprintf("could not lock global transaction xid = %s", request.XID),
-		}, nil
+			Message:       err.Error(),
+		}
+	}
+	if resp != nil {
+		return resp, nil
 	}
-	if result {
-		defer tc.locker.Unlock(gt.GlobalSession)
-		if !gt.Active {
+
+	var asyncCommit bool
+	if request.BranchType == apis.AT {
+		asyncCommit = true
+	} else {
+		asyncCommit = request.AsyncCommit
+	}
+
+	bs := &apis.BranchSession{
+		Addressing:      request.Addressing,
+		XID:             request.XID,
+		BranchID:        uuid.NextID(),
+		TransactionID:   gt.TransactionID,
+		ResourceID:      request.ResourceID,
+		LockKey:         request.LockKey,
+		Type:            request.BranchType,
+		Status:          apis.Registered,
+		ApplicationData: request.ApplicationData,
+		AsyncCommit:     asyncCommit,
+	}
+
+	if bs.Type == apis.AT {
+		err := tc.resourceDataLocker.AcquireLockWait(gt.GlobalSession, bs, tc.lockWaitDeadline(ctx, gt))
+		if lockKeyErr, ok := err.(*storage.LockKeyError); ok {
 			return &apis.BranchRegisterResponse{
 				ResultCode:    apis.ResultCodeFailed,
-				ExceptionCode: apis.GlobalTransactionNotActive,
-				Message:       fmt.Sprintf("could not register branch into global session xid = %s status = %d", gt.XID, gt.Status),
+				ExceptionCode: apis.BranchRegisterFailed,
+				Message: fmt.Sprintf("branch register failed, xid = %s resourceId = %s, err: %s",
+					request.XID, request.ResourceID, lockKeyErr.Error()),
 			}, nil
 		}
-		if gt.Status != apis.Begin {
+		if deadlock, ok := err.(*lock.DeadlockError); ok {
 			return &apis.BranchRegisterResponse{
 				ResultCode:    apis.ResultCodeFailed,
-				ExceptionCode: apis.GlobalTransactionStatusInvalid,
-				Message: fmt.Sprintf("could not register branch into global session xid = %s status = %d while expecting %d",
-					gt.XID, gt.Status, apis.Begin),
+				ExceptionCode: apis.LockWaitDeadlock,
+				Message: fmt.Sprintf("branch lock acquire failed xid = %s resourceId = %s, lockKey = %s, err: %s",
+					request.XID, request.ResourceID, request.LockKey, deadlock.Error()),
+			}, nil
+		}
+		if err != nil {
+			return &apis.BranchRegisterResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.LockKeyConflict,
+				Message: fmt.Sprintf("branch lock acquire failed xid = %s resourceId = %s, lockKey = %s",
+					request.XID, request.ResourceID, request.LockKey),
 			}, nil
 		}
+	}
 
-		var asyncCommit bool
-		if request.BranchType == apis.AT {
-			asyncCommit = true
-		} else {
-			asyncCommit = request.AsyncCommit
+	// the global transaction may have been committed or rolled back while the row locks are waited for
+	gt, resp = tc.lockBranchRegister(request)
+	if resp == nil {
+		resp = checkBranchRegister(gt, request)
+		if resp == nil {
+			resp = tc.addBranchSession(gt, bs)
+		}
+		if err := tc.locker.Unlock(gt.GlobalSession); err != nil && resp == nil {
+			// another TC may have finished the global transaction without the branch meanwhile
+			if removeErr := tc.holder.RemoveBranchSession(gt.GlobalSession, bs); removeErr != nil {
+				log.Error(removeErr)
+			}
+			resp = &apis.BranchRegisterResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       err.Error(),
+			}
 		}
+	}
+	if resp != nil {
+		if bs.Type == apis.AT {
+			tc.resourceDataLocker.ReleaseLock(bs)
+		}
+		return resp, nil
+	}
 
-		bs := &apis.BranchSession{
-			Addressing:      request.Addressing,
-			XID:             request.XID,
-			BranchID:        uuid.NextID(),
-			TransactionID:   gt.TransactionID,
-			ResourceID:      request.ResourceID,
-			LockKey:         request.LockKey,
-			Type:            request.BranchType,
-			Status:          apis.Registered,
-			ApplicationData: request.ApplicationData,
-			AsyncCommit:     asyncCommit,
+	return &apis.BranchRegisterResponse{
+		ResultCode: apis.ResultCodeSuccess,
+		BranchID:   bs.BranchID,
+	}, nil
+}
+
+// addBranchSession adds the branch session to the global transaction, it returns the failed
+// response if the storage fails.
+func (tc *TransactionCoordinator) addBranchSession(gt *model.GlobalTransaction, bs *apis.BranchSession) *apis.BranchRegisterResponse {
+	err := tc.holder.AddBranchSession(gt.GlobalSession, bs)
+	if err != nil {
+		log.Error(err)
+		return &apis.BranchRegisterResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.BranchRegisterFailed,
+			Message:       fmt.Sprintf("branch register failed, xid = %s, branchID = %d, err: %s", gt.XID, bs.BranchID, err.Error()),
 		}
+	}
+	return nil
+}
 
-		if bs.Type == apis.AT {
-			result := tc.resourceDataLocker.AcquireLock(bs)
-			if !result {
-				return &apis.BranchRegisterResponse{
-					ResultCode:    apis.ResultCodeFailed,
-					ExceptionCode: apis.LockKeyConflict,
-					Message: fmt.Sprintf("branch lock acquire failed xid = %s resourceId = %s, lockKey = %s",
-						request.XID, request.ResourceID, request.LockKey),
-				}, nil
-			}
+// lockBranchRegister finds the global transaction of the branch registration and locks its
+// global session, it returns the failed response if either fails.
+func (tc *TransactionCoordinator) lockBranchRegister(request *apis.BranchRegisterRequest) (*model.GlobalTransaction, *apis.BranchRegisterResponse) {
+	gt := tc.holder.FindGlobalTransaction(request.XID)
+	if gt == nil {
+		log.Errorf("could not find global transaction xid = %s", request.XID)
+		return nil, &apis.BranchRegisterResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionNotExist,
+			Message:       fmt.Sprintf("could not find global transaction xid = %s", request.XID),
 		}
+	}
 
-		err := tc.holder.AddBranchSession(gt.GlobalSession, bs)
-		if err != nil {
-			log.Error(err)
-			return &apis.BranchRegisterResponse{
-				ResultCode:    apis.ResultCodeFailed,
-				ExceptionCode: apis.BranchRegisterFailed,
-				Message:       fmt.Sprintf("branch register failed, xid = %s, branchID = %d, err: %s", gt.XID, bs.BranchID, err.Error()),
-			}, nil
+	result, err := tc.locker.TryLock(gt.GlobalSession, time.Duration(gt.Timeout)*time.Millisecond)
+	if err != nil {
+		return nil, &apis.BranchRegisterResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedLockGlobalTransaction,
+			Message:       fmt.Sprintf("could not lock global transaction xid = %s", request.XID),
+		}
+	}
+	if !result {
+		return nil, &apis.BranchRegisterResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedLockGlobalTransaction,
+			Message:       fmt.Sprintf("failed to lock global transaction xid = %s", request.XID),
 		}
+	}
+	return gt, nil
+}
 
+// checkBranchRegister returns the failed response if the branch can not be registered into the
+// global transaction, or nil.
+func checkBranchRegister(gt *model.GlobalTransaction, request *apis.BranchRegisterRequest) *apis.BranchRegisterResponse {
+	if !gt.Active {
 		return &apis.BranchRegisterResponse{
-			ResultCode: apis.ResultCodeSuccess,
-			BranchID:   bs.BranchID,
-		}, nil
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionNotActive,
+			Message:       fmt.Sprintf("could not register branch into global session xid = %s status = %d", gt.XID, gt.Status),
+		}
+	}
+	if gt.Status != apis.Begin {
+		return &apis.BranchRegisterResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.GlobalTransactionStatusInvalid,
+			Message: fmt.Sprintf("could not register branch into global session xid = %s status = %d while expecting %d",
+				gt.XID, gt.Status, apis.Begin),
+		}
 	}
 
-	return &apis.BranchRegisterResponse{
-		ResultCode:    apis.ResultCodeFailed,
-		ExceptionCode: apis.FailedLockGlobalTransaction,
-		Message:       fmt.Sprintf("failed to lock global transaction xid = %s", request.XID),
-	}, nil
+	// saga branches are committed forward only and compensated in reverse order, they can
+	// not be mixed with branches committed or rolled back in two phases.
+	if gt.HasBranch() && gt.IsSaga() != (request.BranchType == apis.SAGA) {
+		return &apis.BranchRegisterResponse{
+			ResultCode:    apis.ResultCodeFailed,
+			ExceptionCode: apis.FailedToAddBranch,
+			Message: fmt.Sprintf("could not register %s branch into global session xid = %s, saga branches can not be mixed with other branch types",
+				request.BranchType.String(), gt.XID),
+		}
+	}
+	return nil
 }
 
 func (tc *TransactionCoordinator) BranchReport(ctx context.Context, request *apis.BranchReportRequest) (*apis.BranchReportResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
 	gt := tc.holder.FindGlobalTransaction(request.XID)
 	if gt == nil {
 		log.Errorf("could not find global transaction xid = %s", request.XID)
@@ -833,6 +1214,10 @@ func (tc *TransactionCoordinator) BranchReport(ctx context.Context, request *api
 }
 
 func (tc *TransactionCoordinator) LockQuery(ctx context.Context, request *apis.GlobalLockQueryRequest) (*apis.GlobalLockQueryResponse, error) {
+	if !tc.enter() {
+		return nil, errShuttingDown
+	}
+	defer tc.inflight.Done()
 	result := tc.resourceDataLocker.IsLockable(request.XID, request.ResourceID, request.LockKey)
 	return &apis.GlobalLockQueryResponse{
 		ResultCode: apis.ResultCodeSuccess,
@@ -841,44 +1226,37 @@ func (tc *TransactionCoordinator) LockQuery(ctx context.Context, request *apis.G
 }
 
 func (tc *TransactionCoordinator) processTimeoutCheck() {
-	for {
-		timer := time.NewTimer(tc.timeoutRetryPeriod)
-
-		<-timer.C
-		tc.timeoutCheck()
-
-		timer.Stop()
-	}
+	tc.loop(tc.timeoutRetryPeriod, tc.timeoutCheck)
 }
 
 func (tc *TransactionCoordinator) processRetryRollingBack() {
-	for {
-		timer := time.NewTimer(tc.rollingBackRetryPeriod)
-
-		<-timer.C
-		tc.handleRetryRollingBack()
-
-		timer.Stop()
-	}
+	tc.loop(tc.rollingBackRetryPeriod, tc.handleRetryRollingBack)
 }
 
 func (tc *TransactionCoordinator) processRetryCommitting() {
-	for {
-		timer := time.NewTimer(tc.committingRetryPeriod)
-
-		<-timer.C
-		tc.handleRetryCommitting()
-
-		timer.Stop()
-	}
+	tc.loop(tc.committingRetryPeriod, tc.handleRetryCommitting)
 }
 
 func (tc *TransactionCoordinator) processAsyncCommitting() {
+	tc.loop(tc.asyncCommittingRetryPeriod, tc.handleAsyncCommitting)
+}
+
+// loop calls handle every period until the retry loops are stopped, the nodes sharing a replicated
+// storage call it on the leader only.
+func (tc *TransactionCoordinator) loop(period time.Duration, handle func()) {
+	defer tc.loops.Done()
 	for {
-		timer := time.NewTimer(tc.asyncCommittingRetryPeriod)
+		timer := time.NewTimer(period)
 
-		<-timer.C
-		tc.handleAsyncCommitting()
+		select {
+		case <-timer.C:
+			if tc.IsLeader() {
+				handle()
+			}
+		case <-tc.stopLoops:
+			timer.Stop()
+			return
+		}
 
 		timer.Stop()
 	}
@@ -898,16 +1276,24 @@ func (tc *TransactionCoordinator) timeoutCheck() {
 					// Highlight: Firstly, close the session, then no more branch can be registered.
 					err = tc.holder.InactiveGlobalSession(globalSession)
 					if err != nil {
+						if unlockErr := tc.locker.Unlock(globalSession); unlockErr != nil {
+							log.Error(unlockErr)
+						}
 						return
 					}
 				}
 
 				err = tc.holder.UpdateGlobalSessionStatus(globalSession, apis.TimeoutRollingBack)
+				unlockErr := tc.locker.Unlock(globalSession)
 				if err != nil {
 					return
 				}
+				if unlockErr != nil {
+					// another TC may have taken the timed out global transaction over meanwhile
+					log.Errorf("skip the timeout of global transaction xid = %s, err: %v", globalSession.XID, unlockErr)
+					continue
+				}
 
-				tc.locker.Unlock(globalSession)
 				evt := event.NewGlobalTransactionEvent(globalSession.TransactionID, event.RoleTC, globalSession.TransactionName, globalSession.BeginTime, 0, globalSession.Status)
 				event.EventBus.GlobalTransactionEventChannel <- evt
 			}
@@ -970,6 +1356,8 @@ func (tc *TransactionCoordinator) handleRetryCommitting() {
 	now := time2.CurrentTimeMillis()
 	for _, transaction := range committingTransactions {
 		if isRetryTimeout(int64(now), tc.maxCommitRetryTimeout, transaction.BeginTime) {
+			// the global transaction is committed, its row locks protect nothing any more
+			tc.resourceDataLocker.ReleaseGlobalSessionLock(transaction)
 			err := tc.holder.RemoveGlobalTransaction(transaction)
 			if err != nil {
 				log.Error(err)
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,9 +12,11 @@ import (
 
 	"github.com/opentrx/seata-golang/v2/pkg/apis"
 	mockholder "github.com/opentrx/seata-golang/v2/pkg/tc/holder/mock"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/lock"
 	mocklock "github.com/opentrx/seata-golang/v2/pkg/tc/lock/mock"
 	"github.com/opentrx/seata-golang/v2/pkg/tc/model"
 	mockserver "github.com/opentrx/seata-golang/v2/pkg/tc/server/mock"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
 )
 
 func TestTransactionCoordinator_GetStatus(t *testing.T) {
@@ -394,6 +397,7 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 	resourceID := "test_DB"
 	lockKey := "test_table:pk1,pk2"
 	addBranchSessionErr := fmt.Errorf("error add branch session")
+	deadlock := &lock.DeadlockError{Victim: xid, XIDs: []string{xid, "localhost:456"}, RowKeys: []string{"test_DB^^^test_table^^^pk1"}}
 	tests := []struct {
 		name                   string
 		transactionCoordinator func(ctrl *gomock.Controller) *TransactionCoordinator
@@ -495,7 +499,7 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 					mockedGlobalTransaction.GlobalSession,
 					time.Duration(mockedGlobalTransaction.Timeout)*time.Millisecond,
 				).Return(true, nil)
-				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return()
+				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil)
 
 				transactionCoordinator.holder = mockedSessionHolder
 				transactionCoordinator.locker = mockedGlobalSessionLock
@@ -540,7 +544,7 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 					time.Duration(mockedGlobalTransaction.Timeout)*time.Millisecond,
 				).Return(true, nil)
 
-				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return()
+				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil)
 
 				transactionCoordinator.holder = mockedSessionHolder
 				transactionCoordinator.locker = mockedGlobalSessionLock
@@ -581,10 +585,10 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 					mockedGlobalTransaction.GlobalSession,
 					time.Duration(mockedGlobalTransaction.Timeout)*time.Millisecond,
 				).Return(true, nil)
-				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return()
+				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil)
 
 				mockedResourceDataLock := mocklock.NewMockLockManagerInterface(ctrl)
-				mockedResourceDataLock.EXPECT().AcquireLock(gomock.Any()).Return(false)
+				mockedResourceDataLock.EXPECT().AcquireLockWait(gomock.Any(), gomock.Any(), gomock.Any()).Return(lock.ErrLockConflict)
 
 				transactionCoordinator.holder = mockedSessionHolder
 				transactionCoordinator.locker = mockedGlobalSessionLock
@@ -607,7 +611,7 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name: "test BranchRegister error acquire resource data lock",
+			name: "test BranchRegister deadlock acquiring resource data lock",
 			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
 				transactionCoordinator := &TransactionCoordinator{}
 				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
@@ -628,10 +632,10 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 					mockedGlobalTransaction.GlobalSession,
 					time.Duration(mockedGlobalTransaction.Timeout)*time.Millisecond,
 				).Return(true, nil)
-				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return()
+				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil)
 
 				mockedResourceDataLock := mocklock.NewMockLockManagerInterface(ctrl)
-				mockedResourceDataLock.EXPECT().AcquireLock(gomock.Any()).Return(false)
+				mockedResourceDataLock.EXPECT().AcquireLockWait(gomock.Any(), gomock.Any(), gomock.Any()).Return(deadlock)
 
 				transactionCoordinator.holder = mockedSessionHolder
 				transactionCoordinator.locker = mockedGlobalSessionLock
@@ -647,9 +651,9 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 			},
 			expectedResult: &apis.BranchRegisterResponse{
 				ResultCode:    apis.ResultCodeFailed,
-				ExceptionCode: apis.LockKeyConflict,
-				Message: fmt.Sprintf("branch lock acquire failed xid = %s resourceId = %s, lockKey = %s",
-					xid, resourceID, lockKey),
+				ExceptionCode: apis.LockWaitDeadlock,
+				Message: fmt.Sprintf("branch lock acquire failed xid = %s resourceId = %s, lockKey = %s, err: %s",
+					xid, resourceID, lockKey, deadlock.Error()),
 			},
 			expectedErr: nil,
 		},
@@ -669,17 +673,18 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 					},
 					BranchSessions: nil,
 				}
-				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(mockedGlobalTransaction)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(mockedGlobalTransaction).Times(2)
 				mockedSessionHolder.EXPECT().AddBranchSession(mockedGlobalTransaction.GlobalSession, gomock.Any()).Return(addBranchSessionErr)
 
 				mockedGlobalSessionLock.EXPECT().TryLock(
 					mockedGlobalTransaction.GlobalSession,
 					time.Duration(mockedGlobalTransaction.Timeout)*time.Millisecond,
-				).Return(true, nil)
-				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return()
+				).Return(true, nil).Times(2)
+				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil).Times(2)
 
 				mockedResourceDataLock := mocklock.NewMockLockManagerInterface(ctrl)
-				mockedResourceDataLock.EXPECT().AcquireLock(gomock.Any()).Return(true)
+				mockedResourceDataLock.EXPECT().AcquireLockWait(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockedResourceDataLock.EXPECT().ReleaseLock(gomock.Any()).Return(true)
 
 				transactionCoordinator.holder = mockedSessionHolder
 				transactionCoordinator.locker = mockedGlobalSessionLock
@@ -700,6 +705,139 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "test BranchRegister malformed lock key",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedGlobalSessionLock := mockserver.NewMockGlobalSessionLocker(ctrl)
+
+				mockedGlobalTransaction := &model.GlobalTransaction{
+					GlobalSession: &apis.GlobalSession{
+						XID:     xid,
+						Timeout: int32(300),
+						Status:  apis.Begin,
+						Active:  true,
+					},
+				}
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(mockedGlobalTransaction)
+				mockedGlobalSessionLock.EXPECT().TryLock(mockedGlobalTransaction.GlobalSession, gomock.Any()).Return(true, nil)
+				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil)
+
+				mockedResourceDataLock := mocklock.NewMockLockManagerInterface(ctrl)
+				mockedResourceDataLock.EXPECT().AcquireLockWait(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					&storage.LockKeyError{LockKey: "product:[5~1]", Reason: "[5~1] is not a valid pk range"})
+
+				return &TransactionCoordinator{
+					holder:             mockedSessionHolder,
+					locker:             mockedGlobalSessionLock,
+					resourceDataLocker: mockedResourceDataLock,
+				}
+			},
+			ctx: nil,
+			request: &apis.BranchRegisterRequest{
+				XID:        xid,
+				ResourceID: resourceID,
+				LockKey:    "product:[5~1]",
+			},
+			expectedResult: &apis.BranchRegisterResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.BranchRegisterFailed,
+				Message: fmt.Sprintf("branch register failed, xid = %s resourceId = %s, err: malformed lock key product:[5~1]: [5~1] is not a valid pk range",
+					xid, resourceID),
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "test BranchRegister global transaction finished while waiting for the row locks",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedGlobalSessionLock := mockserver.NewMockGlobalSessionLocker(ctrl)
+
+				mockedGlobalTransaction := &model.GlobalTransaction{
+					GlobalSession: &apis.GlobalSession{
+						XID:     xid,
+						Timeout: int32(300),
+						Status:  apis.Begin,
+						Active:  true,
+					},
+				}
+				gomock.InOrder(
+					mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(mockedGlobalTransaction),
+					mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(nil),
+				)
+				mockedGlobalSessionLock.EXPECT().TryLock(mockedGlobalTransaction.GlobalSession, gomock.Any()).Return(true, nil)
+				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil)
+
+				mockedResourceDataLock := mocklock.NewMockLockManagerInterface(ctrl)
+				mockedResourceDataLock.EXPECT().AcquireLockWait(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockedResourceDataLock.EXPECT().ReleaseLock(gomock.Any()).Return(true)
+
+				return &TransactionCoordinator{
+					holder:             mockedSessionHolder,
+					locker:             mockedGlobalSessionLock,
+					resourceDataLocker: mockedResourceDataLock,
+				}
+			},
+			ctx: nil,
+			request: &apis.BranchRegisterRequest{
+				XID:        xid,
+				ResourceID: resourceID,
+				LockKey:    lockKey,
+			},
+			expectedResult: &apis.BranchRegisterResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.GlobalTransactionNotExist,
+				Message:       fmt.Sprintf("could not find global transaction xid = %s", xid),
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "test BranchRegister lock lost while adding the branch session",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				mockedGlobalTransaction := &model.GlobalTransaction{
+					GlobalSession: &apis.GlobalSession{
+						XID:     xid,
+						Timeout: int32(300),
+						Status:  apis.Begin,
+						Active:  true,
+					},
+					BranchSessions: nil,
+				}
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(mockedGlobalTransaction).Times(2)
+				mockedSessionHolder.EXPECT().AddBranchSession(mockedGlobalTransaction.GlobalSession, gomock.Any()).Return(nil)
+				mockedSessionHolder.EXPECT().RemoveBranchSession(mockedGlobalTransaction.GlobalSession, gomock.Any()).Return(nil)
+
+				mockedGlobalSessionLock := mockserver.NewMockGlobalSessionLocker(ctrl)
+				mockedGlobalSessionLock.EXPECT().TryLock(mockedGlobalTransaction.GlobalSession, gomock.Any()).Return(true, nil).Times(2)
+				gomock.InOrder(
+					mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil),
+					mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(fmt.Errorf("lock lost")),
+				)
+
+				mockedResourceDataLock := mocklock.NewMockLockManagerInterface(ctrl)
+				mockedResourceDataLock.EXPECT().AcquireLockWait(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockedResourceDataLock.EXPECT().ReleaseLock(gomock.Any()).Return(true)
+
+				return &TransactionCoordinator{
+					holder:             mockedSessionHolder,
+					locker:             mockedGlobalSessionLock,
+					resourceDataLocker: mockedResourceDataLock,
+				}
+			},
+			ctx: nil,
+			request: &apis.BranchRegisterRequest{
+				XID:        xid,
+				ResourceID: resourceID,
+				LockKey:    lockKey,
+			},
+			expectedResult: &apis.BranchRegisterResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       "lock lost",
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "test BranchRegister success",
 			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
@@ -716,17 +854,17 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 					},
 					BranchSessions: nil,
 				}
-				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(mockedGlobalTransaction)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(mockedGlobalTransaction).Times(2)
 				mockedSessionHolder.EXPECT().AddBranchSession(mockedGlobalTransaction.GlobalSession, gomock.Any()).Return(nil)
 
 				mockedGlobalSessionLock.EXPECT().TryLock(
 					mockedGlobalTransaction.GlobalSession,
 					time.Duration(mockedGlobalTransaction.Timeout)*time.Millisecond,
-				).Return(true, nil)
-				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return()
+				).Return(true, nil).Times(2)
+				mockedGlobalSessionLock.EXPECT().Unlock(mockedGlobalTransaction.GlobalSession).Return(nil).Times(2)
 
 				mockedResourceDataLock := mocklock.NewMockLockManagerInterface(ctrl)
-				mockedResourceDataLock.EXPECT().AcquireLock(gomock.Any()).Return(true)
+				mockedResourceDataLock.EXPECT().AcquireLockWait(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 				transactionCoordinator.holder = mockedSessionHolder
 				transactionCoordinator.locker = mockedGlobalSessionLock
@@ -775,10 +913,326 @@ func TestTransactionCoordinator_BranchRegister(t *testing.T) {
 	}
 }
 
+func TestTransactionCoordinator_GlobalReport(t *testing.T) {
+	xid := "localhost:123"
+	tests := []struct {
+		name                   string
+		transactionCoordinator func(ctrl *gomock.Controller) *TransactionCoordinator
+		request                *apis.GlobalReportRequest
+		expectedResult         *apis.GlobalReportResponse
+	}{
+		{
+			name: "test GlobalReport with finished global transaction",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(nil)
+				return &TransactionCoordinator{holder: mockedSessionHolder}
+			},
+			request: &apis.GlobalReportRequest{XID: xid, GlobalStatus: apis.Committed},
+			expectedResult: &apis.GlobalReportResponse{
+				ResultCode:   apis.ResultCodeSuccess,
+				GlobalStatus: apis.Finished,
+			},
+		},
+		{
+			name: "test GlobalReport with invalid status transition",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(&model.GlobalTransaction{
+					GlobalSession:  &apis.GlobalSession{XID: xid, Status: apis.RollingBack},
+					BranchSessions: map[*apis.BranchSession]bool{},
+				})
+				return &TransactionCoordinator{holder: mockedSessionHolder}
+			},
+			request: &apis.GlobalReportRequest{XID: xid, GlobalStatus: apis.Committed},
+			expectedResult: &apis.GlobalReportResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.GlobalTransactionStatusInvalid,
+				Message: fmt.Sprintf("could not report status %s of global transaction xid = %s, its status is %s",
+					apis.Committed.String(), xid, apis.RollingBack.String()),
+				GlobalStatus: apis.RollingBack,
+			},
+		},
+		{
+			name: "test GlobalReport with non-final status",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(&model.GlobalTransaction{
+					GlobalSession:  &apis.GlobalSession{XID: xid, Status: apis.Begin},
+					BranchSessions: map[*apis.BranchSession]bool{},
+				})
+				return &TransactionCoordinator{holder: mockedSessionHolder}
+			},
+			request: &apis.GlobalReportRequest{XID: xid, GlobalStatus: apis.Committing},
+			expectedResult: &apis.GlobalReportResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.GlobalTransactionStatusInvalid,
+				Message: fmt.Sprintf("could not report status %s of global transaction xid = %s, its status is %s",
+					apis.Committing.String(), xid, apis.Begin.String()),
+				GlobalStatus: apis.Begin,
+			},
+		},
+		{
+			name: "test GlobalReport with branches needing phase two",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(&model.GlobalTransaction{
+					GlobalSession: &apis.GlobalSession{XID: xid, Status: apis.Begin},
+					BranchSessions: map[*apis.BranchSession]bool{
+						{XID: xid, BranchID: 1, Type: apis.SAGA}: true,
+						{XID: xid, BranchID: 2, Type: apis.TCC}:  true,
+					},
+				})
+				return &TransactionCoordinator{holder: mockedSessionHolder}
+			},
+			request: &apis.GlobalReportRequest{XID: xid, GlobalStatus: apis.Committed},
+			expectedResult: &apis.GlobalReportResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.GlobalTransactionStatusInvalid,
+				Message: fmt.Sprintf("could not report status %s of global transaction xid = %s, its TCC branch 2 needs phase two",
+					apis.Committed.String(), xid),
+				GlobalStatus: apis.Begin,
+			},
+		},
+		{
+			name: "test GlobalReport with status changed before the lock",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				session := &apis.GlobalSession{XID: xid, Status: apis.Begin, Timeout: 60000}
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				gomock.InOrder(
+					mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(&model.GlobalTransaction{
+						GlobalSession:  session,
+						BranchSessions: map[*apis.BranchSession]bool{},
+					}),
+					mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(&model.GlobalTransaction{
+						GlobalSession:  &apis.GlobalSession{XID: xid, Status: apis.Committing, Timeout: 60000},
+						BranchSessions: map[*apis.BranchSession]bool{},
+					}),
+				)
+
+				mockedLocker := mockserver.NewMockGlobalSessionLocker(ctrl)
+				mockedLocker.EXPECT().TryLock(session, gomock.Any()).Return(true, nil)
+				mockedLocker.EXPECT().Unlock(session)
+
+				return &TransactionCoordinator{holder: mockedSessionHolder, locker: mockedLocker}
+			},
+			request: &apis.GlobalReportRequest{XID: xid, GlobalStatus: apis.RolledBack},
+			expectedResult: &apis.GlobalReportResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.GlobalTransactionStatusInvalid,
+				Message: fmt.Sprintf("could not report status %s of global transaction xid = %s, its status is %s",
+					apis.RolledBack.String(), xid, apis.Committing.String()),
+				GlobalStatus: apis.Committing,
+			},
+		},
+		{
+			name: "test GlobalReport success",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				gt := &model.GlobalTransaction{
+					GlobalSession: &apis.GlobalSession{XID: xid, Status: apis.Begin, Active: true, Timeout: 60000},
+					BranchSessions: map[*apis.BranchSession]bool{
+						{XID: xid, BranchID: 1, Type: apis.SAGA}: true,
+					},
+				}
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(gt).Times(2)
+				mockedSessionHolder.EXPECT().InactiveGlobalSession(gt.GlobalSession).Return(nil)
+				mockedSessionHolder.EXPECT().UpdateGlobalSessionStatus(gt.GlobalSession, apis.RolledBack).Return(nil)
+				mockedSessionHolder.EXPECT().RemoveBranchSession(gt.GlobalSession, gomock.Any()).Return(nil)
+				mockedSessionHolder.EXPECT().RemoveGlobalTransaction(gt).Return(nil)
+
+				mockedLockManager := mocklock.NewMockLockManagerInterface(ctrl)
+				mockedLockManager.EXPECT().ReleaseGlobalSessionLock(gt).Return(true)
+
+				mockedLocker := mockserver.NewMockGlobalSessionLocker(ctrl)
+				mockedLocker.EXPECT().TryLock(gt.GlobalSession, gomock.Any()).Return(true, nil)
+				mockedLocker.EXPECT().Unlock(gt.GlobalSession)
+
+				return &TransactionCoordinator{
+					holder:             mockedSessionHolder,
+					resourceDataLocker: mockedLockManager,
+					locker:             mockedLocker,
+				}
+			},
+			request: &apis.GlobalReportRequest{XID: xid, GlobalStatus: apis.RolledBack},
+			expectedResult: &apis.GlobalReportResponse{
+				ResultCode:   apis.ResultCodeSuccess,
+				GlobalStatus: apis.RolledBack,
+			},
+		},
+		{
+			name: "test GlobalReport with the lock lost",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				gt := &model.GlobalTransaction{
+					GlobalSession:  &apis.GlobalSession{XID: xid, Status: apis.Begin, Timeout: 60000},
+					BranchSessions: map[*apis.BranchSession]bool{},
+				}
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(gt).Times(2)
+				mockedSessionHolder.EXPECT().UpdateGlobalSessionStatus(gt.GlobalSession, apis.Committed).Return(nil)
+				mockedSessionHolder.EXPECT().RemoveGlobalTransaction(gt).Return(nil)
+
+				mockedLockManager := mocklock.NewMockLockManagerInterface(ctrl)
+				mockedLockManager.EXPECT().ReleaseGlobalSessionLock(gt).Return(true)
+
+				mockedLocker := mockserver.NewMockGlobalSessionLocker(ctrl)
+				mockedLocker.EXPECT().TryLock(gt.GlobalSession, gomock.Any()).Return(true, nil)
+				mockedLocker.EXPECT().Unlock(gt.GlobalSession).Return(fmt.Errorf("lock lost"))
+
+				return &TransactionCoordinator{
+					holder:             mockedSessionHolder,
+					resourceDataLocker: mockedLockManager,
+					locker:             mockedLocker,
+				}
+			},
+			request: &apis.GlobalReportRequest{XID: xid, GlobalStatus: apis.Committed},
+			expectedResult: &apis.GlobalReportResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       "lock lost",
+				GlobalStatus:  apis.Begin,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := tt.transactionCoordinator(ctrl)
+
+			actualResp, actualErr := tc.GlobalReport(context.Background(), tt.request)
+			assert.NoError(t, actualErr)
+			assert.Equal(t, tt.expectedResult, actualResp)
+		})
+	}
+}
+
 func TestTransactionCoordinator_Commit(t *testing.T) {
-	// todo
+	xid := "localhost:123"
+
+	tests := []struct {
+		name                   string
+		transactionCoordinator func(ctrl *gomock.Controller) *TransactionCoordinator
+		request                *apis.GlobalCommitRequest
+		expectedResult         *apis.GlobalCommitResponse
+	}{
+		{
+			name: "test Commit with the lock lost",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				gt := &model.GlobalTransaction{
+					GlobalSession:  &apis.GlobalSession{XID: xid, Status: apis.Begin, Active: true, Timeout: 60000},
+					BranchSessions: map[*apis.BranchSession]bool{},
+				}
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(gt)
+				mockedSessionHolder.EXPECT().InactiveGlobalSession(gt.GlobalSession).Return(nil)
+				mockedSessionHolder.EXPECT().UpdateGlobalSessionStatus(gt.GlobalSession, apis.Committing).Return(nil)
+
+				mockedLockManager := mocklock.NewMockLockManagerInterface(ctrl)
+				mockedLockManager.EXPECT().ReleaseGlobalSessionLock(gt).Return(true)
+
+				mockedLocker := mockserver.NewMockGlobalSessionLocker(ctrl)
+				mockedLocker.EXPECT().TryLock(gt.GlobalSession, gomock.Any()).Return(true, nil)
+				mockedLocker.EXPECT().Unlock(gt.GlobalSession).Return(fmt.Errorf("lock lost"))
+
+				return &TransactionCoordinator{
+					holder:             mockedSessionHolder,
+					resourceDataLocker: mockedLockManager,
+					locker:             mockedLocker,
+				}
+			},
+			request: &apis.GlobalCommitRequest{XID: xid},
+			expectedResult: &apis.GlobalCommitResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       "lock lost",
+				GlobalStatus:  apis.Begin,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := tt.transactionCoordinator(ctrl)
+
+			actualResp, actualErr := tc.Commit(context.Background(), tt.request)
+			assert.NoError(t, actualErr)
+			assert.Equal(t, tt.expectedResult, actualResp)
+		})
+	}
 }
 
 func TestTransactionCoordinator_Rollback(t *testing.T) {
-	// todo
+	xid := "localhost:123"
+
+	tests := []struct {
+		name                   string
+		transactionCoordinator func(ctrl *gomock.Controller) *TransactionCoordinator
+		request                *apis.GlobalRollbackRequest
+		expectedResult         *apis.GlobalRollbackResponse
+	}{
+		{
+			name: "test Rollback with the lock lost",
+			transactionCoordinator: func(ctrl *gomock.Controller) *TransactionCoordinator {
+				gt := &model.GlobalTransaction{
+					GlobalSession:  &apis.GlobalSession{XID: xid, Status: apis.Begin, Active: true, Timeout: 60000},
+					BranchSessions: map[*apis.BranchSession]bool{},
+				}
+				mockedSessionHolder := mockholder.NewMockSessionHolderInterface(ctrl)
+				mockedSessionHolder.EXPECT().FindGlobalTransaction(xid).Return(gt)
+				mockedSessionHolder.EXPECT().InactiveGlobalSession(gt.GlobalSession).Return(nil)
+				mockedSessionHolder.EXPECT().UpdateGlobalSessionStatus(gt.GlobalSession, apis.RollingBack).Return(nil)
+
+				mockedLocker := mockserver.NewMockGlobalSessionLocker(ctrl)
+				mockedLocker.EXPECT().TryLock(gt.GlobalSession, gomock.Any()).Return(true, nil)
+				mockedLocker.EXPECT().Unlock(gt.GlobalSession).Return(fmt.Errorf("lock lost"))
+
+				return &TransactionCoordinator{
+					holder: mockedSessionHolder,
+					locker: mockedLocker,
+				}
+			},
+			request: &apis.GlobalRollbackRequest{XID: xid},
+			expectedResult: &apis.GlobalRollbackResponse{
+				ResultCode:    apis.ResultCodeFailed,
+				ExceptionCode: apis.FailedLockGlobalTransaction,
+				Message:       "lock lost",
+				GlobalStatus:  apis.Begin,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := tt.transactionCoordinator(ctrl)
+
+			actualResp, actualErr := tc.Rollback(context.Background(), tt.request)
+			assert.NoError(t, actualErr)
+			assert.Equal(t, tt.expectedResult, actualResp)
+		})
+	}
+}
+
+func TestTransactionCoordinator_HandleRetryCommitting_Timeout(t *testing.T) {
+	tc := newSessionAdminCoordinator(t)
+	tc.activeApplications = &sync.Map{}
+	tc.activeApplications.Store("app1", 1)
+	tc.maxCommitRetryTimeout = 60000
+	bs := &apis.BranchSession{Addressing: "app1", XID: "localhost:8091:2", TransactionID: 2, BranchID: 21,
+		ResourceID: "db", LockKey: "order:2", Type: apis.AT}
+	assert.Nil(t, tc.holder.AddBranchSession(tc.holder.FindGlobalSession(bs.XID), bs))
+	assert.True(t, tc.resourceDataLocker.AcquireLock(bs))
+
+	// the global transaction retried beyond the timeout is removed with its row locks
+	tc.handleRetryCommitting()
+	assert.Nil(t, tc.holder.FindGlobalSession(bs.XID))
+	assert.True(t, tc.resourceDataLocker.IsLockable("localhost:8091:4", "db", "order:2"))
 }
@@ -0,0 +1,610 @@
+package bolt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// The buckets of the driver.
+//
+//	global_sessions   xid -> global session
+//	status_index      status (4 bytes) + xid -> nil
+//	addressing_index  addressing + separator + xid -> nil
+//	branch_sessions   xid + separator + branch id (8 bytes) -> branch session
+//	row_locks         row key -> row lock
+var (
+	globalSessionsBucket  = []byte("global_sessions")
+	statusIndexBucket     = []byte("status_index")
+	addressingIndexBucket = []byte("addressing_index")
+	branchSessionsBucket  = []byte("branch_sessions")
+	rowLocksBucket        = []byte("row_locks")
+
+	buckets = [][]byte{globalSessionsBucket, statusIndexBucket, addressingIndexBucket, branchSessionsBucket, rowLocksBucket}
+)
+
+// separator separates the parts of a composite key, it can not be a part of an xid or an addressing
+const separator = 0
+
+func init() {
+	factory.Register("bolt", &boltFactory{})
+}
+
+type DriverParameters struct {
+	Path        string
+	NoSync      bool
+	OpenTimeout time.Duration
+	QueryLimit  int
+}
+
+// boltFactory implements the factory.StorageDriverFactory interface
+type boltFactory struct{}
+
+func (factory *boltFactory) Create(parameters map[string]interface{}) (storage.Driver, error) {
+	return FromParameters(parameters)
+}
+
+// driver stores the sessions and locks in a bolt database, every change is done in a transaction,
+// so the secondary indexes are always consistent with the sessions.
+type driver struct {
+	db         *bolt.DB
+	queryLimit int
+}
+
+func FromParameters(parameters map[string]interface{}) (storage.Driver, error) {
+	path := parameters["path"]
+	if path == nil {
+		path = "seata.db"
+	}
+
+	var err error
+	noSync := false
+	ns := parameters["nosync"]
+	switch ns := ns.(type) {
+	case string:
+		noSync, err = strconv.ParseBool(ns)
+		if err != nil {
+			log.Error("the nosync parameter should be a boolean")
+		}
+	case bool:
+		noSync = ns
+	case nil:
+		// do nothing
+	default:
+		log.Error("the nosync parameter should be a boolean")
+	}
+
+	openTimeout := time.Second
+	ot := parameters["opentimeout"]
+	switch ot := ot.(type) {
+	case string:
+		openTimeout, err = time.ParseDuration(ot)
+		if err != nil {
+			log.Error("the opentimeout parameter should be a duration")
+		}
+	case time.Duration:
+		openTimeout = ot
+	case nil:
+		// do nothing
+	default:
+		log.Error("the opentimeout parameter should be a duration")
+	}
+
+	queryLimit := 100
+	ql := parameters["querylimit"]
+	switch ql := ql.(type) {
+	case string:
+		queryLimit, err = strconv.Atoi(ql)
+		if err != nil {
+			log.Error("the querylimit parameter should be a integer")
+		}
+	case int:
+		queryLimit = ql
+	case nil:
+		// do nothing
+	default:
+		log.Error("the querylimit parameter should be a integer")
+	}
+
+	driverParameters := DriverParameters{
+		Path:        fmt.Sprint(path),
+		NoSync:      noSync,
+		OpenTimeout: openTimeout,
+		QueryLimit:  queryLimit,
+	}
+
+	return New(driverParameters)
+}
+
+// New constructs a new Driver.
+func New(params DriverParameters) (storage.Driver, error) {
+	if dir := filepath.Dir(params.Path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	db, err := bolt.Open(params.Path, 0600, &bolt.Options{Timeout: params.OpenTimeout})
+	if err != nil {
+		return nil, err
+	}
+	db.NoSync = params.NoSync
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &driver{
+		db:         db,
+		queryLimit: params.QueryLimit,
+	}, nil
+}
+
+// Close closes the database.
+func (driver *driver) Close() error {
+	return driver.db.Close()
+}
+
+// AddGlobalSession adds a global session.
+func (driver *driver) AddGlobalSession(session *apis.GlobalSession) error {
+	return driver.db.Update(func(tx *bolt.Tx) error {
+		if previous := getGlobalSession(tx, session.XID); previous != nil {
+			if err := deleteGlobalSessionIndexes(tx, previous); err != nil {
+				return err
+			}
+		}
+		return putGlobalSession(tx, session)
+	})
+}
+
+// FindGlobalSession finds a global session by xid.
+func (driver *driver) FindGlobalSession(xid string) *apis.GlobalSession {
+	var session *apis.GlobalSession
+	err := driver.db.View(func(tx *bolt.Tx) error {
+		session = getGlobalSession(tx, xid)
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return session
+}
+
+// FindGlobalSessions finds global sessions list by statuses list
+func (driver *driver) FindGlobalSessions(statuses []apis.GlobalSession_GlobalStatus) []*apis.GlobalSession {
+	var sessions []*apis.GlobalSession
+	err := driver.db.View(func(tx *bolt.Tx) error {
+		sessions = findGlobalSessions(tx, statusXIDs(tx, statuses), nil)
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return driver.limit(sessions)
+}
+
+// FindGlobalSessionsWithAddressingIdentities finds global sessions list by addressing identities and statuses list
+func (driver *driver) FindGlobalSessionsWithAddressingIdentities(statuses []apis.GlobalSession_GlobalStatus,
+	addressingIdentities []string) []*apis.GlobalSession {
+	var sessions []*apis.GlobalSession
+	err := driver.db.View(func(tx *bolt.Tx) error {
+		xids := make([]string, 0)
+		for _, addressing := range addressingIdentities {
+			xids = append(xids, scanXIDs(tx.Bucket(addressingIndexBucket), append([]byte(addressing), separator))...)
+		}
+		sessions = findGlobalSessions(tx, xids, func(session *apis.GlobalSession) bool {
+			for _, status := range statuses {
+				if session.Status == status {
+					return true
+				}
+			}
+			return false
+		})
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return driver.limit(sessions)
+}
+
+// AllSessions returns all sessions collection.
+func (driver *driver) AllSessions() []*apis.GlobalSession {
+	sessions := make([]*apis.GlobalSession, 0)
+	err := driver.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(globalSessionsBucket).ForEach(func(k, v []byte) error {
+			session := &apis.GlobalSession{}
+			if err := json.Unmarshal(v, session); err != nil {
+				return err
+			}
+			sessions = append(sessions, session)
+			return nil
+		})
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	sortGlobalSessions(sessions)
+	return driver.limit(sessions)
+}
+
+// UpdateGlobalSessionStatus updates status of global session.
+func (driver *driver) UpdateGlobalSessionStatus(session *apis.GlobalSession, status apis.GlobalSession_GlobalStatus) error {
+	return driver.db.Update(func(tx *bolt.Tx) error {
+		gs := getGlobalSession(tx, session.XID)
+		if gs == nil {
+			return fmt.Errorf("could not find global transaction xid = %s", session.XID)
+		}
+		if err := tx.Bucket(statusIndexBucket).Delete(statusIndexKey(gs.Status, gs.XID)); err != nil {
+			return err
+		}
+		gs.Status = status
+		return putGlobalSession(tx, gs)
+	})
+}
+
+// InactiveGlobalSession inactivates a global session.
+func (driver *driver) InactiveGlobalSession(session *apis.GlobalSession) error {
+	return driver.db.Update(func(tx *bolt.Tx) error {
+		gs := getGlobalSession(tx, session.XID)
+		if gs == nil {
+			return fmt.Errorf("could not find global transaction xid = %s", session.XID)
+		}
+		gs.Active = false
+		return putGlobalSession(tx, gs)
+	})
+}
+
+// RemoveGlobalSession removes a global session.
+func (driver *driver) RemoveGlobalSession(session *apis.GlobalSession) error {
+	return driver.db.Update(func(tx *bolt.Tx) error {
+		gs := getGlobalSession(tx, session.XID)
+		if gs == nil {
+			return nil
+		}
+		if err := deleteGlobalSessionIndexes(tx, gs); err != nil {
+			return err
+		}
+		return tx.Bucket(globalSessionsBucket).Delete([]byte(gs.XID))
+	})
+}
+
+// AddBranchSession adds a branch session.
+func (driver *driver) AddBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	return driver.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(globalSessionsBucket).Get([]byte(globalSession.XID)) == nil {
+			return fmt.Errorf("could not find global transaction xid = %s", globalSession.XID)
+		}
+		return putBranchSession(tx, session)
+	})
+}
+
+// FindBranchSessions finds branch sessions list by xid.
+func (driver *driver) FindBranchSessions(xid string) []*apis.BranchSession {
+	return driver.FindBatchBranchSessions([]string{xid})
+}
+
+// FindBatchBranchSessions finds branch sessions list by xids list.
+func (driver *driver) FindBatchBranchSessions(xids []string) []*apis.BranchSession {
+	branchSessions := make([]*apis.BranchSession, 0)
+	err := driver.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(branchSessionsBucket).Cursor()
+		for _, xid := range xids {
+			prefix := append([]byte(xid), separator)
+			for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+				session := &apis.BranchSession{}
+				if err := json.Unmarshal(v, session); err != nil {
+					return err
+				}
+				branchSessions = append(branchSessions, session)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return branchSessions
+}
+
+// UpdateBranchSessionStatus updates status of branch session.
+func (driver *driver) UpdateBranchSessionStatus(session *apis.BranchSession, status apis.BranchSession_BranchStatus) error {
+	return driver.db.Update(func(tx *bolt.Tx) error {
+		v := tx.Bucket(branchSessionsBucket).Get(branchSessionKey(session.XID, session.BranchID))
+		if v == nil {
+			return nil
+		}
+		bs := &apis.BranchSession{}
+		if err := json.Unmarshal(v, bs); err != nil {
+			return err
+		}
+		bs.Status = status
+		return putBranchSession(tx, bs)
+	})
+}
+
+// RemoveBranchSession removes branch session.
+func (driver *driver) RemoveBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	return driver.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(branchSessionsBucket).Delete(branchSessionKey(session.XID, session.BranchID))
+	})
+}
+
+// AcquireLock acquires row locks.
+func (driver *driver) AcquireLock(rowLocks []*apis.RowLock, skipCheckLock bool) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+	acquired := true
+	err := driver.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(rowLocksBucket)
+		unlocked := rowLocks
+		if !skipCheckLock {
+			unlocked = make([]*apis.RowLock, 0, len(rowLocks))
+			for _, rowLock := range rowLocks {
+				locked, err := conflictRowLock(bucket, rowLock)
+				if err != nil {
+					return err
+				}
+				if locked != nil {
+					log.Infof("row lock [%s] on %s:%s is holding by xid {%s} branchID {%d}", locked.RowKey,
+						rowLock.TableName, rowLock.PK, locked.XID, locked.BranchID)
+					acquired = false
+					return nil
+				}
+				if bucket.Get([]byte(rowLock.RowKey)) == nil {
+					unlocked = append(unlocked, rowLock)
+				}
+			}
+		}
+		for _, rowLock := range unlocked {
+			data, err := json.Marshal(rowLock)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(rowLock.RowKey), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("row locks batch acquire failed, %v, %v", rowLocks, err)
+		return false
+	}
+	return acquired
+}
+
+// ReleaseLock releases locked rows.
+func (driver *driver) ReleaseLock(rowLocks []*apis.RowLock) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+	err := driver.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(rowLocksBucket)
+		for _, rowLock := range rowLocks {
+			locked, err := getRowLock(bucket, rowLock.RowKey)
+			if err != nil {
+				return err
+			}
+			if locked != nil && locked.XID == rowLock.XID {
+				if err := bucket.Delete([]byte(rowLock.RowKey)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+		return false
+	}
+	return true
+}
+
+// IsLockable checks if a global transaction is lockable by xid, resourceID, lockKey.
+func (driver *driver) IsLockable(xid string, resourceID string, lockKey string) bool {
+	rowLocks := storage.CollectRowLocks(lockKey, resourceID, xid)
+	lockable := true
+	err := driver.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(rowLocksBucket)
+		for _, rowLock := range rowLocks {
+			locked, err := conflictRowLock(bucket, rowLock)
+			if err != nil {
+				return err
+			}
+			if locked != nil {
+				lockable = false
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+		return false
+	}
+	return lockable
+}
+
+func (driver *driver) limit(sessions []*apis.GlobalSession) []*apis.GlobalSession {
+	if driver.queryLimit > 0 && len(sessions) > driver.queryLimit {
+		return sessions[:driver.queryLimit]
+	}
+	return sessions
+}
+
+func getGlobalSession(tx *bolt.Tx, xid string) *apis.GlobalSession {
+	v := tx.Bucket(globalSessionsBucket).Get([]byte(xid))
+	if v == nil {
+		return nil
+	}
+	session := &apis.GlobalSession{}
+	if err := json.Unmarshal(v, session); err != nil {
+		log.Errorf("failed to unmarshal global session xid = %s, err: %v", xid, err)
+		return nil
+	}
+	return session
+}
+
+// putGlobalSession stores the global session and indexes it
+func putGlobalSession(tx *bolt.Tx, session *apis.GlobalSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(globalSessionsBucket).Put([]byte(session.XID), data); err != nil {
+		return err
+	}
+	if err := tx.Bucket(statusIndexBucket).Put(statusIndexKey(session.Status, session.XID), nil); err != nil {
+		return err
+	}
+	return tx.Bucket(addressingIndexBucket).Put(addressingIndexKey(session.Addressing, session.XID), nil)
+}
+
+func deleteGlobalSessionIndexes(tx *bolt.Tx, session *apis.GlobalSession) error {
+	if err := tx.Bucket(statusIndexBucket).Delete(statusIndexKey(session.Status, session.XID)); err != nil {
+		return err
+	}
+	return tx.Bucket(addressingIndexBucket).Delete(addressingIndexKey(session.Addressing, session.XID))
+}
+
+// findGlobalSessions loads the global sessions of the xids which match, in the order they begin
+func findGlobalSessions(tx *bolt.Tx, xids []string, match func(session *apis.GlobalSession) bool) []*apis.GlobalSession {
+	sessions := make([]*apis.GlobalSession, 0, len(xids))
+	for _, xid := range xids {
+		session := getGlobalSession(tx, xid)
+		if session != nil && (match == nil || match(session)) {
+			sessions = append(sessions, session)
+		}
+	}
+	sortGlobalSessions(sessions)
+	return sessions
+}
+
+func sortGlobalSessions(sessions []*apis.GlobalSession) {
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].BeginTime != sessions[j].BeginTime {
+			return sessions[i].BeginTime < sessions[j].BeginTime
+		}
+		return sessions[i].XID < sessions[j].XID
+	})
+}
+
+func statusXIDs(tx *bolt.Tx, statuses []apis.GlobalSession_GlobalStatus) []string {
+	xids := make([]string, 0)
+	for _, status := range statuses {
+		xids = append(xids, scanXIDs(tx.Bucket(statusIndexBucket), statusIndexKey(status, ""))...)
+	}
+	return xids
+}
+
+// scanXIDs returns the xids which suffix the index keys with the prefix
+func scanXIDs(bucket *bolt.Bucket, prefix []byte) []string {
+	xids := make([]string, 0)
+	cursor := bucket.Cursor()
+	for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+		xids = append(xids, string(k[len(prefix):]))
+	}
+	return xids
+}
+
+func statusIndexKey(status apis.GlobalSession_GlobalStatus, xid string) []byte {
+	key := make([]byte, 4, 4+len(xid))
+	binary.BigEndian.PutUint32(key, uint32(status))
+	return append(key, xid...)
+}
+
+func addressingIndexKey(addressing string, xid string) []byte {
+	key := make([]byte, 0, len(addressing)+1+len(xid))
+	key = append(key, addressing...)
+	key = append(key, separator)
+	return append(key, xid...)
+}
+
+func branchSessionKey(xid string, branchID int64) []byte {
+	key := make([]byte, len(xid)+1, len(xid)+9)
+	copy(key, xid)
+	key[len(xid)] = separator
+	id := make([]byte, 8)
+	binary.BigEndian.PutUint64(id, uint64(branchID))
+	return append(key, id...)
+}
+
+func putBranchSession(tx *bolt.Tx, session *apis.BranchSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(branchSessionsBucket).Put(branchSessionKey(session.XID, session.BranchID), data)
+}
+
+// conflictRowLock returns a row lock of another xid overlapping the row lock. The row keys of a
+// table share the prefix resource id^^^table name^^^, so a table or pk range lock scans the row
+// locks of its table, and a row lock checks its row key, the table lock and the pk range locks,
+// which are sorted together after the prefix followed by [.
+func conflictRowLock(bucket *bolt.Bucket, rowLock *apis.RowLock) (*apis.RowLock, error) {
+	tablePrefix := rowLock.ResourceID + storage.LockSplit + rowLock.TableName + storage.LockSplit
+	if storage.IsRangeLock(rowLock) {
+		return scanConflictRowLock(bucket, rowLock, []byte(tablePrefix))
+	}
+	for _, rowKey := range []string{rowLock.RowKey, tablePrefix + storage.TableLockPK} {
+		locked, err := getRowLock(bucket, rowKey)
+		if err != nil {
+			return nil, err
+		}
+		if locked != nil && locked.XID != rowLock.XID {
+			return locked, nil
+		}
+	}
+	return scanConflictRowLock(bucket, rowLock, []byte(tablePrefix+storage.RangeLockStart))
+}
+
+// scanConflictRowLock returns a row lock of another xid overlapping the row lock among the row
+// locks whose row key starts with the prefix
+func scanConflictRowLock(bucket *bolt.Bucket, rowLock *apis.RowLock, prefix []byte) (*apis.RowLock, error) {
+	cursor := bucket.Cursor()
+	for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+		locked := &apis.RowLock{}
+		if err := json.Unmarshal(v, locked); err != nil {
+			return nil, err
+		}
+		if locked.XID != rowLock.XID && storage.RowLocksOverlap(locked, rowLock) {
+			return locked, nil
+		}
+	}
+	return nil, nil
+}
+
+func getRowLock(bucket *bolt.Bucket, rowKey string) (*apis.RowLock, error) {
+	v := bucket.Get([]byte(rowKey))
+	if v == nil {
+		return nil, nil
+	}
+	rowLock := &apis.RowLock{}
+	if err := json.Unmarshal(v, rowLock); err != nil {
+		return nil, err
+	}
+	return rowLock, nil
+}
@@ -0,0 +1,153 @@
+package bolt
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/storagetest"
+)
+
+func newTestDriver(t *testing.T, path string) *driver {
+	d, err := FromParameters(map[string]interface{}{
+		"path":   path,
+		"nosync": "true",
+	})
+	assert.Nil(t, err)
+	return d.(*driver)
+}
+
+func TestDriver_GlobalSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seata.db")
+	d := newTestDriver(t, path)
+	gs1 := &apis.GlobalSession{
+		Addressing:      "localhost:8080",
+		XID:             "localhost:8091:1",
+		TransactionID:   1,
+		TransactionName: "createSo",
+		Timeout:         60000,
+		BeginTime:       2,
+		Status:          apis.Begin,
+		Active:          true,
+	}
+	gs2 := &apis.GlobalSession{
+		Addressing:    "localhost:9090",
+		XID:           "localhost:8091:2",
+		TransactionID: 2,
+		BeginTime:     1,
+		Status:        apis.Begin,
+		Active:        true,
+	}
+	assert.Nil(t, d.AddGlobalSession(gs1))
+	assert.Nil(t, d.AddGlobalSession(gs2))
+	assert.Equal(t, gs1, d.FindGlobalSession(gs1.XID))
+	assert.Nil(t, d.FindGlobalSession("localhost:8091:3"))
+
+	sessions := d.AllSessions()
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, gs2.XID, sessions[0].XID)
+
+	assert.Nil(t, d.UpdateGlobalSessionStatus(gs1, apis.Committing))
+	assert.Nil(t, d.InactiveGlobalSession(gs1))
+	assert.NotNil(t, d.UpdateGlobalSessionStatus(&apis.GlobalSession{XID: "localhost:8091:3"}, apis.Committing))
+
+	assert.Len(t, d.FindGlobalSessions([]apis.GlobalSession_GlobalStatus{apis.Begin}), 1)
+	assert.Len(t, d.FindGlobalSessions([]apis.GlobalSession_GlobalStatus{apis.Begin, apis.Committing}), 2)
+	sessions = d.FindGlobalSessionsWithAddressingIdentities(
+		[]apis.GlobalSession_GlobalStatus{apis.Begin, apis.Committing}, []string{"localhost:8080"})
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, gs1.XID, sessions[0].XID)
+	assert.Len(t, d.FindGlobalSessionsWithAddressingIdentities(
+		[]apis.GlobalSession_GlobalStatus{apis.Begin}, []string{"localhost:8080"}), 0)
+
+	// the sessions are persisted
+	assert.Nil(t, d.Close())
+	d = newTestDriver(t, path)
+	defer d.Close()
+	session := d.FindGlobalSession(gs1.XID)
+	assert.Equal(t, apis.Committing, session.Status)
+	assert.False(t, session.Active)
+
+	assert.Nil(t, d.RemoveGlobalSession(gs1))
+	assert.Nil(t, d.FindGlobalSession(gs1.XID))
+	assert.Len(t, d.FindGlobalSessions([]apis.GlobalSession_GlobalStatus{apis.Committing}), 0)
+	assert.Len(t, d.AllSessions(), 1)
+}
+
+func TestDriver_BranchSessions(t *testing.T) {
+	d := newTestDriver(t, filepath.Join(t.TempDir(), "seata.db"))
+	defer d.Close()
+	gs := &apis.GlobalSession{XID: "localhost:8091:1", TransactionID: 1, Status: apis.Begin, Active: true}
+	bs1 := &apis.BranchSession{
+		Addressing:      "localhost:8080",
+		XID:             gs.XID,
+		BranchID:        2,
+		TransactionID:   1,
+		ResourceID:      "db",
+		LockKey:         "product:1",
+		Type:            apis.AT,
+		Status:          apis.Registered,
+		ApplicationData: []byte("data"),
+		AsyncCommit:     true,
+	}
+	bs2 := &apis.BranchSession{XID: gs.XID, BranchID: 3, TransactionID: 1, Type: apis.TCC, Status: apis.Registered}
+	assert.NotNil(t, d.AddBranchSession(gs, bs1))
+	assert.Nil(t, d.AddGlobalSession(gs))
+	assert.Nil(t, d.AddBranchSession(gs, bs1))
+	assert.Nil(t, d.AddBranchSession(gs, bs2))
+
+	assert.Equal(t, []*apis.BranchSession{bs1, bs2}, d.FindBranchSessions(gs.XID))
+
+	assert.Nil(t, d.UpdateBranchSessionStatus(bs2, apis.PhaseOneDone))
+	branchSessions := d.FindBatchBranchSessions([]string{gs.XID, "localhost:8091:2"})
+	assert.Len(t, branchSessions, 2)
+	assert.Equal(t, apis.PhaseOneDone, branchSessions[1].Status)
+
+	assert.Nil(t, d.RemoveBranchSession(gs, bs1))
+	branchSessions = d.FindBranchSessions(gs.XID)
+	assert.Len(t, branchSessions, 1)
+	assert.Equal(t, bs2.BranchID, branchSessions[0].BranchID)
+}
+
+func TestDriver_RowLocks(t *testing.T) {
+	d := newTestDriver(t, filepath.Join(t.TempDir(), "seata.db"))
+	defer d.Close()
+	xid1, xid2 := "localhost:8091:1", "localhost:8091:2"
+
+	assert.True(t, d.AcquireLock(storage.CollectRowLocks("product:1,2", "db", xid1), false))
+	assert.True(t, d.AcquireLock(storage.CollectRowLocks("product:2,3", "db", xid1), false))
+
+	// the batch is all or nothing
+	assert.False(t, d.AcquireLock(storage.CollectRowLocks("product:3,4", "db", xid2), false))
+	assert.True(t, d.IsLockable(xid2, "db", "product:4"))
+	assert.False(t, d.IsLockable(xid2, "db", "product:1"))
+	assert.True(t, d.IsLockable(xid1, "db", "product:1,2,3"))
+
+	// the locks of another xid are not released
+	assert.True(t, d.ReleaseLock(storage.CollectRowLocks("product:1", "db", xid2)))
+	assert.False(t, d.IsLockable(xid2, "db", "product:1"))
+
+	assert.True(t, d.ReleaseLock(storage.CollectRowLocks("product:1,2,3", "db", xid1)))
+	assert.True(t, d.AcquireLock(storage.CollectRowLocks("product:3,4", "db", xid2), false))
+	assert.True(t, d.AcquireLock(storage.CollectRowLocks("product:3", "db", xid1), true))
+	assert.True(t, d.IsLockable(xid1, "db", "product:3"))
+}
+
+func TestDriverSuite(t *testing.T) {
+	storagetest.RunDriverSuite(t, newSuiteDriver)
+}
+
+func TestRangeLockSuite(t *testing.T) {
+	storagetest.RunRangeLockSuite(t, newSuiteDriver)
+}
+
+func newSuiteDriver(t *testing.T) storage.Driver {
+	d := newTestDriver(t, filepath.Join(t.TempDir(), "seata.db"))
+	t.Cleanup(func() {
+		_ = d.Close()
+	})
+	return d
+}
@@ -0,0 +1,473 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/runtime"
+)
+
+const (
+	walFile      = "sessions.wal"
+	snapshotFile = "sessions.snapshot"
+)
+
+func init() {
+	factory.Register("file", &fileFactory{})
+}
+
+type DriverParameters struct {
+	DataDir          string
+	SyncWrite        bool
+	CompactThreshold int64
+	CompactInterval  time.Duration
+	QueryLimit       int
+}
+
+// fileFactory implements the factory.StorageDriverFactory interface
+type fileFactory struct{}
+
+func (factory *fileFactory) Create(parameters map[string]interface{}) (storage.Driver, error) {
+	return FromParameters(parameters)
+}
+
+// driver keeps the sessions and locks in memory and logs every change to a write-ahead log in
+// the data dir. The log is compacted into a snapshot once it exceeds the compact threshold, the
+// state is recovered from the snapshot and the log at start.
+type driver struct {
+	mu               sync.RWMutex
+	dir              string
+	state            *state
+	wal              *wal
+	compactThreshold int64
+	queryLimit       int
+	stop             chan struct{}
+}
+
+func FromParameters(parameters map[string]interface{}) (storage.Driver, error) {
+	dataDir := parameters["datadir"]
+	if dataDir == nil {
+		dataDir = "data"
+	}
+
+	var err error
+	syncWrite := true
+	sw := parameters["syncwrite"]
+	switch sw := sw.(type) {
+	case string:
+		syncWrite, err = strconv.ParseBool(sw)
+		if err != nil {
+			log.Error("the syncwrite parameter should be a boolean")
+		}
+	case bool:
+		syncWrite = sw
+	case nil:
+		// do nothing
+	default:
+		log.Error("the syncwrite parameter should be a boolean")
+	}
+
+	var compactThreshold int64 = 16 * 1024 * 1024
+	ct := parameters["compactthreshold"]
+	switch ct := ct.(type) {
+	case string:
+		compactThreshold, err = strconv.ParseInt(ct, 10, 64)
+		if err != nil {
+			log.Error("the compactthreshold parameter should be a integer")
+		}
+	case int:
+		compactThreshold = int64(ct)
+	case nil:
+		// do nothing
+	default:
+		log.Error("the compactthreshold parameter should be a integer")
+	}
+
+	compactInterval := time.Minute
+	ci := parameters["compactinterval"]
+	switch ci := ci.(type) {
+	case string:
+		compactInterval, err = time.ParseDuration(ci)
+		if err != nil {
+			log.Error("the compactinterval parameter should be a duration")
+		}
+	case time.Duration:
+		compactInterval = ci
+	case nil:
+		// do nothing
+	default:
+		log.Error("the compactinterval parameter should be a duration")
+	}
+
+	queryLimit := 100
+	ql := parameters["querylimit"]
+	switch ql := ql.(type) {
+	case string:
+		queryLimit, err = strconv.Atoi(ql)
+		if err != nil {
+			log.Error("the querylimit parameter should be a integer")
+		}
+	case int:
+		queryLimit = ql
+	case nil:
+		// do nothing
+	default:
+		log.Error("the querylimit parameter should be a integer")
+	}
+
+	driverParameters := DriverParameters{
+		DataDir:          fmt.Sprint(dataDir),
+		SyncWrite:        syncWrite,
+		CompactThreshold: compactThreshold,
+		CompactInterval:  compactInterval,
+		QueryLimit:       queryLimit,
+	}
+
+	return New(driverParameters)
+}
+
+// New constructs a new Driver, it recovers the sessions and locks persisted in the data dir.
+func New(params DriverParameters) (storage.Driver, error) {
+	return newDriver(params)
+}
+
+func newDriver(params DriverParameters) (*driver, error) {
+	if err := os.MkdirAll(params.DataDir, 0755); err != nil {
+		return nil, err
+	}
+
+	s, err := loadSnapshot(filepath.Join(params.DataDir, snapshotFile))
+	if err != nil {
+		return nil, err
+	}
+	w, err := openWAL(filepath.Join(params.DataDir, walFile), params.SyncWrite, func(payload []byte) error {
+		r := &record{}
+		if err := json.Unmarshal(payload, r); err != nil {
+			return err
+		}
+		s.apply(r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d := &driver{
+		dir:              params.DataDir,
+		state:            s,
+		wal:              w,
+		compactThreshold: params.CompactThreshold,
+		queryLimit:       params.QueryLimit,
+		stop:             make(chan struct{}),
+	}
+	if params.CompactInterval > 0 {
+		runtime.GoWithRecover(func() {
+			d.compactLoop(params.CompactInterval)
+		}, nil)
+	}
+	return d, nil
+}
+
+func loadSnapshot(path string) (*state, error) {
+	s := newState()
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(s); err != nil {
+		return nil, fmt.Errorf("failed to load the snapshot %s, err: %v", path, err)
+	}
+	return s, nil
+}
+
+func (driver *driver) compactLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-driver.stop:
+			return
+		case <-ticker.C:
+			driver.mu.Lock()
+			if driver.wal.size >= driver.compactThreshold {
+				if err := driver.compact(); err != nil {
+					log.Errorf("failed to compact the wal, err: %v", err)
+				}
+			}
+			driver.mu.Unlock()
+		}
+	}
+}
+
+// compact writes the state to a new snapshot and then empties the wal, the caller holds the lock.
+func (driver *driver) compact() error {
+	data, err := json.Marshal(driver.state)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(driver.dir, snapshotFile)
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	if err := syncDir(driver.dir); err != nil {
+		return err
+	}
+	return driver.wal.reset()
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// Close compacts the wal and closes it.
+func (driver *driver) Close() error {
+	close(driver.stop)
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if err := driver.compact(); err != nil {
+		log.Errorf("failed to compact the wal, err: %v", err)
+	}
+	return driver.wal.close()
+}
+
+// log appends the record to the wal and applies it, the caller holds the lock.
+func (driver *driver) log(r *record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := driver.wal.append(data); err != nil {
+		return err
+	}
+	driver.state.apply(r)
+	return nil
+}
+
+// AddGlobalSession adds a global session.
+func (driver *driver) AddGlobalSession(session *apis.GlobalSession) error {
+	gs := *session
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	return driver.log(&record{Type: putGlobalSession, GlobalSession: &gs})
+}
+
+// FindGlobalSession finds a global session by xid.
+func (driver *driver) FindGlobalSession(xid string) *apis.GlobalSession {
+	driver.mu.RLock()
+	defer driver.mu.RUnlock()
+	gs, ok := driver.state.GlobalSessions[xid]
+	if !ok {
+		return nil
+	}
+	session := *gs
+	return &session
+}
+
+// FindGlobalSessions finds global sessions list by statuses list
+func (driver *driver) FindGlobalSessions(statuses []apis.GlobalSession_GlobalStatus) []*apis.GlobalSession {
+	driver.mu.RLock()
+	defer driver.mu.RUnlock()
+	return driver.state.findGlobalSessions(func(session *apis.GlobalSession) bool {
+		return containsStatus(statuses, session.Status)
+	}, driver.queryLimit)
+}
+
+// FindGlobalSessionsWithAddressingIdentities finds global sessions list by addressing identities and statuses list
+func (driver *driver) FindGlobalSessionsWithAddressingIdentities(statuses []apis.GlobalSession_GlobalStatus,
+	addressingIdentities []string) []*apis.GlobalSession {
+	driver.mu.RLock()
+	defer driver.mu.RUnlock()
+	return driver.state.findGlobalSessions(func(session *apis.GlobalSession) bool {
+		return containsStatus(statuses, session.Status) && containsAddressing(addressingIdentities, session.Addressing)
+	}, driver.queryLimit)
+}
+
+// AllSessions returns all sessions collection.
+func (driver *driver) AllSessions() []*apis.GlobalSession {
+	driver.mu.RLock()
+	defer driver.mu.RUnlock()
+	return driver.state.findGlobalSessions(func(session *apis.GlobalSession) bool {
+		return true
+	}, driver.queryLimit)
+}
+
+// UpdateGlobalSessionStatus updates status of global session.
+func (driver *driver) UpdateGlobalSessionStatus(session *apis.GlobalSession, status apis.GlobalSession_GlobalStatus) error {
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if _, ok := driver.state.GlobalSessions[session.XID]; !ok {
+		return fmt.Errorf("could not find global transaction xid = %s", session.XID)
+	}
+	return driver.log(&record{Type: updateGlobalSessionStatus, XID: session.XID, GlobalStatus: status})
+}
+
+// InactiveGlobalSession inactivates a global session.
+func (driver *driver) InactiveGlobalSession(session *apis.GlobalSession) error {
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if _, ok := driver.state.GlobalSessions[session.XID]; !ok {
+		return fmt.Errorf("could not find global transaction xid = %s", session.XID)
+	}
+	return driver.log(&record{Type: inactiveGlobalSession, XID: session.XID})
+}
+
+// RemoveGlobalSession removes a global session.
+func (driver *driver) RemoveGlobalSession(session *apis.GlobalSession) error {
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if _, ok := driver.state.GlobalSessions[session.XID]; !ok {
+		return nil
+	}
+	return driver.log(&record{Type: removeGlobalSession, XID: session.XID})
+}
+
+// AddBranchSession adds a branch session.
+func (driver *driver) AddBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	bs := *session
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if _, ok := driver.state.GlobalSessions[globalSession.XID]; !ok {
+		return fmt.Errorf("could not find global transaction xid = %s", globalSession.XID)
+	}
+	return driver.log(&record{Type: putBranchSession, BranchSession: &bs})
+}
+
+// FindBranchSessions finds branch sessions list by xid.
+func (driver *driver) FindBranchSessions(xid string) []*apis.BranchSession {
+	driver.mu.RLock()
+	defer driver.mu.RUnlock()
+	return driver.state.findBranchSessions(xid)
+}
+
+// FindBatchBranchSessions finds branch sessions list by xids list.
+func (driver *driver) FindBatchBranchSessions(xids []string) []*apis.BranchSession {
+	driver.mu.RLock()
+	defer driver.mu.RUnlock()
+	return driver.state.findBranchSessions(xids...)
+}
+
+// UpdateBranchSessionStatus updates status of branch session.
+func (driver *driver) UpdateBranchSessionStatus(session *apis.BranchSession, status apis.BranchSession_BranchStatus) error {
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if _, ok := driver.state.BranchSessions[session.XID][session.BranchID]; !ok {
+		return fmt.Errorf("could not find branch session xid = %s branchID = %d", session.XID, session.BranchID)
+	}
+	return driver.log(&record{
+		Type:         updateBranchSessionStatus,
+		XID:          session.XID,
+		BranchID:     session.BranchID,
+		BranchStatus: status,
+	})
+}
+
+// RemoveBranchSession removes branch session.
+func (driver *driver) RemoveBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if _, ok := driver.state.BranchSessions[session.XID][session.BranchID]; !ok {
+		return nil
+	}
+	return driver.log(&record{Type: removeBranchSession, XID: session.XID, BranchID: session.BranchID})
+}
+
+// AcquireLock acquires row locks.
+func (driver *driver) AcquireLock(rowLocks []*apis.RowLock, skipCheckLock bool) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+
+	unlocked := rowLocks
+	if !skipCheckLock {
+		var ok bool
+		unlocked, ok = driver.state.unlockedRows(rowLocks)
+		if !ok {
+			log.Infof("row locks %v are holding by other global transactions", rowLocks)
+			return false
+		}
+		if len(unlocked) == 0 {
+			return true
+		}
+	}
+	if err := driver.log(&record{Type: putRowLocks, RowLocks: unlocked}); err != nil {
+		log.Errorf("row locks batch acquire failed, %v, %v", unlocked, err)
+		return false
+	}
+	return true
+}
+
+// ReleaseLock releases locked rows.
+func (driver *driver) ReleaseLock(rowLocks []*apis.RowLock) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if err := driver.log(&record{Type: removeRowLocks, RowLocks: rowLocks}); err != nil {
+		log.Errorf(err.Error())
+		return false
+	}
+	return true
+}
+
+// IsLockable checks if a global transaction is lockable by xid, resourceID, lockKey.
+func (driver *driver) IsLockable(xid string, resourceID string, lockKey string) bool {
+	driver.mu.RLock()
+	defer driver.mu.RUnlock()
+	_, ok := driver.state.unlockedRows(storage.CollectRowLocks(lockKey, resourceID, xid))
+	return ok
+}
+
+func containsStatus(statuses []apis.GlobalSession_GlobalStatus, status apis.GlobalSession_GlobalStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAddressing(addressingIdentities []string, addressing string) bool {
+	for _, s := range addressingIdentities {
+		if s == addressing {
+			return true
+		}
+	}
+	return false
+}
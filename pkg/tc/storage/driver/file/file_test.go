@@ -0,0 +1,144 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+)
+
+func newTestDriver(t *testing.T, dir string) *driver {
+	d, err := newDriver(DriverParameters{
+		DataDir:          dir,
+		SyncWrite:        true,
+		CompactThreshold: 1024,
+		QueryLimit:       100,
+	})
+	assert.Nil(t, err)
+	return d
+}
+
+func addTestTransaction(t *testing.T, d *driver) (*apis.GlobalSession, *apis.BranchSession) {
+	gs := &apis.GlobalSession{
+		Addressing:    "localhost:8080",
+		XID:           "localhost:8091:1",
+		TransactionID: 1,
+		Timeout:       60000,
+		BeginTime:     1,
+		Status:        apis.Begin,
+		Active:        true,
+	}
+	bs := &apis.BranchSession{
+		Addressing:      "localhost:8080",
+		XID:             gs.XID,
+		BranchID:        2,
+		TransactionID:   1,
+		ResourceID:      "db",
+		LockKey:         "product:1,2",
+		Type:            apis.AT,
+		Status:          apis.Registered,
+		ApplicationData: []byte("data"),
+	}
+	assert.Nil(t, d.AddGlobalSession(gs))
+	assert.Nil(t, d.AddBranchSession(gs, bs))
+	assert.True(t, d.AcquireLock(storage.CollectBranchSessionRowLocks(bs), false))
+	assert.Nil(t, d.UpdateGlobalSessionStatus(gs, apis.Committing))
+	assert.Nil(t, d.UpdateBranchSessionStatus(bs, apis.PhaseOneDone))
+	return gs, bs
+}
+
+func assertRecovered(t *testing.T, d *driver, gs *apis.GlobalSession) {
+	session := d.FindGlobalSession(gs.XID)
+	assert.NotNil(t, session)
+	assert.Equal(t, apis.Committing, session.Status)
+	assert.True(t, session.Active)
+
+	branchSessions := d.FindBranchSessions(gs.XID)
+	assert.Len(t, branchSessions, 1)
+	assert.Equal(t, apis.PhaseOneDone, branchSessions[0].Status)
+	assert.Equal(t, []byte("data"), branchSessions[0].ApplicationData)
+
+	assert.False(t, d.IsLockable("localhost:8091:3", "db", "product:2"))
+	assert.True(t, d.IsLockable(gs.XID, "db", "product:2"))
+}
+
+func TestDriver_RecoverFromWAL(t *testing.T) {
+	dir := t.TempDir()
+	d := newTestDriver(t, dir)
+	gs, _ := addTestTransaction(t, d)
+	// simulate a crash, the wal is not compacted
+	assert.Nil(t, d.wal.close())
+
+	recovered := newTestDriver(t, dir)
+	defer recovered.Close()
+	assertRecovered(t, recovered, gs)
+}
+
+func TestDriver_RecoverFromSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	d := newTestDriver(t, dir)
+	gs, _ := addTestTransaction(t, d)
+	assert.Nil(t, d.Close())
+
+	info, err := os.Stat(filepath.Join(dir, walFile))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), info.Size())
+
+	recovered := newTestDriver(t, dir)
+	assertRecovered(t, recovered, gs)
+
+	// the changes after the snapshot are replayed on it
+	assert.Nil(t, recovered.InactiveGlobalSession(gs))
+	assert.Nil(t, recovered.wal.close())
+
+	recovered = newTestDriver(t, dir)
+	defer recovered.Close()
+	assert.False(t, recovered.FindGlobalSession(gs.XID).Active)
+}
+
+func TestDriver_TruncateTornRecord(t *testing.T) {
+	dir := t.TempDir()
+	d := newTestDriver(t, dir)
+	gs, bs := addTestTransaction(t, d)
+	size := d.wal.size
+	assert.Nil(t, d.RemoveBranchSession(gs, bs))
+	assert.Nil(t, d.wal.close())
+
+	// cut the last record in the middle
+	path := filepath.Join(dir, walFile)
+	assert.Nil(t, os.Truncate(path, size+5))
+
+	recovered := newTestDriver(t, dir)
+	assert.Equal(t, size, recovered.wal.size)
+	assertRecovered(t, recovered, gs)
+
+	// the records appended after the truncation are recovered
+	assert.Nil(t, recovered.RemoveBranchSession(gs, bs))
+	assert.Nil(t, recovered.wal.close())
+	recovered = newTestDriver(t, dir)
+	defer recovered.Close()
+	assert.Len(t, recovered.FindBranchSessions(gs.XID), 0)
+}
+
+func TestDriver_RowLocks(t *testing.T) {
+	d := newTestDriver(t, t.TempDir())
+	defer d.Close()
+	gs, bs := addTestTransaction(t, d)
+
+	conflict := storage.CollectRowLocks("product:2,3", "db", "localhost:8091:3")
+	assert.False(t, d.AcquireLock(conflict, false))
+	assert.True(t, d.IsLockable("localhost:8091:3", "db", "product:3"))
+	assert.True(t, d.AcquireLock(storage.CollectRowLocks("product:2,3", "db", gs.XID), false))
+
+	// the locks of another xid are not released
+	assert.True(t, d.ReleaseLock(conflict))
+	assert.False(t, d.IsLockable("localhost:8091:3", "db", "product:3"))
+
+	assert.True(t, d.ReleaseLock(storage.CollectBranchSessionRowLocks(bs)))
+	assert.True(t, d.ReleaseLock(storage.CollectRowLocks("product:3", "db", gs.XID)))
+	assert.True(t, d.IsLockable("localhost:8091:3", "db", "product:1,2,3"))
+}
@@ -0,0 +1,152 @@
+package file
+
+import (
+	"sort"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+)
+
+// recordType the type of a change logged by the wal
+type recordType byte
+
+const (
+	putGlobalSession recordType = iota + 1
+	updateGlobalSessionStatus
+	inactiveGlobalSession
+	removeGlobalSession
+	putBranchSession
+	updateBranchSessionStatus
+	removeBranchSession
+	putRowLocks
+	removeRowLocks
+)
+
+// record a change of the state. Applying a record is idempotent, the records logged before a
+// compaction which has not reset the wal are replayed on the snapshot at the next start.
+type record struct {
+	Type          recordType                      `json:"type"`
+	XID           string                          `json:"xid,omitempty"`
+	BranchID      int64                           `json:"branchID,omitempty"`
+	GlobalSession *apis.GlobalSession             `json:"globalSession,omitempty"`
+	BranchSession *apis.BranchSession             `json:"branchSession,omitempty"`
+	GlobalStatus  apis.GlobalSession_GlobalStatus `json:"globalStatus,omitempty"`
+	BranchStatus  apis.BranchSession_BranchStatus `json:"branchStatus,omitempty"`
+	RowLocks      []*apis.RowLock                 `json:"rowLocks,omitempty"`
+}
+
+// state the sessions and locks, it is also the content of a snapshot
+type state struct {
+	// GlobalSessions xid -> global session
+	GlobalSessions map[string]*apis.GlobalSession `json:"globalSessions"`
+
+	// BranchSessions xid -> branch id -> branch session
+	BranchSessions map[string]map[int64]*apis.BranchSession `json:"branchSessions"`
+
+	// RowLocks row key -> row lock
+	RowLocks *storage.RowLockTable `json:"rowLocks"`
+}
+
+func newState() *state {
+	return &state{
+		GlobalSessions: make(map[string]*apis.GlobalSession),
+		BranchSessions: make(map[string]map[int64]*apis.BranchSession),
+		RowLocks:       storage.NewRowLockTable(),
+	}
+}
+
+func (s *state) apply(r *record) {
+	switch r.Type {
+	case putGlobalSession:
+		s.GlobalSessions[r.GlobalSession.XID] = r.GlobalSession
+	case updateGlobalSessionStatus:
+		if gs, ok := s.GlobalSessions[r.XID]; ok {
+			gs.Status = r.GlobalStatus
+		}
+	case inactiveGlobalSession:
+		if gs, ok := s.GlobalSessions[r.XID]; ok {
+			gs.Active = false
+		}
+	case removeGlobalSession:
+		delete(s.GlobalSessions, r.XID)
+	case putBranchSession:
+		branchSessions, ok := s.BranchSessions[r.BranchSession.XID]
+		if !ok {
+			branchSessions = make(map[int64]*apis.BranchSession)
+			s.BranchSessions[r.BranchSession.XID] = branchSessions
+		}
+		branchSessions[r.BranchSession.BranchID] = r.BranchSession
+	case updateBranchSessionStatus:
+		if bs, ok := s.BranchSessions[r.XID][r.BranchID]; ok {
+			bs.Status = r.BranchStatus
+		}
+	case removeBranchSession:
+		branchSessions := s.BranchSessions[r.XID]
+		delete(branchSessions, r.BranchID)
+		if len(branchSessions) == 0 {
+			delete(s.BranchSessions, r.XID)
+		}
+	case putRowLocks:
+		for _, rowLock := range r.RowLocks {
+			s.RowLocks.Put(rowLock)
+		}
+	case removeRowLocks:
+		for _, rowLock := range r.RowLocks {
+			s.RowLocks.Remove(rowLock)
+		}
+	}
+}
+
+// unlockedRows returns the row locks not held by the xid yet, ok is false if any of the rows is
+// held by another xid, including the rows of a table or pk range lock
+func (s *state) unlockedRows(rowLocks []*apis.RowLock) (unlocked []*apis.RowLock, ok bool) {
+	unlocked = make([]*apis.RowLock, 0, len(rowLocks))
+	for _, rowLock := range rowLocks {
+		if s.RowLocks.Conflict(rowLock) != nil {
+			return nil, false
+		}
+		if s.RowLocks.Get(rowLock) == nil {
+			unlocked = append(unlocked, rowLock)
+		}
+	}
+	return unlocked, true
+}
+
+// findGlobalSessions return copies of the global sessions matched in the order they begin, at
+// most limit of them if limit is positive
+func (s *state) findGlobalSessions(match func(session *apis.GlobalSession) bool, limit int) []*apis.GlobalSession {
+	sessions := make([]*apis.GlobalSession, 0)
+	for _, gs := range s.GlobalSessions {
+		if match(gs) {
+			session := *gs
+			sessions = append(sessions, &session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].BeginTime != sessions[j].BeginTime {
+			return sessions[i].BeginTime < sessions[j].BeginTime
+		}
+		return sessions[i].XID < sessions[j].XID
+	})
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions
+}
+
+// findBranchSessions return copies of the branch sessions of the xids in the order they are registered
+func (s *state) findBranchSessions(xids ...string) []*apis.BranchSession {
+	branchSessions := make([]*apis.BranchSession, 0)
+	for _, xid := range xids {
+		start := len(branchSessions)
+		for _, bs := range s.BranchSessions[xid] {
+			session := *bs
+			branchSessions = append(branchSessions, &session)
+		}
+		registered := branchSessions[start:]
+		sort.Slice(registered, func(i, j int) bool {
+			return registered[i].BranchID < registered[j].BranchID
+		})
+	}
+	return branchSessions
+}
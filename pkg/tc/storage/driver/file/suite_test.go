@@ -0,0 +1,27 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/storagetest"
+)
+
+func TestDriverSuite(t *testing.T) {
+	storagetest.RunDriverSuite(t, newSuiteDriver)
+}
+
+func TestRangeLockSuite(t *testing.T) {
+	storagetest.RunRangeLockSuite(t, newSuiteDriver)
+}
+
+func newSuiteDriver(t *testing.T) storage.Driver {
+	d, err := newDriver(DriverParameters{DataDir: t.TempDir(), QueryLimit: 100})
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		_ = d.Close()
+	})
+	return d
+}
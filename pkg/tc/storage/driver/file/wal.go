@@ -0,0 +1,127 @@
+package file
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// recordHeaderSize a record is framed by its length and the crc32 checksum of its payload
+const recordHeaderSize = 8
+
+var errCorruptedRecord = errors.New("corrupted wal record")
+
+// wal an append-only log of the changes since the last compaction
+type wal struct {
+	file     *os.File
+	size     int64
+	syncEach bool
+}
+
+// openWAL opens the log and replays the records by the callback, a torn or corrupted tail left by
+// a crash is truncated.
+func openWAL(path string, syncEach bool, replay func(payload []byte) error) (*wal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		payload, err := readRecord(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Warnf("truncate the wal %s at offset %d, err: %v", path, offset, err)
+			if err := file.Truncate(offset); err != nil {
+				file.Close()
+				return nil, err
+			}
+			break
+		}
+		if err := replay(payload); err != nil {
+			file.Close()
+			return nil, err
+		}
+		offset += int64(recordHeaderSize + len(payload))
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &wal{file: file, size: offset, syncEach: syncEach}, nil
+}
+
+func readRecord(reader io.Reader) ([]byte, error) {
+	header := make([]byte, recordHeaderSize)
+	n, err := io.ReadFull(reader, header)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: read %d header bytes", errCorruptedRecord, n)
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	checksum := binary.BigEndian.Uint32(header[4:])
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", errCorruptedRecord, err)
+	}
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", errCorruptedRecord)
+	}
+	return payload, nil
+}
+
+// append writes a record, it is synced to the disk before returning if syncEach is set
+func (w *wal) append(payload []byte) error {
+	record := make([]byte, recordHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(record[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload))
+	copy(record[recordHeaderSize:], payload)
+
+	n, err := w.file.Write(record)
+	if err != nil {
+		// drop the partial record, so the following records are not appended after garbage
+		if n > 0 {
+			if e := w.file.Truncate(w.size); e == nil {
+				_, _ = w.file.Seek(w.size, io.SeekStart)
+			}
+		}
+		return err
+	}
+	w.size += int64(n)
+	if w.syncEach {
+		return w.file.Sync()
+	}
+	return nil
+}
+
+// reset empties the log once its records are compacted into a snapshot
+func (w *wal) reset() error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w.size = 0
+	return w.file.Sync()
+}
+
+func (w *wal) close() error {
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
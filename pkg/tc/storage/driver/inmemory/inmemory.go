@@ -22,6 +22,7 @@ func (factory *inMemoryFactory) Create(parameters map[string]interface{}) (stora
 	return &driver{
 		SessionMap: &sync.Map{},
 		LockMap:    &sync.Map{},
+		rowLocks:   storage.NewRowLockTable(),
 	}, nil
 }
 
@@ -30,6 +31,12 @@ type driver struct {
 	SessionMap *sync.Map
 
 	LockMap *sync.Map
+
+	// lockMutex makes acquiring a batch of row locks all or nothing
+	lockMutex sync.Mutex
+
+	// rowLocks the row locks held, guarded by lockMutex
+	rowLocks *storage.RowLockTable
 }
 
 // Add global session.
@@ -184,6 +191,13 @@ func (driver *driver) FindBatchBranchSessions(xids []string) []*apis.BranchSessi
 // Update branch session status.
 func (driver *driver) UpdateBranchSessionStatus(session *apis.BranchSession, status apis.BranchSession_BranchStatus) error {
 	session.Status = status
+	globalTransaction, ok := driver.SessionMap.Load(session.XID)
+	if ok {
+		gt := globalTransaction.(*model.GlobalTransaction)
+		if bs := gt.GetBranch(session.BranchID); bs != nil {
+			bs.Status = status
+		}
+	}
 	return nil
 }
 
@@ -192,7 +206,9 @@ func (driver *driver) RemoveBranchSession(globalSession *apis.GlobalSession, ses
 	globalTransaction, ok := driver.SessionMap.Load(globalSession.XID)
 	if ok {
 		gt := globalTransaction.(*model.GlobalTransaction)
-		delete(gt.BranchSessions, session)
+		if bs := gt.GetBranch(session.BranchID); bs != nil {
+			gt.Remove(bs)
+		}
 		return nil
 	}
 	return fmt.Errorf("could not find global transaction xid = %s", session.XID)
@@ -204,19 +220,20 @@ func (driver *driver) AcquireLock(rowLocks []*apis.RowLock, skipCheckLock bool)
 		return true
 	}
 
-	for _, rowLock := range rowLocks {
-		previousLockTransactionID, loaded := driver.LockMap.LoadOrStore(rowLock.RowKey, rowLock.TransactionID)
-		if loaded {
-			if previousLockTransactionID == rowLock.TransactionID {
-				// Locked by me before
-				continue
-			} else {
-				log.Infof("Global rowLock on [%s:%s] is holding by %d", rowLock.TableName, rowLock.PK, previousLockTransactionID)
-				driver.ReleaseLock(rowLocks)
+	driver.lockMutex.Lock()
+	defer driver.lockMutex.Unlock()
+	if !skipCheckLock {
+		for _, rowLock := range rowLocks {
+			if held := driver.rowLocks.Conflict(rowLock); held != nil {
+				log.Infof("Global rowLock on [%s:%s] is holding by %d", rowLock.TableName, rowLock.PK, held.TransactionID)
 				return false
 			}
 		}
 	}
+	for _, rowLock := range rowLocks {
+		driver.LockMap.Store(rowLock.RowKey, rowLock.TransactionID)
+		driver.rowLocks.Put(rowLock)
+	}
 
 	return true
 }
@@ -227,10 +244,13 @@ func (driver *driver) ReleaseLock(rowLocks []*apis.RowLock) bool {
 		return true
 	}
 
+	driver.lockMutex.Lock()
+	defer driver.lockMutex.Unlock()
 	for _, rowLock := range rowLocks {
 		lockedTransactionID, loaded := driver.LockMap.Load(rowLock.RowKey)
 		if loaded && lockedTransactionID == rowLock.TransactionID {
 			driver.LockMap.Delete(rowLock.RowKey)
+			driver.rowLocks.Remove(rowLock)
 		}
 	}
 
@@ -240,11 +260,19 @@ func (driver *driver) ReleaseLock(rowLocks []*apis.RowLock) bool {
 // IsLockable Is lockable boolean.
 func (driver *driver) IsLockable(xid string, resourceID string, lockKey string) bool {
 	rowLocks := storage.CollectRowLocks(lockKey, resourceID, xid)
+	driver.lockMutex.Lock()
+	defer driver.lockMutex.Unlock()
 	for _, rowLock := range rowLocks {
-		lockedTransactionID, loaded := driver.LockMap.Load(rowLock.RowKey)
-		if loaded && lockedTransactionID != rowLock.TransactionID {
+		if driver.rowLocks.Conflict(rowLock) != nil {
 			return false
 		}
 	}
 	return true
 }
+
+// ListRowLocks lists the row locks matching the query.
+func (driver *driver) ListRowLocks(query storage.RowLockQuery) ([]*apis.RowLock, error) {
+	driver.lockMutex.Lock()
+	defer driver.lockMutex.Unlock()
+	return driver.rowLocks.List(query), nil
+}
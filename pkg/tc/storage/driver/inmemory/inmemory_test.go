@@ -0,0 +1,24 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/storagetest"
+)
+
+func newTestDriver(t *testing.T) storage.Driver {
+	driver, err := (&inMemoryFactory{}).Create(nil)
+	assert.Nil(t, err)
+	return driver
+}
+
+func TestDriverSuite(t *testing.T) {
+	storagetest.RunDriverSuite(t, newTestDriver)
+}
+
+func TestRangeLockSuite(t *testing.T) {
+	storagetest.RunRangeLockSuite(t, newTestDriver)
+}
@@ -0,0 +1,106 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/migration"
+)
+
+const (
+	CreateGlobalTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			addressing varchar(128) NOT NULL,
+			xid varchar(128) NOT NULL,
+			transaction_id bigint DEFAULT NULL,
+			transaction_name varchar(128) DEFAULT NULL,
+			timeout int DEFAULT NULL,
+			begin_time bigint DEFAULT NULL,
+			status tinyint NOT NULL,
+			active bit(1) NOT NULL,
+			gmt_create datetime DEFAULT NULL,
+			gmt_modified datetime DEFAULT NULL,
+			PRIMARY KEY (xid),
+			KEY idx_gmt_modified_status (gmt_modified, status),
+			KEY idx_transaction_id (transaction_id)
+		) ENGINE = InnoDB DEFAULT CHARSET = utf8;`
+
+	CreateBranchTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			addressing varchar(128) NOT NULL,
+			xid varchar(128) NOT NULL,
+			branch_id bigint NOT NULL,
+			transaction_id bigint DEFAULT NULL,
+			resource_id varchar(256) DEFAULT NULL,
+			lock_key VARCHAR(1000),
+			branch_type varchar(8) DEFAULT NULL,
+			status tinyint DEFAULT NULL,
+			application_data varchar(2000) DEFAULT NULL,
+			async_commit tinyint NOT NULL DEFAULT 0,
+			gmt_create datetime(6) DEFAULT NULL,
+			gmt_modified datetime(6) DEFAULT NULL,
+			PRIMARY KEY (branch_id),
+			KEY idx_xid (xid)
+		) ENGINE = InnoDB DEFAULT CHARSET = utf8;`
+
+	CreateLockTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			row_key        VARCHAR(256) NOT NULL,
+			xid            VARCHAR(128) NOT NULL,
+			transaction_id BIGINT,
+			branch_id      BIGINT       NOT NULL,
+			resource_id    VARCHAR(256),
+			table_name     VARCHAR(64),
+			pk             VARCHAR(36),
+			gmt_create     DATETIME,
+			gmt_modified   DATETIME,
+			PRIMARY KEY (row_key),
+			KEY idx_branch_id (branch_id)
+		) ENGINE = InnoDB DEFAULT CHARSET = utf8;`
+
+	WidenLockTablePK = `ALTER TABLE %s MODIFY pk VARCHAR(128), ADD KEY idx_resource_table (resource_id, table_name);`
+
+	CreateGlobalSessionLockTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			xid         VARCHAR(128) NOT NULL,
+			owner       VARCHAR(128) NOT NULL,
+			expire_time BIGINT       NOT NULL,
+			PRIMARY KEY (xid)
+		) ENGINE = InnoDB DEFAULT CHARSET = utf8;`
+)
+
+// migrations upgrade the schema of the mysql storage driver, append a new migration to change the schema.
+var migrations = []migration.Migration{
+	{
+		Version:     1,
+		Description: "create the global, branch and lock tables",
+		Up: func(tables migration.Tables) []string {
+			return []string{
+				fmt.Sprintf(CreateGlobalTable, tables.GlobalTable),
+				fmt.Sprintf(CreateBranchTable, tables.BranchTable),
+				fmt.Sprintf(CreateLockTable, tables.LockTable),
+			}
+		},
+	},
+	{
+		Version:     2,
+		Description: "widen the pk of the lock table for the pk range locks and index the locks by table",
+		Up: func(tables migration.Tables) []string {
+			return []string{
+				fmt.Sprintf(WidenLockTablePK, tables.LockTable),
+			}
+		},
+	},
+	{
+		Version:     3,
+		Description: "create the lease table of the database global session locker",
+		Up: func(tables migration.Tables) []string {
+			return []string{
+				fmt.Sprintf(CreateGlobalSessionLockTable, tables.GlobalSessionLockTable),
+			}
+		},
+	},
+}
@@ -0,0 +1,108 @@
+package pgsql
+
+import (
+	"fmt"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/migration"
+)
+
+const (
+	CreateGlobalTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			addressing varchar(128) NOT NULL,
+			xid varchar(128) NOT NULL,
+			transaction_id bigint DEFAULT NULL,
+			transaction_name varchar(128) DEFAULT NULL,
+			timeout int DEFAULT NULL,
+			begin_time bigint DEFAULT NULL,
+			status int NOT NULL,
+			active bool NOT NULL,
+			gmt_create timestamp DEFAULT NULL,
+			gmt_modified timestamp DEFAULT NULL,
+			PRIMARY KEY (xid)
+		);
+		CREATE INDEX IF NOT EXISTS idx_gmt_modified_status ON %s(gmt_modified, status);
+		CREATE INDEX IF NOT EXISTS idx_transaction_id ON %s(transaction_id);`
+
+	CreateBranchTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			addressing varchar(128) NOT NULL,
+			xid varchar(128) NOT NULL,
+			branch_id bigint NOT NULL,
+			transaction_id bigint DEFAULT NULL,
+			resource_id varchar(256) DEFAULT NULL,
+			lock_key VARCHAR(1000),
+			branch_type varchar(8) DEFAULT NULL,
+			status int DEFAULT NULL,
+			application_data varchar(2000) DEFAULT NULL,
+			async_commit tinyint NOT NULL DEFAULT 0,
+			gmt_create timestamp DEFAULT NULL,
+			gmt_modified timestamp DEFAULT NULL,
+			PRIMARY KEY (branch_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_xid ON %s(xid);`
+
+	CreateLockTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			row_key        VARCHAR(256) NOT NULL,
+			xid            VARCHAR(96),
+			transaction_id BIGINT,
+			branch_id      BIGINT NOT NULL,
+			resource_id    VARCHAR(256),
+			table_name     VARCHAR(64),
+			pk             VARCHAR(36),
+			gmt_create     TIMESTAMP,
+			gmt_modified   TIMESTAMP,
+			PRIMARY KEY (row_key)
+		);
+		CREATE INDEX IF NOT EXISTS idx_branch_id ON %s(branch_id);`
+
+	WidenLockTablePK = `
+		ALTER TABLE %s ALTER COLUMN pk TYPE VARCHAR(128);
+		CREATE INDEX IF NOT EXISTS idx_%s_resource_table ON %s(resource_id, table_name);`
+
+	CreateGlobalSessionLockTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			xid         VARCHAR(128) NOT NULL,
+			owner       VARCHAR(128) NOT NULL,
+			expire_time BIGINT       NOT NULL,
+			PRIMARY KEY (xid)
+		);`
+)
+
+// migrations upgrade the schema of the pgsql storage driver, append a new migration to change the schema.
+var migrations = []migration.Migration{
+	{
+		Version:     1,
+		Description: "create the global, branch and lock tables",
+		Up: func(tables migration.Tables) []string {
+			return []string{
+				fmt.Sprintf(CreateGlobalTable, tables.GlobalTable, tables.GlobalTable, tables.GlobalTable),
+				fmt.Sprintf(CreateBranchTable, tables.BranchTable, tables.BranchTable),
+				fmt.Sprintf(CreateLockTable, tables.LockTable, tables.LockTable),
+			}
+		},
+	},
+	{
+		Version:     2,
+		Description: "widen the pk of the lock table for the pk range locks and index the locks by table",
+		Up: func(tables migration.Tables) []string {
+			return []string{
+				fmt.Sprintf(WidenLockTablePK, tables.LockTable, tables.LockTable, tables.LockTable),
+			}
+		},
+	},
+	{
+		Version:     3,
+		Description: "create the lease table of the database global session locker",
+		Up: func(tables migration.Tables) []string {
+			return []string{
+				fmt.Sprintf(CreateGlobalSessionLockTable, tables.GlobalSessionLockTable),
+			}
+		},
+	},
+}
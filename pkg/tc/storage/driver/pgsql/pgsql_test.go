@@ -0,0 +1,76 @@
+package pgsql
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-xorm/xorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	_ "github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/sqlite" // register the stand-in database
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/storagetest"
+)
+
+// newTestDriver creates a driver on the database of the PGSQL_DSN environment variable, every driver
+// creates its own tables and drops them afterwards. Without PGSQL_DSN the queries shared by the sql
+// drivers run on a sqlite stand-in database, the pgsql schema is not covered then.
+func newTestDriver(t *testing.T) storage.Driver {
+	dsn := os.Getenv("PGSQL_DSN")
+	if dsn == "" {
+		return newStandInDriver(t)
+	}
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	tables := []string{"global_table_" + suffix, "branch_table_" + suffix, "lock_table_" + suffix,
+		"schema_version_" + suffix, "global_session_lock_" + suffix}
+	d, err := FromParameters(map[string]interface{}{
+		"dsn":                    dsn,
+		"globaltable":            tables[0],
+		"branchtable":            tables[1],
+		"locktable":              tables[2],
+		"versiontable":           tables[3],
+		"globalsessionlocktable": tables[4],
+	})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		_ = d.(io.Closer).Close()
+		engine, err := xorm.NewEngine("postgres", dsn)
+		if err != nil {
+			return
+		}
+		for _, table := range tables {
+			_, _ = engine.Exec(fmt.Sprintf("DROP TABLE %s", table))
+		}
+		_ = engine.Close()
+	})
+	return d
+}
+
+func newStandInDriver(t *testing.T) storage.Driver {
+	d, err := factory.Create("sqlite", map[string]interface{}{
+		"dsn": filepath.Join(t.TempDir(), "seata.db"),
+	})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		_ = d.(io.Closer).Close()
+	})
+	return d
+}
+
+func TestDriverSuite(t *testing.T) {
+	storagetest.RunDriverSuite(t, newTestDriver)
+}
+
+func TestRangeLockSuite(t *testing.T) {
+	storagetest.RunRangeLockSuite(t, newTestDriver)
+}
@@ -0,0 +1,237 @@
+package raft
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// forwardRPC the first byte of a connection forwarding commands to the leader, the raft rpc types
+// start from 0, so it tells the forward connections from the raft ones sharing the listener.
+const forwardRPC byte = 0xff
+
+// forwardResponse the result of a command applied by the leader for a follower
+type forwardResponse struct {
+	// Index the index of the raft log the command is applied at
+	Index uint64 `json:"index"`
+
+	// Result the result of the command, a bool or nil
+	Result interface{} `json:"result,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// dialFunc dials the node at the raft address
+type dialFunc func(address raft.ServerAddress, timeout time.Duration) (net.Conn, error)
+
+// forward sends the command to the leader, then waits until this node applies it too, so that a
+// read after the write sees it.
+func (driver *driver) forward(cmd *command) (interface{}, error) {
+	leader := driver.raft.Leader()
+	if leader == "" {
+		return nil, raft.ErrNotLeader
+	}
+	conn, err := driver.dial(leader, driver.applyTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(driver.applyTimeout)); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(append([]byte{forwardRPC}, data...)); err != nil {
+		return nil, err
+	}
+	resp := &forwardResponse{}
+	if err := json.NewDecoder(conn).Decode(resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		if resp.Error == raft.ErrNotLeader.Error() {
+			return nil, raft.ErrNotLeader
+		}
+		return nil, errors.New(resp.Error)
+	}
+
+	driver.waitApplied(resp.Index)
+	return resp.Result, nil
+}
+
+// waitApplied waits at most the apply timeout for the fsm to apply the log at the index, the
+// command is committed already, so a follower lagging behind only logs it.
+func (driver *driver) waitApplied(index uint64) {
+	deadline := time.Now().Add(driver.applyTimeout)
+	for driver.fsm.appliedIndex() < index {
+		if time.Now().After(deadline) {
+			log.Warnf("the raft log at index %d is not applied in %s", index, driver.applyTimeout)
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// serveForward applies the commands forwarded by the followers until the stream layer is closed
+func (driver *driver) serveForward(layer *muxStreamLayer) {
+	for {
+		select {
+		case conn := <-layer.forwards:
+			go driver.handleForward(conn)
+		case <-layer.closed:
+			return
+		}
+	}
+}
+
+// handleForward applies a command forwarded by a follower, the forward byte is read already.
+func (driver *driver) handleForward(conn net.Conn) {
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(driver.applyTimeout)); err != nil {
+		return
+	}
+	cmd := &command{}
+	if err := json.NewDecoder(conn).Decode(cmd); err != nil {
+		log.Errorf("failed to decode the forwarded raft command, err: %v", err)
+		return
+	}
+	resp := &forwardResponse{}
+	index, result, err := driver.applyLocal(cmd)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Index, resp.Result = index, result
+	}
+	data, err := json.Marshal(resp)
+	if err == nil {
+		_, err = conn.Write(data)
+	}
+	if err != nil {
+		log.Errorf("failed to reply the forwarded raft command, err: %v", err)
+	}
+}
+
+// muxStreamLayer shares a tcp listener between the raft transport and the forward connections, it
+// implements the raft.StreamLayer interface.
+type muxStreamLayer struct {
+	listener  net.Listener
+	advertise net.Addr
+
+	// conns the raft connections accepted
+	conns chan net.Conn
+
+	// forwards the forward connections accepted
+	forwards chan net.Conn
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newMuxStreamLayer(bindAddress string, advertise net.Addr) (*muxStreamLayer, error) {
+	listener, err := net.Listen("tcp", bindAddress)
+	if err != nil {
+		return nil, err
+	}
+	if advertise == nil {
+		advertise = listener.Addr()
+	}
+	layer := &muxStreamLayer{
+		listener:  listener,
+		advertise: advertise,
+		conns:     make(chan net.Conn),
+		forwards:  make(chan net.Conn),
+		closed:    make(chan struct{}),
+	}
+	go layer.serve()
+	return layer, nil
+}
+
+// serve accepts the connections until the listener is closed
+func (layer *muxStreamLayer) serve() {
+	for {
+		conn, err := layer.listener.Accept()
+		if err != nil {
+			select {
+			case <-layer.closed:
+				return
+			default:
+			}
+			log.Errorf("failed to accept raft connection, err: %v", err)
+			continue
+		}
+		go layer.dispatch(conn)
+	}
+}
+
+// dispatch reads the first byte of the connection to tell a forward connection from a raft one
+func (layer *muxStreamLayer) dispatch(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	if err := conn.SetReadDeadline(time.Now().Add(transportTimeout)); err != nil {
+		conn.Close()
+		return
+	}
+	first, err := reader.Peek(1)
+	if err != nil || conn.SetReadDeadline(time.Time{}) != nil {
+		conn.Close()
+		return
+	}
+
+	target := layer.conns
+	if first[0] == forwardRPC {
+		_, _ = reader.Discard(1)
+		target = layer.forwards
+	}
+	select {
+	case target <- &bufferedConn{Conn: conn, reader: reader}:
+	case <-layer.closed:
+		conn.Close()
+	}
+}
+
+// Accept waits for the next raft connection
+func (layer *muxStreamLayer) Accept() (net.Conn, error) {
+	select {
+	case conn := <-layer.conns:
+		return conn, nil
+	case <-layer.closed:
+		return nil, errors.New("raft stream layer is closed")
+	}
+}
+
+func (layer *muxStreamLayer) Close() error {
+	var err error
+	layer.closeOnce.Do(func() {
+		close(layer.closed)
+		err = layer.listener.Close()
+	})
+	return err
+}
+
+func (layer *muxStreamLayer) Addr() net.Addr {
+	return layer.advertise
+}
+
+func (layer *muxStreamLayer) Dial(address raft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	return dialer.Dial("tcp", string(address))
+}
+
+// bufferedConn reads the bytes peeked by dispatch before the rest of the connection
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (conn *bufferedConn) Read(b []byte) (int, error) {
+	return conn.reader.Read(b)
+}
@@ -0,0 +1,277 @@
+package raft
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/raft"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// commandType the type of a command replicated by the raft log
+type commandType byte
+
+const (
+	addGlobalSession commandType = iota + 1
+	updateGlobalSessionStatus
+	inactiveGlobalSession
+	removeGlobalSession
+	addBranchSession
+	updateBranchSessionStatus
+	removeBranchSession
+	acquireLock
+	releaseLock
+)
+
+// command a change of the storage, it is applied to the fsm of every node in the same order
+type command struct {
+	Type          commandType                     `json:"type"`
+	GlobalSession *apis.GlobalSession             `json:"globalSession,omitempty"`
+	BranchSession *apis.BranchSession             `json:"branchSession,omitempty"`
+	GlobalStatus  apis.GlobalSession_GlobalStatus `json:"globalStatus,omitempty"`
+	BranchStatus  apis.BranchSession_BranchStatus `json:"branchStatus,omitempty"`
+	RowLocks      []*apis.RowLock                 `json:"rowLocks,omitempty"`
+	SkipCheckLock bool                            `json:"skipCheckLock,omitempty"`
+}
+
+// fsmState the state of the storage, it is also the content of a snapshot
+type fsmState struct {
+	// GlobalSessions xid -> global session
+	GlobalSessions map[string]*apis.GlobalSession `json:"globalSessions"`
+
+	// BranchSessions xid -> branch id -> branch session
+	BranchSessions map[string]map[int64]*apis.BranchSession `json:"branchSessions"`
+
+	// RowLocks row key -> row lock
+	RowLocks *storage.RowLockTable `json:"rowLocks"`
+}
+
+func newFSMState() *fsmState {
+	return &fsmState{
+		GlobalSessions: make(map[string]*apis.GlobalSession),
+		BranchSessions: make(map[string]map[int64]*apis.BranchSession),
+		RowLocks:       storage.NewRowLockTable(),
+	}
+}
+
+// fsm applies the committed commands to the state, the sessions it returns are copies so that
+// the callers can not change the state without replicating the change.
+type fsm struct {
+	mu    sync.RWMutex
+	state *fsmState
+
+	// index the index of the last raft log applied
+	index uint64
+}
+
+func newFSM() *fsm {
+	return &fsm{state: newFSMState()}
+}
+
+// Apply applies a committed command, it returns an error or the result of the command
+func (f *fsm) Apply(entry *raft.Log) interface{} {
+	defer atomic.StoreUint64(&f.index, entry.Index)
+	cmd := &command{}
+	if err := json.Unmarshal(entry.Data, cmd); err != nil {
+		log.Errorf("failed to unmarshal raft command at index %d, err: %v", entry.Index, err)
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state := f.state
+
+	switch cmd.Type {
+	case addGlobalSession:
+		state.GlobalSessions[cmd.GlobalSession.XID] = cmd.GlobalSession
+		return nil
+	case updateGlobalSessionStatus:
+		gs, ok := state.GlobalSessions[cmd.GlobalSession.XID]
+		if !ok {
+			return fmt.Errorf("could not find global transaction xid = %s", cmd.GlobalSession.XID)
+		}
+		gs.Status = cmd.GlobalStatus
+		return nil
+	case inactiveGlobalSession:
+		gs, ok := state.GlobalSessions[cmd.GlobalSession.XID]
+		if !ok {
+			return fmt.Errorf("could not find global transaction xid = %s", cmd.GlobalSession.XID)
+		}
+		gs.Active = false
+		return nil
+	case removeGlobalSession:
+		delete(state.GlobalSessions, cmd.GlobalSession.XID)
+		return nil
+	case addBranchSession:
+		if _, ok := state.GlobalSessions[cmd.GlobalSession.XID]; !ok {
+			return fmt.Errorf("could not find global transaction xid = %s", cmd.GlobalSession.XID)
+		}
+		branchSessions, ok := state.BranchSessions[cmd.BranchSession.XID]
+		if !ok {
+			branchSessions = make(map[int64]*apis.BranchSession)
+			state.BranchSessions[cmd.BranchSession.XID] = branchSessions
+		}
+		branchSessions[cmd.BranchSession.BranchID] = cmd.BranchSession
+		return nil
+	case updateBranchSessionStatus:
+		bs, ok := state.BranchSessions[cmd.BranchSession.XID][cmd.BranchSession.BranchID]
+		if !ok {
+			return fmt.Errorf("could not find branch session xid = %s branchID = %d", cmd.BranchSession.XID,
+				cmd.BranchSession.BranchID)
+		}
+		bs.Status = cmd.BranchStatus
+		return nil
+	case removeBranchSession:
+		branchSessions := state.BranchSessions[cmd.BranchSession.XID]
+		delete(branchSessions, cmd.BranchSession.BranchID)
+		if len(branchSessions) == 0 {
+			delete(state.BranchSessions, cmd.BranchSession.XID)
+		}
+		return nil
+	case acquireLock:
+		return f.acquireLock(cmd.RowLocks, cmd.SkipCheckLock)
+	case releaseLock:
+		for _, rowLock := range cmd.RowLocks {
+			state.RowLocks.Remove(rowLock)
+		}
+		return true
+	default:
+		return fmt.Errorf("unknown raft command type %d", cmd.Type)
+	}
+}
+
+// appliedIndex returns the index of the last raft log applied
+func (f *fsm) appliedIndex() uint64 {
+	return atomic.LoadUint64(&f.index)
+}
+
+// acquireLock locks all the rows or none of them, a row locked by the same xid is lockable
+func (f *fsm) acquireLock(rowLocks []*apis.RowLock, skipCheckLock bool) bool {
+	if !skipCheckLock {
+		for _, rowLock := range rowLocks {
+			if locked := f.state.RowLocks.Conflict(rowLock); locked != nil {
+				log.Infof("row lock [%s] on %s:%s is holding by xid {%s} branchID {%d}", locked.RowKey, rowLock.TableName,
+					rowLock.PK, locked.XID, locked.BranchID)
+				return false
+			}
+		}
+	}
+	for _, rowLock := range rowLocks {
+		if skipCheckLock || f.state.RowLocks.Get(rowLock) == nil {
+			f.state.RowLocks.Put(rowLock)
+		}
+	}
+	return true
+}
+
+// Snapshot copies the state, it is persisted while new commands are applied
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	data, err := json.Marshal(f.state)
+	if err != nil {
+		return nil, err
+	}
+	return &fsmSnapshot{data: data}, nil
+}
+
+// Restore replaces the state with a snapshot
+func (f *fsm) Restore(snapshot io.ReadCloser) error {
+	defer snapshot.Close()
+	state := newFSMState()
+	if err := json.NewDecoder(snapshot).Decode(state); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.state = state
+	return nil
+}
+
+func (f *fsm) findGlobalSession(xid string) *apis.GlobalSession {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	gs, ok := f.state.GlobalSessions[xid]
+	if !ok {
+		return nil
+	}
+	session := *gs
+	return &session
+}
+
+// findGlobalSessions return the global sessions matched in the order they begin, at most limit
+// of them if limit is positive
+func (f *fsm) findGlobalSessions(match func(session *apis.GlobalSession) bool, limit int) []*apis.GlobalSession {
+	f.mu.RLock()
+	sessions := make([]*apis.GlobalSession, 0)
+	for _, gs := range f.state.GlobalSessions {
+		if match(gs) {
+			session := *gs
+			sessions = append(sessions, &session)
+		}
+	}
+	f.mu.RUnlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].BeginTime != sessions[j].BeginTime {
+			return sessions[i].BeginTime < sessions[j].BeginTime
+		}
+		return sessions[i].XID < sessions[j].XID
+	})
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions
+}
+
+// findBranchSessions return the branch sessions of the xids in the order they are registered
+func (f *fsm) findBranchSessions(xids ...string) []*apis.BranchSession {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	branchSessions := make([]*apis.BranchSession, 0)
+	for _, xid := range xids {
+		start := len(branchSessions)
+		for _, bs := range f.state.BranchSessions[xid] {
+			session := *bs
+			branchSessions = append(branchSessions, &session)
+		}
+		registered := branchSessions[start:]
+		sort.Slice(registered, func(i, j int) bool {
+			return registered[i].BranchID < registered[j].BranchID
+		})
+	}
+	return branchSessions
+}
+
+func (f *fsm) isLockable(rowLocks []*apis.RowLock) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, rowLock := range rowLocks {
+		if f.state.RowLocks.Conflict(rowLock) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type fsmSnapshot struct {
+	data []byte
+}
+
+func (snapshot *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	if _, err := sink.Write(snapshot.data); err != nil {
+		_ = sink.Cancel()
+		return err
+	}
+	return sink.Close()
+}
+
+func (snapshot *fsmSnapshot) Release() {}
@@ -0,0 +1,512 @@
+package raft
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	raftboltdb "github.com/hashicorp/raft-boltdb/v2"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+const (
+	raftLogFile         = "raft.db"
+	retainSnapshotCount = 2
+	maxTransportPool    = 3
+	transportTimeout    = 10 * time.Second
+)
+
+func init() {
+	factory.Register("raft", &raftFactory{})
+}
+
+// DriverParameters the parameters of a raft node, the nodes of a cluster are listed by Peers
+// as "id=address" pairs separated by commas.
+type DriverParameters struct {
+	NodeID            string
+	BindAddress       string
+	AdvertiseAddress  string
+	DataDir           string
+	Peers             map[string]string
+	Bootstrap         bool
+	ApplyTimeout      time.Duration
+	SnapshotInterval  time.Duration
+	SnapshotThreshold uint64
+	QueryLimit        int
+}
+
+// raftFactory implements the factory.StorageDriverFactory interface
+type raftFactory struct{}
+
+func (factory *raftFactory) Create(parameters map[string]interface{}) (storage.Driver, error) {
+	return FromParameters(parameters)
+}
+
+// driver replicates the changes of the storage to the nodes of a raft cluster. The changes are
+// applied by the leader, a follower forwards the changes made while the leadership moves to the
+// leader on the raft listener and waits to apply them too. The sessions and locks are read from the
+// local state machine.
+//
+// Only the storage is replicated: the lock waits and the wait-for graph of the lock table, the
+// global session locks and the branch streams are kept in the memory of the TC node serving them.
+// So the TC serves the clients and runs its timeout and retry loops on the leader only, the
+// followers refuse the calls as unavailable and report unhealthy, the clients connect to the
+// leader through a load balancer checking the health of the nodes. The memory state is lost when
+// the leader fails, the clients reconnect to the new leader and the global transactions are
+// retried from the replicated storage.
+type driver struct {
+	raft         *raft.Raft
+	fsm          *fsm
+	applyTimeout time.Duration
+	queryLimit   int
+
+	// dial connects to the leader to forward the changes, the changes are not forwarded if it is nil
+	dial dialFunc
+
+	// closers the transport and stores closed after the raft node is shut down
+	closers []io.Closer
+}
+
+func FromParameters(parameters map[string]interface{}) (storage.Driver, error) {
+	nodeID := parameters["nodeid"]
+	if nodeID == nil {
+		nodeID = ""
+	}
+
+	bindAddress := parameters["bindaddress"]
+	if bindAddress == nil {
+		bindAddress = "127.0.0.1:7091"
+	}
+
+	advertiseAddress := parameters["advertiseaddress"]
+	if advertiseAddress == nil {
+		advertiseAddress = bindAddress
+	}
+
+	dataDir := parameters["datadir"]
+	if dataDir == nil {
+		dataDir = filepath.Join("raft", fmt.Sprint(nodeID))
+	}
+
+	peers, err := parsePeers(parameters["peers"])
+	if err != nil {
+		return nil, err
+	}
+
+	bootstrap := false
+	bs := parameters["bootstrap"]
+	switch bs := bs.(type) {
+	case string:
+		bootstrap, err = strconv.ParseBool(bs)
+		if err != nil {
+			log.Error("the bootstrap parameter should be a boolean")
+		}
+	case bool:
+		bootstrap = bs
+	case nil:
+		// do nothing
+	default:
+		log.Error("the bootstrap parameter should be a boolean")
+	}
+
+	applyTimeout := 5 * time.Second
+	at := parameters["applytimeout"]
+	switch at := at.(type) {
+	case string:
+		applyTimeout, err = time.ParseDuration(at)
+		if err != nil {
+			log.Error("the applytimeout parameter should be a duration")
+		}
+	case time.Duration:
+		applyTimeout = at
+	case nil:
+		// do nothing
+	default:
+		log.Error("the applytimeout parameter should be a duration")
+	}
+
+	snapshotInterval := 2 * time.Minute
+	si := parameters["snapshotinterval"]
+	switch si := si.(type) {
+	case string:
+		snapshotInterval, err = time.ParseDuration(si)
+		if err != nil {
+			log.Error("the snapshotinterval parameter should be a duration")
+		}
+	case time.Duration:
+		snapshotInterval = si
+	case nil:
+		// do nothing
+	default:
+		log.Error("the snapshotinterval parameter should be a duration")
+	}
+
+	var snapshotThreshold uint64 = 8192
+	st := parameters["snapshotthreshold"]
+	switch st := st.(type) {
+	case string:
+		snapshotThreshold, err = strconv.ParseUint(st, 10, 64)
+		if err != nil {
+			log.Error("the snapshotthreshold parameter should be a integer")
+		}
+	case int:
+		snapshotThreshold = uint64(st)
+	case nil:
+		// do nothing
+	default:
+		log.Error("the snapshotthreshold parameter should be a integer")
+	}
+
+	queryLimit := 100
+	ql := parameters["querylimit"]
+	switch ql := ql.(type) {
+	case string:
+		queryLimit, err = strconv.Atoi(ql)
+		if err != nil {
+			log.Error("the querylimit parameter should be a integer")
+		}
+	case int:
+		queryLimit = ql
+	case nil:
+		// do nothing
+	default:
+		log.Error("the querylimit parameter should be a integer")
+	}
+
+	driverParameters := DriverParameters{
+		NodeID:            fmt.Sprint(nodeID),
+		BindAddress:       fmt.Sprint(bindAddress),
+		AdvertiseAddress:  fmt.Sprint(advertiseAddress),
+		DataDir:           fmt.Sprint(dataDir),
+		Peers:             peers,
+		Bootstrap:         bootstrap,
+		ApplyTimeout:      applyTimeout,
+		SnapshotInterval:  snapshotInterval,
+		SnapshotThreshold: snapshotThreshold,
+		QueryLimit:        queryLimit,
+	}
+
+	return New(driverParameters)
+}
+
+// parsePeers parses the "id=address" pairs separated by commas
+func parsePeers(peers interface{}) (map[string]string, error) {
+	result := make(map[string]string)
+	if peers == nil {
+		return result, nil
+	}
+	for _, peer := range strings.Split(fmt.Sprint(peers), ",") {
+		peer = strings.TrimSpace(peer)
+		if peer == "" {
+			continue
+		}
+		idx := strings.Index(peer, "=")
+		if idx <= 0 || idx == len(peer)-1 {
+			return nil, fmt.Errorf("the peer %s should be formatted as id=address", peer)
+		}
+		result[peer[:idx]] = peer[idx+1:]
+	}
+	return result, nil
+}
+
+// New constructs a new Driver, it starts a raft node which stores its log and snapshots in the data dir.
+func New(params DriverParameters) (storage.Driver, error) {
+	if params.NodeID == "" {
+		return nil, fmt.Errorf("the nodeid parameter should not be empty")
+	}
+	if err := os.MkdirAll(params.DataDir, 0755); err != nil {
+		return nil, err
+	}
+
+	addr, err := net.ResolveTCPAddr("tcp", params.AdvertiseAddress)
+	if err != nil {
+		return nil, err
+	}
+	layer, err := newMuxStreamLayer(params.BindAddress, addr)
+	if err != nil {
+		return nil, err
+	}
+	transport := raft.NewNetworkTransport(layer, maxTransportPool, transportTimeout, os.Stderr)
+	store, err := raftboltdb.NewBoltStore(filepath.Join(params.DataDir, raftLogFile))
+	if err != nil {
+		transport.Close()
+		return nil, err
+	}
+	snapshots, err := raft.NewFileSnapshotStore(params.DataDir, retainSnapshotCount, os.Stderr)
+	if err != nil {
+		transport.Close()
+		store.Close()
+		return nil, err
+	}
+
+	d, err := newDriver(params, store, store, snapshots, transport)
+	if err != nil {
+		transport.Close()
+		store.Close()
+		return nil, err
+	}
+	d.dial = layer.Dial
+	d.closers = []io.Closer{transport, store}
+	go d.serveForward(layer)
+	return d, nil
+}
+
+func newDriver(params DriverParameters, logs raft.LogStore, stable raft.StableStore,
+	snapshots raft.SnapshotStore, transport raft.Transport) (*driver, error) {
+	conf := raft.DefaultConfig()
+	conf.LocalID = raft.ServerID(params.NodeID)
+	if params.SnapshotInterval > 0 {
+		conf.SnapshotInterval = params.SnapshotInterval
+	}
+	if params.SnapshotThreshold > 0 {
+		conf.SnapshotThreshold = params.SnapshotThreshold
+	}
+	conf.Logger = hclog.New(&hclog.LoggerOptions{
+		Name:  "raft",
+		Level: hclog.Warn,
+	})
+
+	f := newFSM()
+	r, err := raft.NewRaft(conf, f, logs, stable, snapshots, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Bootstrap {
+		hasState, err := raft.HasExistingState(logs, stable, snapshots)
+		if err != nil {
+			return nil, err
+		}
+		if !hasState {
+			configuration := raft.Configuration{
+				Servers: []raft.Server{{ID: conf.LocalID, Address: transport.LocalAddr()}},
+			}
+			for id, address := range params.Peers {
+				if raft.ServerID(id) == conf.LocalID {
+					continue
+				}
+				configuration.Servers = append(configuration.Servers, raft.Server{
+					ID:      raft.ServerID(id),
+					Address: raft.ServerAddress(address),
+				})
+			}
+			if err := r.BootstrapCluster(configuration).Error(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	applyTimeout := params.ApplyTimeout
+	if applyTimeout <= 0 {
+		applyTimeout = 5 * time.Second
+	}
+	return &driver{
+		raft:         r,
+		fsm:          f,
+		applyTimeout: applyTimeout,
+		queryLimit:   params.QueryLimit,
+	}, nil
+}
+
+// IsLeader returns whether this node is the leader of the raft cluster.
+func (driver *driver) IsLeader() bool {
+	return driver.raft.State() == raft.Leader
+}
+
+// Shutdown stops the raft node.
+func (driver *driver) Shutdown() error {
+	err := driver.raft.Shutdown().Error()
+	for _, closer := range driver.closers {
+		if e := closer.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// apply replicates a command and returns the result of applying it to the state machine, a
+// follower forwards the command to the leader.
+func (driver *driver) apply(cmd *command) (interface{}, error) {
+	if driver.dial != nil && !driver.IsLeader() {
+		return driver.forward(cmd)
+	}
+	_, result, err := driver.applyLocal(cmd)
+	return result, err
+}
+
+// applyLocal applies a command on this node, which should be the leader, it returns the index of
+// the raft log the command is applied at.
+func (driver *driver) applyLocal(cmd *command) (uint64, interface{}, error) {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return 0, nil, err
+	}
+	future := driver.raft.Apply(data, driver.applyTimeout)
+	if err := future.Error(); err != nil {
+		return 0, nil, err
+	}
+	if err, ok := future.Response().(error); ok {
+		return 0, nil, err
+	}
+	return future.Index(), future.Response(), nil
+}
+
+// Close shuts the raft node down.
+func (driver *driver) Close() error {
+	return driver.Shutdown()
+}
+
+// AddGlobalSession adds a global session.
+func (driver *driver) AddGlobalSession(session *apis.GlobalSession) error {
+	_, err := driver.apply(&command{Type: addGlobalSession, GlobalSession: session})
+	return err
+}
+
+// FindGlobalSession finds a global session by xid.
+func (driver *driver) FindGlobalSession(xid string) *apis.GlobalSession {
+	return driver.fsm.findGlobalSession(xid)
+}
+
+// FindGlobalSessions finds global sessions list by statuses list
+func (driver *driver) FindGlobalSessions(statuses []apis.GlobalSession_GlobalStatus) []*apis.GlobalSession {
+	return driver.fsm.findGlobalSessions(func(session *apis.GlobalSession) bool {
+		return containsStatus(statuses, session.Status)
+	}, driver.queryLimit)
+}
+
+// FindGlobalSessionsWithAddressingIdentities finds global sessions list by addressing identities and statuses list
+func (driver *driver) FindGlobalSessionsWithAddressingIdentities(statuses []apis.GlobalSession_GlobalStatus,
+	addressingIdentities []string) []*apis.GlobalSession {
+	return driver.fsm.findGlobalSessions(func(session *apis.GlobalSession) bool {
+		return containsStatus(statuses, session.Status) && containsAddressing(addressingIdentities, session.Addressing)
+	}, driver.queryLimit)
+}
+
+// AllSessions returns all sessions collection.
+func (driver *driver) AllSessions() []*apis.GlobalSession {
+	return driver.fsm.findGlobalSessions(func(session *apis.GlobalSession) bool {
+		return true
+	}, driver.queryLimit)
+}
+
+// UpdateGlobalSessionStatus updates status of global session.
+func (driver *driver) UpdateGlobalSessionStatus(session *apis.GlobalSession, status apis.GlobalSession_GlobalStatus) error {
+	_, err := driver.apply(&command{
+		Type:          updateGlobalSessionStatus,
+		GlobalSession: &apis.GlobalSession{XID: session.XID},
+		GlobalStatus:  status,
+	})
+	return err
+}
+
+// InactiveGlobalSession inactivates a global session.
+func (driver *driver) InactiveGlobalSession(session *apis.GlobalSession) error {
+	_, err := driver.apply(&command{Type: inactiveGlobalSession, GlobalSession: &apis.GlobalSession{XID: session.XID}})
+	return err
+}
+
+// RemoveGlobalSession removes a global session.
+func (driver *driver) RemoveGlobalSession(session *apis.GlobalSession) error {
+	_, err := driver.apply(&command{Type: removeGlobalSession, GlobalSession: &apis.GlobalSession{XID: session.XID}})
+	return err
+}
+
+// AddBranchSession adds a branch session.
+func (driver *driver) AddBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	_, err := driver.apply(&command{
+		Type:          addBranchSession,
+		GlobalSession: &apis.GlobalSession{XID: globalSession.XID},
+		BranchSession: session,
+	})
+	return err
+}
+
+// FindBranchSessions finds branch sessions list by xid.
+func (driver *driver) FindBranchSessions(xid string) []*apis.BranchSession {
+	return driver.fsm.findBranchSessions(xid)
+}
+
+// FindBatchBranchSessions finds branch sessions list by xids list.
+func (driver *driver) FindBatchBranchSessions(xids []string) []*apis.BranchSession {
+	return driver.fsm.findBranchSessions(xids...)
+}
+
+// UpdateBranchSessionStatus updates status of branch session.
+func (driver *driver) UpdateBranchSessionStatus(session *apis.BranchSession, status apis.BranchSession_BranchStatus) error {
+	_, err := driver.apply(&command{
+		Type:          updateBranchSessionStatus,
+		BranchSession: &apis.BranchSession{XID: session.XID, BranchID: session.BranchID},
+		BranchStatus:  status,
+	})
+	return err
+}
+
+// RemoveBranchSession removes branch session.
+func (driver *driver) RemoveBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	_, err := driver.apply(&command{
+		Type:          removeBranchSession,
+		BranchSession: &apis.BranchSession{XID: session.XID, BranchID: session.BranchID},
+	})
+	return err
+}
+
+// AcquireLock acquires row locks.
+func (driver *driver) AcquireLock(rowLocks []*apis.RowLock, skipCheckLock bool) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+	result, err := driver.apply(&command{Type: acquireLock, RowLocks: rowLocks, SkipCheckLock: skipCheckLock})
+	if err != nil {
+		log.Errorf("row locks batch acquire failed, %v, %v", rowLocks, err)
+		return false
+	}
+	return result.(bool)
+}
+
+// ReleaseLock releases locked rows.
+func (driver *driver) ReleaseLock(rowLocks []*apis.RowLock) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+	_, err := driver.apply(&command{Type: releaseLock, RowLocks: rowLocks})
+	if err != nil {
+		log.Errorf(err.Error())
+		return false
+	}
+	return true
+}
+
+// IsLockable checks if a global transaction is lockable by xid, resourceID, lockKey.
+func (driver *driver) IsLockable(xid string, resourceID string, lockKey string) bool {
+	return driver.fsm.isLockable(storage.CollectRowLocks(lockKey, resourceID, xid))
+}
+
+func containsStatus(statuses []apis.GlobalSession_GlobalStatus, status apis.GlobalSession_GlobalStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAddressing(addressingIdentities []string, addressing string) bool {
+	for _, s := range addressingIdentities {
+		if s == addressing {
+			return true
+		}
+	}
+	return false
+}
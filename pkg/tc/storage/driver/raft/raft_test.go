@@ -0,0 +1,283 @@
+package raft
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/storagetest"
+)
+
+type testNode struct {
+	driver    *driver
+	transport *raft.InmemTransport
+}
+
+// newTestCluster starts a cluster of in-process nodes connected by in-memory transports
+func newTestCluster(t *testing.T, size int) []*testNode {
+	nodes := make([]*testNode, 0, size)
+	peers := make(map[string]string)
+	for i := 0; i < size; i++ {
+		addr, transport := raft.NewInmemTransport("")
+		peers[fmt.Sprintf("node%d", i)] = string(addr)
+		nodes = append(nodes, &testNode{transport: transport})
+	}
+	for _, node := range nodes {
+		for _, peer := range nodes {
+			if peer != node {
+				node.transport.Connect(peer.transport.LocalAddr(), peer.transport)
+			}
+		}
+	}
+
+	for i, node := range nodes {
+		store := raft.NewInmemStore()
+		d, err := newDriver(DriverParameters{
+			NodeID:       fmt.Sprintf("node%d", i),
+			Peers:        peers,
+			Bootstrap:    i == 0,
+			ApplyTimeout: time.Second,
+			QueryLimit:   100,
+		}, store, store, raft.NewInmemSnapshotStore(), node.transport)
+		assert.Nil(t, err)
+		node.driver = d
+	}
+	// the followers forward the changes through pipes instead of the raft listener
+	for _, node := range nodes {
+		node.driver.dial = func(address raft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+			for _, peer := range nodes {
+				if peer.transport.LocalAddr() == address {
+					client, server := net.Pipe()
+					go func() {
+						if _, err := io.ReadFull(server, make([]byte, 1)); err == nil {
+							peer.driver.handleForward(server)
+						}
+					}()
+					return client, nil
+				}
+			}
+			return nil, fmt.Errorf("unknown address %s", address)
+		}
+	}
+	t.Cleanup(func() {
+		for _, node := range nodes {
+			_ = node.driver.Shutdown()
+		}
+	})
+	return nodes
+}
+
+func waitForLeader(t *testing.T, nodes []*testNode) *testNode {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, node := range nodes {
+			if node.driver.IsLeader() {
+				return node
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("no leader elected")
+	return nil
+}
+
+func waitForReplication(t *testing.T, nodes []*testNode, check func(d *driver) bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		replicated := true
+		for _, node := range nodes {
+			if !check(node.driver) {
+				replicated = false
+				break
+			}
+		}
+		if replicated {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("the change is not replicated")
+}
+
+func TestDriver_ReplicatesSessionsAndLocks(t *testing.T) {
+	nodes := newTestCluster(t, 3)
+	leader := waitForLeader(t, nodes)
+
+	gs := &apis.GlobalSession{
+		Addressing:    "localhost:8080",
+		XID:           "localhost:8091:1",
+		TransactionID: 1,
+		Timeout:       60000,
+		BeginTime:     1,
+		Status:        apis.Begin,
+		Active:        true,
+	}
+	bs := &apis.BranchSession{
+		Addressing:    "localhost:8080",
+		XID:           gs.XID,
+		BranchID:      2,
+		TransactionID: 1,
+		ResourceID:    "db",
+		LockKey:       "product:1,2",
+		Type:          apis.AT,
+		Status:        apis.Registered,
+	}
+	assert.Nil(t, leader.driver.AddGlobalSession(gs))
+	assert.Nil(t, leader.driver.AddBranchSession(gs, bs))
+	assert.True(t, leader.driver.AcquireLock(storage.CollectBranchSessionRowLocks(bs), false))
+	assert.Nil(t, leader.driver.UpdateGlobalSessionStatus(gs, apis.Committing))
+
+	waitForReplication(t, nodes, func(d *driver) bool {
+		session := d.FindGlobalSession(gs.XID)
+		return session != nil && session.Status == apis.Committing && len(d.FindBranchSessions(gs.XID)) == 1
+	})
+	for _, node := range nodes {
+		assert.False(t, node.driver.IsLockable("localhost:8091:3", "db", "product:2"))
+		assert.True(t, node.driver.IsLockable(gs.XID, "db", "product:2"))
+		assert.Len(t, node.driver.FindGlobalSessions([]apis.GlobalSession_GlobalStatus{apis.Committing}), 1)
+		assert.Len(t, node.driver.FindGlobalSessionsWithAddressingIdentities(
+			[]apis.GlobalSession_GlobalStatus{apis.Committing}, []string{"localhost:9090"}), 0)
+	}
+
+	conflict := storage.CollectRowLocks("product:2,3", "db", "localhost:8091:3")
+	assert.False(t, leader.driver.AcquireLock(conflict, false))
+	assert.True(t, leader.driver.IsLockable("localhost:8091:3", "db", "product:3"))
+
+	// a follower forwards the changes to the leader and reads them back at once
+	for _, node := range nodes {
+		if node != leader {
+			other := &apis.GlobalSession{XID: "localhost:8091:4", Status: apis.Begin}
+			assert.Nil(t, node.driver.AddGlobalSession(other))
+			assert.NotNil(t, node.driver.FindGlobalSession(other.XID))
+			assert.False(t, node.driver.AcquireLock(storage.CollectRowLocks("product:1", "db", other.XID), false))
+			assert.Nil(t, node.driver.RemoveGlobalSession(other))
+			assert.Nil(t, node.driver.FindGlobalSession(other.XID))
+			assert.Error(t, node.driver.UpdateGlobalSessionStatus(other, apis.Committing))
+			break
+		}
+	}
+
+	assert.True(t, leader.driver.ReleaseLock(storage.CollectBranchSessionRowLocks(bs)))
+	assert.Nil(t, leader.driver.RemoveBranchSession(gs, bs))
+	assert.Nil(t, leader.driver.RemoveGlobalSession(gs))
+	waitForReplication(t, nodes, func(d *driver) bool {
+		return d.FindGlobalSession(gs.XID) == nil && len(d.FindBranchSessions(gs.XID)) == 0 &&
+			d.IsLockable("localhost:8091:3", "db", "product:1,2")
+	})
+}
+
+func TestDriver_SurvivesLeaderLoss(t *testing.T) {
+	nodes := newTestCluster(t, 3)
+	leader := waitForLeader(t, nodes)
+
+	gs := &apis.GlobalSession{XID: "localhost:8091:1", TransactionID: 1, Status: apis.Begin, Active: true}
+	assert.Nil(t, leader.driver.AddGlobalSession(gs))
+	assert.True(t, leader.driver.AcquireLock(storage.CollectRowLocks("product:1", "db", gs.XID), false))
+
+	assert.Nil(t, leader.driver.Shutdown())
+	survivors := make([]*testNode, 0, 2)
+	for _, node := range nodes {
+		if node != leader {
+			node.transport.Disconnect(leader.transport.LocalAddr())
+			survivors = append(survivors, node)
+		}
+	}
+
+	newLeader := waitForLeader(t, survivors)
+	session := newLeader.driver.FindGlobalSession(gs.XID)
+	assert.NotNil(t, session)
+	assert.False(t, newLeader.driver.IsLockable("localhost:8091:2", "db", "product:1"))
+
+	assert.Nil(t, newLeader.driver.UpdateGlobalSessionStatus(session, apis.Committed))
+	waitForReplication(t, survivors, func(d *driver) bool {
+		session := d.FindGlobalSession(gs.XID)
+		return session != nil && session.Status == apis.Committed
+	})
+}
+
+func TestMuxStreamLayer(t *testing.T) {
+	layer, err := newMuxStreamLayer("127.0.0.1:0", nil)
+	assert.Nil(t, err)
+	defer layer.Close()
+
+	raftConn, err := layer.Dial(raft.ServerAddress(layer.Addr().String()), time.Second)
+	assert.Nil(t, err)
+	defer raftConn.Close()
+	_, err = raftConn.Write([]byte{0, 1})
+	assert.Nil(t, err)
+	accepted, err := layer.Accept()
+	assert.Nil(t, err)
+	data := make([]byte, 2)
+	_, err = io.ReadFull(accepted, data)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 1}, data)
+
+	forwardConn, err := layer.Dial(raft.ServerAddress(layer.Addr().String()), time.Second)
+	assert.Nil(t, err)
+	defer forwardConn.Close()
+	_, err = forwardConn.Write([]byte{forwardRPC, 2})
+	assert.Nil(t, err)
+	forwarded := <-layer.forwards
+	_, err = io.ReadFull(forwarded, data[:1])
+	assert.Nil(t, err)
+	assert.Equal(t, byte(2), data[0])
+}
+
+func TestFSM_SnapshotRestore(t *testing.T) {
+	f := newFSM()
+	f.state.GlobalSessions["localhost:8091:1"] = &apis.GlobalSession{XID: "localhost:8091:1", Status: apis.Begin}
+	f.state.BranchSessions["localhost:8091:1"] = map[int64]*apis.BranchSession{
+		2: {XID: "localhost:8091:1", BranchID: 2, ApplicationData: []byte("data")},
+	}
+	f.state.RowLocks.Put(&apis.RowLock{XID: "localhost:8091:1", ResourceID: "db", TableName: "product", PK: "1",
+		RowKey: "db^^^product^^^1"})
+	f.state.RowLocks.Put(&apis.RowLock{XID: "localhost:8091:1", ResourceID: "db", TableName: "order", PK: "[1~9]",
+		RowKey: "db^^^order^^^[1~9]"})
+
+	snapshot, err := f.Snapshot()
+	assert.Nil(t, err)
+	sink := &testSnapshotSink{}
+	assert.Nil(t, snapshot.Persist(sink))
+
+	restored := newFSM()
+	assert.Nil(t, restored.Restore(ioutil.NopCloser(&sink.Buffer)))
+	assert.Equal(t, f.state, restored.state)
+}
+
+type testSnapshotSink struct {
+	bytes.Buffer
+}
+
+func (sink *testSnapshotSink) ID() string {
+	return "test"
+}
+
+func (sink *testSnapshotSink) Cancel() error {
+	return nil
+}
+
+func (sink *testSnapshotSink) Close() error {
+	return nil
+}
+
+func TestDriverSuite(t *testing.T) {
+	storagetest.RunDriverSuite(t, newTestDriver)
+}
+
+func TestRangeLockSuite(t *testing.T) {
+	storagetest.RunRangeLockSuite(t, newTestDriver)
+}
+
+func newTestDriver(t *testing.T) storage.Driver {
+	nodes := newTestCluster(t, 1)
+	return waitForLeader(t, nodes).driver
+}
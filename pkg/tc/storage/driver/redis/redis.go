@@ -0,0 +1,762 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+// The keys of the driver, they are prefixed by the key prefix parameter.
+//
+//	global:{xid}            hash of a global session
+//	globals                 set of all the xids
+//	status:{status}         set of the xids of the global sessions in the status
+//	addressing:{addressing} set of the xids of the global sessions began by the addressing
+//	branch:{branchID}       hash of a branch session
+//	branches:{xid}          list of the branch ids of a global session in the order they are registered
+//	lock:{rowKey}           hash of a row lock
+//	tablelocks:{table}      set of the row keys locked in a table, the table is resourceID^^^tableName
+//	rangelocks:{table}      set of the row keys of the table and pk range locks of a table
+//
+// Every key a script or a transaction touches is passed in its KEYS or watched, on Redis Cluster
+// the key prefix should be a hash tag, e.g. {seata}:, so that all of the keys are in the same slot.
+const (
+	globalKey     = "global:"
+	globalsKey    = "globals"
+	statusKey     = "status:"
+	addressingKey = "addressing:"
+	branchKey     = "branch:"
+	branchesKey   = "branches:"
+	rowLockKey    = "lock:"
+	tableLocksKey = "tablelocks:"
+	rangeLocksKey = "rangelocks:"
+)
+
+// maxWatchRetries is how many times a transaction is retried when the keys it watches are changed
+const maxWatchRetries = 16
+
+var (
+	// addBranchSessionScript adds a branch session only if its global session exists.
+	// KEYS[1] the global session, KEYS[2] the branch session, KEYS[3] the branch ids of the global
+	// session, ARGV[1] the branch id, ARGV[2..] the fields and values of the branch session
+	addBranchSessionScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[2], unpack(ARGV, 2))
+redis.call('RPUSH', KEYS[3], ARGV[1])
+return 1`)
+
+	// setIfExistsScript sets a field of a hash only if the hash exists.
+	// KEYS[1] the hash, ARGV[1] the field, ARGV[2] the value
+	setIfExistsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+return 1`)
+
+	// releaseLockScript releases the rows locked by the xid.
+	// KEYS 3 keys per row lock: the row lock, the row keys of its table and the row keys of the range
+	// locks of its table, ARGV[1] the xid, ARGV[2..] the row keys
+	releaseLockScript = redis.NewScript(`
+for i = 1, #KEYS / 3 do
+	local lock = KEYS[i * 3 - 2]
+	if redis.call('HGET', lock, 'xid') == ARGV[1] then
+		redis.call('DEL', lock)
+		redis.call('SREM', KEYS[i * 3 - 1], ARGV[i + 1])
+		redis.call('SREM', KEYS[i * 3], ARGV[i + 1])
+	end
+end
+return 1`)
+)
+
+func init() {
+	factory.Register("redis", &redisFactory{})
+}
+
+type DriverParameters struct {
+	Addr         string
+	Password     string
+	DB           int
+	KeyPrefix    string
+	PoolSize     int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	QueryLimit   int
+}
+
+// redisFactory implements the factory.StorageDriverFactory interface
+type redisFactory struct{}
+
+func (factory *redisFactory) Create(parameters map[string]interface{}) (storage.Driver, error) {
+	return FromParameters(parameters)
+}
+
+type driver struct {
+	client     *redis.Client
+	keyPrefix  string
+	queryLimit int
+}
+
+func FromParameters(parameters map[string]interface{}) (storage.Driver, error) {
+	addr := parameters["addr"]
+	if addr == nil {
+		addr = "127.0.0.1:6379"
+	}
+
+	password := parameters["password"]
+	if password == nil {
+		password = ""
+	}
+
+	keyPrefix := parameters["keyprefix"]
+	if keyPrefix == nil {
+		keyPrefix = "seata:"
+	}
+
+	db := intParameter(parameters, "db", 0)
+	poolSize := intParameter(parameters, "poolsize", 100)
+	queryLimit := intParameter(parameters, "querylimit", 100)
+	dialTimeout := durationParameter(parameters, "dialtimeout", 5*time.Second)
+	readTimeout := durationParameter(parameters, "readtimeout", 3*time.Second)
+	writeTimeout := durationParameter(parameters, "writetimeout", 3*time.Second)
+
+	driverParameters := DriverParameters{
+		Addr:         fmt.Sprint(addr),
+		Password:     fmt.Sprint(password),
+		DB:           db,
+		KeyPrefix:    fmt.Sprint(keyPrefix),
+		PoolSize:     poolSize,
+		DialTimeout:  dialTimeout,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		QueryLimit:   queryLimit,
+	}
+
+	return New(driverParameters)
+}
+
+func intParameter(parameters map[string]interface{}, key string, defaultValue int) int {
+	switch value := parameters[key].(type) {
+	case string:
+		result, err := strconv.Atoi(value)
+		if err != nil {
+			log.Errorf("the %s parameter should be a integer", key)
+			return defaultValue
+		}
+		return result
+	case int:
+		return value
+	case nil:
+		return defaultValue
+	default:
+		log.Errorf("the %s parameter should be a integer", key)
+		return defaultValue
+	}
+}
+
+func durationParameter(parameters map[string]interface{}, key string, defaultValue time.Duration) time.Duration {
+	switch value := parameters[key].(type) {
+	case string:
+		result, err := time.ParseDuration(value)
+		if err != nil {
+			log.Errorf("the %s parameter should be a duration", key)
+			return defaultValue
+		}
+		return result
+	case time.Duration:
+		return value
+	case nil:
+		return defaultValue
+	default:
+		log.Errorf("the %s parameter should be a duration", key)
+		return defaultValue
+	}
+}
+
+// New constructs a new Driver.
+func New(params DriverParameters) (storage.Driver, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         params.Addr,
+		Password:     params.Password,
+		DB:           params.DB,
+		PoolSize:     params.PoolSize,
+		DialTimeout:  params.DialTimeout,
+		ReadTimeout:  params.ReadTimeout,
+		WriteTimeout: params.WriteTimeout,
+	})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &driver{
+		client:     client,
+		keyPrefix:  params.KeyPrefix,
+		queryLimit: params.QueryLimit,
+	}, nil
+}
+
+func (driver *driver) key(parts ...string) string {
+	key := driver.keyPrefix
+	for _, part := range parts {
+		key += part
+	}
+	return key
+}
+
+// Close closes the client.
+func (driver *driver) Close() error {
+	return driver.client.Close()
+}
+
+// AddGlobalSession adds a global session.
+func (driver *driver) AddGlobalSession(session *apis.GlobalSession) error {
+	ctx := context.Background()
+	status := strconv.Itoa(int(session.Status))
+	_, err := driver.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, driver.key(globalKey, session.XID), globalSessionFields(session))
+		pipe.SAdd(ctx, driver.key(globalsKey), session.XID)
+		pipe.SAdd(ctx, driver.key(statusKey, status), session.XID)
+		pipe.SAdd(ctx, driver.key(addressingKey, session.Addressing), session.XID)
+		return nil
+	})
+	return err
+}
+
+// FindGlobalSession finds a global session by xid.
+func (driver *driver) FindGlobalSession(xid string) *apis.GlobalSession {
+	fields, err := driver.client.HGetAll(context.Background(), driver.key(globalKey, xid)).Result()
+	if err != nil {
+		log.Errorf(err.Error())
+		return nil
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return parseGlobalSession(fields)
+}
+
+// FindGlobalSessions finds global sessions list by statuses list
+func (driver *driver) FindGlobalSessions(statuses []apis.GlobalSession_GlobalStatus) []*apis.GlobalSession {
+	xids, err := driver.client.SUnion(context.Background(), driver.statusKeys(statuses)...).Result()
+	if err != nil {
+		log.Errorf(err.Error())
+		return nil
+	}
+	return driver.findGlobalSessions(xids)
+}
+
+// FindGlobalSessionsWithAddressingIdentities finds global sessions list by addressing identities and statuses list
+func (driver *driver) FindGlobalSessionsWithAddressingIdentities(statuses []apis.GlobalSession_GlobalStatus,
+	addressingIdentities []string) []*apis.GlobalSession {
+	if len(statuses) == 0 || len(addressingIdentities) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	addressingKeys := make([]string, 0, len(addressingIdentities))
+	for _, addressing := range addressingIdentities {
+		addressingKeys = append(addressingKeys, driver.key(addressingKey, addressing))
+	}
+
+	var statusXIDs, addressingXIDs *redis.StringSliceCmd
+	_, err := driver.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		statusXIDs = pipe.SUnion(ctx, driver.statusKeys(statuses)...)
+		addressingXIDs = pipe.SUnion(ctx, addressingKeys...)
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+		return nil
+	}
+
+	addressed := make(map[string]bool)
+	for _, xid := range addressingXIDs.Val() {
+		addressed[xid] = true
+	}
+	xids := make([]string, 0)
+	for _, xid := range statusXIDs.Val() {
+		if addressed[xid] {
+			xids = append(xids, xid)
+		}
+	}
+	return driver.findGlobalSessions(xids)
+}
+
+// AllSessions returns all sessions collection.
+func (driver *driver) AllSessions() []*apis.GlobalSession {
+	xids, err := driver.client.SMembers(context.Background(), driver.key(globalsKey)).Result()
+	if err != nil {
+		log.Errorf(err.Error())
+		return nil
+	}
+	return driver.findGlobalSessions(xids)
+}
+
+func (driver *driver) statusKeys(statuses []apis.GlobalSession_GlobalStatus) []string {
+	keys := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		keys = append(keys, driver.key(statusKey, strconv.Itoa(int(status))))
+	}
+	return keys
+}
+
+// findGlobalSessions loads the global sessions of the xids in the order they begin, at most query limit of them
+func (driver *driver) findGlobalSessions(xids []string) []*apis.GlobalSession {
+	globalSessions := make([]*apis.GlobalSession, 0)
+	if len(xids) == 0 {
+		return globalSessions
+	}
+	ctx := context.Background()
+	cmds, err := driver.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, xid := range xids {
+			pipe.HGetAll(ctx, driver.key(globalKey, xid))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+		return globalSessions
+	}
+	for _, cmd := range cmds {
+		fields := cmd.(*redis.StringStringMapCmd).Val()
+		// the session may be removed after its xid is read from the index
+		if len(fields) > 0 {
+			globalSessions = append(globalSessions, parseGlobalSession(fields))
+		}
+	}
+
+	sort.Slice(globalSessions, func(i, j int) bool {
+		if globalSessions[i].BeginTime != globalSessions[j].BeginTime {
+			return globalSessions[i].BeginTime < globalSessions[j].BeginTime
+		}
+		return globalSessions[i].XID < globalSessions[j].XID
+	})
+	if driver.queryLimit > 0 && len(globalSessions) > driver.queryLimit {
+		globalSessions = globalSessions[:driver.queryLimit]
+	}
+	return globalSessions
+}
+
+// UpdateGlobalSessionStatus updates status of global session, the xid is moved to the index of
+// the new status.
+func (driver *driver) UpdateGlobalSessionStatus(session *apis.GlobalSession, status apis.GlobalSession_GlobalStatus) error {
+	ctx := context.Background()
+	global := driver.key(globalKey, session.XID)
+	return driver.watch(func(tx *redis.Tx) error {
+		current, err := tx.HGet(ctx, global, "status").Result()
+		if err == redis.Nil {
+			return fmt.Errorf("could not find global transaction xid = %s", session.XID)
+		}
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SRem(ctx, driver.key(statusKey, current), session.XID)
+			pipe.SAdd(ctx, driver.key(statusKey, strconv.Itoa(int(status))), session.XID)
+			pipe.HSet(ctx, global, "status", int(status))
+			return nil
+		})
+		return err
+	}, global)
+}
+
+// InactiveGlobalSession inactivates a global session.
+func (driver *driver) InactiveGlobalSession(session *apis.GlobalSession) error {
+	updated, err := setIfExistsScript.Run(context.Background(), driver.client,
+		[]string{driver.key(globalKey, session.XID)}, "active", "0").Int()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("could not find global transaction xid = %s", session.XID)
+	}
+	return nil
+}
+
+// RemoveGlobalSession removes a global session and its xid from the indexes.
+func (driver *driver) RemoveGlobalSession(session *apis.GlobalSession) error {
+	ctx := context.Background()
+	global := driver.key(globalKey, session.XID)
+	return driver.watch(func(tx *redis.Tx) error {
+		fields, err := tx.HMGet(ctx, global, "status", "addressing").Result()
+		if err != nil {
+			return err
+		}
+		if fields[0] == nil {
+			return nil
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SRem(ctx, driver.key(statusKey, fmt.Sprint(fields[0])), session.XID)
+			pipe.SRem(ctx, driver.key(addressingKey, fmt.Sprint(fields[1])), session.XID)
+			pipe.SRem(ctx, driver.key(globalsKey), session.XID)
+			pipe.Del(ctx, global)
+			return nil
+		})
+		return err
+	}, global)
+}
+
+// watch runs the transaction watching the keys, it is retried if the keys are changed before the
+// transaction is executed.
+func (driver *driver) watch(fn func(tx *redis.Tx) error, keys ...string) error {
+	for i := 0; i < maxWatchRetries; i++ {
+		err := driver.client.Watch(context.Background(), fn, keys...)
+		if err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return fmt.Errorf("keys %v are changed concurrently %d times", keys, maxWatchRetries)
+}
+
+// AddBranchSession adds a branch session, it fails if the global session does not exist.
+func (driver *driver) AddBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	branchID := strconv.FormatInt(session.BranchID, 10)
+	args := []interface{}{branchID}
+	for field, value := range branchSessionFields(session) {
+		args = append(args, field, value)
+	}
+	added, err := addBranchSessionScript.Run(context.Background(), driver.client,
+		[]string{driver.key(globalKey, session.XID), driver.key(branchKey, branchID), driver.key(branchesKey, session.XID)},
+		args...).Int()
+	if err != nil {
+		return err
+	}
+	if added == 0 {
+		return fmt.Errorf("could not find global transaction xid = %s", session.XID)
+	}
+	return nil
+}
+
+// FindBranchSessions finds branch sessions list by xid.
+func (driver *driver) FindBranchSessions(xid string) []*apis.BranchSession {
+	return driver.FindBatchBranchSessions([]string{xid})
+}
+
+// FindBatchBranchSessions finds branch sessions list by xids list.
+func (driver *driver) FindBatchBranchSessions(xids []string) []*apis.BranchSession {
+	branchSessions := make([]*apis.BranchSession, 0)
+	if len(xids) == 0 {
+		return branchSessions
+	}
+	ctx := context.Background()
+	cmds, err := driver.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, xid := range xids {
+			pipe.LRange(ctx, driver.key(branchesKey, xid), 0, -1)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+		return branchSessions
+	}
+	branchIDs := make([]string, 0)
+	for _, cmd := range cmds {
+		branchIDs = append(branchIDs, cmd.(*redis.StringSliceCmd).Val()...)
+	}
+	if len(branchIDs) == 0 {
+		return branchSessions
+	}
+
+	cmds, err = driver.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, branchID := range branchIDs {
+			pipe.HGetAll(ctx, driver.key(branchKey, branchID))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf(err.Error())
+		return branchSessions
+	}
+	for _, cmd := range cmds {
+		fields := cmd.(*redis.StringStringMapCmd).Val()
+		if len(fields) > 0 {
+			branchSessions = append(branchSessions, parseBranchSession(fields))
+		}
+	}
+	return branchSessions
+}
+
+// UpdateBranchSessionStatus updates status of branch session.
+func (driver *driver) UpdateBranchSessionStatus(session *apis.BranchSession, status apis.BranchSession_BranchStatus) error {
+	return setIfExistsScript.Run(context.Background(), driver.client,
+		[]string{driver.key(branchKey, strconv.FormatInt(session.BranchID, 10))}, "status", int(status)).Err()
+}
+
+// RemoveBranchSession removes branch session.
+func (driver *driver) RemoveBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	ctx := context.Background()
+	branchID := strconv.FormatInt(session.BranchID, 10)
+	_, err := driver.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, driver.key(branchKey, branchID))
+		pipe.LRem(ctx, driver.key(branchesKey, session.XID), 0, branchID)
+		return nil
+	})
+	return err
+}
+
+// AcquireLock acquires row locks. The row locks of the tables locked are loaded and checked while
+// the row keys of the tables are watched, so the row locks are held only if no row lock of these
+// tables is acquired or released meanwhile.
+func (driver *driver) AcquireLock(rowLocks []*apis.RowLock, skipCheckLock bool) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+	ctx := context.Background()
+	acquired := true
+	err := driver.watch(func(tx *redis.Tx) error {
+		acquired = true
+		unlocked := rowLocks
+		if !skipCheckLock {
+			held, err := driver.heldRowLocks(ctx, tx, rowLocks)
+			if err != nil {
+				return err
+			}
+			unlocked = make([]*apis.RowLock, 0, len(rowLocks))
+			for _, rowLock := range rowLocks {
+				if locked := held.Conflict(rowLock); locked != nil {
+					log.Infof("row lock [%s] on %s:%s is holding by xid {%s} branchID {%d}", locked.RowKey,
+						rowLock.TableName, rowLock.PK, locked.XID, locked.BranchID)
+					acquired = false
+					return nil
+				}
+				if held.Get(rowLock) == nil {
+					unlocked = append(unlocked, rowLock)
+				}
+			}
+		}
+		if len(unlocked) == 0 {
+			return nil
+		}
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, rowLock := range unlocked {
+				pipe.HSet(ctx, driver.key(rowLockKey, rowLock.RowKey), rowLockFields(rowLock))
+				pipe.SAdd(ctx, driver.key(tableLocksKey, tableOf(rowLock)), rowLock.RowKey)
+				if storage.IsRangeLock(rowLock) {
+					pipe.SAdd(ctx, driver.key(rangeLocksKey, tableOf(rowLock)), rowLock.RowKey)
+				}
+			}
+			return nil
+		})
+		return err
+	}, driver.tableLocksKeys(rowLocks)...)
+	if err != nil {
+		log.Errorf("row locks batch acquire failed, %v, %v", rowLocks, err)
+		return false
+	}
+	return acquired
+}
+
+// ReleaseLock releases locked rows.
+func (driver *driver) ReleaseLock(rowLocks []*apis.RowLock) bool {
+	if len(rowLocks) == 0 {
+		return true
+	}
+	keys := make([]string, 0, len(rowLocks)*3)
+	args := make([]interface{}, 0, 1+len(rowLocks))
+	args = append(args, rowLocks[0].XID)
+	for _, rowLock := range rowLocks {
+		keys = append(keys, driver.key(rowLockKey, rowLock.RowKey), driver.key(tableLocksKey, tableOf(rowLock)),
+			driver.key(rangeLocksKey, tableOf(rowLock)))
+		args = append(args, rowLock.RowKey)
+	}
+	err := releaseLockScript.Run(context.Background(), driver.client, keys, args...).Err()
+	if err != nil {
+		log.Errorf(err.Error())
+		return false
+	}
+	return true
+}
+
+// IsLockable checks if a global transaction is lockable by xid, resourceID, lockKey.
+func (driver *driver) IsLockable(xid string, resourceID string, lockKey string) bool {
+	rowLocks := storage.CollectRowLocks(lockKey, resourceID, xid)
+	if len(rowLocks) == 0 {
+		return true
+	}
+	held, err := driver.heldRowLocks(context.Background(), driver.client, rowLocks)
+	if err != nil {
+		log.Errorf(err.Error())
+		return false
+	}
+	for _, rowLock := range rowLocks {
+		if held.Conflict(rowLock) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// heldRowLocks loads the row locks which may overlap the row locks: all the row locks of a table
+// locked by a table or pk range lock, otherwise the row locks on the same row keys and the table and
+// pk range locks of the table.
+func (driver *driver) heldRowLocks(ctx context.Context, client redis.Cmdable, rowLocks []*apis.RowLock) (*storage.RowLockTable, error) {
+	ranged := make(map[string]bool)
+	for _, rowLock := range rowLocks {
+		ranged[tableOf(rowLock)] = ranged[tableOf(rowLock)] || storage.IsRangeLock(rowLock)
+	}
+	cmds, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for table, isRanged := range ranged {
+			if isRanged {
+				pipe.SMembers(ctx, driver.key(tableLocksKey, table))
+			} else {
+				pipe.SMembers(ctx, driver.key(rangeLocksKey, table))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	rowKeys := make(map[string]bool)
+	for _, cmd := range cmds {
+		for _, rowKey := range cmd.(*redis.StringSliceCmd).Val() {
+			rowKeys[rowKey] = true
+		}
+	}
+	for _, rowLock := range rowLocks {
+		rowKeys[rowLock.RowKey] = true
+	}
+
+	held := storage.NewRowLockTable()
+	keys := make([]string, 0, len(rowKeys))
+	cmds, err = client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for rowKey := range rowKeys {
+			keys = append(keys, rowKey)
+			pipe.HGetAll(ctx, driver.key(rowLockKey, rowKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, cmd := range cmds {
+		fields := cmd.(*redis.StringStringMapCmd).Val()
+		if len(fields) > 0 {
+			held.Put(parseRowLock(keys[i], fields))
+		}
+	}
+	return held, nil
+}
+
+// tableLocksKeys returns the keys of the row keys of the tables locked by the row locks
+func (driver *driver) tableLocksKeys(rowLocks []*apis.RowLock) []string {
+	tables := make(map[string]bool)
+	keys := make([]string, 0)
+	for _, rowLock := range rowLocks {
+		if table := tableOf(rowLock); !tables[table] {
+			tables[table] = true
+			keys = append(keys, driver.key(tableLocksKey, table))
+		}
+	}
+	return keys
+}
+
+func tableOf(rowLock *apis.RowLock) string {
+	return rowLock.ResourceID + storage.LockSplit + rowLock.TableName
+}
+
+func rowLockFields(rowLock *apis.RowLock) map[string]interface{} {
+	return map[string]interface{}{
+		"xid":           rowLock.XID,
+		"transactionID": rowLock.TransactionID,
+		"branchID":      rowLock.BranchID,
+		"resourceID":    rowLock.ResourceID,
+		"tableName":     rowLock.TableName,
+		"pk":            rowLock.PK,
+	}
+}
+
+func parseRowLock(rowKey string, fields map[string]string) *apis.RowLock {
+	return &apis.RowLock{
+		XID:           fields["xid"],
+		TransactionID: parseInt(fields["transactionID"]),
+		BranchID:      parseInt(fields["branchID"]),
+		ResourceID:    fields["resourceID"],
+		TableName:     fields["tableName"],
+		PK:            fields["pk"],
+		RowKey:        rowKey,
+	}
+}
+
+func globalSessionFields(session *apis.GlobalSession) map[string]interface{} {
+	return map[string]interface{}{
+		"addressing":      session.Addressing,
+		"xid":             session.XID,
+		"transactionID":   session.TransactionID,
+		"transactionName": session.TransactionName,
+		"timeout":         session.Timeout,
+		"beginTime":       session.BeginTime,
+		"status":          int(session.Status),
+		"active":          formatBool(session.Active),
+	}
+}
+
+func parseGlobalSession(fields map[string]string) *apis.GlobalSession {
+	return &apis.GlobalSession{
+		Addressing:      fields["addressing"],
+		XID:             fields["xid"],
+		TransactionID:   parseInt(fields["transactionID"]),
+		TransactionName: fields["transactionName"],
+		Timeout:         int32(parseInt(fields["timeout"])),
+		BeginTime:       parseInt(fields["beginTime"]),
+		Status:          apis.GlobalSession_GlobalStatus(parseInt(fields["status"])),
+		Active:          fields["active"] == "1",
+	}
+}
+
+func branchSessionFields(session *apis.BranchSession) map[string]interface{} {
+	return map[string]interface{}{
+		"addressing":      session.Addressing,
+		"xid":             session.XID,
+		"branchID":        session.BranchID,
+		"transactionID":   session.TransactionID,
+		"resourceID":      session.ResourceID,
+		"lockKey":         session.LockKey,
+		"type":            int(session.Type),
+		"status":          int(session.Status),
+		"applicationData": session.ApplicationData,
+		"asyncCommit":     formatBool(session.AsyncCommit),
+	}
+}
+
+func parseBranchSession(fields map[string]string) *apis.BranchSession {
+	var applicationData []byte
+	if data := fields["applicationData"]; data != "" {
+		applicationData = []byte(data)
+	}
+	return &apis.BranchSession{
+		Addressing:      fields["addressing"],
+		XID:             fields["xid"],
+		BranchID:        parseInt(fields["branchID"]),
+		TransactionID:   parseInt(fields["transactionID"]),
+		ResourceID:      fields["resourceID"],
+		LockKey:         fields["lockKey"],
+		Type:            apis.BranchSession_BranchType(parseInt(fields["type"])),
+		Status:          apis.BranchSession_BranchStatus(parseInt(fields["status"])),
+		ApplicationData: applicationData,
+		AsyncCommit:     fields["asyncCommit"] == "1",
+	}
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+func parseInt(value string) int64 {
+	result, _ := strconv.ParseInt(value, 10, 64)
+	return result
+}
@@ -0,0 +1,690 @@
+// Package sqldriver implements the storage driver shared by the sql databases, which are accessed
+// with xorm. A database package provides the Dialect of the driver: the database/sql driver, the
+// function of the current time and the schema migrations.
+package sqldriver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-xorm/xorm"
+	"xorm.io/builder"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/factory"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/migration"
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+	"github.com/opentrx/seata-golang/v2/pkg/util/sql"
+)
+
+// The placeholders of the statements are written as `?`, xorm rewrites them to the bind vars of the
+// database. The %[2]s verbs are replaced by the function of the current time of the dialect.
+const (
+	InsertGlobalTransaction = `insert into %[1]s (addressing, xid, transaction_id, transaction_name, timeout, begin_time,
+		status, active, gmt_create, gmt_modified) values(?, ?, ?, ?, ?, ?, ?, ?, %[2]s, %[2]s)`
+
+	QueryGlobalTransactionByXid = `select addressing, xid, transaction_id, transaction_name, timeout, begin_time,
+		status, active, gmt_create, gmt_modified from %s where xid = ?`
+
+	UpdateGlobalTransaction = "update %[1]s set status = ?, gmt_modified = %[2]s where xid = ?"
+
+	InactiveGlobalTransaction = "update %[1]s set active = ?, gmt_modified = %[2]s where xid = ?"
+
+	DeleteGlobalTransaction = "delete from %s where xid = ?"
+
+	InsertBranchTransaction = `insert into %[1]s (addressing, xid, branch_id, transaction_id, resource_id, lock_key, branch_type,
+        status, application_data, async_commit, gmt_create, gmt_modified) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, %[2]s, %[2]s)`
+
+	QueryBranchTransaction = `select addressing, xid, branch_id, transaction_id, resource_id, lock_key, branch_type, status,
+	    application_data, async_commit, gmt_create, gmt_modified from %s where %s order by gmt_create asc, branch_id asc`
+
+	QueryBranchTransactionByXid = `select addressing, xid, branch_id, transaction_id, resource_id, lock_key, branch_type, status,
+	    application_data, async_commit, gmt_create, gmt_modified from %s where xid = ? order by gmt_create asc, branch_id asc`
+
+	UpdateBranchTransaction = "update %[1]s set status = ?, gmt_modified = %[2]s where branch_id = ?"
+
+	DeleteBranchTransaction = "delete from %s where branch_id = ?"
+
+	InsertRowLock = `insert into %s (xid, transaction_id, branch_id, resource_id, table_name, pk, row_key, gmt_create,
+		gmt_modified) values %s`
+
+	QueryRowKey = `select xid, transaction_id, branch_id, resource_id, table_name, pk, row_key, gmt_create, gmt_modified
+		from %s where %s order by gmt_create asc`
+)
+
+// Dialect the differences of a database the driver stores the sessions and row locks in
+type Dialect struct {
+	// Name the name of the storage driver, it is registered with it in the factory and migration packages
+	Name string
+
+	// DriverName the name of the database/sql driver the xorm engine is opened with
+	DriverName string
+
+	// Now the sql function of the current time stored in gmt_create and gmt_modified, the branch
+	// sessions of a global session are ordered by it
+	Now string
+
+	// MaxOpenConnections the default of the maxopenconnections parameter
+	MaxOpenConnections int
+
+	// MaxIdleConnections the default of the maxidleconnections parameter
+	MaxIdleConnections int
+
+	// Migrations upgrade the schema of the global, branch, lock and global session lock tables
+	Migrations []migration.Migration
+}
+
+// Register makes the storage driver and the migrator of the dialect available by the name of the
+// dialect, the database packages call it in their init() funcs.
+func Register(dialect Dialect) {
+	factory.Register(dialect.Name, &sqlFactory{dialect: dialect})
+	migration.Register(dialect.Name, &sqlFactory{dialect: dialect})
+}
+
+type DriverParameters struct {
+	DSN                    string
+	GlobalTable            string
+	BranchTable            string
+	LockTable              string
+	VersionTable           string
+	GlobalSessionLockTable string
+	AutoMigrate            bool
+	QueryLimit             int
+	MaxOpenConnections     int
+	MaxIdleConnections     int
+	MaxLifeTime            time.Duration
+}
+
+// sqlFactory implements the factory.StorageDriverFactory and migration.MigratorFactory interfaces
+type sqlFactory struct {
+	dialect Dialect
+}
+
+func (factory *sqlFactory) Create(parameters map[string]interface{}) (storage.Driver, error) {
+	return FromParameters(factory.dialect, parameters)
+}
+
+func (factory *sqlFactory) CreateMigrator(parameters map[string]interface{}) (*migration.Migrator, error) {
+	params := parseParameters(factory.dialect, parameters)
+	engine, err := newEngine(factory.dialect, params)
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := newMigrator(factory.dialect, engine, params)
+	if err != nil {
+		_ = engine.Close()
+		return nil, err
+	}
+	return migrator, nil
+}
+
+type driver struct {
+	engine      *xorm.Engine
+	now         string
+	globalTable string
+	branchTable string
+	lockTable   string
+	queryLimit  int
+
+	// rangeMutex excludes the acquisitions involving table or pk range locks from the others
+	rangeMutex sync.RWMutex
+}
+
+// FromParameters constructs a new Driver of the dialect with the given parameters.
+func FromParameters(dialect Dialect, parameters map[string]interface{}) (storage.Driver, error) {
+	return New(dialect, parseParameters(dialect, parameters))
+}
+
+func parseParameters(dialect Dialect, parameters map[string]interface{}) DriverParameters {
+	dsn := parameters["dsn"]
+	if dsn == nil {
+		dsn = ""
+	}
+
+	globalTable := parameters["globaltable"]
+	if globalTable == nil {
+		globalTable = "global_table"
+	}
+
+	branchTable := parameters["branchtable"]
+	if branchTable == nil {
+		branchTable = "branch_table"
+	}
+
+	lockTable := parameters["locktable"]
+	if lockTable == nil {
+		lockTable = "lock_table"
+	}
+
+	globalSessionLockTable := parameters["globalsessionlocktable"]
+	if globalSessionLockTable == nil {
+		globalSessionLockTable = "global_session_lock"
+	}
+
+	versionTable := parameters["versiontable"]
+	if versionTable == nil {
+		versionTable = "schema_version"
+	}
+
+	autoMigrate := true
+	am := parameters["automigrate"]
+	switch am := am.(type) {
+	case string:
+		var err error
+		autoMigrate, err = strconv.ParseBool(am)
+		if err != nil {
+			log.Error("the automigrate parameter should be a boolean")
+		}
+	case bool:
+		autoMigrate = am
+	case nil:
+		// do nothing
+	default:
+		log.Error("the automigrate parameter should be a boolean")
+	}
+
+	queryLimit := 100
+	ql := parameters["querylimit"]
+	switch ql := ql.(type) {
+	case string:
+		var err error
+		queryLimit, err = strconv.Atoi(ql)
+		if err != nil {
+			log.Error("the querylimit parameter should be a integer")
+		}
+	case int:
+		queryLimit = ql
+	case nil:
+		// do nothing
+	default:
+		log.Error("the querylimit parameter should be a integer")
+	}
+
+	maxOpenConnections := dialect.MaxOpenConnections
+	mc := parameters["maxopenconnections"]
+	switch mc := mc.(type) {
+	case string:
+		var err error
+		maxOpenConnections, err = strconv.Atoi(mc)
+		if err != nil {
+			log.Error("the maxopenconnections parameter should be a integer")
+		}
+	case int:
+		maxOpenConnections = mc
+	case nil:
+		// do nothing
+	default:
+		log.Error("the maxopenconnections parameter should be a integer")
+	}
+
+	maxIdleConnections := dialect.MaxIdleConnections
+	mi := parameters["maxidleconnections"]
+	switch mi := mi.(type) {
+	case string:
+		var err error
+		maxIdleConnections, err = strconv.Atoi(mi)
+		if err != nil {
+			log.Error("the maxidleconnections parameter should be a integer")
+		}
+	case int:
+		maxIdleConnections = mi
+	case nil:
+		// do nothing
+	default:
+		log.Error("the maxidleconnections parameter should be a integer")
+	}
+
+	maxlifetime := 4 * time.Hour
+	ml := parameters["maxlifetime"]
+	switch ml := ml.(type) {
+	case string:
+		var err error
+		maxlifetime, err = time.ParseDuration(ml)
+		if err != nil {
+			log.Error("the maxlifetime parameter should be a duration")
+		}
+	case time.Duration:
+		maxlifetime = ml
+	case nil:
+		// do nothing
+	default:
+		log.Error("the maxlifetime parameter should be a duration")
+	}
+
+	return DriverParameters{
+		DSN:                    fmt.Sprint(dsn),
+		GlobalTable:            fmt.Sprint(globalTable),
+		BranchTable:            fmt.Sprint(branchTable),
+		LockTable:              fmt.Sprint(lockTable),
+		VersionTable:           fmt.Sprint(versionTable),
+		GlobalSessionLockTable: fmt.Sprint(globalSessionLockTable),
+		AutoMigrate:            autoMigrate,
+		QueryLimit:             queryLimit,
+		MaxOpenConnections:     maxOpenConnections,
+		MaxIdleConnections:     maxIdleConnections,
+		MaxLifeTime:            maxlifetime,
+	}
+}
+
+// New constructs a new Driver of the dialect.
+func New(dialect Dialect, params DriverParameters) (storage.Driver, error) {
+	engine, err := newEngine(dialect, params)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := newMigrator(dialect, engine, params)
+	if err == nil {
+		if params.AutoMigrate {
+			_, err = migrator.Up()
+		} else {
+			err = migrator.Check()
+		}
+	}
+	if err != nil {
+		_ = engine.Close()
+		return nil, err
+	}
+
+	return &driver{
+		engine:      engine,
+		now:         dialect.Now,
+		globalTable: params.GlobalTable,
+		branchTable: params.BranchTable,
+		lockTable:   params.LockTable,
+		queryLimit:  params.QueryLimit,
+	}, nil
+}
+
+func newEngine(dialect Dialect, params DriverParameters) (*xorm.Engine, error) {
+	if params.DSN == "" {
+		return nil, fmt.Errorf("the dsn parameter should not be empty")
+	}
+	engine, err := xorm.NewEngine(dialect.DriverName, params.DSN)
+	if err != nil {
+		return nil, err
+	}
+	engine.SetMaxOpenConns(params.MaxOpenConnections)
+	engine.SetMaxIdleConns(params.MaxIdleConnections)
+	engine.SetConnMaxLifetime(params.MaxLifeTime)
+	return engine, nil
+}
+
+func newMigrator(dialect Dialect, engine *xorm.Engine, params DriverParameters) (*migration.Migrator, error) {
+	return migration.NewMigrator(engine, params.VersionTable, migration.Tables{
+		GlobalTable:            params.GlobalTable,
+		BranchTable:            params.BranchTable,
+		LockTable:              params.LockTable,
+		GlobalSessionLockTable: params.GlobalSessionLockTable,
+	}, dialect.Migrations)
+}
+
+// Close closes the database.
+func (driver *driver) Close() error {
+	return driver.engine.Close()
+}
+
+// AddGlobalSession adds a global session.
+func (driver *driver) AddGlobalSession(session *apis.GlobalSession) error {
+	_, err := driver.engine.Exec(fmt.Sprintf(InsertGlobalTransaction, driver.globalTable, driver.now),
+		session.Addressing, session.XID, session.TransactionID, session.TransactionName,
+		session.Timeout, session.BeginTime, session.Status, session.Active)
+	return err
+}
+
+// FindGlobalSession finds a global session by xid.
+func (driver *driver) FindGlobalSession(xid string) *apis.GlobalSession {
+	var globalTransaction apis.GlobalSession
+	result, err := driver.engine.SQL(fmt.Sprintf(QueryGlobalTransactionByXid, driver.globalTable), xid).
+		Get(&globalTransaction)
+	if result {
+		return &globalTransaction
+	}
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return nil
+}
+
+// FindGlobalSessions finds global sessions list by statuses list
+func (driver *driver) FindGlobalSessions(statuses []apis.GlobalSession_GlobalStatus) []*apis.GlobalSession {
+	var globalSessions []*apis.GlobalSession
+	err := driver.engine.Table(driver.globalTable).
+		Where(builder.In("status", statuses)).
+		OrderBy("gmt_modified").
+		Limit(driver.queryLimit).
+		Find(&globalSessions)
+
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return globalSessions
+}
+
+// FindGlobalSessionsWithAddressingIdentities finds global sessions list by addressing identities and statuses list
+func (driver *driver) FindGlobalSessionsWithAddressingIdentities(statuses []apis.GlobalSession_GlobalStatus, addressingIdentities []string) []*apis.GlobalSession {
+	var globalSessions []*apis.GlobalSession
+	err := driver.engine.Table(driver.globalTable).
+		Where(builder.
+			In("status", statuses).
+			And(builder.In("addressing", addressingIdentities))).
+		OrderBy("gmt_modified").
+		Limit(driver.queryLimit).
+		Find(&globalSessions)
+
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return globalSessions
+}
+
+// AllSessions returns all sessions collection.
+func (driver *driver) AllSessions() []*apis.GlobalSession {
+	var globalSessions []*apis.GlobalSession
+	err := driver.engine.Table(driver.globalTable).
+		OrderBy("gmt_modified").
+		Limit(driver.queryLimit).
+		Find(&globalSessions)
+
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return globalSessions
+}
+
+// UpdateGlobalSessionStatus updates status of global session.
+func (driver *driver) UpdateGlobalSessionStatus(session *apis.GlobalSession, status apis.GlobalSession_GlobalStatus) error {
+	_, err := driver.engine.Exec(fmt.Sprintf(UpdateGlobalTransaction, driver.globalTable, driver.now), status, session.XID)
+	return err
+}
+
+// InactiveGlobalSession inactivates a global session.
+func (driver *driver) InactiveGlobalSession(session *apis.GlobalSession) error {
+	_, err := driver.engine.Exec(fmt.Sprintf(InactiveGlobalTransaction, driver.globalTable, driver.now), false,
+		session.XID)
+	return err
+}
+
+// RemoveGlobalSession removes a global session.
+func (driver *driver) RemoveGlobalSession(session *apis.GlobalSession) error {
+	_, err := driver.engine.Exec(fmt.Sprintf(DeleteGlobalTransaction, driver.globalTable), session.XID)
+	return err
+}
+
+// AddBranchSession adds a branch session.
+func (driver *driver) AddBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	_, err := driver.engine.Exec(fmt.Sprintf(InsertBranchTransaction, driver.branchTable, driver.now),
+		session.Addressing, session.XID, session.BranchID, session.TransactionID, session.ResourceID, session.LockKey,
+		session.Type, session.Status, session.ApplicationData, session.AsyncCommit)
+	return err
+}
+
+// FindBranchSessions finds branch sessions list by xid.
+func (driver *driver) FindBranchSessions(xid string) []*apis.BranchSession {
+	var branchTransactions []*apis.BranchSession
+	err := driver.engine.SQL(fmt.Sprintf(QueryBranchTransactionByXid, driver.branchTable), xid).Find(&branchTransactions)
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return branchTransactions
+}
+
+// FindBatchBranchSessions finds branch sessions list by xids list.
+func (driver *driver) FindBatchBranchSessions(xids []string) []*apis.BranchSession {
+	var (
+		branchTransactions []*apis.BranchSession
+		xidArgs            []interface{}
+	)
+	whereCond := fmt.Sprintf("xid in %s", sql.MysqlAppendInParam(len(xids)))
+	for _, xid := range xids {
+		xidArgs = append(xidArgs, xid)
+	}
+	err := driver.engine.SQL(fmt.Sprintf(QueryBranchTransaction, driver.branchTable, whereCond), xidArgs...).Find(&branchTransactions)
+
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	return branchTransactions
+}
+
+// UpdateBranchSessionStatus updates status of branch session.
+func (driver *driver) UpdateBranchSessionStatus(session *apis.BranchSession, status apis.BranchSession_BranchStatus) error {
+	_, err := driver.engine.Exec(fmt.Sprintf(UpdateBranchTransaction, driver.branchTable, driver.now),
+		status,
+		session.BranchID)
+	return err
+}
+
+// RemoveBranchSession removes branch session.
+func (driver *driver) RemoveBranchSession(globalSession *apis.GlobalSession, session *apis.BranchSession) error {
+	_, err := driver.engine.Exec(fmt.Sprintf(DeleteBranchTransaction, driver.branchTable),
+		session.BranchID)
+	return err
+}
+
+// AcquireLock acquires row locks. The conflicts with the table and pk range locks are checked
+// before the row locks are inserted, so the acquisitions of a driver involving them exclude each
+// other, but TC nodes sharing the lock table may race on overlapping ranges.
+func (driver *driver) AcquireLock(rowLocks []*apis.RowLock, skipCheckLock bool) bool {
+	locks, _ := distinctByKey(rowLocks)
+	if hasRangeLock(locks) {
+		driver.rangeMutex.Lock()
+		defer driver.rangeMutex.Unlock()
+	} else {
+		driver.rangeMutex.RLock()
+		defer driver.rangeMutex.RUnlock()
+	}
+
+	var existedRowLocks []*apis.RowLock
+	whereCond, condArgs := lockQueryCond(locks)
+	err := driver.engine.SQL(fmt.Sprintf(QueryRowKey, driver.lockTable, whereCond), condArgs...).Find(&existedRowLocks)
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+
+	var unrepeatedLocks []*apis.RowLock
+	if !skipCheckLock {
+		currentXID := locks[0].XID
+		canLock := true
+		existedRowKeys := make([]string, 0)
+		unrepeatedLocks = make([]*apis.RowLock, 0)
+		for _, rowLock := range existedRowLocks {
+			if rowLock.XID != currentXID && overlapsAny(rowLock, locks) {
+				log.Infof("row lock [%s] on %s:%s is holding by xid {%s} branchID {%d}", rowLock.RowKey, driver.lockTable, rowLock.TableName,
+					rowLock.PK, rowLock.XID, rowLock.BranchID)
+				canLock = false
+				break
+			}
+			if rowLock.XID == currentXID {
+				existedRowKeys = append(existedRowKeys, rowLock.RowKey)
+			}
+		}
+		if !canLock {
+			return false
+		}
+		if len(existedRowKeys) > 0 {
+			for _, lock := range locks {
+				if !contains(existedRowKeys, lock.RowKey) {
+					unrepeatedLocks = append(unrepeatedLocks, lock)
+				}
+			}
+		} else {
+			unrepeatedLocks = locks
+		}
+		if len(unrepeatedLocks) == 0 {
+			return true
+		}
+	}
+
+	if unrepeatedLocks == nil {
+		unrepeatedLocks = rowLocks
+	}
+	var (
+		sb        strings.Builder
+		args      []interface{}
+		sqlOrArgs []interface{}
+	)
+	for i := 0; i < len(unrepeatedLocks); i++ {
+		fmt.Fprintf(&sb, "(?, ?, ?, ?, ?, ?, ?, %[1]s, %[1]s),", driver.now)
+		args = append(args, unrepeatedLocks[i].XID, unrepeatedLocks[i].TransactionID, unrepeatedLocks[i].BranchID,
+			unrepeatedLocks[i].ResourceID, unrepeatedLocks[i].TableName, unrepeatedLocks[i].PK, unrepeatedLocks[i].RowKey)
+	}
+	values := sb.String()
+	valueStr := values[:len(values)-1]
+
+	sqlOrArgs = append(sqlOrArgs, fmt.Sprintf(InsertRowLock, driver.lockTable, valueStr))
+	sqlOrArgs = append(sqlOrArgs, args...)
+	_, err = driver.engine.Exec(sqlOrArgs...)
+	if err != nil {
+		// In an extremely high concurrency scenario, the row lock has been written to the database,
+		// but the database driver reports invalid connection exception, and then re-registers the branch,
+		// it will report the duplicate key exception.
+		log.Errorf("row locks batch acquire failed, %v, %v", unrepeatedLocks, err)
+		return false
+	}
+	return true
+}
+
+// ReleaseLock releases locked rows.
+func (driver *driver) ReleaseLock(rowLocks []*apis.RowLock) bool {
+	if rowLocks != nil && len(rowLocks) == 0 {
+		return true
+	}
+	rowKeys := make([]string, 0)
+	for _, lock := range rowLocks {
+		rowKeys = append(rowKeys, lock.RowKey)
+	}
+
+	var lock = apis.RowLock{}
+	_, err := driver.engine.Table(driver.lockTable).
+		Where(builder.In("row_key", rowKeys).And(builder.Eq{"xid": rowLocks[0].XID})).
+		Delete(&lock)
+
+	if err != nil {
+		log.Errorf(err.Error())
+		return false
+	}
+	return true
+}
+
+// IsLockable checks if a global transaction is lockable by xid, resourceID, lockKey.
+func (driver *driver) IsLockable(xid string, resourceID string, lockKey string) bool {
+	locks := storage.CollectRowLocks(lockKey, resourceID, xid)
+	if len(locks) == 0 {
+		return true
+	}
+	var existedRowLocks []*apis.RowLock
+	whereCond, args := lockQueryCond(locks)
+	err := driver.engine.SQL(fmt.Sprintf(QueryRowKey, driver.lockTable, whereCond), args...).Find(&existedRowLocks)
+	if err != nil {
+		log.Errorf(err.Error())
+	}
+	for _, rowLock := range existedRowLocks {
+		if rowLock.XID != xid && overlapsAny(rowLock, locks) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListRowLocks lists the row locks matching the query.
+func (driver *driver) ListRowLocks(query storage.RowLockQuery) ([]*apis.RowLock, error) {
+	cond := builder.Eq{}
+	if query.ResourceID != "" {
+		cond["resource_id"] = query.ResourceID
+	}
+	if query.TableName != "" {
+		cond["table_name"] = query.TableName
+	}
+	if query.XID != "" {
+		cond["xid"] = query.XID
+	}
+	if query.BranchID != 0 {
+		cond["branch_id"] = query.BranchID
+	}
+
+	rowLocks := make([]*apis.RowLock, 0)
+	session := driver.engine.Table(driver.lockTable)
+	if len(cond) > 0 {
+		session = session.Where(cond)
+	}
+	err := session.OrderBy("xid, row_key").Find(&rowLocks)
+	return rowLocks, err
+}
+
+func distinctByKey(locks []*apis.RowLock) ([]*apis.RowLock, []interface{}) {
+	result := make([]*apis.RowLock, 0)
+	rowKeys := make([]interface{}, 0)
+	lockMap := make(map[string]byte)
+	for _, lockDO := range locks {
+		l := len(lockMap)
+		lockMap[lockDO.RowKey] = 0
+		if len(lockMap) != l {
+			result = append(result, lockDO)
+			rowKeys = append(rowKeys, lockDO.RowKey)
+		}
+	}
+	return result, rowKeys
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+// lockQueryCond returns the condition querying the row locks which may conflict with the row locks:
+// the row locks of the same row keys, the table and pk range locks of their tables, and all the row
+// locks of the tables they hold a table or pk range lock of.
+func lockQueryCond(rowLocks []*apis.RowLock) (string, []interface{}) {
+	args := make([]interface{}, 0, len(rowLocks))
+	ranged := make(map[string]bool)
+	tables := make([]*apis.RowLock, 0)
+	for _, rowLock := range rowLocks {
+		args = append(args, rowLock.RowKey)
+		table := rowLock.ResourceID + storage.LockSplit + rowLock.TableName
+		if _, ok := ranged[table]; !ok {
+			tables = append(tables, rowLock)
+		}
+		ranged[table] = ranged[table] || storage.IsRangeLock(rowLock)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "row_key in %s", sql.MysqlAppendInParam(len(args)))
+	for _, rowLock := range tables {
+		if ranged[rowLock.ResourceID+storage.LockSplit+rowLock.TableName] {
+			sb.WriteString(" or (resource_id = ? and table_name = ?)")
+			args = append(args, rowLock.ResourceID, rowLock.TableName)
+		} else {
+			sb.WriteString(" or (resource_id = ? and table_name = ? and (pk = ? or pk like ?))")
+			args = append(args, rowLock.ResourceID, rowLock.TableName, storage.TableLockPK, storage.RangeLockStart+"%")
+		}
+	}
+	return sb.String(), args
+}
+
+func hasRangeLock(rowLocks []*apis.RowLock) bool {
+	for _, rowLock := range rowLocks {
+		if storage.IsRangeLock(rowLock) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlapsAny reports whether the row lock overlaps one of the row locks
+func overlapsAny(rowLock *apis.RowLock, rowLocks []*apis.RowLock) bool {
+	for _, lock := range rowLocks {
+		if storage.RowLocksOverlap(rowLock, lock) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/migration"
+)
+
+const (
+	CreateGlobalTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			addressing       VARCHAR(128) NOT NULL,
+			xid              VARCHAR(128) NOT NULL,
+			transaction_id   BIGINT DEFAULT NULL,
+			transaction_name VARCHAR(128) DEFAULT NULL,
+			timeout          INTEGER DEFAULT NULL,
+			begin_time       BIGINT DEFAULT NULL,
+			status           TINYINT NOT NULL,
+			active           BOOLEAN NOT NULL,
+			gmt_create       DATETIME DEFAULT NULL,
+			gmt_modified     DATETIME DEFAULT NULL,
+			PRIMARY KEY (xid)
+		);
+		CREATE INDEX IF NOT EXISTS idx_%s_gmt_modified_status ON %s (gmt_modified, status);
+		CREATE INDEX IF NOT EXISTS idx_%s_transaction_id ON %s (transaction_id);`
+
+	CreateBranchTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			addressing       VARCHAR(128) NOT NULL,
+			xid              VARCHAR(128) NOT NULL,
+			branch_id        BIGINT NOT NULL,
+			transaction_id   BIGINT DEFAULT NULL,
+			resource_id      VARCHAR(256) DEFAULT NULL,
+			lock_key         VARCHAR(1000),
+			branch_type      VARCHAR(8) DEFAULT NULL,
+			status           TINYINT DEFAULT NULL,
+			application_data VARCHAR(2000) DEFAULT NULL,
+			async_commit     BOOLEAN NOT NULL DEFAULT 0,
+			gmt_create       DATETIME DEFAULT NULL,
+			gmt_modified     DATETIME DEFAULT NULL,
+			PRIMARY KEY (branch_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_%s_xid ON %s (xid);`
+
+	CreateLockTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			row_key        VARCHAR(256) NOT NULL,
+			xid            VARCHAR(128) NOT NULL,
+			transaction_id BIGINT,
+			branch_id      BIGINT       NOT NULL,
+			resource_id    VARCHAR(256),
+			table_name     VARCHAR(64),
+			pk             VARCHAR(36),
+			gmt_create     DATETIME,
+			gmt_modified   DATETIME,
+			PRIMARY KEY (row_key)
+		);
+		CREATE INDEX IF NOT EXISTS idx_%s_branch_id ON %s (branch_id);`
+
+	// the length of a VARCHAR is not enforced by sqlite, the pk range locks fit the pk column
+	IndexLockTableByTable = `CREATE INDEX IF NOT EXISTS idx_%s_resource_table ON %s (resource_id, table_name);`
+)
+
+// migrations upgrade the schema of the sqlite storage driver, append a new migration to change the schema.
+var migrations = []migration.Migration{
+	{
+		Version:     1,
+		Description: "create the global, branch and lock tables",
+		Up: func(tables migration.Tables) []string {
+			return []string{
+				fmt.Sprintf(CreateGlobalTable, tables.GlobalTable, tables.GlobalTable, tables.GlobalTable, tables.GlobalTable, tables.GlobalTable),
+				fmt.Sprintf(CreateBranchTable, tables.BranchTable, tables.BranchTable, tables.BranchTable),
+				fmt.Sprintf(CreateLockTable, tables.LockTable, tables.LockTable, tables.LockTable),
+			}
+		},
+	},
+	{
+		Version:     2,
+		Description: "index the locks by table for the table and pk range locks",
+		Up: func(tables migration.Tables) []string {
+			return []string{
+				fmt.Sprintf(IndexLockTableByTable, tables.LockTable, tables.LockTable),
+			}
+		},
+	},
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	_ "modernc.org/sqlite" // register sqlite
+	"xorm.io/core"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/driver/sqldriver"
+)
+
+// dialect the sqlite dialect of the sql storage driver. The current time is precise to the
+// millisecond, so that the branch sessions keep their order.
+var dialect = sqldriver.Dialect{
+	Name:       "sqlite",
+	DriverName: "sqlite",
+	Now:        "strftime('%Y-%m-%d %H:%M:%f', 'now')",
+	// sqlite allows a single writer, the writes wait for each other in the connection pool instead of
+	// failing with database is locked
+	MaxOpenConnections: 1,
+	MaxIdleConnections: 1,
+	Migrations:         migrations,
+}
+
+func init() {
+	// xorm knows the sqlite dialect by the name of the cgo driver, the pure go driver registers
+	// itself as sqlite
+	core.RegisterDriver("sqlite", core.QueryDriver("sqlite3"))
+	sqldriver.Register(dialect)
+}
+
+type DriverParameters = sqldriver.DriverParameters
+
+func FromParameters(parameters map[string]interface{}) (storage.Driver, error) {
+	return sqldriver.FromParameters(dialect, parameters)
+}
+
+// New constructs a new Driver.
+func New(params DriverParameters) (storage.Driver, error) {
+	return sqldriver.New(dialect, params)
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/migration"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage/storagetest"
+)
+
+func newTestDriver(t *testing.T) storage.Driver {
+	d, err := FromParameters(map[string]interface{}{
+		"dsn": filepath.Join(t.TempDir(), "seata.db"),
+	})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		_ = d.(io.Closer).Close()
+	})
+	return d
+}
+
+func TestDriverSuite(t *testing.T) {
+	storagetest.RunDriverSuite(t, newTestDriver)
+}
+
+func TestRangeLockSuite(t *testing.T) {
+	storagetest.RunRangeLockSuite(t, newTestDriver)
+}
+
+func TestDriver_Migrations(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "seata.db")
+
+	// the driver refuses to start on a schema which is not migrated
+	_, err := FromParameters(map[string]interface{}{"dsn": dsn, "automigrate": "false"})
+	assert.Equal(t, migration.SchemaVersionError{Current: 0, Latest: len(migrations)}, err)
+
+	migrator, err := migration.Create("sqlite", map[string]interface{}{"dsn": dsn})
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	applied, err := migrator.Up()
+	assert.Nil(t, err)
+	assert.Len(t, applied, len(migrations))
+	assert.Nil(t, migrator.Close())
+
+	d, err := FromParameters(map[string]interface{}{"dsn": dsn, "automigrate": false})
+	assert.Nil(t, err)
+	assert.Nil(t, d.(io.Closer).Close())
+}
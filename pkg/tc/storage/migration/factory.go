@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"fmt"
+)
+
+// migratorFactories stores an internal mapping between storage driver names and their respective
+// migrator factories
+var migratorFactories = make(map[string]MigratorFactory)
+
+// MigratorFactory is a factory interface for creating the Migrator of a sql storage driver.
+// The sql storage drivers register it with the same name and parameters as their
+// factory.StorageDriverFactory, usually in their init() funcs.
+type MigratorFactory interface {
+	// CreateMigrator returns a new Migrator with the given storage driver parameters
+	CreateMigrator(parameters map[string]interface{}) (*Migrator, error)
+}
+
+// Register makes the migrator of a storage driver available by the provided name.
+// If Register is called twice with the same name or if migrator factory is nil, it panics.
+func Register(name string, factory MigratorFactory) {
+	if factory == nil {
+		panic("Must not provide nil MigratorFactory")
+	}
+	_, registered := migratorFactories[name]
+	if registered {
+		panic(fmt.Sprintf("MigratorFactory named %s already registered", name))
+	}
+
+	migratorFactories[name] = factory
+}
+
+// Create a new Migrator for the storage driver with the given name and parameters. An
+// UnsupportedDriverError is returned if the storage driver has no schema to migrate.
+func Create(name string, parameters map[string]interface{}) (*Migrator, error) {
+	migratorFactory, ok := migratorFactories[name]
+	if !ok {
+		return nil, UnsupportedDriverError{name}
+	}
+	return migratorFactory.CreateMigrator(parameters)
+}
+
+// UnsupportedDriverError records an attempt to migrate a storage driver without a migrator
+type UnsupportedDriverError struct {
+	Name string
+}
+
+func (err UnsupportedDriverError) Error() string {
+	return fmt.Sprintf("Driver has no schema migrations: %s", err.Name)
+}
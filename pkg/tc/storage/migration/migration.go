@@ -0,0 +1,184 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-xorm/xorm"
+
+	"github.com/opentrx/seata-golang/v2/pkg/util/log"
+)
+
+const (
+	CreateVersionTable = `
+		CREATE TABLE IF NOT EXISTS %s
+		(
+			version     int          NOT NULL,
+			description varchar(256) NOT NULL,
+			applied_at  bigint       NOT NULL,
+			PRIMARY KEY (version)
+		)`
+
+	QueryCurrentVersion = "select max(version) as version from %s"
+
+	InsertVersion = "insert into %s (version, description, applied_at) values (?, ?, ?)"
+)
+
+// Tables are the table names configured for a sql storage driver.
+type Tables struct {
+	GlobalTable string
+	BranchTable string
+	LockTable   string
+
+	// GlobalSessionLockTable is the table of the database global session locker
+	GlobalSessionLockTable string
+}
+
+// Migration is a versioned change of the schema of a sql storage driver. A released migration
+// must never be changed, the schema is changed by appending a migration with a higher version.
+type Migration struct {
+	Version     int
+	Description string
+	// Up returns the statements which upgrade the schema of the previous version to this version
+	Up func(tables Tables) []string
+}
+
+// Migrator applies the migrations of a sql storage driver in order and records every applied
+// version in the version table.
+type Migrator struct {
+	engine       *xorm.Engine
+	versionTable string
+	tables       Tables
+	migrations   []Migration
+}
+
+// NewMigrator constructs a Migrator, the versions of the migrations must be unique and positive.
+func NewMigrator(engine *xorm.Engine, versionTable string, tables Tables, migrations []Migration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	for i, migration := range sorted {
+		if migration.Version <= 0 {
+			return nil, fmt.Errorf("the version of migration %q should be positive", migration.Description)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("duplicated migration version %d", migration.Version)
+		}
+	}
+	return &Migrator{
+		engine:       engine,
+		versionTable: versionTable,
+		tables:       tables,
+		migrations:   sorted,
+	}, nil
+}
+
+// LatestVersion returns the schema version the migrations upgrade to.
+func (m *Migrator) LatestVersion() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// CurrentVersion returns the schema version of the database, it is 0 if no migration is applied.
+func (m *Migrator) CurrentVersion() (int, error) {
+	exist, err := m.engine.IsTableExist(m.versionTable)
+	if err != nil || !exist {
+		return 0, err
+	}
+	results, err := m.engine.QueryString(fmt.Sprintf(QueryCurrentVersion, m.versionTable))
+	if err != nil || len(results) == 0 || results[0]["version"] == "" {
+		return 0, err
+	}
+	return strconv.Atoi(results[0]["version"])
+}
+
+// Check returns a SchemaVersionError unless the schema of the database is the latest version.
+func (m *Migrator) Check() error {
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+	if current != m.LatestVersion() {
+		return SchemaVersionError{Current: current, Latest: m.LatestVersion()}
+	}
+	return nil
+}
+
+// Up applies the pending migrations in order and returns them. Every migration is applied in its own
+// transaction, note that some databases such as mysql commit a DDL statement implicitly. A schema newer
+// than the latest version is never downgraded, a SchemaVersionError is returned instead.
+func (m *Migrator) Up() ([]Migration, error) {
+	if _, err := m.engine.Exec(fmt.Sprintf(CreateVersionTable, m.versionTable)); err != nil {
+		return nil, err
+	}
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return nil, err
+	}
+	if current > m.LatestVersion() {
+		return nil, SchemaVersionError{Current: current, Latest: m.LatestVersion()}
+	}
+
+	var applied []Migration
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := m.apply(migration); err != nil {
+			return applied, fmt.Errorf("failed to apply migration %d (%s): %v", migration.Version,
+				migration.Description, err)
+		}
+		log.Infof("applied schema migration %d: %s", migration.Version, migration.Description)
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
+
+func (m *Migrator) apply(migration Migration) error {
+	session := m.engine.NewSession()
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+	for _, statement := range migration.Up(m.tables) {
+		if _, err := session.Exec(statement); err != nil {
+			_ = session.Rollback()
+			return err
+		}
+	}
+	_, err := session.Exec(fmt.Sprintf(InsertVersion, m.versionTable),
+		migration.Version, migration.Description, time.Now().UnixNano()/int64(time.Millisecond))
+	if err != nil {
+		_ = session.Rollback()
+		return err
+	}
+	return session.Commit()
+}
+
+// Close closes the database connections of the migrator.
+func (m *Migrator) Close() error {
+	return m.engine.Close()
+}
+
+// SchemaVersionError records that the schema of the database is not the version the storage driver
+// requires.
+type SchemaVersionError struct {
+	Current int
+	Latest  int
+}
+
+func (err SchemaVersionError) Error() string {
+	if err.Current > err.Latest {
+		return fmt.Sprintf("the schema version %d is newer than the supported version %d, upgrade the tc",
+			err.Current, err.Latest)
+	}
+	return fmt.Sprintf("the schema version %d is older than the required version %d, run tc migrate",
+		err.Current, err.Latest)
+}
@@ -0,0 +1,121 @@
+package migration
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-xorm/xorm"
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+	"xorm.io/core"
+)
+
+func init() {
+	core.RegisterDriver("sqlite", core.QueryDriver("sqlite3"))
+}
+
+var testMigrations = []Migration{
+	{
+		Version:     2,
+		Description: "add the branch table",
+		Up: func(tables Tables) []string {
+			return []string{fmt.Sprintf("CREATE TABLE %s (branch_id bigint NOT NULL)", tables.BranchTable)}
+		},
+	},
+	{
+		Version:     1,
+		Description: "add the global table",
+		Up: func(tables Tables) []string {
+			return []string{fmt.Sprintf("CREATE TABLE %s (xid varchar(128) NOT NULL)", tables.GlobalTable)}
+		},
+	},
+}
+
+func newTestEngine(t *testing.T) *xorm.Engine {
+	engine, err := xorm.NewEngine("sqlite", filepath.Join(t.TempDir(), "seata.db"))
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	engine.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = engine.Close()
+	})
+	return engine
+}
+
+func TestMigrator_Up(t *testing.T) {
+	engine := newTestEngine(t)
+	tables := Tables{GlobalTable: "global_table", BranchTable: "branch_table", LockTable: "lock_table"}
+
+	migrator, err := NewMigrator(engine, "schema_version", tables, testMigrations[1:])
+	assert.Nil(t, err)
+	current, err := migrator.CurrentVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, current)
+	assert.Equal(t, SchemaVersionError{Current: 0, Latest: 1}, migrator.Check())
+
+	applied, err := migrator.Up()
+	assert.Nil(t, err)
+	assert.Len(t, applied, 1)
+	assert.Nil(t, migrator.Check())
+
+	// only the pending migrations are applied, in order of their versions
+	migrator, err = NewMigrator(engine, "schema_version", tables, testMigrations)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, migrator.LatestVersion())
+	assert.Equal(t, SchemaVersionError{Current: 1, Latest: 2}, migrator.Check())
+	applied, err = migrator.Up()
+	assert.Nil(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, 2, applied[0].Version)
+	exist, err := engine.IsTableExist("branch_table")
+	assert.Nil(t, err)
+	assert.True(t, exist)
+
+	applied, err = migrator.Up()
+	assert.Nil(t, err)
+	assert.Len(t, applied, 0)
+
+	// a schema newer than the migrations is refused
+	migrator, err = NewMigrator(engine, "schema_version", tables, testMigrations[1:])
+	assert.Nil(t, err)
+	_, err = migrator.Up()
+	assert.Equal(t, SchemaVersionError{Current: 2, Latest: 1}, err)
+	assert.Equal(t, SchemaVersionError{Current: 2, Latest: 1}, migrator.Check())
+}
+
+func TestMigrator_UpFailure(t *testing.T) {
+	engine := newTestEngine(t)
+	migrator, err := NewMigrator(engine, "schema_version", Tables{GlobalTable: "global_table"}, []Migration{
+		testMigrations[1],
+		{
+			Version:     2,
+			Description: "invalid",
+			Up: func(tables Tables) []string {
+				return []string{"CREATE TABLE lock_table (row_key varchar(256))", "CREATE TABLE"}
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	applied, err := migrator.Up()
+	assert.NotNil(t, err)
+	assert.Len(t, applied, 1)
+	current, err := migrator.CurrentVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, current)
+
+	// the statements of the failed migration are rolled back
+	exist, err := engine.IsTableExist("lock_table")
+	assert.Nil(t, err)
+	assert.False(t, exist)
+}
+
+func TestNewMigrator(t *testing.T) {
+	engine := newTestEngine(t)
+	_, err := NewMigrator(engine, "schema_version", Tables{}, append(testMigrations, testMigrations[0]))
+	assert.NotNil(t, err)
+	_, err = NewMigrator(engine, "schema_version", Tables{}, []Migration{{Version: 0}})
+	assert.NotNil(t, err)
+}
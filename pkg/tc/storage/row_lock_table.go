@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"encoding/json"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+)
+
+// RowLockTable holds the row locks of the drivers keeping them in memory. The row locks are indexed
+// by the table they lock, so that a table or pk range lock is only checked against the row locks of
+// its table, and a row lock against them only if the table holds a table or pk range lock. It is not
+// safe for concurrent use, and it is marshaled to JSON as a map of row key -> row lock.
+type RowLockTable struct {
+	// tables resource id^^^table name -> row key -> row lock
+	tables map[string]map[string]*apis.RowLock
+
+	// ranges resource id^^^table name -> the count of its table and pk range locks
+	ranges map[string]int
+}
+
+func NewRowLockTable() *RowLockTable {
+	return &RowLockTable{
+		tables: make(map[string]map[string]*apis.RowLock),
+		ranges: make(map[string]int),
+	}
+}
+
+// Get returns the row lock held on the row key of the row lock.
+func (t *RowLockTable) Get(rowLock *apis.RowLock) *apis.RowLock {
+	return t.tables[tableKey(rowLock)][rowLock.RowKey]
+}
+
+// Conflict returns a row lock of another xid overlapping the row lock.
+func (t *RowLockTable) Conflict(rowLock *apis.RowLock) *apis.RowLock {
+	table := tableKey(rowLock)
+	locks := t.tables[table]
+	if held, ok := locks[rowLock.RowKey]; ok && held.XID != rowLock.XID {
+		return held
+	}
+	if !IsRangeLock(rowLock) && t.ranges[table] == 0 {
+		return nil
+	}
+	for _, held := range locks {
+		if held.XID != rowLock.XID && RowLocksOverlap(held, rowLock) {
+			return held
+		}
+	}
+	return nil
+}
+
+// Put holds the row lock, it replaces the row lock held on the same row key.
+func (t *RowLockTable) Put(rowLock *apis.RowLock) {
+	table := tableKey(rowLock)
+	locks, ok := t.tables[table]
+	if !ok {
+		locks = make(map[string]*apis.RowLock)
+		t.tables[table] = locks
+	}
+	if _, ok := locks[rowLock.RowKey]; !ok && IsRangeLock(rowLock) {
+		t.ranges[table]++
+	}
+	locks[rowLock.RowKey] = rowLock
+}
+
+// Remove releases the row lock if it is held by the same xid.
+func (t *RowLockTable) Remove(rowLock *apis.RowLock) {
+	table := tableKey(rowLock)
+	held, ok := t.tables[table][rowLock.RowKey]
+	if !ok || held.XID != rowLock.XID {
+		return
+	}
+	delete(t.tables[table], rowLock.RowKey)
+	if len(t.tables[table]) == 0 {
+		delete(t.tables, table)
+	}
+	if IsRangeLock(rowLock) {
+		if t.ranges[table]--; t.ranges[table] == 0 {
+			delete(t.ranges, table)
+		}
+	}
+}
+
+// List returns the row locks matching the query, in the order of their xids and row keys.
+func (t *RowLockTable) List(query RowLockQuery) []*apis.RowLock {
+	rowLocks := make([]*apis.RowLock, 0)
+	for _, locks := range t.tables {
+		for _, rowLock := range locks {
+			if query.Matches(rowLock) {
+				rowLocks = append(rowLocks, rowLock)
+			}
+		}
+	}
+	SortRowLocks(rowLocks)
+	return rowLocks
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (t *RowLockTable) MarshalJSON() ([]byte, error) {
+	rowLocks := make(map[string]*apis.RowLock)
+	for _, locks := range t.tables {
+		for rowKey, rowLock := range locks {
+			rowLocks[rowKey] = rowLock
+		}
+	}
+	return json.Marshal(rowLocks)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *RowLockTable) UnmarshalJSON(data []byte) error {
+	rowLocks := make(map[string]*apis.RowLock)
+	if err := json.Unmarshal(data, &rowLocks); err != nil {
+		return err
+	}
+	*t = *NewRowLockTable()
+	for _, rowLock := range rowLocks {
+		t.Put(rowLock)
+	}
+	return nil
+}
+
+func tableKey(rowLock *apis.RowLock) string {
+	return rowLock.ResourceID + LockSplit + rowLock.TableName
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowLockTable(t *testing.T) {
+	table := NewRowLockTable()
+	for _, rowLock := range CollectRowLocks("product:1,[10~20]", "db", "localhost:8091:1") {
+		table.Put(rowLock)
+	}
+
+	row := CollectRowLocks("product:15", "db", "localhost:8091:2")[0]
+	assert.Nil(t, table.Get(row))
+	assert.Equal(t, "db^^^product^^^[10~20]", table.Conflict(row).RowKey)
+	assert.Nil(t, table.Conflict(CollectRowLocks("product:15", "db", "localhost:8091:1")[0]))
+	assert.Nil(t, table.Conflict(CollectRowLocks("order:15", "db", "localhost:8091:2")[0]))
+	assert.Equal(t, "db^^^product^^^1", table.Conflict(CollectRowLocks("product:*", "db", "localhost:8091:2")[0]).RowKey)
+
+	// the table survives a JSON round trip with its pk range locks indexed
+	data, err := json.Marshal(table)
+	assert.Nil(t, err)
+	restored := &RowLockTable{}
+	assert.Nil(t, json.Unmarshal(data, restored))
+	assert.Equal(t, table, restored)
+	assert.Len(t, restored.List(RowLockQuery{TableName: "product"}), 2)
+
+	// a row lock is released by its own xid only
+	rangeLock := CollectRowLocks("product:[10~20]", "db", "localhost:8091:2")[0]
+	table.Remove(rangeLock)
+	assert.NotNil(t, table.Conflict(row))
+	table.Remove(CollectRowLocks("product:[10~20]", "db", "localhost:8091:1")[0])
+	assert.Nil(t, table.Conflict(row))
+	assert.Empty(t, table.ranges)
+}
@@ -2,6 +2,8 @@ package storage
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/opentrx/seata-golang/v2/pkg/apis"
@@ -10,44 +12,79 @@ import (
 
 const LockSplit = "^^^"
 
+const (
+	// TableLockPK is the pk of a lock key locking all the rows of a table, e.g. product:*
+	TableLockPK = "*"
+
+	// RangeLockStart, RangeLockSplit and RangeLockEnd delimit the pk of a lock key locking the rows
+	// whose pk is between the bounds, both inclusive, e.g. product:[100~200]. A missing bound leaves
+	// the range open on that side, e.g. product:[100~].
+	RangeLockStart = "["
+	RangeLockSplit = "~"
+	RangeLockEnd   = "]"
+)
+
+// pkRange is the pks covered by the pk of a row lock
+type pkRange struct {
+	lower, upper       string
+	hasLower, hasUpper bool
+}
+
+// LockKeyError is returned on a malformed lock key, such as a pk range missing its end.
+type LockKeyError struct {
+	LockKey string
+	Reason  string
+}
+
+func (e *LockKeyError) Error() string {
+	return fmt.Sprintf("malformed lock key %s: %s", e.LockKey, e.Reason)
+}
+
 func CollectBranchSessionRowLocks(branchSession *apis.BranchSession) []*apis.RowLock {
+	locks, _ := ParseBranchSessionRowLocks(branchSession)
+	return locks
+}
+
+// ParseBranchSessionRowLocks returns the row locks of the branch session, or a LockKeyError if its
+// lock key is malformed.
+func ParseBranchSessionRowLocks(branchSession *apis.BranchSession) ([]*apis.RowLock, error) {
 	if branchSession == nil || branchSession.LockKey == "" {
-		return nil
+		return nil, nil
 	}
-	return collectRowLocks(branchSession.LockKey, branchSession.ResourceID, branchSession.XID, branchSession.TransactionID, branchSession.BranchID)
+	return parseRowLocks(branchSession.LockKey, branchSession.ResourceID, branchSession.XID, branchSession.TransactionID, branchSession.BranchID)
 }
 
 func CollectRowLocks(lockKey string, resourceID string, xid string) []*apis.RowLock {
-	return collectRowLocks(lockKey, resourceID, xid, common.GetTransactionID(xid), 0)
+	locks, _ := parseRowLocks(lockKey, resourceID, xid, common.GetTransactionID(xid), 0)
+	return locks
 }
 
-func collectRowLocks(lockKey string,
+func parseRowLocks(lockKey string,
 	resourceID string,
 	xid string,
 	transactionID int64,
-	branchID int64) []*apis.RowLock {
+	branchID int64) ([]*apis.RowLock, error) {
 	var locks = make([]*apis.RowLock, 0)
 	tableGroupedLockKeys := strings.Split(lockKey, ";")
 	for _, tableGroupedLockKey := range tableGroupedLockKeys {
 		if tableGroupedLockKey != "" {
 			idx := strings.Index(tableGroupedLockKey, ":")
 			if idx < 0 {
-				return nil
+				return nil, &LockKeyError{LockKey: lockKey, Reason: fmt.Sprintf("%s has no table name", tableGroupedLockKey)}
 			}
 
 			tableName := tableGroupedLockKey[0:idx]
 			mergedPKs := tableGroupedLockKey[idx+1:]
 
 			if mergedPKs == "" {
-				return nil
+				return nil, &LockKeyError{LockKey: lockKey, Reason: fmt.Sprintf("table %s has no pks", tableName)}
 			}
 
 			pks := strings.Split(mergedPKs, ",")
-			if len(pks) == 0 {
-				return nil
-			}
-
 			for _, pk := range pks {
+				if _, ok := parsePKRange(pk); !ok {
+					return nil, &LockKeyError{LockKey: lockKey, Reason: fmt.Sprintf("%s is not a valid pk range", pk)}
+				}
 				if pk != "" {
 					rowLock := &apis.RowLock{
 						XID:           xid,
@@ -63,9 +100,83 @@ func collectRowLocks(lockKey string,
 			}
 		}
 	}
-	return locks
+	return locks, nil
 }
 
 func getRowKey(resourceID string, tableName string, pk string) string {
 	return fmt.Sprintf("%s^^^%s^^^%s", resourceID, tableName, pk)
 }
+
+// IsRangeLock reports whether the row lock is a table or a pk range lock rather than a lock on a
+// single row.
+func IsRangeLock(rowLock *apis.RowLock) bool {
+	return rowLock.PK == TableLockPK || strings.HasPrefix(rowLock.PK, RangeLockStart)
+}
+
+// RowLocksOverlap reports whether the row locks cover a row in common, regardless of their xids.
+func RowLocksOverlap(a, b *apis.RowLock) bool {
+	if a.ResourceID != b.ResourceID || a.TableName != b.TableName {
+		return false
+	}
+	if !IsRangeLock(a) && !IsRangeLock(b) {
+		return a.PK == b.PK
+	}
+	rangeA, _ := parsePKRange(a.PK)
+	rangeB, _ := parsePKRange(b.PK)
+	if rangeA.hasLower && rangeB.hasUpper && ComparePK(rangeA.lower, rangeB.upper) > 0 {
+		return false
+	}
+	if rangeB.hasLower && rangeA.hasUpper && ComparePK(rangeB.lower, rangeA.upper) > 0 {
+		return false
+	}
+	return true
+}
+
+// SortRowLocks sorts the row locks in the order of their xids and row keys.
+func SortRowLocks(rowLocks []*apis.RowLock) {
+	sort.Slice(rowLocks, func(i, j int) bool {
+		if rowLocks[i].XID != rowLocks[j].XID {
+			return rowLocks[i].XID < rowLocks[j].XID
+		}
+		return rowLocks[i].RowKey < rowLocks[j].RowKey
+	})
+}
+
+// ComparePK compares two pks as integers if both of them are, otherwise as strings.
+func ComparePK(a, b string) int {
+	intA, errA := strconv.ParseInt(a, 10, 64)
+	intB, errB := strconv.ParseInt(b, 10, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case intA < intB:
+		return -1
+	case intA > intB:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parsePKRange returns the pks covered by the pk of a lock key, it fails on a malformed pk range
+func parsePKRange(pk string) (pkRange, bool) {
+	if pk == TableLockPK {
+		return pkRange{}, true
+	}
+	if !strings.HasPrefix(pk, RangeLockStart) {
+		return pkRange{lower: pk, upper: pk, hasLower: true, hasUpper: true}, true
+	}
+	if !strings.HasSuffix(pk, RangeLockEnd) {
+		return pkRange{}, false
+	}
+	bounds := strings.Split(pk[len(RangeLockStart):len(pk)-len(RangeLockEnd)], RangeLockSplit)
+	if len(bounds) != 2 {
+		return pkRange{}, false
+	}
+	r := pkRange{lower: bounds[0], upper: bounds[1], hasLower: bounds[0] != "", hasUpper: bounds[1] != ""}
+	if r.hasLower && r.hasUpper && ComparePK(r.lower, r.upper) > 0 {
+		return pkRange{}, false
+	}
+	return r, true
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+)
+
+func TestCollectRowLocks(t *testing.T) {
+	locks := CollectRowLocks("product:1,[2~5],[~0];order:*", "db", "localhost:8091:1")
+	assert.Len(t, locks, 4)
+	assert.Equal(t, "db^^^product^^^1", locks[0].RowKey)
+	assert.False(t, IsRangeLock(locks[0]))
+	assert.Equal(t, "db^^^product^^^[2~5]", locks[1].RowKey)
+	assert.True(t, IsRangeLock(locks[1]))
+	assert.True(t, IsRangeLock(locks[2]))
+	assert.Equal(t, "db^^^order^^^*", locks[3].RowKey)
+	assert.True(t, IsRangeLock(locks[3]))
+
+	// the malformed pk ranges are rejected like the other malformed lock keys
+	assert.Nil(t, CollectRowLocks("product:[1~5", "db", "localhost:8091:1"))
+	assert.Nil(t, CollectRowLocks("product:[1~2~5]", "db", "localhost:8091:1"))
+	assert.Nil(t, CollectRowLocks("product:[5~1]", "db", "localhost:8091:1"))
+
+	_, err := ParseBranchSessionRowLocks(&apis.BranchSession{LockKey: "product:1,[5~1]", ResourceID: "db"})
+	assert.EqualError(t, err, "malformed lock key product:1,[5~1]: [5~1] is not a valid pk range")
+	_, err = ParseBranchSessionRowLocks(&apis.BranchSession{LockKey: "product", ResourceID: "db"})
+	assert.EqualError(t, err, "malformed lock key product: product has no table name")
+}
+
+func TestRowLocksOverlap(t *testing.T) {
+	lock := func(lockKey string) *apis.RowLock {
+		return CollectRowLocks(lockKey, "db", "localhost:8091:1")[0]
+	}
+	tests := []struct {
+		a, b    string
+		overlap bool
+	}{
+		{"product:1", "product:1", true},
+		{"product:1", "product:2", false},
+		{"product:1", "order:1", false},
+		{"product:1", "product:*", true},
+		{"product:*", "order:*", false},
+		{"product:[1~10]", "product:10", true},
+		{"product:[1~10]", "product:11", false},
+		{"product:[1~10]", "product:[10~20]", true},
+		{"product:[1~10]", "product:[11~]", false},
+		{"product:[~10]", "product:[5~]", true},
+		{"product:[2~10]", "product:9", true},
+		{"product:[a~c]", "product:b", true},
+		{"product:[a~c]", "product:d", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.overlap, RowLocksOverlap(lock(test.a), lock(test.b)), "%s %s", test.a, test.b)
+		assert.Equal(t, test.overlap, RowLocksOverlap(lock(test.b), lock(test.a)), "%s %s", test.b, test.a)
+	}
+}
+
+func TestComparePK(t *testing.T) {
+	assert.Equal(t, -1, ComparePK("9", "10"))
+	assert.Equal(t, 1, ComparePK("b", "a"))
+	assert.Equal(t, -1, ComparePK("10", "9a"))
+	assert.Equal(t, 0, ComparePK("7", "7"))
+}
@@ -57,6 +57,35 @@ type LockManager interface {
 	IsLockable(xid string, resourceID string, lockKey string) bool
 }
 
+// RowLockQuery filters the row locks listed by a LockLister, the zero fields match all the row locks.
+type RowLockQuery struct {
+	ResourceID string
+	TableName  string
+	XID        string
+	BranchID   int64
+}
+
+// Matches reports whether the row lock passes the filters of the query.
+func (query RowLockQuery) Matches(rowLock *apis.RowLock) bool {
+	return (query.ResourceID == "" || query.ResourceID == rowLock.ResourceID) &&
+		(query.TableName == "" || query.TableName == rowLock.TableName) &&
+		(query.XID == "" || query.XID == rowLock.XID) &&
+		(query.BranchID == 0 || query.BranchID == rowLock.BranchID)
+}
+
+// LeaderElector is implemented by the drivers replicating the storage to a cluster of TC nodes, the
+// TC runs its timeout and retry loops on the leader only.
+type LeaderElector interface {
+	// IsLeader reports whether this node is the leader of the cluster.
+	IsLeader() bool
+}
+
+// LockLister is implemented by the lock managers which can list the row locks they hold.
+type LockLister interface {
+	// ListRowLocks lists the row locks matching the query, in the order of their xids and row keys.
+	ListRowLocks(query RowLockQuery) ([]*apis.RowLock, error)
+}
+
 type Driver interface {
 	SessionManager
 	LockManager
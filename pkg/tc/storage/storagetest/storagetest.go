@@ -0,0 +1,382 @@
+// Package storagetest provides a conformance suite which every storage.Driver implementation
+// should pass, so the transaction coordinator behaves the same whatever storage it runs on.
+package storagetest
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opentrx/seata-golang/v2/pkg/apis"
+	"github.com/opentrx/seata-golang/v2/pkg/tc/storage"
+)
+
+// DriverFactory creates an empty driver for a test, the driver should be released by the cleanup
+// functions registered to the test.
+type DriverFactory func(t *testing.T) storage.Driver
+
+// RunDriverSuite runs the conformance suite against the drivers created by the factory, every
+// sub test runs on a new driver.
+func RunDriverSuite(t *testing.T, factory DriverFactory) {
+	t.Run("GlobalSessionCRUD", func(t *testing.T) {
+		testGlobalSessionCRUD(t, factory(t))
+	})
+	t.Run("GlobalSessionQueries", func(t *testing.T) {
+		testGlobalSessionQueries(t, factory(t))
+	})
+	t.Run("BranchSessionCRUD", func(t *testing.T) {
+		testBranchSessionCRUD(t, factory(t))
+	})
+	t.Run("BatchBranchSessions", func(t *testing.T) {
+		testBatchBranchSessions(t, factory(t))
+	})
+	t.Run("AcquireAndReleaseLock", func(t *testing.T) {
+		testAcquireAndReleaseLock(t, factory(t))
+	})
+	t.Run("LockConflict", func(t *testing.T) {
+		testLockConflict(t, factory(t))
+	})
+	t.Run("ReentrantLock", func(t *testing.T) {
+		testReentrantLock(t, factory(t))
+	})
+	t.Run("SkipCheckLock", func(t *testing.T) {
+		testSkipCheckLock(t, factory(t))
+	})
+	t.Run("ListRowLocks", func(t *testing.T) {
+		testListRowLocks(t, factory(t))
+	})
+}
+
+// RunRangeLockSuite runs the conformance suite of the table and pk range locks against the drivers
+// created by the factory, for the drivers detecting the conflicts between row, range and table
+// locks. The other drivers only detect the conflicts between identical lock keys.
+func RunRangeLockSuite(t *testing.T, factory DriverFactory) {
+	t.Run("RangeLockConflict", func(t *testing.T) {
+		testRangeLockConflict(t, factory(t))
+	})
+	t.Run("TableLockConflict", func(t *testing.T) {
+		testTableLockConflict(t, factory(t))
+	})
+	t.Run("ReentrantRangeLock", func(t *testing.T) {
+		testReentrantRangeLock(t, factory(t))
+	})
+}
+
+func newGlobalSession(xid string, transactionID int64, addressing string, beginTime int64) *apis.GlobalSession {
+	return &apis.GlobalSession{
+		Addressing:      addressing,
+		XID:             xid,
+		TransactionID:   transactionID,
+		TransactionName: "test",
+		Timeout:         60000,
+		BeginTime:       beginTime,
+		Status:          apis.Begin,
+		Active:          true,
+	}
+}
+
+func newBranchSession(gs *apis.GlobalSession, branchID int64, lockKey string) *apis.BranchSession {
+	return &apis.BranchSession{
+		Addressing:      gs.Addressing,
+		XID:             gs.XID,
+		BranchID:        branchID,
+		TransactionID:   gs.TransactionID,
+		ResourceID:      "db",
+		LockKey:         lockKey,
+		Type:            apis.AT,
+		Status:          apis.Registered,
+		ApplicationData: []byte(`{"skipCheckLock":false}`),
+		AsyncCommit:     true,
+	}
+}
+
+func xids(sessions []*apis.GlobalSession) []string {
+	result := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, session.XID)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func branchIDs(sessions []*apis.BranchSession) []int64 {
+	result := make([]int64, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, session.BranchID)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
+func findBranchSession(sessions []*apis.BranchSession, branchID int64) *apis.BranchSession {
+	for _, session := range sessions {
+		if session.BranchID == branchID {
+			return session
+		}
+	}
+	return nil
+}
+
+func testGlobalSessionCRUD(t *testing.T, driver storage.Driver) {
+	gs := newGlobalSession("127.0.0.1:8091:1001", 1001, "127.0.0.1:8080", 1)
+	assert.Nil(t, driver.AddGlobalSession(gs))
+
+	session := driver.FindGlobalSession(gs.XID)
+	if !assert.NotNil(t, session) {
+		return
+	}
+	assert.Equal(t, gs.Addressing, session.Addressing)
+	assert.Equal(t, gs.XID, session.XID)
+	assert.Equal(t, gs.TransactionID, session.TransactionID)
+	assert.Equal(t, gs.TransactionName, session.TransactionName)
+	assert.Equal(t, gs.Timeout, session.Timeout)
+	assert.Equal(t, gs.BeginTime, session.BeginTime)
+	assert.Equal(t, apis.Begin, session.Status)
+	assert.True(t, session.Active)
+	assert.Nil(t, driver.FindGlobalSession("127.0.0.1:8091:1002"))
+
+	assert.Nil(t, driver.UpdateGlobalSessionStatus(gs, apis.Committing))
+	assert.Equal(t, apis.Committing, driver.FindGlobalSession(gs.XID).Status)
+
+	assert.Nil(t, driver.InactiveGlobalSession(gs))
+	assert.False(t, driver.FindGlobalSession(gs.XID).Active)
+
+	assert.Nil(t, driver.RemoveGlobalSession(gs))
+	assert.Nil(t, driver.FindGlobalSession(gs.XID))
+	assert.Len(t, driver.AllSessions(), 0)
+}
+
+func testGlobalSessionQueries(t *testing.T, driver storage.Driver) {
+	gs1 := newGlobalSession("127.0.0.1:8091:2001", 2001, "127.0.0.1:8080", 1)
+	gs2 := newGlobalSession("127.0.0.1:8091:2002", 2002, "127.0.0.1:8080", 2)
+	gs3 := newGlobalSession("127.0.0.1:8091:2003", 2003, "127.0.0.1:9090", 3)
+	for _, gs := range []*apis.GlobalSession{gs1, gs2, gs3} {
+		assert.Nil(t, driver.AddGlobalSession(gs))
+	}
+	assert.Nil(t, driver.UpdateGlobalSessionStatus(gs2, apis.Committing))
+	assert.Nil(t, driver.UpdateGlobalSessionStatus(gs3, apis.RollingBack))
+
+	assert.Equal(t, []string{gs1.XID, gs2.XID, gs3.XID}, xids(driver.AllSessions()))
+	assert.Equal(t, []string{gs1.XID}, xids(driver.FindGlobalSessions([]apis.GlobalSession_GlobalStatus{apis.Begin})))
+	assert.Equal(t, []string{gs2.XID, gs3.XID}, xids(driver.FindGlobalSessions(
+		[]apis.GlobalSession_GlobalStatus{apis.Committing, apis.RollingBack})))
+	assert.Len(t, driver.FindGlobalSessions([]apis.GlobalSession_GlobalStatus{apis.Committed}), 0)
+
+	assert.Equal(t, []string{gs1.XID, gs2.XID}, xids(driver.FindGlobalSessionsWithAddressingIdentities(
+		[]apis.GlobalSession_GlobalStatus{apis.Begin, apis.Committing, apis.RollingBack},
+		[]string{"127.0.0.1:8080"})))
+	assert.Equal(t, []string{gs2.XID, gs3.XID}, xids(driver.FindGlobalSessionsWithAddressingIdentities(
+		[]apis.GlobalSession_GlobalStatus{apis.Committing, apis.RollingBack},
+		[]string{"127.0.0.1:8080", "127.0.0.1:9090"})))
+	assert.Len(t, driver.FindGlobalSessionsWithAddressingIdentities(
+		[]apis.GlobalSession_GlobalStatus{apis.Begin}, []string{"127.0.0.1:9090"}), 0)
+
+	assert.Nil(t, driver.RemoveGlobalSession(gs2))
+	assert.Equal(t, []string{gs3.XID}, xids(driver.FindGlobalSessions(
+		[]apis.GlobalSession_GlobalStatus{apis.Committing, apis.RollingBack})))
+}
+
+func testBranchSessionCRUD(t *testing.T, driver storage.Driver) {
+	gs := newGlobalSession("127.0.0.1:8091:3001", 3001, "127.0.0.1:8080", 1)
+	bs1 := newBranchSession(gs, 3002, "product:1")
+	bs2 := newBranchSession(gs, 3003, "product:2")
+	assert.Nil(t, driver.AddGlobalSession(gs))
+	assert.Nil(t, driver.AddBranchSession(gs, bs1))
+	assert.Nil(t, driver.AddBranchSession(gs, bs2))
+
+	branchSessions := driver.FindBranchSessions(gs.XID)
+	assert.Equal(t, []int64{bs1.BranchID, bs2.BranchID}, branchIDs(branchSessions))
+	session := findBranchSession(branchSessions, bs1.BranchID)
+	if !assert.NotNil(t, session) {
+		return
+	}
+	assert.Equal(t, bs1.Addressing, session.Addressing)
+	assert.Equal(t, bs1.XID, session.XID)
+	assert.Equal(t, bs1.TransactionID, session.TransactionID)
+	assert.Equal(t, bs1.ResourceID, session.ResourceID)
+	assert.Equal(t, bs1.LockKey, session.LockKey)
+	assert.Equal(t, bs1.Type, session.Type)
+	assert.Equal(t, bs1.Status, session.Status)
+	assert.Equal(t, bs1.ApplicationData, session.ApplicationData)
+	assert.Equal(t, bs1.AsyncCommit, session.AsyncCommit)
+	assert.Len(t, driver.FindBranchSessions("127.0.0.1:8091:3004"), 0)
+
+	// the branch sessions are identified by xid and branch id, not by the instance
+	assert.Nil(t, driver.UpdateBranchSessionStatus(&apis.BranchSession{XID: gs.XID, BranchID: bs2.BranchID},
+		apis.PhaseOneDone))
+	branchSessions = driver.FindBranchSessions(gs.XID)
+	assert.Equal(t, apis.Registered, findBranchSession(branchSessions, bs1.BranchID).Status)
+	assert.Equal(t, apis.PhaseOneDone, findBranchSession(branchSessions, bs2.BranchID).Status)
+
+	assert.Nil(t, driver.RemoveBranchSession(gs, &apis.BranchSession{XID: gs.XID, BranchID: bs1.BranchID}))
+	assert.Equal(t, []int64{bs2.BranchID}, branchIDs(driver.FindBranchSessions(gs.XID)))
+	assert.Nil(t, driver.RemoveBranchSession(gs, bs2))
+	assert.Len(t, driver.FindBranchSessions(gs.XID), 0)
+}
+
+func testBatchBranchSessions(t *testing.T, driver storage.Driver) {
+	gs1 := newGlobalSession("127.0.0.1:8091:4001", 4001, "127.0.0.1:8080", 1)
+	gs2 := newGlobalSession("127.0.0.1:8091:4002", 4002, "127.0.0.1:8080", 2)
+	gs3 := newGlobalSession("127.0.0.1:8091:4003", 4003, "127.0.0.1:8080", 3)
+	for _, gs := range []*apis.GlobalSession{gs1, gs2, gs3} {
+		assert.Nil(t, driver.AddGlobalSession(gs))
+	}
+	assert.Nil(t, driver.AddBranchSession(gs1, newBranchSession(gs1, 4011, "")))
+	assert.Nil(t, driver.AddBranchSession(gs1, newBranchSession(gs1, 4012, "")))
+	assert.Nil(t, driver.AddBranchSession(gs2, newBranchSession(gs2, 4021, "")))
+	assert.Nil(t, driver.AddBranchSession(gs3, newBranchSession(gs3, 4031, "")))
+
+	assert.Equal(t, []int64{4011, 4012, 4021},
+		branchIDs(driver.FindBatchBranchSessions([]string{gs1.XID, gs2.XID, "127.0.0.1:8091:4004"})))
+	assert.Equal(t, []int64{4031}, branchIDs(driver.FindBatchBranchSessions([]string{gs3.XID})))
+}
+
+func testAcquireAndReleaseLock(t *testing.T, driver storage.Driver) {
+	xid1, xid2 := "127.0.0.1:8091:5001", "127.0.0.1:8091:5002"
+	assert.True(t, driver.IsLockable(xid1, "db", "product:1,2"))
+
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:1,2", "db", xid1), false))
+	assert.True(t, driver.IsLockable(xid1, "db", "product:1,2"))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:2"))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:3"))
+	assert.True(t, driver.IsLockable(xid2, "another", "product:1"))
+
+	// the locks of another xid are not released
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("product:1,2", "db", xid2)))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:1"))
+
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("product:1", "db", xid1)))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:1"))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:2"))
+
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("product:2", "db", xid1)))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:1,2"))
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:1,2", "db", xid2), false))
+}
+
+func testLockConflict(t *testing.T, driver storage.Driver) {
+	xid1, xid2 := "127.0.0.1:8091:6001", "127.0.0.1:8091:6002"
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:1", "db", xid1), false))
+
+	// a batch is acquired all or nothing
+	assert.False(t, driver.AcquireLock(storage.CollectRowLocks("product:2,1", "db", xid2), false))
+	assert.True(t, driver.IsLockable(xid1, "db", "product:2"))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:1"))
+
+	// a failed batch does not release the locks held by its own xid
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:3", "db", xid2), false))
+	assert.False(t, driver.AcquireLock(storage.CollectRowLocks("product:3,1", "db", xid2), false))
+	assert.False(t, driver.IsLockable(xid1, "db", "product:3"))
+}
+
+func testReentrantLock(t *testing.T, driver storage.Driver) {
+	xid1, xid2 := "127.0.0.1:8091:7001", "127.0.0.1:8091:7002"
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:1,2", "db", xid1), false))
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:1,2", "db", xid1), false))
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:2,3", "db", xid1), false))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:3"))
+
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("product:1,2,3", "db", xid1)))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:1,2,3"))
+}
+
+func testSkipCheckLock(t *testing.T, driver storage.Driver) {
+	xid1, xid2 := "127.0.0.1:8091:8001", "127.0.0.1:8091:8002"
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:1,2", "db", xid1), true))
+	assert.True(t, driver.IsLockable(xid1, "db", "product:1,2"))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:1"))
+	assert.False(t, driver.AcquireLock(storage.CollectRowLocks("product:2,3", "db", xid2), false))
+
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("product:1,2", "db", xid1)))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:1,2"))
+}
+
+func testRangeLockConflict(t *testing.T, driver storage.Driver) {
+	xid1, xid2 := "127.0.0.1:8091:9001", "127.0.0.1:8091:9002"
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:[10~20]", "db", xid1), false))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:10"))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:15"))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:[20~]"))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:9,21"))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:[~9]"))
+	assert.True(t, driver.IsLockable(xid2, "db", "order:15"))
+
+	// a row lock conflicts with a range lock covering it, and the other way around
+	assert.False(t, driver.AcquireLock(storage.CollectRowLocks("product:5,15", "db", xid2), false))
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:5,25", "db", xid2), false))
+	assert.False(t, driver.AcquireLock(storage.CollectRowLocks("product:[21~30]", "db", xid1), false))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:7"))
+
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("product:[10~20]", "db", xid1)))
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:15", "db", xid2), false))
+}
+
+func testTableLockConflict(t *testing.T, driver storage.Driver) {
+	xid1, xid2 := "127.0.0.1:8091:10001", "127.0.0.1:8091:10002"
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:1", "db", xid1), false))
+	assert.False(t, driver.AcquireLock(storage.CollectRowLocks("product:*", "db", xid2), false))
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("order:*", "db", xid2), false))
+	assert.False(t, driver.IsLockable(xid1, "db", "order:1"))
+	assert.False(t, driver.IsLockable(xid1, "db", "order:[1~2]"))
+	assert.True(t, driver.IsLockable(xid1, "another", "order:1"))
+
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("order:*", "db", xid2)))
+	assert.True(t, driver.IsLockable(xid1, "db", "order:1"))
+}
+
+func testReentrantRangeLock(t *testing.T, driver storage.Driver) {
+	xid1, xid2 := "127.0.0.1:8091:11001", "127.0.0.1:8091:11002"
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:*", "db", xid1), false))
+	assert.True(t, driver.AcquireLock(storage.CollectRowLocks("product:1,[2~5]", "db", xid1), false))
+	assert.True(t, driver.IsLockable(xid1, "db", "product:*"))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:100"))
+
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("product:*", "db", xid1)))
+	assert.False(t, driver.IsLockable(xid2, "db", "product:3"))
+	assert.True(t, driver.ReleaseLock(storage.CollectRowLocks("product:1,[2~5]", "db", xid1)))
+	assert.True(t, driver.IsLockable(xid2, "db", "product:*"))
+}
+
+func testListRowLocks(t *testing.T, driver storage.Driver) {
+	lister, ok := driver.(storage.LockLister)
+	if !ok {
+		t.Skip("the driver does not list its row locks")
+	}
+	xid1, xid2 := "127.0.0.1:8091:12001", "127.0.0.1:8091:12002"
+	bs1 := &apis.BranchSession{XID: xid1, TransactionID: 12001, BranchID: 1, ResourceID: "db", LockKey: "product:2,1"}
+	bs2 := &apis.BranchSession{XID: xid1, TransactionID: 12001, BranchID: 2, ResourceID: "db", LockKey: "order:1"}
+	bs3 := &apis.BranchSession{XID: xid2, TransactionID: 12002, BranchID: 3, ResourceID: "another", LockKey: "product:1"}
+	for _, bs := range []*apis.BranchSession{bs1, bs2, bs3} {
+		assert.True(t, driver.AcquireLock(storage.CollectBranchSessionRowLocks(bs), false))
+	}
+
+	rowKeys := func(query storage.RowLockQuery) []string {
+		rowLocks, err := lister.ListRowLocks(query)
+		assert.Nil(t, err)
+		keys := make([]string, 0, len(rowLocks))
+		for _, rowLock := range rowLocks {
+			keys = append(keys, rowLock.RowKey)
+		}
+		return keys
+	}
+	assert.Equal(t, []string{"db^^^order^^^1", "db^^^product^^^1", "db^^^product^^^2", "another^^^product^^^1"},
+		rowKeys(storage.RowLockQuery{}))
+	assert.Equal(t, []string{"db^^^product^^^1", "db^^^product^^^2", "another^^^product^^^1"},
+		rowKeys(storage.RowLockQuery{TableName: "product"}))
+	assert.Equal(t, []string{"db^^^order^^^1"}, rowKeys(storage.RowLockQuery{XID: xid1, BranchID: 2}))
+	assert.Equal(t, []string{"another^^^product^^^1"}, rowKeys(storage.RowLockQuery{ResourceID: "another"}))
+
+	rowLocks, err := lister.ListRowLocks(storage.RowLockQuery{XID: xid2})
+	assert.Nil(t, err)
+	if assert.Len(t, rowLocks, 1) {
+		assert.Equal(t, int64(3), rowLocks[0].BranchID)
+		assert.Equal(t, "product", rowLocks[0].TableName)
+		assert.Equal(t, "1", rowLocks[0].PK)
+	}
+
+	assert.True(t, driver.ReleaseLock(storage.CollectBranchSessionRowLocks(bs1)))
+	assert.Equal(t, []string{"db^^^order^^^1"}, rowKeys(storage.RowLockQuery{XID: xid1}))
+}
@@ -0,0 +1,139 @@
+package pgsql
+
+import "strings"
+
+// PostgreSQLKeyword the reserved keywords of postgresql, they can not be used as a table or column
+// name without quotes.
+var PostgreSQLKeyword = map[string]string{
+	"ALL":               "ALL",
+	"ANALYSE":           "ANALYSE",
+	"ANALYZE":           "ANALYZE",
+	"AND":               "AND",
+	"ANY":               "ANY",
+	"ARRAY":             "ARRAY",
+	"AS":                "AS",
+	"ASC":               "ASC",
+	"ASYMMETRIC":        "ASYMMETRIC",
+	"AUTHORIZATION":     "AUTHORIZATION",
+	"BINARY":            "BINARY",
+	"BOTH":              "BOTH",
+	"CASE":              "CASE",
+	"CAST":              "CAST",
+	"CHECK":             "CHECK",
+	"COLLATE":           "COLLATE",
+	"COLLATION":         "COLLATION",
+	"COLUMN":            "COLUMN",
+	"CONCURRENTLY":      "CONCURRENTLY",
+	"CONSTRAINT":        "CONSTRAINT",
+	"CREATE":            "CREATE",
+	"CROSS":             "CROSS",
+	"CURRENT_CATALOG":   "CURRENT_CATALOG",
+	"CURRENT_DATE":      "CURRENT_DATE",
+	"CURRENT_ROLE":      "CURRENT_ROLE",
+	"CURRENT_SCHEMA":    "CURRENT_SCHEMA",
+	"CURRENT_TIME":      "CURRENT_TIME",
+	"CURRENT_TIMESTAMP": "CURRENT_TIMESTAMP",
+	"CURRENT_USER":      "CURRENT_USER",
+	"DEFAULT":           "DEFAULT",
+	"DEFERRABLE":        "DEFERRABLE",
+	"DESC":              "DESC",
+	"DISTINCT":          "DISTINCT",
+	"DO":                "DO",
+	"ELSE":              "ELSE",
+	"END":               "END",
+	"EXCEPT":            "EXCEPT",
+	"FALSE":             "FALSE",
+	"FETCH":             "FETCH",
+	"FOR":               "FOR",
+	"FOREIGN":           "FOREIGN",
+	"FREEZE":            "FREEZE",
+	"FROM":              "FROM",
+	"FULL":              "FULL",
+	"GRANT":             "GRANT",
+	"GROUP":             "GROUP",
+	"HAVING":            "HAVING",
+	"ILIKE":             "ILIKE",
+	"IN":                "IN",
+	"INITIALLY":         "INITIALLY",
+	"INNER":             "INNER",
+	"INTERSECT":         "INTERSECT",
+	"INTO":              "INTO",
+	"IS":                "IS",
+	"ISNULL":            "ISNULL",
+	"JOIN":              "JOIN",
+	"LATERAL":           "LATERAL",
+	"LEADING":           "LEADING",
+	"LEFT":              "LEFT",
+	"LIKE":              "LIKE",
+	"LIMIT":             "LIMIT",
+	"LOCALTIME":         "LOCALTIME",
+	"LOCALTIMESTAMP":    "LOCALTIMESTAMP",
+	"NATURAL":           "NATURAL",
+	"NOT":               "NOT",
+	"NOTNULL":           "NOTNULL",
+	"NULL":              "NULL",
+	"OFFSET":            "OFFSET",
+	"ON":                "ON",
+	"ONLY":              "ONLY",
+	"OR":                "OR",
+	"ORDER":             "ORDER",
+	"OUTER":             "OUTER",
+	"OVERLAPS":          "OVERLAPS",
+	"PLACING":           "PLACING",
+	"PRIMARY":           "PRIMARY",
+	"REFERENCES":        "REFERENCES",
+	"RETURNING":         "RETURNING",
+	"RIGHT":             "RIGHT",
+	"SELECT":            "SELECT",
+	"SESSION_USER":      "SESSION_USER",
+	"SIMILAR":           "SIMILAR",
+	"SOME":              "SOME",
+	"SYMMETRIC":         "SYMMETRIC",
+	"TABLE":             "TABLE",
+	"TABLESAMPLE":       "TABLESAMPLE",
+	"THEN":              "THEN",
+	"TO":                "TO",
+	"TRAILING":          "TRAILING",
+	"TRUE":              "TRUE",
+	"UNION":             "UNION",
+	"UNIQUE":            "UNIQUE",
+	"USER":              "USER",
+	"USING":             "USING",
+	"VARIADIC":          "VARIADIC",
+	"VERBOSE":           "VERBOSE",
+	"WHEN":              "WHEN",
+	"WHERE":             "WHERE",
+	"WINDOW":            "WINDOW",
+	"WITH":              "WITH",
+}
+
+func Check(fieldOrTableName string) bool {
+	_, ok := PostgreSQLKeyword[strings.ToUpper(fieldOrTableName)]
+	return ok
+}
+
+// CheckAndReplace quotes the table or column name with double quotes if it is a keyword, or if it
+// is not a lower case identifier which postgresql would fold to another name. A name qualified with
+// the schema is checked part by part.
+func CheckAndReplace(fieldOrTableName string) string {
+	parts := strings.Split(fieldOrTableName, ".")
+	for i, part := range parts {
+		if Check(part) || !isLowerIdent(part) {
+			parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func isLowerIdent(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c != '_' && (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,17 @@
+package pgsql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAndReplace(t *testing.T) {
+	assert.Equal(t, "product", CheckAndReplace("product"))
+	assert.Equal(t, `"order"`, CheckAndReplace("order"))
+	assert.Equal(t, `"user"`, CheckAndReplace("user"))
+	// mysql keywords which are not reserved by postgresql are left unquoted
+	assert.Equal(t, "key", CheckAndReplace("key"))
+	assert.Equal(t, `"userId"`, CheckAndReplace("userId"))
+	assert.Equal(t, `public."order"`, CheckAndReplace("public.order"))
+}
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -30,3 +31,28 @@ func PgsqlAppendInParam(size int) string {
 	fmt.Fprintf(&sb, ")")
 	return sb.String()
 }
+
+// PgsqlRebind replaces the `?` placeholders out of quotes with $1, $2 ...
+func PgsqlRebind(query string) string {
+	var (
+		sb    strings.Builder
+		n     int
+		quote rune
+	)
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
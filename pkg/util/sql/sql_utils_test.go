@@ -0,0 +1,12 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPgsqlRebind(t *testing.T) {
+	assert.Equal(t, `UPDATE t SET a = $1, b = '?', "c?" = $2 WHERE id = $3`,
+		PgsqlRebind(`UPDATE t SET a = ?, b = '?', "c?" = ? WHERE id = ?`))
+}
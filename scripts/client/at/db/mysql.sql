@@ -0,0 +1,18 @@
+-- -------------------------------- The script used by the AT mode data source proxy --------------------------------
+
+-- the table to store the undo log of AT branches, it must be created in every business database
+CREATE TABLE IF NOT EXISTS `undo_log`
+(
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `branch_id` bigint(20) NOT NULL,
+  `xid` varchar(128) NOT NULL,
+  `context` varchar(128) NOT NULL,
+  `rollback_info` longblob NOT NULL,
+  `log_status` int(11) NOT NULL,
+  `log_created` datetime(6) NOT NULL,
+  `log_modified` datetime(6) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `ux_undo_log` (`xid`, `branch_id`)
+) ENGINE = InnoDB
+  AUTO_INCREMENT = 1
+  DEFAULT CHARSET = utf8;
@@ -0,0 +1,16 @@
+-- -------------------------------- The script used by the AT mode data source proxy --------------------------------
+
+-- the table to store the undo log of AT branches, it must be created in every business database
+CREATE TABLE IF NOT EXISTS undo_log
+(
+  id bigserial NOT NULL,
+  branch_id bigint NOT NULL,
+  xid varchar(128) NOT NULL,
+  context varchar(128) NOT NULL,
+  rollback_info bytea NOT NULL,
+  log_status int NOT NULL,
+  log_created timestamp NOT NULL,
+  log_modified timestamp NOT NULL,
+  PRIMARY KEY (id),
+  CONSTRAINT ux_undo_log UNIQUE (xid, branch_id)
+);
@@ -60,3 +60,13 @@ CREATE TABLE IF NOT EXISTS `lock_table`
     KEY `idx_branch_id` (`branch_id`)
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8;
+
+-- the table to store the global session locks of the database locker
+CREATE TABLE IF NOT EXISTS `global_session_lock`
+(
+    `xid`         VARCHAR(128) NOT NULL,
+    `owner`       VARCHAR(128) NOT NULL,
+    `expire_time` BIGINT       NOT NULL,
+    PRIMARY KEY (`xid`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8;
@@ -57,3 +57,12 @@ CREATE TABLE IF NOT EXISTS lock_table
 );
 
 CREATE INDEX idx_branch_id ON lock_table(branch_id);
+
+-- the table to store the global session locks of the database locker
+CREATE TABLE IF NOT EXISTS global_session_lock
+(
+    xid         VARCHAR(128) NOT NULL,
+    owner       VARCHAR(128) NOT NULL,
+    expire_time BIGINT       NOT NULL,
+    PRIMARY KEY (xid)
+);